@@ -0,0 +1,115 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ailabstw/go-pttai/cmd/utils"
+	"github.com/ailabstw/go-pttai/node"
+	"github.com/ailabstw/go-pttai/rpc"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+/*
+authTokenIssue issues the api-token through the ipc of the running gptt.
+*/
+func authTokenIssue(ctx *cli.Context) error {
+	utils.SetLogging(ctx)
+
+	if len(ctx.Args()) < 2 {
+		return ErrInvalidArgs
+	}
+	name := ctx.Args().First()
+	scopes := ctx.Args().Tail()
+
+	client, err := dialIPC(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var issued *node.BackendIssueToken
+	err = client.Call(&issued, "admin_issueToken", name, scopes)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("id: %v\nname: %v\nscopes: %v\ntoken: %v\n", issued.ID, issued.Name, issued.Scopes, issued.Token)
+
+	return nil
+}
+
+func authTokenRevoke(ctx *cli.Context) error {
+	utils.SetLogging(ctx)
+
+	if len(ctx.Args()) != 1 {
+		return ErrInvalidArgs
+	}
+
+	client, err := dialIPC(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var isOk bool
+	err = client.Call(&isOk, "admin_revokeToken", ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("revoked: %v\n", ctx.Args().First())
+
+	return nil
+}
+
+func authTokenList(ctx *cli.Context) error {
+	utils.SetLogging(ctx)
+
+	client, err := dialIPC(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var tokens []*node.AuthToken
+	err = client.Call(&tokens, "admin_getTokenList")
+	if err != nil {
+		return err
+	}
+
+	for _, each := range tokens {
+		scopes := make([]string, len(each.Scopes))
+		for i, scope := range each.Scopes {
+			scopes[i] = string(scope)
+		}
+		fmt.Printf("%v\t%v\t%v\n", each.ID, strings.Join(scopes, ","), each.Name)
+	}
+
+	return nil
+}
+
+func dialIPC(ctx *cli.Context) (*rpc.Client, error) {
+	cfg, err := setupConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.Dial(cfg.Node.IPCEndpoint())
+}
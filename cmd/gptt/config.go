@@ -26,6 +26,7 @@ import (
 	"github.com/ailabstw/go-pttai/cmd/utils"
 	"github.com/ailabstw/go-pttai/content"
 	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/group"
 	"github.com/ailabstw/go-pttai/me"
 	"github.com/ailabstw/go-pttai/node"
 	pkgservice "github.com/ailabstw/go-pttai/service"
@@ -39,6 +40,7 @@ type Config struct {
 	Content *content.Config
 	Account *account.Config
 	Friend  *friend.Config
+	Group   *group.Config
 	Ptt     *pkgservice.Config
 	Utils   *utils.Config
 }
@@ -50,6 +52,7 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		Content: &content.DefaultConfig,
 		Account: &account.DefaultConfig,
 		Friend:  &friend.DefaultConfig,
+		Group:   &group.DefaultConfig,
 		Ptt:     &pkgservice.DefaultConfig,
 		Utils:   &utils.DefaultConfig,
 	}, nil
@@ -0,0 +1,97 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/account"
+	"github.com/ailabstw/go-pttai/cmd/utils"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/group"
+	"github.com/ailabstw/go-pttai/me"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/ethereum/go-ethereum/event"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+func dbCheck(ctx *cli.Context) error {
+	return checkDB(ctx, false)
+}
+
+func dbRepair(ctx *cli.Context) error {
+	return checkDB(ctx, true)
+}
+
+/*
+checkDB constructs the services without starting the p2p-server and the entities,
+and checks (and repairs) the dbs of all the entities.
+*/
+func checkDB(ctx *cli.Context, isRepair bool) error {
+	utils.SetLogging(ctx)
+
+	cfg, err := setupConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	serviceCtx := &pkgservice.ServiceContext{
+		Services: make(map[reflect.Type]pkgservice.PttService),
+		EventMux: new(event.TypeMux),
+	}
+
+	ptt, err := newServices(serviceCtx, cfg)
+	defer teardownServices()
+	if err != nil {
+		return err
+	}
+
+	issues, err := ptt.CheckDB(isRepair)
+	if err != nil {
+		return err
+	}
+
+	nRepaired := 0
+	for _, issue := range issues {
+		repaired := ""
+		if issue.IsRepaired {
+			repaired = " (repaired)"
+			nRepaired++
+		}
+		fmt.Printf("%v\t%v\t%v\t%v\t%v%v\n", issue.Service, issue.EntityID, issue.Type, issue.Name, hex.EncodeToString(issue.Key), repaired)
+	}
+
+	fmt.Printf("issues: %v repaired: %v\n", len(issues), nRepaired)
+
+	if nRepaired != len(issues) {
+		return ErrDBInconsistent
+	}
+
+	return nil
+}
+
+func teardownServices() {
+	me.TeardownMe()
+	group.TeardownGroup()
+	friend.TeardownFriend()
+	content.TeardownContent()
+	account.TeardownAccount()
+	pkgservice.TeardownService()
+}
@@ -16,4 +16,10 @@
 
 package main
 
-var ()
+import "errors"
+
+var (
+	ErrDBInconsistent = errors.New("db inconsistent")
+
+	ErrInvalidArgs = errors.New("invalid args")
+)
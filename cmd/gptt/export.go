@@ -0,0 +1,78 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/cmd/utils"
+	"github.com/ailabstw/go-pttai/content"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/ethereum/go-ethereum/event"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+/*
+exportBoard exports the board as a static html site or a markdown + json tree.
+*/
+func exportBoard(ctx *cli.Context) error {
+	utils.SetLogging(ctx)
+
+	if len(ctx.Args()) != 1 {
+		return ErrInvalidArgs
+	}
+	entityIDBytes := []byte(ctx.Args().First())
+
+	format := content.ExportFormat(ctx.String(utils.ExportFormatFlag.Name))
+	if !format.IsValid() {
+		return content.ErrInvalidExportFormat
+	}
+
+	cfg, err := setupConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	serviceCtx := &pkgservice.ServiceContext{
+		Services: make(map[reflect.Type]pkgservice.PttService),
+		EventMux: new(event.TypeMux),
+	}
+
+	ptt, err := newServices(serviceCtx, cfg)
+	defer teardownServices()
+	if err != nil {
+		return err
+	}
+
+	contentBackend := ptt.GetService("content").(*content.Backend)
+
+	var exported *content.BackendExportBoard
+	dir := ctx.String(utils.ExportDirFlag.Name)
+	if dir == "" {
+		exported, err = contentBackend.ExportBoard(entityIDBytes, format)
+	} else {
+		exported, err = contentBackend.ExportBoardToDir(entityIDBytes, format, dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("exported: %v articles: %v media: %v dir: %v\n", exported.BoardID, exported.NArticle, exported.NMedia, exported.Dir)
+
+	return nil
+}
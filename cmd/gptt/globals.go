@@ -25,6 +25,7 @@ import (
 	"github.com/ailabstw/go-pttai/cmd/utils"
 	"github.com/ailabstw/go-pttai/content"
 	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/group"
 	"github.com/ailabstw/go-pttai/me"
 	"github.com/ailabstw/go-pttai/node"
 	pkgservice "github.com/ailabstw/go-pttai/service"
@@ -42,6 +43,7 @@ var (
 		Content: &content.DefaultConfig,
 		Account: &account.DefaultConfig,
 		Friend:  &friend.DefaultConfig,
+		Group:   &group.DefaultConfig,
 		Ptt:     &pkgservice.DefaultConfig,
 		Utils:   &utils.DefaultConfig,
 	}
@@ -59,6 +61,7 @@ var (
 		utils.MyDataDirFlag,
 		utils.MyKeyFileFlag,
 		utils.MyKeyHexFlag,
+		utils.MyMnemonicFlag,
 		utils.ServerFlag,
 	}
 
@@ -100,6 +103,7 @@ var (
 		utils.TestWebrtcFlag,
 
 		utils.E2EFlag,
+		utils.DBEngineFlag,
 		utils.PrivateAsPublicFlag,
 		utils.OffsetSecondFlag,
 
@@ -154,6 +158,7 @@ var (
 		utils.ExternRPCAddrFlag,
 
 		utils.RPCApiFlag,
+		utils.RPCAuthFlag,
 
 		utils.IPCDisabledFlag,
 		utils.IPCPathFlag,
@@ -187,6 +192,116 @@ The output of this command is supposed to be machine-readable.
 		Category:  "MISCELLANEOUS COMMANDS",
 	}
 
+	dbCommand = cli.Command{
+		Name:     "db",
+		Usage:    "Offline database maintenance",
+		Category: "DATABASE COMMANDS",
+		Subcommands: []cli.Command{
+			{
+				Action:    utils.MigrateFlags(dbCheck),
+				Name:      "check",
+				Usage:     "Check the consistency of the databases",
+				ArgsUsage: " ",
+				Flags:     append(nodeFlags, serviceFlags...),
+				Description: `
+The check command walks through the oplogs, merkle-trees, objects and blocks of all the entities,
+and reports the inconsistencies. gptt needs to be stopped before checking.
+`,
+			},
+			{
+				Action:    utils.MigrateFlags(dbRepair),
+				Name:      "repair",
+				Usage:     "Check and repair the consistency of the databases",
+				ArgsUsage: " ",
+				Flags:     append(nodeFlags, serviceFlags...),
+				Description: `
+The repair command fails the expired pending oplogs, reconstructs the inconsistent merkle-trees,
+and removes the corrupted oplogs / objects and the orphan blocks.
+Missing blocks are not repairable offline and are synced from the peers.
+gptt needs to be stopped before repairing.
+`,
+			},
+		},
+	}
+
+	restoreCommand = cli.Command{
+		Action:    utils.MigrateFlags(restore),
+		Name:      "restore",
+		Usage:     "Restore the identity from the encrypted backup",
+		ArgsUsage: "<backup-file>",
+		Category:  "DATABASE COMMANDS",
+		Flags:     append(append(nodeFlags, contentFlags...), utils.BackupPassphraseFlag),
+		Description: `
+The restore command decrypts the backup exported by me_exportBackup,
+and restores the keys and the databases to the data-dir.
+The data-dir is required to be new, and gptt needs to be stopped before restoring.
+
+The contents of the boards are synced from the peers after restarting,
+and the node joins me as a new node with a new node-key.
+`,
+	}
+
+	importBBSCommand = cli.Command{
+		Action:    utils.MigrateFlags(importBBS),
+		Name:      "importbbs",
+		Usage:     "Import the articles from the classic PTT BBS text dumps",
+		ArgsUsage: "<board-id> <file> [<file>...]",
+		Category:  "DATABASE COMMANDS",
+		Flags:     append(nodeFlags, utils.IPCPathFlag),
+		Description: `
+The importbbs command parses the BBS text dumps (big5 or utf8) into the articles and the push / boo / → comments,
+and creates them in the board through the ipc of the running gptt (same as content_importBBSArticles),
+with the original authors and timestamps kept in the content.
+`,
+	}
+
+	exportBoardCommand = cli.Command{
+		Action:    utils.MigrateFlags(exportBoard),
+		Name:      "exportboard",
+		Usage:     "Export the board as a static html site or a markdown tree",
+		ArgsUsage: "<board-id>",
+		Category:  "DATABASE COMMANDS",
+		Flags:     append(append(append(nodeFlags, contentFlags...), serviceFlags...), utils.ExportFormatFlag, utils.ExportDirFlag),
+		Description: `
+The exportboard command exports all the articles, comments, replies and media of the board
+as a browsable static html site (--format html) or a markdown + json tree (--format markdown).
+gptt needs to be stopped before exporting.
+`,
+	}
+
+	authTokenCommand = cli.Command{
+		Name:     "authtoken",
+		Usage:    "Manage the api-tokens of the running gptt",
+		Category: "MISCELLANEOUS COMMANDS",
+		Subcommands: []cli.Command{
+			{
+				Action:    utils.MigrateFlags(authTokenIssue),
+				Name:      "issue",
+				Usage:     "Issue a new api-token",
+				ArgsUsage: "<name> <scope> [<scope>...]",
+				Flags:     append(nodeFlags, utils.IPCPathFlag),
+				Description: `
+The issue command issues a new api-token through the ipc of the running gptt (same as admin_issueToken).
+The scopes are read / post / admin / key. The token is shown only once.
+`,
+			},
+			{
+				Action:    utils.MigrateFlags(authTokenRevoke),
+				Name:      "revoke",
+				Usage:     "Revoke the api-token",
+				ArgsUsage: "<token-id>",
+				Flags:     append(nodeFlags, utils.IPCPathFlag),
+			},
+			{
+				Action:    utils.MigrateFlags(authTokenList),
+				Name:      "list",
+				Usage:     "List the api-tokens",
+				ArgsUsage: " ",
+				Flags:     append(nodeFlags, utils.IPCPathFlag),
+			},
+		},
+	}
+
 	dumpConfigCommand = cli.Command{
 		Action:      utils.MigrateFlags(dumpConfig),
 		Name:        "dumpconfig",
@@ -27,6 +27,7 @@ import (
 	"github.com/ailabstw/go-pttai/cmd/utils"
 	"github.com/ailabstw/go-pttai/content"
 	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/group"
 	"github.com/ailabstw/go-pttai/internal/debug"
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/me"
@@ -43,26 +44,11 @@ func gptt(ctx *cli.Context) error {
 	log.Info("PTT.ai: Hello world!")
 
 	// Load Config
-	cfg, err := loadConfig(ctx)
+	cfg, err := setupConfig(ctx)
 	if err != nil {
 		return err
 	}
 
-	utils.SetUtilsConfig(ctx, cfg.Utils)
-
-	// we need NodeConfig be the 1st. The DataDir in other configs are referring to the DataDir in NodeConfig.
-	utils.SetNodeConfig(ctx, cfg.Node)
-
-	utils.SetMeConfig(ctx, cfg.Me, cfg.Node)
-
-	utils.SetAccountConfig(ctx, cfg.Account, cfg.Node)
-
-	utils.SetContentConfig(ctx, cfg.Content, cfg.Node)
-
-	utils.SetFriendConfig(ctx, cfg.Friend, cfg.Node)
-
-	utils.SetPttConfig(ctx, cfg.Ptt, cfg.Node, gitCommit, theVersion)
-
 	// Setup metrics
 	utils.SetupMetrics(ctx)
 
@@ -112,6 +98,32 @@ func gptt(ctx *cli.Context) error {
 	return nil
 }
 
+func setupConfig(ctx *cli.Context) (*Config, error) {
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.SetUtilsConfig(ctx, cfg.Utils)
+
+	// we need NodeConfig be the 1st. The DataDir in other configs are referring to the DataDir in NodeConfig.
+	utils.SetNodeConfig(ctx, cfg.Node)
+
+	utils.SetMeConfig(ctx, cfg.Me, cfg.Node)
+
+	utils.SetAccountConfig(ctx, cfg.Account, cfg.Node)
+
+	utils.SetContentConfig(ctx, cfg.Content, cfg.Node)
+
+	utils.SetFriendConfig(ctx, cfg.Friend, cfg.Node)
+
+	utils.SetGroupConfig(ctx, cfg.Group, cfg.Node)
+
+	utils.SetPttConfig(ctx, cfg.Ptt, cfg.Node, gitCommit, theVersion)
+
+	return cfg, nil
+}
+
 func registerPtt(n *node.Node, cfg *Config) error {
 	return n.Register(func(ctx *pkgservice.ServiceContext) (pkgservice.PttService, error) {
 		return registerServices(ctx, cfg)
@@ -119,6 +131,21 @@ func registerPtt(n *node.Node, cfg *Config) error {
 }
 
 func registerServices(ctx *pkgservice.ServiceContext, cfg *Config) (pkgservice.PttService, error) {
+	ptt, err := newServices(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ptt.Prestart()
+	if err != nil {
+		log.Error("unable to do Prestart", "e", err)
+		return nil, err
+	}
+
+	return ptt, nil
+}
+
+func newServices(ctx *pkgservice.ServiceContext, cfg *Config) (*pkgservice.BasePtt, error) {
 	myNodeKey := cfg.Node.NodeKey()
 	myNodeID := discover.PubkeyID(&myNodeKey.PublicKey)
 
@@ -156,20 +183,24 @@ func registerServices(ctx *pkgservice.ServiceContext, cfg *Config) (pkgservice.P
 		return nil, err
 	}
 
-	// me
-	meBackend, err := me.NewBackend(ctx, cfg.Me, ptt, accountBackend, contentBackend, friendBackend)
+	// group
+	groupBackend, err := group.NewBackend(ctx, cfg.Group, cfg.Me.ID, ptt, accountBackend)
+	if err != nil {
+		return nil, err
+	}
+	err = ptt.RegisterService(groupBackend)
 	if err != nil {
 		return nil, err
 	}
 
-	err = ptt.RegisterService(meBackend)
+	// me
+	meBackend, err := me.NewBackend(ctx, cfg.Me, ptt, accountBackend, contentBackend, friendBackend, groupBackend)
 	if err != nil {
 		return nil, err
 	}
 
-	err = ptt.Prestart()
+	err = ptt.RegisterService(meBackend)
 	if err != nil {
-		log.Error("unable to do Prestart", "e", err)
 		return nil, err
 	}
 
@@ -0,0 +1,68 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ailabstw/go-pttai/cmd/utils"
+	"github.com/ailabstw/go-pttai/content"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+/*
+importBBS imports the articles from the classic PTT BBS text dumps to the board
+through the ipc of the running gptt, because the articles / comments are signed and synced by the running services.
+*/
+func importBBS(ctx *cli.Context) error {
+	utils.SetLogging(ctx)
+
+	if len(ctx.Args()) < 2 {
+		return ErrInvalidArgs
+	}
+	entityID := ctx.Args().First()
+
+	files := make([][]byte, len(ctx.Args())-1)
+	for i, filename := range ctx.Args().Tail() {
+		file, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		files[i] = file
+	}
+
+	client, err := dialIPC(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var imported []*content.BackendImportBBSArticle
+	err = client.Call(&imported, "content_importBBSArticles", entityID, files)
+	if err != nil {
+		return err
+	}
+
+	for _, each := range imported {
+		fmt.Printf("%v\t%v\t%v\t%v\n", each.ArticleID, each.Author, each.NComment, each.Title)
+	}
+
+	fmt.Printf("imported: %v\n", len(imported))
+
+	return nil
+}
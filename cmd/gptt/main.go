@@ -74,6 +74,11 @@ func InitApp() *cli.App {
 		versionCommand,
 		licenseCommand,
 		dumpConfigCommand,
+		dbCommand,
+		restoreCommand,
+		exportBoardCommand,
+		importBBSCommand,
+		authTokenCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
@@ -0,0 +1,136 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/ailabstw/go-pttai/account"
+	"github.com/ailabstw/go-pttai/cmd/utils"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/group"
+	"github.com/ailabstw/go-pttai/me"
+	"github.com/ailabstw/go-pttai/p2p/discover"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"golang.org/x/crypto/ssh/terminal"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+/*
+restore decrypts the backup exported by me_exportBackup,
+and restores the keys and the dbs to the (new) data-dir.
+
+The contents of the boards are synced from the peers after restarting.
+*/
+func restore(ctx *cli.Context) error {
+	utils.SetLogging(ctx)
+
+	if len(ctx.Args()) != 1 {
+		return ErrInvalidArgs
+	}
+
+	f, err := os.Open(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	passphrase, err := getPassphrase(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := setupConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = initDBs(cfg)
+	defer teardownServices()
+	if err != nil {
+		return err
+	}
+
+	backup, err := me.RestoreBackup(bufio.NewReader(f), passphrase)
+	if err != nil {
+		return err
+	}
+
+	err = cfg.Me.RestoreBackupKey(backup)
+	if err != nil {
+		return err
+	}
+
+	// the node-key is not in the backup, the restored node joins me as a new node.
+	nodeKey := cfg.Node.NodeKey()
+	nodeID := discover.PubkeyID(&nodeKey.PublicKey)
+	err = backup.SaveMyNode(&nodeID, cfg.Ptt.NodeType)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("restored: %v node: %v\n", backup.ID, nodeID)
+
+	return nil
+}
+
+func getPassphrase(ctx *cli.Context) ([]byte, error) {
+	passphrase := ctx.String(utils.BackupPassphraseFlag.Name)
+	if passphrase != "" {
+		return []byte(passphrase), nil
+	}
+
+	fmt.Print("Passphrase: ")
+	defer fmt.Println()
+
+	return terminal.ReadPassword(int(os.Stdin.Fd()))
+}
+
+/*
+initDBs opens the dbs of all the services without constructing the services.
+*/
+func initDBs(cfg *Config) error {
+	err := pkgservice.InitService(cfg.Ptt.DataDir)
+	if err != nil {
+		return err
+	}
+
+	err = account.InitAccount(cfg.Account.DataDir)
+	if err != nil {
+		return err
+	}
+
+	err = content.InitContent(cfg.Content.DataDir, cfg.Content.KeystoreDir)
+	if err != nil {
+		return err
+	}
+
+	err = friend.InitFriend(cfg.Friend.DataDir)
+	if err != nil {
+		return err
+	}
+
+	err = group.InitGroup(cfg.Group.DataDir)
+	if err != nil {
+		return err
+	}
+
+	return me.InitMe(cfg.Me.DataDir)
+}
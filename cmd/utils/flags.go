@@ -38,6 +38,7 @@ import (
 	"github.com/ailabstw/go-pttai/content"
 	"github.com/ailabstw/go-pttai/me"
 	"github.com/ailabstw/go-pttai/node"
+	"github.com/ailabstw/go-pttai/pttdb"
 	"gopkg.in/urfave/cli.v1"
 )
 
@@ -82,6 +83,28 @@ var (
 		Usage: "e2e environment",
 	}
 
+	DBEngineFlag = cli.StringFlag{
+		Name:  "dbengine",
+		Usage: "Storage engine of the databases (leveldb, memory)",
+		Value: pttdb.StoreTypeLevelDB,
+	}
+
+	BackupPassphraseFlag = cli.StringFlag{
+		Name:  "passphrase",
+		Usage: "Passphrase of the backup (prompted if not specified)",
+	}
+
+	ExportFormatFlag = cli.StringFlag{
+		Name:  "format",
+		Usage: "Format of the exported board (html / markdown)",
+		Value: "html",
+	}
+
+	ExportDirFlag = cli.StringFlag{
+		Name:  "outputdir",
+		Usage: "Output directory of the exported board (default: <contentdatadir>/export/<board-id>/<format>)",
+	}
+
 	PrivateAsPublicFlag = cli.BoolFlag{
 		Name:  "private-as-public",
 		Usage: "Private api as public api",
@@ -122,6 +145,11 @@ var (
 		Usage: "my postfix (20 bytes)",
 	}
 
+	MyMnemonicFlag = cli.StringFlag{
+		Name:  "mnemonic",
+		Usage: "my mnemonic to recover my key",
+	}
+
 	ServerFlag = cli.BoolFlag{
 		Name:  "server",
 		Usage: "set as server mode",
@@ -265,6 +293,10 @@ var (
 		Usage: "API's offered over the HTTP-RPC interface",
 		Value: "",
 	}
+	RPCAuthFlag = cli.BoolFlag{
+		Name:  "rpcauth",
+		Usage: "Require api-tokens for the HTTP-RPC / WS-RPC / HTTP servers (issue tokens with 'gptt authtoken issue' through IPC)",
+	}
 	IPCDisabledFlag = cli.BoolFlag{
 		Name:  "ipcdisable",
 		Usage: "Disable the IPC-RPC server",
@@ -31,6 +31,7 @@ import (
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/content"
 	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/group"
 	"github.com/ailabstw/go-pttai/internal/debug"
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/me"
@@ -40,6 +41,7 @@ import (
 	"github.com/ailabstw/go-pttai/p2p/nat"
 	"github.com/ailabstw/go-pttai/p2p/netutil"
 	"github.com/ailabstw/go-pttai/params"
+	"github.com/ailabstw/go-pttai/pttdb"
 	"github.com/ailabstw/etcd/raft"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 	"github.com/ethereum/go-ethereum/common/fdlimit"
@@ -119,11 +121,26 @@ func SetMeConfig(ctx *cli.Context, cfg *me.Config, cfgNode *node.Config) {
 // method returns nil and an emphemeral key is to be generated.
 func setMyKey(ctx *cli.Context, cfg *me.Config) error {
 	var (
-		hex     = ctx.GlobalString(MyKeyHexFlag.Name)
-		file    = ctx.GlobalString(MyKeyFileFlag.Name)
-		postfix = ctx.GlobalString(MyPostfixFlag.Name)
+		hex      = ctx.GlobalString(MyKeyHexFlag.Name)
+		file     = ctx.GlobalString(MyKeyFileFlag.Name)
+		postfix  = ctx.GlobalString(MyPostfixFlag.Name)
+		mnemonic = ctx.GlobalString(MyMnemonicFlag.Name)
 	)
 
+	// mnemonic: recover my key, and save it for the following restarts.
+	if mnemonic != "" {
+		if hex != "" || file != "" || postfix != "" {
+			Fatalf("Invalid mnemonic: %v", me.ErrInvalidPrivateKeyMnemonic)
+		}
+
+		err := cfg.SetMnemonic(mnemonic)
+		if err != nil {
+			Fatalf("Invalid mnemonic: %v", err)
+		}
+
+		return cfg.SetMyKey("", "", "", true)
+	}
+
 	err := cfg.SetMyKey(hex, file, postfix, false)
 	if err != nil {
 		return err
@@ -171,6 +188,13 @@ func SetFriendConfig(ctx *cli.Context, cfg *friend.Config, cfgNode *node.Config)
 	friend.MinSyncRandomSeconds = cfg.MinSyncRandomSeconds
 }
 
+// SetGroupConfig applies node-related command line flags to the config.
+func SetGroupConfig(ctx *cli.Context, cfg *group.Config, cfgNode *node.Config) {
+	// datadir
+	log.Debug("SetGroupConfig: to set DataDir", "cfgNode.DataDIR", cfgNode.DataDir)
+	cfg.DataDir = filepath.Join(cfgNode.DataDir, "group")
+}
+
 // SetPttConfig applies ptt-related command line flags to the config.
 func SetPttConfig(ctx *cli.Context, cfg *pkgservice.Config, cfgNode *node.Config, gitCommit string, version string) {
 	log.Debug("SetPttConfig: start", "cfg", cfg, "cfgNode", cfgNode, "cfgNode.DataDir", cfgNode.DataDir, "params.Version", params.Version)
@@ -201,6 +225,16 @@ func SetPttConfig(ctx *cli.Context, cfg *pkgservice.Config, cfgNode *node.Config
 	}
 	pkgservice.IsE2E = cfg.IsE2E
 
+	// db engine
+	if ctx.GlobalIsSet(DBEngineFlag.Name) {
+		pttdb.DefaultStoreType = ctx.GlobalString(DBEngineFlag.Name)
+	}
+	switch pttdb.DefaultStoreType {
+	case pttdb.StoreTypeLevelDB, pttdb.StoreTypeMemory:
+	default:
+		Fatalf("Option %q: invalid db engine: %v", DBEngineFlag.Name, pttdb.DefaultStoreType)
+	}
+
 	// private as public
 	if ctx.GlobalIsSet(PrivateAsPublicFlag.Name) {
 		cfg.IsPrivateAsPublic = ctx.GlobalBool(PrivateAsPublicFlag.Name)
@@ -212,7 +246,7 @@ func SetPttConfig(ctx *cli.Context, cfg *pkgservice.Config, cfgNode *node.Config
 		types.OffsetSecond = ctx.GlobalInt64(OffsetSecondFlag.Name)
 	}
 
-	log.Debug("SetPttConfig: to return", "ExpireOplogSeconds", pkgservice.ExpireOplogSeconds, "IsE2E", pkgservice.IsE2E, "IsPrivateAsPublic", pkgservice.IsPrivateAsPublic, "OffsetSecond", types.OffsetSecond)
+	log.Debug("SetPttConfig: to return", "ExpireOplogSeconds", pkgservice.ExpireOplogSeconds, "IsE2E", pkgservice.IsE2E, "DBEngine", pttdb.DefaultStoreType, "IsPrivateAsPublic", pkgservice.IsPrivateAsPublic, "OffsetSecond", types.OffsetSecond)
 
 }
 
@@ -385,6 +419,9 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.GlobalIsSet(RPCVirtualHostsFlag.Name) {
 		cfg.HTTPVirtualHosts = splitAndTrim(ctx.GlobalString(RPCVirtualHostsFlag.Name))
 	}
+	if ctx.GlobalIsSet(RPCAuthFlag.Name) {
+		cfg.RPCAuth = ctx.GlobalBool(RPCAuthFlag.Name)
+	}
 
 	if ctx.GlobalIsSet(ExternRPCAddrFlag.Name) {
 		cfg.ExternHTTPAddr = ctx.GlobalString(ExternRPCAddrFlag.Name)
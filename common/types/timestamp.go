@@ -38,6 +38,11 @@ func TimeToTimestamp(t time.Time) Timestamp {
 	return Timestamp{int64(t.Unix() + OffsetSecond), uint32(t.Nanosecond())}
 }
 
+// ToTime converts the timestamp back to the UTC time, removing the OffsetSecond added in TimeToTimestamp.
+func (t *Timestamp) ToTime() time.Time {
+	return time.Unix(t.Ts-OffsetSecond, int64(t.NanoTs)).UTC()
+}
+
 func (t *Timestamp) ToMilli() Timestamp {
 	return Timestamp{t.Ts, (t.NanoTs / common.MILLION) * common.MILLION}
 }
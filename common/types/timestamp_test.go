@@ -19,6 +19,7 @@ package types
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestTimestamp_IsEqMilli(t *testing.T) {
@@ -357,3 +358,37 @@ func TestTimestamp_ToMilli(t *testing.T) {
 
 	// teardown test
 }
+
+func TestTimestamp_ToTime(t *testing.T) {
+	// setup test
+	origOffsetSecond := OffsetSecond
+	defer func() {
+		OffsetSecond = origOffsetSecond
+	}()
+
+	theTime := time.Date(2019, time.January, 2, 3, 4, 5, 6, time.UTC)
+
+	// prepare test-cases
+	tests := []struct {
+		name         string
+		offsetSecond int64
+	}{
+		{name: "no-offset", offsetSecond: 0},
+		{name: "offset", offsetSecond: 10000},
+		{name: "negative-offset", offsetSecond: -10000},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			OffsetSecond = tt.offsetSecond
+
+			ts := TimeToTimestamp(theTime)
+			if got := ts.ToTime(); !reflect.DeepEqual(got, theTime) {
+				t.Errorf("Timestamp.ToTime() = %v, want %v", got, theTime)
+			}
+		})
+	}
+
+	// teardown test
+}
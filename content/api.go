@@ -17,8 +17,11 @@
 package content
 
 import (
+	"context"
+
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/ailabstw/go-pttai/rpc"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
@@ -53,16 +56,43 @@ func (api *PrivateAPI) CreateComment(entityID string, articleID string, commentT
 	)
 }
 
-func (api *PrivateAPI) CreateReply(entityID string, articleID string, commentID string, reply [][]byte, mediaID string) (*BackendCreateReply, error) {
+/*
+CreateReply creates the reply in the thread of the comment.
+
+parentID is the reply-id to reply to, or empty to reply to the comment directly.
+*/
+func (api *PrivateAPI) CreateReply(entityID string, articleID string, commentID string, parentID string, reply [][]byte, mediaID string) (*BackendCreateReply, error) {
 	return api.b.CreateReply(
 		[]byte(entityID),
 		[]byte(articleID),
 		[]byte(commentID),
+		[]byte(parentID),
 		reply,
 		[]byte(mediaID),
 	)
 }
 
+/*
+React reacts to the article (targetID as empty) or to the comment (targetID as the comment-id).
+*/
+func (api *PrivateAPI) React(entityID string, articleID string, targetID string, reactionType ReactionType) (*BackendReact, error) {
+	return api.b.React(
+		[]byte(entityID),
+		[]byte(articleID),
+		[]byte(targetID),
+		reactionType,
+	)
+}
+
+func (api *PrivateAPI) Unreact(entityID string, articleID string, targetID string, reactionType ReactionType) (*BackendUnreact, error) {
+	return api.b.Unreact(
+		[]byte(entityID),
+		[]byte(articleID),
+		[]byte(targetID),
+		reactionType,
+	)
+}
+
 func (api *PrivateAPI) SetTitle(entityID string, title []byte) (*BackendGetBoard, error) {
 	return api.b.SetTitle([]byte(entityID), title)
 }
@@ -104,11 +134,12 @@ func (api *PrivateAPI) DeleteComment(entityID string, articleID string, commentI
 	)
 }
 
-func (api *PrivateAPI) DeleteReply(entityID string, articleID string, commentID string) (*BackendDeleteReply, error) {
+func (api *PrivateAPI) DeleteReply(entityID string, articleID string, commentID string, replyID string) (*BackendDeleteReply, error) {
 	return api.b.DeleteReply(
 		[]byte(entityID),
 		[]byte(articleID),
 		[]byte(commentID),
+		[]byte(replyID),
 	)
 }
 
@@ -120,6 +151,96 @@ func (api *PrivateAPI) DeleteMember(entityID string, userID string) (bool, error
 	return api.b.DeleteMember([]byte(entityID), []byte(userID))
 }
 
+/*
+BanMember bans the user from the board (only by the master). The member is deleted and is not able to join again.
+*/
+func (api *PrivateAPI) BanMember(entityID string, userID string) (*BackendBan, error) {
+	return api.b.BanMember([]byte(entityID), []byte(userID))
+}
+
+/*
+MuteMember mutes the user on the board for seconds (only by the master or the moderator with PermMute).
+*/
+func (api *PrivateAPI) MuteMember(entityID string, userID string, seconds int64) (*BackendBan, error) {
+	return api.b.MuteMember([]byte(entityID), []byte(userID), seconds)
+}
+
+/*
+LiftBan lifts the ban and the mute of the user (only by the master, the moderator is able to lift only the mute).
+*/
+func (api *PrivateAPI) LiftBan(entityID string, userID string) (bool, error) {
+	return api.b.LiftBan([]byte(entityID), []byte(userID))
+}
+
+/*
+AddModerator grants the perms to the member (only by the master).
+The perms are the bitwise-or of PermDeleteArticle (1), PermDeleteComment (2), PermMute (4) and PermPin (8),
+all the perms if not specified.
+*/
+func (api *PrivateAPI) AddModerator(entityID string, userID string, perms *uint32) (*BackendModerator, error) {
+	return api.b.AddModerator([]byte(entityID), []byte(userID), perms)
+}
+
+/*
+RemoveModerator removes all the perms of the member (only by the master).
+*/
+func (api *PrivateAPI) RemoveModerator(entityID string, userID string) (bool, error) {
+	return api.b.RemoveModerator([]byte(entityID), []byte(userID))
+}
+
+/*
+PinArticle pins the article on the board (only by the master or the moderator with PermPin).
+The pinned articles are ordered by the order (ascending).
+*/
+func (api *PrivateAPI) PinArticle(entityID string, articleID string, order int) (*BackendPin, error) {
+	return api.b.PinArticle([]byte(entityID), []byte(articleID), order)
+}
+
+func (api *PrivateAPI) UnpinArticle(entityID string, articleID string) (bool, error) {
+	return api.b.UnpinArticle([]byte(entityID), []byte(articleID))
+}
+
+/*
+SetArticleTags sets the tags of the article (only by the creator or the master), keeping the content.
+The tags are required to be in the tag-vocabulary of the board if the board has the tag-vocabulary.
+*/
+func (api *PrivateAPI) SetArticleTags(entityID string, articleID string, tags []string) (*BackendUpdateArticle, error) {
+	return api.b.SetArticleTags([]byte(entityID), []byte(articleID), tags)
+}
+
+/*
+SetArticleRevisionLimit sets the number of the previous revisions kept for each article of the board on this node
+(0 to 100, default 10).
+
+The limit is node-local and is not synced with the other members of the board.
+Each node keeps the revisions based on its own limit.
+*/
+func (api *PrivateAPI) SetArticleRevisionLimit(entityID string, limit int) (bool, error) {
+	return api.b.SetArticleRevisionLimit([]byte(entityID), limit)
+}
+
+/*
+GetArticleRevisionLimit gets the (node-local) number of the previous revisions kept for each article of the board.
+*/
+func (api *PrivateAPI) GetArticleRevisionLimit(entityID string) (int, error) {
+	return api.b.GetArticleRevisionLimit([]byte(entityID))
+}
+
+/*
+SetBoardTags sets the tag-vocabulary of the board (only by the master).
+*/
+func (api *PrivateAPI) SetBoardTags(entityID string, tags []string) ([]string, error) {
+	return api.b.SetBoardTags([]byte(entityID), tags)
+}
+
+/*
+SetBoardPostInterval sets the min intervals (in seconds) between the articles and between the comments / replies
+of each member (only by the master, 0 as not limited).
+*/
+func (api *PrivateAPI) SetBoardPostInterval(entityID string, articleSeconds int64, commentSeconds int64) (*BackendPostInterval, error) {
+	return api.b.SetBoardPostInterval([]byte(entityID), articleSeconds, commentSeconds)
+}
+
 func (api *PrivateAPI) InviteMaster(entityID string, userID string, nodeURL string) (*BackendInviteMaster, error) {
 	return api.b.InviteMaster(
 		[]byte(entityID),
@@ -185,11 +306,10 @@ func (api *PrivateAPI) GetRawComment(entityID string, commentID string) (*Commen
 	)
 }
 
-func (api *PrivateAPI) GetRawReply(entityID string, articleID string, commentID string) (*Reply, error) {
+func (api *PrivateAPI) GetRawReply(entityID string, replyID string) (*Reply, error) {
 	return api.b.GetRawReply(
 		[]byte(entityID),
-		[]byte(articleID),
-		[]byte(commentID),
+		[]byte(replyID),
 	)
 }
 
@@ -220,6 +340,96 @@ func (api *PublicAPI) GetArticleList(entityID string, startingArticleID string,
 	)
 }
 
+func (api *PublicAPI) SearchArticles(entityID string, query string, limit int) ([]*BackendGetArticle, error) {
+	return api.b.SearchArticles([]byte(entityID), query, limit)
+}
+
+/*
+GetArticleListByTag gets the articles with the tag, ordered by the create-ts as GetArticleList.
+*/
+func (api *PublicAPI) GetArticleListByTag(entityID string, tag string, startingArticleID string, limit int, listOrder pttdb.ListOrder) ([]*BackendGetArticle, error) {
+	return api.b.GetArticleListByTag(
+		[]byte(entityID),
+		tag,
+		[]byte(startingArticleID),
+		limit,
+		listOrder,
+	)
+}
+
+func (api *PublicAPI) GetBoardTags(entityID string) ([]string, error) {
+	return api.b.GetBoardTags([]byte(entityID))
+}
+
+func (api *PublicAPI) GetBoardPostInterval(entityID string) (*BackendPostInterval, error) {
+	return api.b.GetBoardPostInterval([]byte(entityID))
+}
+
+/*
+GetArticleRevisionList gets the revisions of the article kept on this node, ordered by the update-ts.
+The last revision is the current content of the article.
+*/
+func (api *PublicAPI) GetArticleRevisionList(entityID string, articleID string) ([]*BackendArticleRevision, error) {
+	return api.b.GetArticleRevisionList([]byte(entityID), []byte(articleID))
+}
+
+func (api *PublicAPI) GetArticleRevision(entityID string, articleID string, revisionID string) (*BackendGetArticleRevision, error) {
+	return api.b.GetArticleRevision([]byte(entityID), []byte(articleID), []byte(revisionID))
+}
+
+/*
+GetArticleRevisionDiff gets the line-level diff of the contents from the revision to the revision.
+The op of each line is 0 (equal), 1 (deleted) or 2 (inserted).
+*/
+func (api *PublicAPI) GetArticleRevisionDiff(entityID string, articleID string, fromRevisionID string, toRevisionID string) ([]*pkgservice.LineDiff, error) {
+	return api.b.GetArticleRevisionDiff([]byte(entityID), []byte(articleID), []byte(fromRevisionID), []byte(toRevisionID))
+}
+
+/*
+GetReplyList gets the replies in the thread of the comment, ordered by the create-ts.
+
+The nested structure of the thread is given by the ParentID / Depth of each reply.
+*/
+func (api *PublicAPI) GetReplyList(entityID string, articleID string, commentID string, startingReplyID string, limit int, listOrder pttdb.ListOrder) ([]*BackendGetReply, error) {
+	return api.b.GetReplyList(
+		[]byte(entityID),
+		[]byte(articleID),
+		[]byte(commentID),
+		[]byte(startingReplyID),
+		limit,
+		listOrder,
+	)
+}
+
+/*
+GetReactions gets the counts of each reaction-type on the article (targetID as empty) or on the comment.
+*/
+func (api *PublicAPI) GetReactions(entityID string, articleID string, targetID string) (*BackendGetReactions, error) {
+	return api.b.GetReactions(
+		[]byte(entityID),
+		[]byte(articleID),
+		[]byte(targetID),
+	)
+}
+
+/*
+GetBanList gets the bans and the (not expired) mutes of the board.
+*/
+func (api *PublicAPI) GetBanList(entityID string) ([]*BackendBan, error) {
+	return api.b.GetBanList([]byte(entityID))
+}
+
+func (api *PublicAPI) GetModeratorList(entityID string) ([]*BackendModerator, error) {
+	return api.b.GetModeratorList([]byte(entityID))
+}
+
+/*
+GetPinnedArticleList gets the pinned (announcement) articles of the board, in the order of the pins.
+*/
+func (api *PublicAPI) GetPinnedArticleList(entityID string) ([]*BackendPinnedArticle, error) {
+	return api.b.GetPinnedArticleList([]byte(entityID))
+}
+
 func (api *PublicAPI) GetPokedArticleList(entityID string) ([]*BackendGetArticle, error) {
 	return api.b.GetPokedArticleList([]byte(entityID))
 }
@@ -228,6 +438,13 @@ func (api *PublicAPI) ShowBoardURL(entityID string) (*pkgservice.BackendJoinURL,
 	return api.b.ShowBoardURL([]byte(entityID))
 }
 
+/*
+SubscribeBoard notifies the title / article / comment events of the board (content_subscribe with "subscribeBoard").
+*/
+func (api *PublicAPI) SubscribeBoard(ctx context.Context, entityID string) (*rpc.Subscription, error) {
+	return api.b.SubscribeBoard(ctx, []byte(entityID))
+}
+
 /**********
  * BoardOplog
  **********/
@@ -264,12 +481,51 @@ func (api *PrivateAPI) GetFile(entityID string, mediaID string) (*BackendGetFile
 	return api.b.GetFile([]byte(entityID), []byte(mediaID))
 }
 
+func (api *PrivateAPI) CreateFileUpload(entityID string, filename string, size int64) (*BackendFileUpload, error) {
+	return api.b.CreateFileUpload([]byte(entityID), []byte(filename), size)
+}
+
+func (api *PrivateAPI) CreateAudioUpload(entityID string, filename string, size int64, contentType string, codec string, duration int64) (*BackendFileUpload, error) {
+	return api.b.CreateAudioUpload([]byte(entityID), []byte(filename), size, contentType, codec, duration)
+}
+
+func (api *PrivateAPI) CreateVideoUpload(entityID string, filename string, size int64, contentType string, codec string, audioCodec string, duration int64, width uint16, height uint16) (*BackendFileUpload, error) {
+	return api.b.CreateVideoUpload([]byte(entityID), []byte(filename), size, contentType, codec, audioCodec, duration, width, height)
+}
+
+func (api *PrivateAPI) UploadFileChunk(entityID string, uploadID string, offset int64, bytes []byte) (*BackendFileUpload, error) {
+	return api.b.UploadFileChunk([]byte(entityID), []byte(uploadID), offset, bytes)
+}
+
+func (api *PrivateAPI) GetFileUpload(entityID string, uploadID string) (*BackendFileUpload, error) {
+	return api.b.GetFileUpload([]byte(entityID), []byte(uploadID))
+}
+
+func (api *PrivateAPI) DeleteFileUpload(entityID string, uploadID string) (bool, error) {
+	return api.b.DeleteFileUpload([]byte(entityID), []byte(uploadID))
+}
+
+func (api *PrivateAPI) GetFileInfo(entityID string, mediaID string) (*BackendGetFileInfo, error) {
+	return api.b.GetFileInfo([]byte(entityID), []byte(mediaID))
+}
+
+func (api *PrivateAPI) GetFileRange(entityID string, mediaID string, start int64, end int64) (*BackendGetFileRange, error) {
+	return api.b.GetFileRange([]byte(entityID), []byte(mediaID), start, end)
+}
+
 func (api *PrivateAPI) UploadImage(entityID string, fileType string, bytes []byte) (*BackendUploadImg, error) {
 	return api.b.UploadImage([]byte(entityID), fileType, bytes)
 }
 
-func (api *PrivateAPI) GetImage(entityID string, imgID string) (*BackendGetImg, error) {
-	return api.b.GetImage([]byte(entityID), []byte(imgID))
+/*
+GetImage gets the image with the size (small / medium / large, the original image if not provided).
+*/
+func (api *PrivateAPI) GetImage(entityID string, imgID string, size *string) (*BackendGetImg, error) {
+	theSize := ""
+	if size != nil {
+		theSize = *size
+	}
+	return api.b.GetImage([]byte(entityID), []byte(imgID), theSize)
 }
 
 func (api *PublicAPI) GetArticleSummary(entityID string, articleInfo *BackendArticleSummaryParams) (*ArticleBlock, error) {
@@ -288,6 +544,22 @@ func (api *PrivateAPI) MarkArticleSeen(entityID string, articleID string) (types
 	return api.b.MarkArticleSeen([]byte(entityID), []byte(articleID))
 }
 
+func (api *PrivateAPI) SetBoardPublicFeed(entityID string, isPublicFeed bool) (bool, error) {
+	return api.b.SetBoardPublicFeed([]byte(entityID), isPublicFeed)
+}
+
+func (api *PublicAPI) IsBoardPublicFeed(entityID string) (bool, error) {
+	return api.b.IsBoardPublicFeed([]byte(entityID))
+}
+
+func (api *PrivateAPI) ImportBBSArticles(entityID string, files [][]byte) ([]*BackendImportBBSArticle, error) {
+	return api.b.ImportBBSArticles([]byte(entityID), files)
+}
+
+func (api *PrivateAPI) ExportBoard(entityID string, format string) (*BackendExportBoard, error) {
+	return api.b.ExportBoard([]byte(entityID), ExportFormat(format))
+}
+
 /**********
  * MasterOplog
  **********/
@@ -29,7 +29,8 @@ import (
 type SyncArticleInfo struct {
 	*pkgservice.BaseSyncInfo `json:"b"`
 
-	Title []byte `json:"T,omitempty"`
+	Title []byte   `json:"T,omitempty"`
+	Tags  []string `json:"tg,omitempty"`
 }
 
 func NewEmptySyncArticleInfo() *SyncArticleInfo {
@@ -45,6 +46,7 @@ func (s *SyncArticleInfo) ToObject(theObj pkgservice.Object) error {
 	s.BaseSyncInfo.ToObject(obj)
 
 	obj.Title = s.Title
+	obj.Tags = s.Tags
 
 	return nil
 }
@@ -56,7 +58,10 @@ type Article struct {
 
 	SyncInfo *SyncArticleInfo `json:"s,omitempty"`
 
-	Title []byte `json:"T,omitempty"`
+	Title []byte   `json:"T,omitempty"`
+	Tags  []string `json:"tg,omitempty"`
+
+	Import *ImportInfo `json:"i,omitempty"`
 
 	NPush *pkgservice.Count `json:"-"` // from other db-records
 	NBoo  *pkgservice.Count `json:"-"` // from other db-records
@@ -423,7 +428,7 @@ func (a *Article) LoadBoo() (*pkgservice.Count, error) {
 	return count, err
 }
 
-func (a *Article) DeleteAll(comment *Comment, isLocked bool) error {
+func (a *Article) DeleteAll(comment *Comment, reply *Reply, reaction *Reaction, isLocked bool) error {
 
 	var err error
 	if !isLocked {
@@ -443,14 +448,14 @@ func (a *Article) DeleteAll(comment *Comment, isLocked bool) error {
 
 	// postdelete
 
-	a.Postdelete(comment, true)
+	a.Postdelete(comment, reply, reaction, true)
 
 	a.Delete(true)
 
 	return nil
 }
 
-func (a *Article) Postdelete(comment *Comment, isLocked bool) error {
+func (a *Article) Postdelete(comment *Comment, reply *Reply, reaction *Reaction, isLocked bool) error {
 	var err error
 	if !isLocked {
 		err = a.Lock()
@@ -474,8 +479,17 @@ func (a *Article) Postdelete(comment *Comment, isLocked bool) error {
 		id, err = comment.KeyToID(key)
 		comment.SetID(id)
 		comment.GetAndDeleteAll(false)
+
+		dbSearch.Delete(a.EntityID[:], id[:])
+
+		reply.DeleteAllByCommentID(id)
+
+		reaction.DeleteAllByTargetID(id)
 	}
 
+	// reaction
+	reaction.DeleteAllByTargetID(a.ID)
+
 	// push
 	count, err := pkgservice.NewCount(dbBoard, a.EntityID, a.ID, DBPushPrefix, PCommentCount, false)
 	if err == nil {
@@ -500,5 +514,8 @@ func (a *Article) Postdelete(comment *Comment, isLocked bool) error {
 		a.DB().DB().Delete(key)
 	}
 
+	// revision
+	a.DeleteRevisions()
+
 	return nil
 }
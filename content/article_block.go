@@ -40,6 +40,8 @@ type ArticleBlock struct {
 	UpdaterID *types.PttID `json:"UID"`
 
 	Buf [][]byte `json:"B"`
+
+	Import *ImportInfo `json:"I,omitempty"` // the original author / create-ts of the imported comment.
 }
 
 func NewArticleBlock() (*ArticleBlock, error) {
@@ -84,6 +86,8 @@ func commentToArticleBlock(pm *ProtocolManager, comment *Comment) (*ArticleBlock
 		UpdateTS:  comment.UpdateTS,
 		CreatorID: comment.CreatorID,
 		UpdaterID: comment.UpdaterID,
+
+		Import: comment.Import,
 	}
 
 	if comment.Status > types.StatusAlive {
@@ -0,0 +1,233 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+ArticleRevision is the local record of the content of the article after each create / update oplog.
+
+The blocks of the revision are the blocks of the article (with the block-info-id of the revision),
+and are kept until the revision is pruned (over the revision-limit of the board) or the article is deleted.
+
+Key: DBArticleRevisionPrefix | entityID | articleID | updateTS | ID
+*/
+type ArticleRevision struct {
+	ID        *types.PttID          `json:"ID"` // the create / update oplog
+	EntityID  *types.PttID          `json:"e"`
+	ArticleID *types.PttID          `json:"AID"`
+	UpdateTS  types.Timestamp       `json:"UT"`
+	UpdaterID *types.PttID          `json:"UID"`
+	Title     []byte                `json:"T,omitempty"`
+	Tags      []string              `json:"tg,omitempty"`
+	BlockInfo *pkgservice.BlockInfo `json:"b"`
+}
+
+func NewArticleRevision(a *Article, oplog *pkgservice.BaseOplog) *ArticleRevision {
+	return &ArticleRevision{
+		ID:        oplog.ID,
+		EntityID:  a.EntityID,
+		ArticleID: a.ID,
+		UpdateTS:  oplog.UpdateTS,
+		UpdaterID: oplog.CreatorID,
+		Title:     a.Title,
+		Tags:      a.Tags,
+		BlockInfo: a.GetBlockInfo(),
+	}
+}
+
+func (r *ArticleRevision) MarshalKey() ([]byte, error) {
+	marshalTimestamp, err := r.UpdateTS.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return common.Concat([][]byte{DBArticleRevisionPrefix, r.EntityID[:], r.ArticleID[:], marshalTimestamp, r.ID[:]})
+}
+
+func (r *ArticleRevision) Save() error {
+	key, err := r.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	return dbBoardCore.Put(key, marshaled)
+}
+
+/*
+Delete deletes the revision and the blocks of the revision.
+*/
+func (r *ArticleRevision) Delete(setBlockInfoDB func(blockInfo *pkgservice.BlockInfo, objID *types.PttID)) error {
+	if r.BlockInfo != nil {
+		setBlockInfoDB(r.BlockInfo, r.ArticleID)
+
+		block := pkgservice.NewEmptyBlock()
+		r.BlockInfo.SetBlockDB(block)
+		block.ID = r.BlockInfo.ID
+
+		err := block.RemoveAll()
+		if err != nil {
+			return err
+		}
+	}
+
+	key, err := r.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	return dbBoardCore.Delete(key)
+}
+
+/*
+SaveRevision saves the current content of the article as the revision of the oplog.
+*/
+func (a *Article) SaveRevision(oplog *pkgservice.BaseOplog) (*ArticleRevision, error) {
+	if a.GetBlockInfo() == nil {
+		return nil, pkgservice.ErrInvalidBlock
+	}
+
+	revision := NewArticleRevision(a, oplog)
+	err := revision.Save()
+	if err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+/*
+GetRevisionList gets the revisions of the article, ordered by the update-ts.
+*/
+func (a *Article) GetRevisionList() ([]*ArticleRevision, error) {
+	prefix, err := a.MarshalRevisionPrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := dbBoardCore.NewIteratorWithPrefix(nil, prefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	revisions := make([]*ArticleRevision, 0)
+	for iter.Next() {
+		revision := &ArticleRevision{}
+		err = json.Unmarshal(iter.Value(), revision)
+		if err != nil {
+			continue
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+func (a *Article) GetRevision(revisionID *types.PttID) (*ArticleRevision, error) {
+	revisions, err := a.GetRevisionList()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, revision := range revisions {
+		if reflect.DeepEqual(revision.ID, revisionID) {
+			return revision, nil
+		}
+	}
+
+	return nil, leveldb.ErrNotFound
+}
+
+/*
+PruneRevisions deletes the oldest revisions, keeping the current revision and at most limit previous revisions.
+The blocks of the current content of the article are never deleted.
+*/
+func (a *Article) PruneRevisions(limit int) error {
+	revisions, err := a.GetRevisionList()
+	if err != nil {
+		return err
+	}
+
+	nPrune := len(revisions) - limit - 1
+	for i := 0; i < nPrune; i++ {
+		err = a.deleteRevision(revisions[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+DeleteRevisions deletes all the revisions of the article.
+*/
+func (a *Article) DeleteRevisions() error {
+	revisions, err := a.GetRevisionList()
+	if err != nil {
+		return err
+	}
+
+	for _, revision := range revisions {
+		err = a.deleteRevision(revision)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+IsCurrentRevision checks whether the revision shares the blocks with the current content of the article.
+*/
+func (a *Article) IsCurrentRevision(revision *ArticleRevision) bool {
+	blockInfo := a.GetBlockInfo()
+	if blockInfo == nil || revision.BlockInfo == nil {
+		return false
+	}
+
+	return reflect.DeepEqual(blockInfo.ID, revision.BlockInfo.ID)
+}
+
+func (a *Article) deleteRevision(revision *ArticleRevision) error {
+	if a.IsCurrentRevision(revision) {
+		revision.BlockInfo = nil
+	}
+
+	return revision.Delete(a.SetBlockInfoDB())
+}
+
+func (a *Article) MarshalRevisionPrefix() ([]byte, error) {
+	return common.Concat([][]byte{DBArticleRevisionPrefix, a.EntityID[:], a.ID[:]})
+}
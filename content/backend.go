@@ -25,6 +25,9 @@ import (
 
 type Backend struct {
 	*pkgservice.BaseService
+
+	Config *Config
+
 	accountBackend *account.Backend
 }
 
@@ -37,6 +40,7 @@ func NewBackend(ctx *pkgservice.ServiceContext, cfg *Config, id *types.PttID, pt
 
 	// backend
 	backend := &Backend{
+		Config:         cfg,
 		accountBackend: accountBackend,
 	}
 
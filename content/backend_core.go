@@ -17,10 +17,16 @@
 package content
 
 import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sort"
+
 	"github.com/ailabstw/go-pttai/account"
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/ailabstw/go-pttai/rpc"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 	"github.com/syndtr/goleveldb/leveldb"
 )
@@ -64,7 +70,7 @@ func (b *Backend) CreateArticle(entityIDBytes []byte, title []byte, article [][]
 		}
 	}
 
-	theArticle, err := pm.CreateArticle(title, article, mediaIDs)
+	theArticle, err := pm.CreateArticle(title, article, mediaIDs, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -105,9 +111,106 @@ func (b *Backend) CreateComment(entityIDBytes []byte, articleIDBytes []byte, com
 	return backendComment, nil
 }
 
-func (b *Backend) CreateReply(entityIDBytes []byte, articleIDBytes []byte, commentIDBytes []byte, reply [][]byte, mediaIDBytes []byte) (*BackendCreateReply, error) {
+func (b *Backend) CreateReply(entityIDBytes []byte, articleIDBytes []byte, commentIDBytes []byte, parentIDBytes []byte, reply [][]byte, mediaIDBytes []byte) (*BackendCreateReply, error) {
 
-	return nil, types.ErrNotImplemented
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if articleID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	commentID, err := types.UnmarshalTextPttID(commentIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if commentID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	parentID, err := types.UnmarshalTextPttID(parentIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaID, err := types.UnmarshalTextPttID(mediaIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	theReply, err := pm.CreateReply(articleID, commentID, parentID, reply, mediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	backendReply := replyToBackendCreateReply(theReply)
+
+	return backendReply, nil
+}
+
+func (b *Backend) React(entityIDBytes []byte, articleIDBytes []byte, targetIDBytes []byte, reactionType ReactionType) (*BackendReact, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if articleID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	targetID, err := types.UnmarshalTextPttID(targetIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	theReaction, err := pm.React(articleID, targetID, reactionType)
+	if err != nil {
+		return nil, err
+	}
+
+	return reactionToBackendReact(theReaction), nil
+}
+
+func (b *Backend) Unreact(entityIDBytes []byte, articleIDBytes []byte, targetIDBytes []byte, reactionType ReactionType) (*BackendUnreact, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if articleID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	targetID, err := types.UnmarshalTextPttID(targetIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pm.Unreact(articleID, targetID, reactionType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendUnreact{}, nil
 }
 
 func (b *Backend) UpdateArticle(entityIDBytes []byte, articleIDBytes []byte, article [][]byte, mediaIDStrs []string) (*BackendUpdateArticle, error) {
@@ -137,7 +240,7 @@ func (b *Backend) UpdateArticle(entityIDBytes []byte, articleIDBytes []byte, art
 		}
 	}
 
-	theArticle, err := pm.UpdateArticle(articleID, article, mediaIDs)
+	theArticle, err := pm.UpdateArticle(articleID, article, mediaIDs, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -197,9 +300,44 @@ func (b *Backend) DeleteComment(entityIDBytes []byte, commentIDBytes []byte) (*B
 	return &BackendDeleteComment{}, nil
 }
 
-func (b *Backend) DeleteReply(entityIDBytes []byte, articleIDBytes []byte, commentIDBytes []byte) (*BackendDeleteReply, error) {
+func (b *Backend) DeleteReply(entityIDBytes []byte, articleIDBytes []byte, commentIDBytes []byte, replyIDBytes []byte) (*BackendDeleteReply, error) {
 
-	return nil, types.ErrNotImplemented
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	commentID, err := types.UnmarshalTextPttID(commentIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	replyID, err := types.UnmarshalTextPttID(replyIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// the reply needs to be in the thread of the comment.
+	reply, err := pm.GetReply(replyID)
+	if err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(reply.ArticleID, articleID) || !reflect.DeepEqual(reply.CommentID, commentID) {
+		return nil, types.ErrInvalidID
+	}
+
+	err = pm.DeleteReply(replyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendDeleteReply{}, nil
 }
 
 func (b *Backend) DeleteBoard(entityIDBytes []byte) (bool, error) {
@@ -329,9 +467,100 @@ func (b *Backend) GetRawComment(entityIDBytes []byte, commentIDBytes []byte) (*C
 	return pm.GetComment(commentID)
 }
 
-func (b *Backend) GetRawReply(entityIDBytes []byte, articleIDBytes []byte, commentIDBytes []byte) (*Reply, error) {
+func (b *Backend) GetRawReply(entityIDBytes []byte, replyIDBytes []byte) (*Reply, error) {
 
-	return nil, types.ErrNotImplemented
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	replyID, err := types.UnmarshalTextPttID(replyIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pm.GetReply(replyID)
+}
+
+func (b *Backend) GetReplyList(entityIDBytes []byte, articleIDBytes []byte, commentIDBytes []byte, startingReplyIDBytes []byte, limit int, listOrder pttdb.ListOrder) ([]*BackendGetReply, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	commentID, err := types.UnmarshalTextPttID(commentIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if commentID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	startID, err := types.UnmarshalTextPttID(startingReplyIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	replyList, err := pm.GetReplyList(articleID, commentID, startID, limit, listOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	theList := make([]*BackendGetReply, 0, len(replyList))
+	for _, reply := range replyList {
+		buf, err := pm.getReplyBuf(reply)
+		if err != nil {
+			continue
+		}
+		theList = append(theList, replyToBackendGetReply(reply, buf))
+	}
+
+	return theList, nil
+}
+
+func (b *Backend) GetReactions(entityIDBytes []byte, articleIDBytes []byte, targetIDBytes []byte) (*BackendGetReactions, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if articleID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	targetID, err := types.UnmarshalTextPttID(targetIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+	if targetID == nil {
+		targetID = articleID
+	}
+
+	reactions, err := pm.GetReactions(articleID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendGetReactions{
+		BoardID:   pm.Entity().GetID(),
+		ArticleID: articleID,
+		TargetID:  targetID,
+		Reactions: reactions,
+	}, nil
 }
 
 func (b *Backend) GetArticleBlockList(entityIDBytes []byte, articleIDBytes []byte, subContentIDBytes []byte, contentType ContentType, blockID uint32, limit int, listOrder pttdb.ListOrder) ([]*ArticleBlock, error) {
@@ -388,6 +617,97 @@ func (b *Backend) GetArticleList(entityIDBytes []byte, startingArticleIDBytes []
 	return theList, nil
 }
 
+func (b *Backend) SearchArticles(entityIDBytes []byte, query string, limit int) ([]*BackendGetArticle, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	articleList, err := pm.SearchArticles(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	theList := make([]*BackendGetArticle, len(articleList))
+	for i, article := range articleList {
+		theList[i] = articleToBackendGetArticle(article)
+	}
+
+	return theList, nil
+}
+
+/*
+ImportBBSArticles imports the articles from the BBS text dumps.
+All the files are parsed before importing, and the articles are imported in the order of the original create-ts.
+*/
+func (b *Backend) ImportBBSArticles(entityIDBytes []byte, files [][]byte) ([]*BackendImportBBSArticle, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	bbsArticles := make([]*BBSArticle, len(files))
+	for i, file := range files {
+		bbsArticles[i], err = ParseBBSArticle(file)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(bbsArticles, func(i, j int) bool {
+		return bbsArticles[i].CreateTS.IsLess(bbsArticles[j].CreateTS)
+	})
+
+	results := make([]*BackendImportBBSArticle, 0, len(bbsArticles))
+	for _, bbsArticle := range bbsArticles {
+		article, err := pm.ImportBBSArticle(bbsArticle)
+		if err != nil {
+			return results, err
+		}
+
+		results = append(results, bbsArticleToBackendImportBBSArticle(bbsArticle, article))
+	}
+
+	return results, nil
+}
+
+/*
+ExportBoard exports the board to <content-data-dir>/export/<board-id>/<format>.
+*/
+func (b *Backend) ExportBoard(entityIDBytes []byte, format ExportFormat) (*BackendExportBoard, error) {
+	dir := filepath.Join(b.Config.DataDir, ExportDir, string(entityIDBytes), string(format))
+
+	return b.ExportBoardToDir(entityIDBytes, format, dir)
+}
+
+func (b *Backend) ExportBoardToDir(entityIDBytes []byte, format ExportFormat, dir string) (*BackendExportBoard, error) {
+	if !format.IsValid() {
+		return nil, ErrInvalidExportFormat
+	}
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	exportBoard, err := pm.ExportBoard(dir, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendExportBoard{
+		BoardID:  exportBoard.ID,
+		Format:   format,
+		Dir:      dir,
+		NArticle: len(exportBoard.Articles),
+		NMedia:   len(exportBoard.AllMedia()),
+	}, nil
+}
+
 func (b *Backend) GetPokedArticleList(boardID []byte) ([]*BackendGetArticle, error) {
 
 	return nil, types.ErrNotImplemented
@@ -428,6 +748,11 @@ func (b *Backend) ShowBoardURL(entityIDBytes []byte) (*pkgservice.BackendJoinURL
 	return pkgservice.MarshalBackendJoinURL(board.CreatorID, nodeID, keyInfo, title, pkgservice.PathJoinBoard)
 }
 
+func (b *Backend) SubscribeBoard(ctx context.Context, entityIDBytes []byte) (*rpc.Subscription, error) {
+
+	return b.SubscribeEntityObjEvent(ctx, entityIDBytes)
+}
+
 /**********
  * BoardOplog
  **********/
@@ -561,7 +886,7 @@ func (b *Backend) GetFile(entityIDBytes []byte, mediaIDBytes []byte) (*BackendGe
 	return mediaToBackendGetFile(f), nil
 }
 
-func (b *Backend) UploadImage(entityIDBytes []byte, fileType string, bytes []byte) (*BackendUploadImg, error) {
+func (b *Backend) CreateFileUpload(entityIDBytes []byte, filename []byte, size int64) (*BackendFileUpload, error) {
 
 	thePM, err := b.EntityIDToPM(entityIDBytes)
 	if err != nil {
@@ -569,15 +894,15 @@ func (b *Backend) UploadImage(entityIDBytes []byte, fileType string, bytes []byt
 	}
 	pm := thePM.(*ProtocolManager)
 
-	media, err := pm.UploadImage(fileType, bytes)
+	upload, err := pm.CreateFileUpload(filename, size)
 	if err != nil {
 		return nil, err
 	}
 
-	return mediaToBackendUploadImg(media), nil
+	return mediaUploadToBackendFileUpload(upload, nil), nil
 }
 
-func (b *Backend) GetImage(entityIDBytes []byte, mediaIDBytes []byte) (*BackendGetImg, error) {
+func (b *Backend) CreateAudioUpload(entityIDBytes []byte, filename []byte, size int64, contentType string, codec string, duration int64) (*BackendFileUpload, error) {
 
 	thePM, err := b.EntityIDToPM(entityIDBytes)
 	if err != nil {
@@ -585,20 +910,188 @@ func (b *Backend) GetImage(entityIDBytes []byte, mediaIDBytes []byte) (*BackendG
 	}
 	pm := thePM.(*ProtocolManager)
 
-	mediaID, err := types.UnmarshalTextPttID(mediaIDBytes, false)
+	upload, err := pm.CreateAudioUpload(filename, size, contentType, codec, duration)
 	if err != nil {
 		return nil, err
 	}
-	if mediaID == nil {
-		return nil, types.ErrInvalidID
-	}
 
-	media, err := pm.GetMedia(mediaID)
-	if err != nil {
-		return nil, err
+	return mediaUploadToBackendFileUpload(upload, nil), nil
+}
+
+func (b *Backend) CreateVideoUpload(entityIDBytes []byte, filename []byte, size int64, contentType string, codec string, audioCodec string, duration int64, width uint16, height uint16) (*BackendFileUpload, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	upload, err := pm.CreateVideoUpload(filename, size, contentType, codec, audioCodec, duration, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return mediaUploadToBackendFileUpload(upload, nil), nil
+}
+
+func (b *Backend) UploadFileChunk(entityIDBytes []byte, uploadIDBytes []byte, offset int64, bytes []byte) (*BackendFileUpload, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	uploadID, err := types.UnmarshalTextPttID(uploadIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, media, err := pm.UploadFileChunk(uploadID, offset, bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return mediaUploadToBackendFileUpload(upload, media), nil
+}
+
+func (b *Backend) GetFileUpload(entityIDBytes []byte, uploadIDBytes []byte) (*BackendFileUpload, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	uploadID, err := types.UnmarshalTextPttID(uploadIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := pm.GetMediaUpload(uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mediaUploadToBackendFileUpload(upload, nil), nil
+}
+
+func (b *Backend) DeleteFileUpload(entityIDBytes []byte, uploadIDBytes []byte) (bool, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	uploadID, err := types.UnmarshalTextPttID(uploadIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+
+	err = pm.DeleteMediaUpload(uploadID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetFileInfo(entityIDBytes []byte, mediaIDBytes []byte) (*BackendGetFileInfo, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	mediaID, err := types.UnmarshalTextPttID(mediaIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if mediaID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	media, size, err := pm.GetMediaInfo(mediaID)
+	if err != nil {
+		return nil, err
+	}
+
+	readySize, err := media.GetReadySize(size)
+	if err != nil {
+		return nil, err
+	}
+
+	return mediaToBackendGetFileInfo(media, size, readySize), nil
+}
+
+func (b *Backend) GetFileRange(entityIDBytes []byte, mediaIDBytes []byte, start int64, end int64) (*BackendGetFileRange, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	mediaID, err := types.UnmarshalTextPttID(mediaIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if mediaID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	buf, err := pm.GetMediaRange(mediaID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendGetFileRange{ID: mediaID, Start: start, Buf: buf}, nil
+}
+
+func (b *Backend) UploadImage(entityIDBytes []byte, fileType string, bytes []byte) (*BackendUploadImg, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	media, err := pm.UploadImage(fileType, bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return mediaToBackendUploadImg(media), nil
+}
+
+func (b *Backend) GetImage(entityIDBytes []byte, mediaIDBytes []byte, size string) (*BackendGetImg, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	mediaID, err := types.UnmarshalTextPttID(mediaIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if mediaID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	media, imageSize, err := pm.GetImage(mediaID, pkgservice.ImageSize(size))
+	if err != nil {
+		return nil, err
 	}
 
-	return mediaToBackendGetImg(media), nil
+	backendGetImg := mediaToBackendGetImg(media)
+	backendGetImg.ID = mediaID
+	backendGetImg.Size = imageSize
+
+	return backendGetImg, nil
 
 }
 
@@ -681,6 +1174,33 @@ func (b *Backend) MarkBoardSeen(entityIDBytes []byte) (types.Timestamp, error) {
 	return pm.SaveLastSeen(types.ZeroTimestamp)
 }
 
+func (b *Backend) SetBoardPublicFeed(entityIDBytes []byte, isPublicFeed bool) (bool, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.SetPublicFeed(isPublicFeed)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) IsBoardPublicFeed(entityIDBytes []byte) (bool, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return pm.IsPublicFeed()
+}
+
 func (b *Backend) MarkArticleSeen(entityIDBytes []byte, articleIDBytes []byte) (types.Timestamp, error) {
 
 	thePM, err := b.EntityIDToPM(entityIDBytes)
@@ -780,3 +1300,431 @@ func (b *Backend) DeleteMember(entityIDBytes []byte, userIDBytes []byte) (bool,
 
 	return pm.DeleteMember(userID)
 }
+
+func (b *Backend) BanMember(entityIDBytes []byte, userIDBytes []byte) (*BackendBan, error) {
+
+	userID, err := types.UnmarshalTextPttID(userIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	ban, err := pm.BanMember(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return banToBackendBan(ban), nil
+}
+
+func (b *Backend) MuteMember(entityIDBytes []byte, userIDBytes []byte, seconds int64) (*BackendBan, error) {
+
+	userID, err := types.UnmarshalTextPttID(userIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	ban, err := pm.MuteMember(userID, seconds)
+	if err != nil {
+		return nil, err
+	}
+
+	return banToBackendBan(ban), nil
+}
+
+func (b *Backend) LiftBan(entityIDBytes []byte, userIDBytes []byte) (bool, error) {
+
+	userID, err := types.UnmarshalTextPttID(userIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.LiftBan(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetBanList(entityIDBytes []byte) ([]*BackendBan, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	bans, err := pm.GetBanList()
+	if err != nil {
+		return nil, err
+	}
+
+	backendBans := make([]*BackendBan, len(bans))
+	for i, ban := range bans {
+		backendBans[i] = banToBackendBan(ban)
+	}
+
+	return backendBans, nil
+}
+
+func (b *Backend) AddModerator(entityIDBytes []byte, userIDBytes []byte, perms *uint32) (*BackendModerator, error) {
+
+	userID, err := types.UnmarshalTextPttID(userIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	thePerms := PermModerator
+	if perms != nil {
+		thePerms = pkgservice.MemberPerm(*perms)
+	}
+
+	member, err := pm.AddModerator(userID, thePerms)
+	if err != nil {
+		return nil, err
+	}
+
+	return memberToBackendModerator(member), nil
+}
+
+func (b *Backend) RemoveModerator(entityIDBytes []byte, userIDBytes []byte) (bool, error) {
+
+	userID, err := types.UnmarshalTextPttID(userIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.RemoveModerator(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetModeratorList(entityIDBytes []byte) ([]*BackendModerator, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	members, err := pm.GetModeratorList()
+	if err != nil {
+		return nil, err
+	}
+
+	backendModerators := make([]*BackendModerator, len(members))
+	for i, member := range members {
+		backendModerators[i] = memberToBackendModerator(member)
+	}
+
+	return backendModerators, nil
+}
+
+func (b *Backend) PinArticle(entityIDBytes []byte, articleIDBytes []byte, order int) (*BackendPin, error) {
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	pin, err := pm.PinArticle(articleID, order)
+	if err != nil {
+		return nil, err
+	}
+
+	return pinToBackendPin(pin), nil
+}
+
+func (b *Backend) UnpinArticle(entityIDBytes []byte, articleIDBytes []byte) (bool, error) {
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.UnpinArticle(articleID)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetPinnedArticleList(entityIDBytes []byte) ([]*BackendPinnedArticle, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	pins, articles, err := pm.GetPinnedArticleList()
+	if err != nil {
+		return nil, err
+	}
+
+	theList := make([]*BackendPinnedArticle, len(pins))
+	for i, pin := range pins {
+		theList[i] = pinToBackendPinnedArticle(pin, articles[i])
+	}
+
+	return theList, nil
+}
+
+func (b *Backend) SetArticleTags(entityIDBytes []byte, articleIDBytes []byte, tags []string) (*BackendUpdateArticle, error) {
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	theArticle, err := pm.SetArticleTags(articleID, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return articleToBackendUpdateArticle(theArticle), nil
+}
+
+func (b *Backend) GetArticleListByTag(entityIDBytes []byte, tag string, startingArticleIDBytes []byte, limit int, listOrder pttdb.ListOrder) ([]*BackendGetArticle, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	startID, err := types.UnmarshalTextPttID(startingArticleIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	articleList, err := pm.GetArticleListByTag(tag, startID, limit, listOrder)
+	if err != nil {
+		return nil, err
+	}
+	theList := make([]*BackendGetArticle, len(articleList))
+	for i, article := range articleList {
+		theList[i] = articleToBackendGetArticle(article)
+	}
+
+	return theList, nil
+}
+
+func (b *Backend) SetBoardTags(entityIDBytes []byte, tags []string) ([]string, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.SetBoardTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return pm.GetBoardTags()
+}
+
+func (b *Backend) GetBoardTags(entityIDBytes []byte) ([]string, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return pm.GetBoardTags()
+}
+
+func (b *Backend) SetBoardPostInterval(entityIDBytes []byte, articleSeconds int64, commentSeconds int64) (*BackendPostInterval, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.SetPostInterval(articleSeconds, commentSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.getBoardPostInterval(pm)
+}
+
+func (b *Backend) GetBoardPostInterval(entityIDBytes []byte) (*BackendPostInterval, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return b.getBoardPostInterval(pm)
+}
+
+func (b *Backend) getBoardPostInterval(pm *ProtocolManager) (*BackendPostInterval, error) {
+	articleSeconds, commentSeconds, err := pm.GetPostInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendPostInterval{
+		BoardID:             pm.Entity().GetID(),
+		PostArticleInterval: articleSeconds,
+		PostCommentInterval: commentSeconds,
+	}, nil
+}
+
+func (b *Backend) GetArticleRevisionList(entityIDBytes []byte, articleIDBytes []byte) ([]*BackendArticleRevision, error) {
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	revisions, err := pm.GetArticleRevisionList(articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	lenRevisions := len(revisions)
+	theList := make([]*BackendArticleRevision, lenRevisions)
+	for i, revision := range revisions {
+		theList[i] = revisionToBackendArticleRevision(revision, i == lenRevisions-1)
+	}
+
+	return theList, nil
+}
+
+func (b *Backend) GetArticleRevision(entityIDBytes []byte, articleIDBytes []byte, revisionIDBytes []byte) (*BackendGetArticleRevision, error) {
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	revisionID, err := types.UnmarshalTextPttID(revisionIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	revision, lines, err := pm.GetArticleRevision(articleID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err := pm.getRevisionArticle(articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendGetArticleRevision{
+		BackendArticleRevision: revisionToBackendArticleRevision(revision, article.IsCurrentRevision(revision)),
+		Article:                lines,
+	}, nil
+}
+
+func (b *Backend) GetArticleRevisionDiff(entityIDBytes []byte, articleIDBytes []byte, fromRevisionIDBytes []byte, toRevisionIDBytes []byte) ([]*pkgservice.LineDiff, error) {
+
+	articleID, err := types.UnmarshalTextPttID(articleIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	fromRevisionID, err := types.UnmarshalTextPttID(fromRevisionIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	toRevisionID, err := types.UnmarshalTextPttID(toRevisionIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return pm.GetArticleRevisionDiff(articleID, fromRevisionID, toRevisionID)
+}
+
+func (b *Backend) SetArticleRevisionLimit(entityIDBytes []byte, limit int) (bool, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.SetArticleRevisionLimit(limit)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetArticleRevisionLimit(entityIDBytes []byte) (int, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return 0, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return pm.GetArticleRevisionLimit()
+}
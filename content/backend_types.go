@@ -103,6 +103,40 @@ type BackendCreateReply struct {
 	ContentBlockID *types.PttID `json:"cID"`
 }
 
+func replyToBackendCreateReply(r *Reply) *BackendCreateReply {
+	blockInfo := r.GetBlockInfo()
+	var blockInfoID *types.PttID
+	if blockInfo != nil {
+		blockInfoID = blockInfo.ID
+	}
+
+	return &BackendCreateReply{
+		BoardID:        r.EntityID,
+		ArticleID:      r.ArticleID,
+		CommentID:      r.CommentID,
+		ReplyID:        r.ID,
+		ContentBlockID: blockInfoID,
+	}
+}
+
+type BackendReact struct {
+	BoardID      *types.PttID `json:"BID"`
+	ArticleID    *types.PttID `json:"AID"`
+	TargetID     *types.PttID `json:"TID"`
+	ReactionID   *types.PttID `json:"RID"`
+	ReactionType ReactionType `json:"R"`
+}
+
+func reactionToBackendReact(r *Reaction) *BackendReact {
+	return &BackendReact{
+		BoardID:      r.EntityID,
+		ArticleID:    r.ArticleID,
+		TargetID:     r.TargetID,
+		ReactionID:   r.ID,
+		ReactionType: r.ReactionType,
+	}
+}
+
 type BackendUpdateArticle struct {
 	BoardID        *types.PttID `json:"BID"`
 	ArticleID      *types.PttID `json:"AID"`
@@ -145,6 +179,9 @@ type BackendDeleteComment struct {
 type BackendDeleteReply struct {
 }
 
+type BackendUnreact struct {
+}
+
 type BackendJoinBoard struct {
 }
 
@@ -168,6 +205,7 @@ type BackendGetBoard struct {
 	LastSeen        types.Timestamp
 	CreatorID       *types.PttID          `json:"C"`
 	BoardType       pkgservice.EntityType `json:"BT"`
+	IsPublicFeed    bool                  `json:"PF"`
 }
 
 func boardToBackendGetBoard(b *Board, myName string, theTitle *Title, myID *types.PttID) *BackendGetBoard {
@@ -180,6 +218,11 @@ func boardToBackendGetBoard(b *Board, myName string, theTitle *Title, myID *type
 		title = DefaultTitle(myID, b.CreatorID, myName)
 	}
 
+	isPublicFeed := false
+	if theTitle != nil {
+		isPublicFeed = theTitle.IsPublicFeed
+	}
+
 	articleCreateTS := b.ArticleCreateTS
 	/*
 		if articleCreateTS.IsLess(b.CreateTS) {
@@ -205,6 +248,7 @@ func boardToBackendGetBoard(b *Board, myName string, theTitle *Title, myID *type
 		LastSeen:        lastSeen,
 		CreatorID:       b.CreatorID,
 		BoardType:       b.EntityType,
+		IsPublicFeed:    isPublicFeed,
 	}
 }
 
@@ -219,6 +263,8 @@ type BackendGetArticle struct {
 	NPush           int             `json:"NP"`
 	NBoo            int             `json:"NB"`
 	Title           []byte          //`json:"T"`
+	Tags            []string        `json:"TG"`
+	Import          *ImportInfo     `json:"I,omitempty"`
 	CommentCreateTS types.Timestamp `json:"c"`
 	LastSeen        types.Timestamp `json:"L"`
 	Status          types.Status    `json:"S"`
@@ -259,6 +305,8 @@ func articleToBackendGetArticle(a *Article) *BackendGetArticle {
 		NPush:           int(nPush),
 		NBoo:            int(nBoo),
 		Title:           a.Title,
+		Tags:            a.Tags,
+		Import:          a.Import,
 		CommentCreateTS: commentCreateTS,
 		LastSeen:        lastSeen,
 		Status:          a.Status,
@@ -301,6 +349,7 @@ type BackendGetImg struct {
 	BoardID *types.PttID         `json:"BID"`
 	Type    pkgservice.MediaType `json:"T"`
 	Buf     []byte               `json:"B"`
+	Size    pkgservice.ImageSize `json:"s,omitempty"` // the size of the returned image, empty as the original image.
 }
 
 func mediaToBackendGetImg(img *pkgservice.Media) *BackendGetImg {
@@ -340,7 +389,247 @@ func mediaToBackendGetFile(media *pkgservice.Media) *BackendGetFile {
 	}
 }
 
+type BackendFileUpload struct {
+	ID        *types.PttID
+	BoardID   *types.PttID         `json:"BID"`
+	Filename  []byte               `json:"f"`
+	Size      int64                `json:"S"`
+	Offset    int64                `json:"O"`
+	MediaType pkgservice.MediaType `json:"M"`
+	MediaID   *types.PttID         `json:"MID,omitempty"` // set when all the bytes are uploaded.
+}
+
+func mediaUploadToBackendFileUpload(upload *pkgservice.MediaUpload, media *pkgservice.Media) *BackendFileUpload {
+	backendUpload := &BackendFileUpload{
+		ID:        upload.ID,
+		BoardID:   upload.EntityID,
+		Filename:  upload.Filename,
+		Size:      upload.Size,
+		Offset:    upload.Offset,
+		MediaType: upload.MediaType,
+	}
+	if media != nil {
+		backendUpload.MediaID = media.ID
+	}
+
+	return backendUpload
+}
+
+type BackendGetFileInfo struct {
+	ID          *types.PttID
+	BoardID     *types.PttID         `json:"BID"`
+	MediaType   pkgservice.MediaType `json:"M"`
+	MediaData   pkgservice.MediaData `json:"D,omitempty"`
+	ContentType string               `json:"t"`
+	Size        int64                `json:"S"`
+	ReadySize   int64                `json:"R"` // bytes from the beginning already synced (< Size while in sync).
+	CreateTS    types.Timestamp      `json:"CT"`
+}
+
+func mediaToBackendGetFileInfo(media *pkgservice.Media, size int64, readySize int64) *BackendGetFileInfo {
+	return &BackendGetFileInfo{
+		ID:          media.ID,
+		BoardID:     media.EntityID,
+		MediaType:   media.MediaType,
+		MediaData:   media.MediaData,
+		ContentType: media.GetContentType(),
+		Size:        size,
+		ReadySize:   readySize,
+		CreateTS:    media.CreateTS,
+	}
+}
+
+type BackendGetFileRange struct {
+	ID    *types.PttID
+	Start int64  `json:"s"`
+	Buf   []byte `json:"B"`
+}
+
+type BackendImportBBSArticle struct {
+	ArticleID *types.PttID
+	Title     string
+	Author    string
+	CreateTS  types.Timestamp
+	NComment  int
+}
+
+func bbsArticleToBackendImportBBSArticle(bbsArticle *BBSArticle, article *Article) *BackendImportBBSArticle {
+	return &BackendImportBBSArticle{
+		ArticleID: article.ID,
+		Title:     bbsArticle.Title,
+		Author:    bbsArticle.Author,
+		CreateTS:  bbsArticle.CreateTS,
+		NComment:  len(bbsArticle.Comments),
+	}
+}
+
+type BackendExportBoard struct {
+	BoardID  *types.PttID
+	Format   ExportFormat
+	Dir      string
+	NArticle int
+	NMedia   int
+}
+
 type BackendArticleSummaryParams struct {
 	ArticleID      string `json:"A"`
 	ContentBlockID string `json:"B"`
 }
+
+type BackendGetReply struct {
+	ID        *types.PttID
+	CreateTS  types.Timestamp
+	UpdateTS  types.Timestamp
+	CreatorID *types.PttID
+	BoardID   *types.PttID
+	ArticleID *types.PttID `json:"AID"`
+	CommentID *types.PttID `json:"CID"`
+	ParentID  *types.PttID `json:"PID"`
+	Depth     uint8        `json:"D"`
+	Buf       [][]byte     `json:"B"`
+	Status    types.Status `json:"S"`
+}
+
+func replyToBackendGetReply(r *Reply, buf [][]byte) *BackendGetReply {
+	return &BackendGetReply{
+		ID:        r.ID,
+		CreateTS:  r.CreateTS,
+		UpdateTS:  r.UpdateTS,
+		CreatorID: r.CreatorID,
+		BoardID:   r.EntityID,
+		ArticleID: r.ArticleID,
+		CommentID: r.CommentID,
+		ParentID:  r.ParentID,
+		Depth:     r.Depth,
+		Buf:       buf,
+		Status:    r.Status,
+	}
+}
+
+type BackendGetReactions struct {
+	BoardID   *types.PttID     `json:"BID"`
+	ArticleID *types.PttID     `json:"AID"`
+	TargetID  *types.PttID     `json:"TID"`
+	Reactions []*ReactionCount `json:"Rs"`
+}
+
+type BackendBan struct {
+	ID        *types.PttID    `json:"ID"`
+	BoardID   *types.PttID    `json:"BID"`
+	UserID    *types.PttID    `json:"UID"`
+	CreatorID *types.PttID    `json:"CID"`
+	BanType   BanType         `json:"B"`
+	CreateTS  types.Timestamp `json:"CT"`
+	ExpireTS  types.Timestamp `json:"ET"`
+}
+
+func banToBackendBan(b *Ban) *BackendBan {
+	return &BackendBan{
+		ID:        b.ID,
+		BoardID:   b.EntityID,
+		UserID:    b.UserID,
+		CreatorID: b.CreatorID,
+		BanType:   b.BanType,
+		CreateTS:  b.CreateTS,
+		ExpireTS:  b.ExpireTS,
+	}
+}
+
+type BackendPostInterval struct {
+	BoardID             *types.PttID `json:"BID"`
+	PostArticleInterval int64        `json:"A"`
+	PostCommentInterval int64        `json:"C"`
+}
+
+type BackendModerator struct {
+	BoardID  *types.PttID          `json:"BID"`
+	UserID   *types.PttID          `json:"UID"`
+	Perms    pkgservice.MemberPerm `json:"P"`
+	UpdateTS types.Timestamp       `json:"UT"`
+}
+
+func memberToBackendModerator(m *pkgservice.Member) *BackendModerator {
+	return &BackendModerator{
+		BoardID:  m.EntityID,
+		UserID:   m.ID,
+		Perms:    m.GetPerms(),
+		UpdateTS: m.PermUpdateTS,
+	}
+}
+
+type BackendPinnedArticle struct {
+	*BackendGetArticle
+
+	PinID    *types.PttID    `json:"PID"`
+	Order    int             `json:"O"`
+	PinnerID *types.PttID    `json:"PCID"`
+	PinTS    types.Timestamp `json:"PT"`
+}
+
+func pinToBackendPinnedArticle(p *Pin, a *Article) *BackendPinnedArticle {
+	return &BackendPinnedArticle{
+		BackendGetArticle: articleToBackendGetArticle(a),
+
+		PinID:    p.ID,
+		Order:    p.Order,
+		PinnerID: p.CreatorID,
+		PinTS:    p.CreateTS,
+	}
+}
+
+type BackendPin struct {
+	ID        *types.PttID    `json:"ID"`
+	BoardID   *types.PttID    `json:"BID"`
+	ArticleID *types.PttID    `json:"AID"`
+	CreatorID *types.PttID    `json:"CID"`
+	Order     int             `json:"O"`
+	CreateTS  types.Timestamp `json:"CT"`
+}
+
+func pinToBackendPin(p *Pin) *BackendPin {
+	return &BackendPin{
+		ID:        p.ID,
+		BoardID:   p.EntityID,
+		ArticleID: p.ArticleID,
+		CreatorID: p.CreatorID,
+		Order:     p.Order,
+		CreateTS:  p.CreateTS,
+	}
+}
+
+type BackendArticleRevision struct {
+	ID        *types.PttID    `json:"ID"`
+	BoardID   *types.PttID    `json:"BID"`
+	ArticleID *types.PttID    `json:"AID"`
+	UpdateTS  types.Timestamp `json:"UT"`
+	UpdaterID *types.PttID    `json:"UID"`
+	Title     []byte          `json:"T"`
+	Tags      []string        `json:"TG"`
+	NBlock    int             `json:"N"`
+	IsCurrent bool            `json:"C"`
+}
+
+func revisionToBackendArticleRevision(r *ArticleRevision, isCurrent bool) *BackendArticleRevision {
+	nBlock := 0
+	if r.BlockInfo != nil {
+		nBlock = r.BlockInfo.NBlock
+	}
+
+	return &BackendArticleRevision{
+		ID:        r.ID,
+		BoardID:   r.EntityID,
+		ArticleID: r.ArticleID,
+		UpdateTS:  r.UpdateTS,
+		UpdaterID: r.UpdaterID,
+		Title:     r.Title,
+		Tags:      r.Tags,
+		NBlock:    nBlock,
+		IsCurrent: isCurrent,
+	}
+}
+
+type BackendGetArticleRevision struct {
+	*BackendArticleRevision
+
+	Article [][]byte `json:"A"`
+}
@@ -0,0 +1,342 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+Ban is the ban (or the timed mute) of the user on the board, created by the master.
+
+The bans are synced as the board-oplogs, so that every member enforces the bans on the articles / comments from the user.
+The ban takes effect on the contents created after the create-ts of the ban,
+and the mute expires at the expire-ts. (ExpireTS as zero: never expires.)
+Each user has at most one alive ban of each ban-type, tracked by the user-key (entity-id, user-id, ban-type).
+*/
+type Ban struct {
+	*pkgservice.BaseObject `json:"b"`
+
+	UpdateTS types.Timestamp `json:"UT"`
+
+	SyncInfo *pkgservice.BaseSyncInfo `json:"s,omitempty"`
+
+	UserID   *types.PttID    `json:"UID"`
+	BanType  BanType         `json:"B"`
+	ExpireTS types.Timestamp `json:"ET"`
+}
+
+func NewBan(
+	createTS types.Timestamp,
+	creatorID *types.PttID,
+	entityID *types.PttID,
+
+	logID *types.PttID,
+
+	status types.Status,
+
+	userID *types.PttID,
+	banType BanType,
+	expireTS types.Timestamp,
+
+) (*Ban, error) {
+
+	id, err := types.NewPttID()
+	if err != nil {
+		return nil, err
+	}
+
+	o := pkgservice.NewObject(id, createTS, creatorID, entityID, logID, status)
+
+	return &Ban{
+		BaseObject: o,
+
+		UpdateTS: createTS,
+
+		UserID:   userID,
+		BanType:  banType,
+		ExpireTS: expireTS,
+	}, nil
+}
+
+func NewEmptyBan() *Ban {
+	return &Ban{BaseObject: &pkgservice.BaseObject{}}
+}
+
+func BansToObjs(typedObjs []*Ban) []pkgservice.Object {
+	objs := make([]pkgservice.Object, len(typedObjs))
+	for i, obj := range typedObjs {
+		objs[i] = obj
+	}
+	return objs
+}
+
+func ObjsToBans(objs []pkgservice.Object) []*Ban {
+	typedObjs := make([]*Ban, len(objs))
+	for i, obj := range objs {
+		typedObjs[i] = obj.(*Ban)
+	}
+	return typedObjs
+}
+
+func (pm *ProtocolManager) SetBanDB(u *Ban) {
+
+	u.SetDB(dbBoard, pm.DBObjLock(), pm.Entity().GetID(), pm.dbBanPrefix, pm.dbBanIdxPrefix, nil, nil)
+}
+
+func (b *Ban) Save(isLocked bool) error {
+	var err error
+
+	if !isLocked {
+		err = b.Lock()
+		if err != nil {
+			return err
+		}
+		defer b.Unlock()
+	}
+
+	key, err := b.MarshalKey()
+	if err != nil {
+		return err
+	}
+	marshaled, err := b.Marshal()
+	if err != nil {
+		return err
+	}
+
+	idxKey, err := b.IdxKey()
+	if err != nil {
+		return err
+	}
+
+	idx := &pttdb.Index{Keys: [][]byte{key}, UpdateTS: b.UpdateTS}
+
+	kvs := []*pttdb.KeyVal{
+		&pttdb.KeyVal{K: key, V: marshaled},
+	}
+
+	_, err = b.DB().ForcePutAll(idxKey, idx, kvs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *Ban) NewEmptyObj() pkgservice.Object {
+	newObj := NewEmptyBan()
+	newObj.CloneDB(b.BaseObject)
+	return newObj
+}
+
+func (b *Ban) GetNewObjByID(id *types.PttID, isLocked bool) (pkgservice.Object, error) {
+	newU := b.NewEmptyObj()
+	newU.SetID(id)
+	err := newU.GetByID(isLocked)
+	if err != nil {
+		return nil, err
+	}
+	return newU, nil
+}
+
+func (b *Ban) SetUpdateTS(ts types.Timestamp) {
+	b.UpdateTS = ts
+}
+
+func (b *Ban) GetUpdateTS() types.Timestamp {
+	return b.UpdateTS
+}
+
+func (b *Ban) Get(isLocked bool) error {
+	var err error
+
+	if !isLocked {
+		err = b.RLock()
+		if err != nil {
+			return err
+		}
+		defer b.RUnlock()
+	}
+
+	key, err := b.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	val, err := b.DB().DBGet(key)
+	if err != nil {
+		return err
+	}
+
+	return b.Unmarshal(val)
+}
+
+func (b *Ban) GetByID(isLocked bool) error {
+	var err error
+
+	val, err := b.GetValueByID(isLocked)
+	if err != nil {
+		return err
+	}
+
+	return b.Unmarshal(val)
+}
+
+func (b *Ban) MarshalKey() ([]byte, error) {
+	return common.Concat([][]byte{b.FullDBPrefix(), b.UserID[:], b.ID[:]})
+}
+
+func (b *Ban) Marshal() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+func (b *Ban) Unmarshal(theBytes []byte) error {
+	return json.Unmarshal(theBytes, b)
+}
+
+func (b *Ban) GetSyncInfo() pkgservice.SyncInfo {
+	if b.SyncInfo == nil {
+		return nil
+	}
+	return b.SyncInfo
+}
+
+func (b *Ban) SetSyncInfo(theSyncInfo pkgservice.SyncInfo) error {
+	if theSyncInfo == nil {
+		b.SyncInfo = nil
+		return nil
+	}
+
+	syncInfo, ok := theSyncInfo.(*pkgservice.BaseSyncInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+	b.SyncInfo = syncInfo
+
+	return nil
+}
+
+/*
+IsEffective checks whether the ban is in effect on the content created at ts.
+*/
+func (b *Ban) IsEffective(ts types.Timestamp) bool {
+	if b.Status != types.StatusAlive {
+		return false
+	}
+
+	if ts.IsLess(b.CreateTS) {
+		return false
+	}
+
+	if b.ExpireTS.Ts == 0 {
+		return true
+	}
+
+	return ts.IsLess(b.ExpireTS)
+}
+
+/**********
+ * User-Key
+ **********/
+
+func (b *Ban) MarshalUserKey() ([]byte, error) {
+	return common.Concat([][]byte{DBBanUserPrefix, b.EntityID[:], b.UserID[:], b.BanType.Marshal()})
+}
+
+func (b *Ban) SaveUserKey() error {
+	key, err := b.MarshalUserKey()
+	if err != nil {
+		return err
+	}
+
+	return b.DB().DB().Put(key, b.ID[:])
+}
+
+func (b *Ban) DeleteUserKey() error {
+	key, err := b.MarshalUserKey()
+	if err != nil {
+		return err
+	}
+
+	return b.DB().DB().Delete(key)
+}
+
+/*
+GetIDByUser gets the id of the ban of the user with the ban-type.
+*/
+func (b *Ban) GetIDByUser(userID *types.PttID, banType BanType) (*types.PttID, error) {
+	key, err := common.Concat([][]byte{DBBanUserPrefix, b.EntityID[:], userID[:], banType.Marshal()})
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := b.DB().DBGet(key)
+	if err != nil {
+		return nil, err
+	}
+
+	id := &types.PttID{}
+	copy(id[:], val)
+
+	return id, nil
+}
+
+/*
+GetList gets all the bans (including the deleted ones) of the board.
+*/
+func (b *Ban) GetList() ([]*Ban, error) {
+	iter, err := b.GetObjIterWithObj(nil, pttdb.ListOrderNext, false)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	bans := make([]*Ban, 0)
+	for iter.Next() {
+		ban := NewEmptyBan()
+		ban.CloneDB(b.BaseObject)
+		err = ban.Unmarshal(iter.Value())
+		if err != nil {
+			continue
+		}
+		bans = append(bans, ban)
+	}
+
+	return bans, nil
+}
+
+/*
+DeleteAll deletes all the bans and the user-keys of the board.
+*/
+func (b *Ban) DeleteAll() error {
+	bans, err := b.GetList()
+	if err != nil {
+		return err
+	}
+
+	for _, ban := range bans {
+		ban.DeleteUserKey()
+		ban.Delete(false)
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+/*
+BBSArticle is the article parsed from the classic PTT BBS text dump.
+The original author and timestamps are kept as the import-info,
+because the imported articles / comments are created by me.
+*/
+type BBSArticle struct {
+	Author   string
+	Nickname string
+	Board    string
+	Title    string
+	CreateTS types.Timestamp
+
+	Lines    [][]byte
+	Comments []*BBSComment
+}
+
+type BBSComment struct {
+	CommentType CommentType
+	Author      string
+	Content     string
+	IP          string
+	CreateTS    types.Timestamp
+}
+
+var (
+	bbsLocation = time.FixedZone("CST", BBSUTCOffsetSeconds)
+
+	bbsANSIRegexp = regexp.MustCompile("\x1b\\[[0-9;]*[A-Za-z]")
+
+	// <type> <author>: <content> [<ip>] <MM/DD> [<hh:mm>]
+	bbsCommentRegexp = regexp.MustCompile(`^(推|噓|→) ?([^:\s]+)\s*:(.*?)\s*(?:(\d{1,3}(?:\.\d{1,3}){3})\s+)?(\d{1,2})/(\d{1,2})(?:\s+(\d{1,2}):(\d{2}))?$`)
+)
+
+/*
+ParseBBSArticle parses the article in the BBS text format:
+
+	作者: <author> (<nickname>) 看板: <board>
+	標題: <title>
+	時間: <Mon Jan _2 15:04:05 2006>
+	───────────────────────────
+	<content>
+	--
+	※ 發信站: ...
+	推 <author>: <comment> <MM/DD hh:mm>
+
+Both big5 and utf8 dumps are accepted, and the ANSI escape codes are removed.
+*/
+func ParseBBSArticle(buf []byte) (*BBSArticle, error) {
+	if !utf8.Valid(buf) {
+		decoded, err := traditionalchinese.Big5.NewDecoder().Bytes(buf)
+		if err != nil {
+			return nil, ErrInvalidBBSArticle
+		}
+		buf = decoded
+	}
+
+	buf = bbsANSIRegexp.ReplaceAll(buf, nil)
+	buf = bytes.Replace(buf, []byte("\r\n"), []byte("\n"), -1)
+	lines := trimBBSEmptyLines(bytes.Split(buf, []byte("\n")))
+
+	a := &BBSArticle{}
+	nHeader := a.parseHeader(lines)
+	if a.Author == "" || a.Title == "" {
+		return nil, ErrInvalidBBSArticle
+	}
+
+	a.parseBody(lines[nHeader:])
+
+	return a, nil
+}
+
+func (a *BBSArticle) ImportInfo() *ImportInfo {
+	return &ImportInfo{
+		Author:   a.Author,
+		CreateTS: a.CreateTS,
+	}
+}
+
+func (c *BBSComment) ImportInfo() *ImportInfo {
+	return &ImportInfo{
+		Author:   c.Author,
+		CreateTS: c.CreateTS,
+	}
+}
+
+/*
+parseHeader parses the header lines and returns the number of the header lines.
+*/
+func (a *BBSArticle) parseHeader(lines [][]byte) int {
+	for i, eachLine := range lines {
+		line := bytes.TrimSpace(eachLine)
+		switch {
+		case bytes.HasPrefix(line, BBSHeaderAuthor):
+			author := bbsHeaderValue(line, BBSHeaderAuthor)
+			idx := bytes.Index(author, BBSHeaderBoard)
+			if idx >= 0 {
+				a.Board = string(bbsHeaderValue(author[idx:], BBSHeaderBoard))
+				author = author[:idx]
+			}
+			a.Author, a.Nickname = parseBBSAuthor(author)
+		case bytes.HasPrefix(line, BBSHeaderBoard):
+			a.Board = string(bbsHeaderValue(line, BBSHeaderBoard))
+		case bytes.HasPrefix(line, BBSHeaderTitle):
+			a.Title = string(bbsHeaderValue(line, BBSHeaderTitle))
+		case bytes.HasPrefix(line, BBSHeaderTime):
+			t, err := time.ParseInLocation(BBSTimeFormat, string(bbsHeaderValue(line, BBSHeaderTime)), bbsLocation)
+			if err == nil {
+				a.CreateTS = types.TimeToTimestamp(t)
+			}
+		case bytes.HasPrefix(line, BBSSeparator):
+		default:
+			return i
+		}
+	}
+
+	return len(lines)
+}
+
+/*
+parseBody parses the content and the comments.
+The comments are parsed only after the signature if the signature exists,
+and the comments without the year are in the year of the article (or the following years).
+*/
+func (a *BBSArticle) parseBody(lines [][]byte) {
+	idxSignature := -1
+	for i, line := range lines {
+		if bytes.HasPrefix(bytes.TrimSpace(line), BBSSignature) {
+			idxSignature = i
+			break
+		}
+	}
+
+	t := time.Now().In(bbsLocation)
+	if a.CreateTS.Ts != 0 {
+		t = a.CreateTS.ToTime().In(bbsLocation)
+	}
+	year, lastMonth := t.Year(), t.Month()
+
+	a.Lines = make([][]byte, 0, len(lines))
+	a.Comments = make([]*BBSComment, 0)
+	var comment *BBSComment
+	for i, line := range lines {
+		if i > idxSignature {
+			comment, year, lastMonth = parseBBSComment(line, year, lastMonth)
+			if comment != nil {
+				a.Comments = append(a.Comments, comment)
+				continue
+			}
+		}
+
+		a.Lines = append(a.Lines, bytes.TrimRight(line, " \t"))
+	}
+
+	a.Lines = trimBBSEmptyLines(a.Lines)
+}
+
+func parseBBSComment(line []byte, year int, lastMonth time.Month) (*BBSComment, int, time.Month) {
+	m := bbsCommentRegexp.FindSubmatch(bytes.TrimSpace(line))
+	if m == nil {
+		return nil, year, lastMonth
+	}
+
+	month, _ := strconv.Atoi(string(m[5]))
+	day, _ := strconv.Atoi(string(m[6]))
+	hour, _ := strconv.Atoi(string(m[7]))
+	minute, _ := strconv.Atoi(string(m[8]))
+	if month < 1 || month > 12 {
+		return nil, year, lastMonth
+	}
+
+	if time.Month(month) < lastMonth {
+		year++
+	}
+	lastMonth = time.Month(month)
+
+	t := time.Date(year, time.Month(month), day, hour, minute, 0, 0, bbsLocation)
+
+	return &BBSComment{
+		CommentType: BBSCommentTypes[string(m[1])],
+		Author:      string(m[2]),
+		Content:     strings.TrimSpace(string(m[3])),
+		IP:          string(m[4]),
+		CreateTS:    types.TimeToTimestamp(t),
+	}, year, lastMonth
+}
+
+/*
+bbsHeaderValue gets the value of the header line, accepting both "作者: xxx" and "作者  xxx".
+*/
+func bbsHeaderValue(line []byte, header []byte) []byte {
+	value := bytes.TrimSpace(line[len(header):])
+	value = bytes.TrimPrefix(value, []byte(":"))
+	value = bytes.TrimPrefix(value, []byte("："))
+
+	return bytes.TrimSpace(value)
+}
+
+/*
+parseBBSAuthor parses "<author> (<nickname>)".
+*/
+func parseBBSAuthor(author []byte) (string, string) {
+	author = bytes.TrimSpace(author)
+
+	idx := bytes.IndexByte(author, '(')
+	if idx < 0 {
+		return string(author), ""
+	}
+
+	nickname := author[idx+1:]
+	idxEnd := bytes.LastIndexByte(nickname, ')')
+	if idxEnd >= 0 {
+		nickname = nickname[:idxEnd]
+	}
+
+	return string(bytes.TrimSpace(author[:idx])), string(bytes.TrimSpace(nickname))
+}
+
+func trimBBSEmptyLines(lines [][]byte) [][]byte {
+	for len(lines) > 0 && len(bytes.TrimSpace(lines[0])) == 0 {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && len(bytes.TrimSpace(lines[len(lines)-1])) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
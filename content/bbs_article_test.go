@@ -0,0 +1,119 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+func TestParseBBSArticle(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	// the comments are across the new year of the article.
+	article := strings.Join([]string{
+		"作者: abc (ABC) 看板: Test",
+		"標題: [問卦] hello",
+		"時間: Thu Dec 31 23:50:00 2015",
+		"───────────────────────",
+		"",
+		"\x1b[1;33mline1\x1b[m",
+		"",
+		"推 x: not a comment 01/01",
+		"--",
+		"※ 發信站: 批踢踢實業坊(ptt.cc), 來自: 1.2.3.4",
+		"推 u1: good 1.2.3.4 12/31 23:55",
+		"噓 u2: bad 01/01 00:10",
+		"→ u3 : hmm 01/02",
+		"",
+	}, "\n")
+
+	big5Article, err := traditionalchinese.Big5.NewEncoder().Bytes([]byte(strings.Replace(article, "\n", "\r\n", -1)))
+	if err != nil {
+		t.Fatalf("unable to encode big5: e: %v", err)
+	}
+
+	tsAt := func(year int, month time.Month, day, hour, minute int) types.Timestamp {
+		return types.TimeToTimestamp(time.Date(year, month, day, hour, minute, 0, 0, bbsLocation))
+	}
+
+	want := &BBSArticle{
+		Author:   "abc",
+		Nickname: "ABC",
+		Board:    "Test",
+		Title:    "[問卦] hello",
+		CreateTS: tsAt(2015, time.December, 31, 23, 50),
+		Lines: [][]byte{
+			[]byte("line1"),
+			[]byte(""),
+			[]byte("推 x: not a comment 01/01"),
+			[]byte("--"),
+			[]byte("※ 發信站: 批踢踢實業坊(ptt.cc), 來自: 1.2.3.4"),
+		},
+		Comments: []*BBSComment{
+			{CommentType: CommentTypePush, Author: "u1", Content: "good", IP: "1.2.3.4", CreateTS: tsAt(2015, time.December, 31, 23, 55)},
+			{CommentType: CommentTypeBoo, Author: "u2", Content: "bad", CreateTS: tsAt(2016, time.January, 1, 0, 10)},
+			{CommentType: CommentTypeNone, Author: "u3", Content: "hmm", CreateTS: tsAt(2016, time.January, 2, 0, 0)},
+		},
+	}
+
+	// prepare test-cases
+	tests := []struct {
+		name    string
+		buf     []byte
+		want    *BBSArticle
+		wantErr bool
+	}{
+		{name: "utf8", buf: []byte(article), want: want},
+		{name: "big5", buf: big5Article, want: want},
+		{name: "no-title", buf: []byte("作者: abc\n\nline1\n"), wantErr: true},
+		{name: "empty", buf: nil, wantErr: true},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBBSArticle(tt.buf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseBBSArticle() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got.Lines, tt.want.Lines) {
+				t.Errorf("ParseBBSArticle() lines = %q, want %q", got.Lines, tt.want.Lines)
+			}
+			for i, comment := range got.Comments {
+				if i < len(tt.want.Comments) && !reflect.DeepEqual(comment, tt.want.Comments[i]) {
+					t.Errorf("ParseBBSArticle() comment[%v] = %+v, want %+v", i, comment, tt.want.Comments[i])
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseBBSArticle() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -217,6 +217,50 @@ func (b *Board) MarshalLastSeenKey() ([]byte, error) {
 	return common.Concat([][]byte{DBBoardLastSeenPrefix, b.ID[:]})
 }
 
+/*
+SaveArticleRevisionLimit saves the number of the previous revisions kept for each article on this node.
+*/
+func (b *Board) SaveArticleRevisionLimit(limit int) error {
+	key, err := b.MarshalArticleRevisionLimitKey()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := json.Marshal(limit)
+	if err != nil {
+		return err
+	}
+
+	return dbBoardCore.Put(key, marshaled)
+}
+
+func (b *Board) LoadArticleRevisionLimit() (int, error) {
+	key, err := b.MarshalArticleRevisionLimitKey()
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := dbBoardCore.Get(key)
+	if err == leveldb.ErrNotFound {
+		return DefaultArticleRevisionLimit, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	limit := 0
+	err = json.Unmarshal(data, &limit)
+	if err != nil {
+		return 0, err
+	}
+
+	return limit, nil
+}
+
+func (b *Board) MarshalArticleRevisionLimitKey() ([]byte, error) {
+	return common.Concat([][]byte{DBBoardRevisionLimitPrefix, b.ID[:]})
+}
+
 func (b *Board) SaveArticleCreateTS(ts types.Timestamp) error {
 	b.ArticleCreateTS = ts
 
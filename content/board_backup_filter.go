@@ -0,0 +1,134 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"bytes"
+
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+)
+
+/*
+BoardBackupFilter filters out the key/vals in the board-db re-syncable from the peers
+through the board-oplogs (the board-oplogs with the merkle-trees, the contents and the media).
+
+The log0 (the first alive board-oplog) of each board is kept with the idx and the merkle-node,
+as the boards are prestarted with the log0.
+*/
+type BoardBackupFilter struct {
+	prefixes [][]byte
+	keptKeys map[string]bool
+}
+
+/*
+NewBoardBackupFilter gets the log0s of the boards from the iter of the board-db.
+The iter is required to be reset before iterating the key/vals to filter.
+*/
+func NewBoardBackupFilter(iter iterator.Iterator) (*BoardBackupFilter, error) {
+	log0Keys, err := pkgservice.GetLog0Keys(iter, DBBoardOplogPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BoardBackupFilter{
+		prefixes: dbBoardResyncablePrefixes(),
+		keptKeys: log0Keys,
+	}, nil
+}
+
+/*
+IsExcluded checks whether the key/val is excluded from the backup.
+The key/vals are required to be checked in the order of the keys,
+as the idx (.bdig) of the log0 comes before the merkle-node (.bdmk).
+*/
+func (f *BoardBackupFilter) IsExcluded(key []byte, val []byte) bool {
+	if f.keptKeys[string(key)] {
+		return false
+	}
+
+	if bytes.HasPrefix(key, DBBoardIdxOplogPrefix) {
+		idx := &pttdb.Index{}
+		err := idx.Unmarshal(val)
+		if err != nil || len(idx.Keys) == 0 || !f.keptKeys[string(idx.Keys[0])] {
+			return true
+		}
+
+		for _, eachKey := range idx.Keys {
+			f.keptKeys[string(eachKey)] = true
+		}
+		return false
+	}
+
+	for _, prefix := range f.prefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+dbBoardResyncablePrefixes returns the db-prefixes in the board-db
+which are synced from the peers through the board-oplogs (the oplogs, the contents and the media).
+The titles are not included as the board-settings.
+*/
+func dbBoardResyncablePrefixes() [][]byte {
+	prefixes := pkgservice.OplogDBPrefixes(DBBoardOplogPrefix, DBBoardIdxOplogPrefix, DBBoardMerkleOplogPrefix)
+
+	return append(prefixes,
+		DBBoardArticleCreateTSPrefix,
+		DBBoardCommentCreateTSPrefix,
+		DBArticlePrefix,
+		DBArticleIdxPrefix,
+		DBArticleCommentCreateTSPrefix,
+		DBArticleRevisionPrefix,
+		DBPushPrefix,
+		DBBooPrefix,
+		DBCommentPrefix,
+		DBCommentIdxPrefix,
+		DBReplyPrefix,
+		DBReplyIdxPrefix,
+		DBReactionPrefix,
+		DBReactionIdxPrefix,
+		DBReactionUserPrefix,
+		DBReactionCountPrefix,
+		DBBanPrefix,
+		DBBanIdxPrefix,
+		DBBanUserPrefix,
+		DBPinPrefix,
+		DBPinIdxPrefix,
+		DBPinArticlePrefix,
+		DBImagePrefix,
+		DBImageIdxPrefix,
+		DBMediaPrefix,
+		DBMediaIdxPrefix,
+		DBSearchTermPrefix,
+		DBSearchDocPrefix,
+		DBTagPrefix,
+		DBTagDocPrefix,
+		DBSearchBackfillPrefix,
+		pkgservice.DBMediaPrefix,
+		pkgservice.DBMediaIdxPrefix,
+		pkgservice.DBMediaUploadPrefix,
+		pkgservice.DBBlockInfoPrefix,
+		pkgservice.DBBlockInfoIdxPrefix,
+		pkgservice.DBContentBlockPrefix,
+	)
+}
@@ -0,0 +1,107 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+)
+
+func TestBoardBackupFilter(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	db, _ := pttdb.NewMemLDBDatabase("test-backup", "./test.out")
+	defer pttdb.ResetMemStores()
+	defer db.Close()
+
+	boardID, _ := types.NewPttID()
+	boardID2, _ := types.NewPttID()
+
+	concat := func(bs ...[]byte) []byte {
+		theBytes, _ := common.Concat(bs)
+		return theBytes
+	}
+	putIdx := func(idxKey []byte, keys ...[]byte) {
+		idx := &pttdb.Index{Keys: keys}
+		marshaled, _ := idx.Marshal()
+		db.Put(idxKey, marshaled)
+	}
+
+	log0 := concat(DBBoardOplogPrefix, boardID[:], []byte("ts1"), []byte("log0"))
+	merkle0 := concat(DBBoardMerkleOplogPrefix, boardID[:], []byte("ts1"), []byte("log0"))
+	log1 := concat(DBBoardOplogPrefix, boardID[:], []byte("ts2"), []byte("log1"))
+	merkle1 := concat(DBBoardMerkleOplogPrefix, boardID[:], []byte("ts2"), []byte("log1"))
+	board2Log0 := concat(DBBoardOplogPrefix, boardID2[:], []byte("ts3"), []byte("log2"))
+	idx0 := concat(DBBoardIdxOplogPrefix, boardID[:], []byte("log0"))
+	idx1 := concat(DBBoardIdxOplogPrefix, boardID[:], []byte("log1"))
+	board := concat(DBBoardPrefix, boardID[:])
+	lastSeen := concat(DBBoardLastSeenPrefix, boardID[:])
+	title := concat(DBTitlePrefix, boardID[:])
+
+	for _, key := range [][]byte{log0, merkle0, log1, merkle1, board2Log0, board, lastSeen, title} {
+		db.Put(key, []byte("v"))
+	}
+	putIdx(idx0, log0, merkle0)
+	putIdx(idx1, log1, merkle1)
+
+	// re-syncable
+	for _, key := range [][]byte{
+		concat([]byte(".bdli"), boardID[:], []byte("ts4")),
+		concat([]byte(".bdmt"), boardID[:]),
+		concat(DBArticlePrefix, boardID[:]),
+		concat(DBCommentPrefix, boardID[:]),
+		concat(DBMediaPrefix, boardID[:]),
+	} {
+		db.Put(key, []byte("v"))
+	}
+
+	want := [][]byte{log0, merkle0, board2Log0, idx0, board, lastSeen, title}
+	sort.Slice(want, func(i, j int) bool { return string(want[i]) < string(want[j]) })
+
+	// run test
+	iter, err := db.NewSnapshotIterator()
+	if err != nil {
+		t.Errorf("NewSnapshotIterator() error = %v", err)
+		return
+	}
+	defer iter.Release()
+
+	filter, err := NewBoardBackupFilter(iter)
+	if err != nil {
+		t.Errorf("NewBoardBackupFilter() error = %v", err)
+		return
+	}
+
+	got := make([][]byte, 0)
+	for isOK := iter.First(); isOK; isOK = iter.Next() {
+		if filter.IsExcluded(iter.Key(), iter.Value()) {
+			continue
+		}
+		got = append(got, common.CloneBytes(iter.Key()))
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BoardBackupFilter = %v, want %v", got, want)
+	}
+}
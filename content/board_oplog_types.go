@@ -45,6 +45,15 @@ const (
 	BoardOpTypeUpdateReply
 	BoardOpTypeDeleteReply
 
+	BoardOpTypeCreateReaction
+	BoardOpTypeDeleteReaction
+
+	BoardOpTypeCreateBan
+	BoardOpTypeDeleteBan
+
+	BoardOpTypeCreatePin
+	BoardOpTypeDeletePin
+
 	NBoardOpType
 )
 
@@ -65,6 +74,13 @@ type BoardOpCreateTitle struct {
 
 type BoardOpUpdateTitle struct {
 	TitleHash []byte `json:"TH"`
+
+	PostArticleInterval int64 `json:"pa,omitempty"`
+	PostCommentInterval int64 `json:"pc,omitempty"`
+
+	IsPublicFeed bool `json:"pf,omitempty"`
+
+	TagsHash []byte `json:"tH,omitempty"`
 }
 
 type BoardOpCreateArticle struct {
@@ -72,8 +88,12 @@ type BoardOpCreateArticle struct {
 	Hashs       [][][]byte   `json:"H"`
 	NBlock      int          `json:"NB"`
 
+	ImportAuthor   string `json:"iA,omitempty"`
+	ImportCreateTS int64  `json:"iT,omitempty"`
+
 	MediaIDs []*types.PttID `json:"ms,omitempty"`
 
+	TagsHash  []byte `json:"tH,omitempty"`
 	TitleHash []byte `json:"th"`
 }
 
@@ -84,6 +104,7 @@ type BoardOpUpdateArticle struct {
 
 	MediaIDs []*types.PttID `json:"ms,omitempty"`
 
+	TagsHash  []byte `json:"tH,omitempty"`
 	TitleHash []byte `json:"th"`
 }
 
@@ -93,9 +114,13 @@ type BoardOpDeleteArticle struct {
 type BoardOpCreateComment struct {
 	ArticleID *types.PttID `json:"AID"`
 
-	BlockInfoID *types.PttID   `json:"BID"`
-	Hashs       [][][]byte     `json:"H"`
-	MediaIDs    []*types.PttID `json:"ms,omitempty"`
+	BlockInfoID *types.PttID `json:"BID"`
+	Hashs       [][][]byte   `json:"H"`
+
+	ImportAuthor   string `json:"iA,omitempty"`
+	ImportCreateTS int64  `json:"iT,omitempty"`
+
+	MediaIDs []*types.PttID `json:"ms,omitempty"`
 }
 
 type BoardOpDeleteComment struct {
@@ -103,17 +128,19 @@ type BoardOpDeleteComment struct {
 }
 
 type BoardOpCreateReply struct {
-	ArticleID *types.PttID `json:"AID"`
-	CommentID *types.PttID `json:"ACD"`
+	CommentID   *types.PttID `json:"ACD"`
+	ArticleID   *types.PttID `json:"AID"`
+	BlockInfoID *types.PttID `json:"BID"`
+	Depth       uint8        `json:"D"`
+	Hashs       [][][]byte   `json:"H"`
+	ParentID    *types.PttID `json:"PID"`
 
-	BlockInfoID *types.PttID   `json:"BID"`
-	Hashs       [][][]byte     `json:"H"`
-	MediaIDs    []*types.PttID `json:"ms,omitempty"`
+	MediaIDs []*types.PttID `json:"ms,omitempty"`
 }
 
 type BoardOpDeleteReply struct {
-	ArticleID *types.PttID `json:"AID"`
 	CommentID *types.PttID `json:"ACD"`
+	ArticleID *types.PttID `json:"AID"`
 }
 
 type BoardOpUpdateReply struct {
@@ -121,3 +148,35 @@ type BoardOpUpdateReply struct {
 	Hashs       [][][]byte     `json:"H"`
 	MediaIDs    []*types.PttID `json:"ms,omitempty"`
 }
+
+type BoardOpCreateReaction struct {
+	ArticleID    *types.PttID `json:"AID"`
+	ReactionType ReactionType `json:"R"`
+	TargetID     *types.PttID `json:"TID"`
+}
+
+type BoardOpDeleteReaction struct {
+	ArticleID    *types.PttID `json:"AID"`
+	ReactionType ReactionType `json:"R"`
+	TargetID     *types.PttID `json:"TID"`
+}
+
+type BoardOpCreateBan struct {
+	BanType  BanType      `json:"B"`
+	ExpireTS int64        `json:"ET"` // in seconds, as the nested keys of types.Timestamp are not sorted.
+	UserID   *types.PttID `json:"UID"`
+}
+
+type BoardOpDeleteBan struct {
+	BanType BanType      `json:"B"`
+	UserID  *types.PttID `json:"UID"`
+}
+
+type BoardOpCreatePin struct {
+	ArticleID *types.PttID `json:"AID"`
+	Order     int          `json:"O"`
+}
+
+type BoardOpDeletePin struct {
+	ArticleID *types.PttID `json:"AID"`
+}
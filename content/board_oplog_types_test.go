@@ -0,0 +1,140 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func TestBoardOplog_SignVerify(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	id, _ := types.NewPttID()
+	id2, _ := types.NewPttID()
+	id3, _ := types.NewPttID()
+
+	// prepare test-cases
+	tests := []struct {
+		name string
+		op   pkgservice.OpType
+		data interface{}
+	}{
+		{
+			name: "create-reply",
+			op:   BoardOpTypeCreateReply,
+			data: &BoardOpCreateReply{ArticleID: id, CommentID: id2, ParentID: id3, Depth: 2, BlockInfoID: id3, Hashs: [][][]byte{{{1, 2}}}, MediaIDs: []*types.PttID{id}},
+		},
+		{
+			name: "update-reply",
+			op:   BoardOpTypeUpdateReply,
+			data: &BoardOpUpdateReply{BlockInfoID: id3, Hashs: [][][]byte{{{1, 2}}}, MediaIDs: []*types.PttID{id}},
+		},
+		{
+			name: "delete-reply",
+			op:   BoardOpTypeDeleteReply,
+			data: &BoardOpDeleteReply{ArticleID: id, CommentID: id2},
+		},
+		{
+			name: "create-reaction",
+			op:   BoardOpTypeCreateReaction,
+			data: &BoardOpCreateReaction{ArticleID: id, ReactionType: ReactionTypeLove, TargetID: id2},
+		},
+		{
+			name: "delete-reaction",
+			op:   BoardOpTypeDeleteReaction,
+			data: &BoardOpDeleteReaction{ArticleID: id, ReactionType: ReactionTypeLove, TargetID: id2},
+		},
+		{
+			name: "create-ban",
+			op:   BoardOpTypeCreateBan,
+			data: &BoardOpCreateBan{BanType: BanTypeMute, ExpireTS: tDefaultTimestamp.Ts, UserID: id2},
+		},
+		{
+			name: "delete-ban",
+			op:   BoardOpTypeDeleteBan,
+			data: &BoardOpDeleteBan{BanType: BanTypeMute, UserID: id2},
+		},
+		{
+			name: "update-member",
+			op:   pkgservice.MemberOpTypeUpdateMember,
+			data: &pkgservice.MemberOpUpdateMember{Perms: PermDeleteArticle | PermPin},
+		},
+		{
+			name: "create-pin",
+			op:   BoardOpTypeCreatePin,
+			data: &BoardOpCreatePin{ArticleID: id, Order: 2},
+		},
+		{
+			name: "delete-pin",
+			op:   BoardOpTypeDeletePin,
+			data: &BoardOpDeletePin{ArticleID: id},
+		},
+		{
+			name: "create-article",
+			op:   BoardOpTypeCreateArticle,
+			data: &BoardOpCreateArticle{BlockInfoID: id3, Hashs: [][][]byte{{{1, 2}}}, NBlock: 1, MediaIDs: []*types.PttID{id}, TagsHash: []byte{3, 4}, TitleHash: []byte{5, 6}},
+		},
+		{
+			name: "create-article-import",
+			op:   BoardOpTypeCreateArticle,
+			data: &BoardOpCreateArticle{BlockInfoID: id3, Hashs: [][][]byte{{{1, 2}}}, NBlock: 1, ImportAuthor: "abc", ImportCreateTS: tDefaultTimestamp.Ts, TitleHash: []byte{5, 6}},
+		},
+		{
+			name: "create-comment-import",
+			op:   BoardOpTypeCreateComment,
+			data: &BoardOpCreateComment{ArticleID: id, BlockInfoID: id3, Hashs: [][][]byte{{{1, 2}}}, ImportAuthor: "abc", ImportCreateTS: tDefaultTimestamp.Ts, MediaIDs: []*types.PttID{id}},
+		},
+		{
+			name: "update-article",
+			op:   BoardOpTypeUpdateArticle,
+			data: &BoardOpUpdateArticle{BlockInfoID: id3, Hashs: [][][]byte{{{1, 2}}}, NBlock: 1, MediaIDs: []*types.PttID{id}, TagsHash: []byte{3, 4}, TitleHash: []byte{5, 6}},
+		},
+		{
+			name: "update-title",
+			op:   BoardOpTypeUpdateTitle,
+			data: &BoardOpUpdateTitle{TitleHash: []byte{5, 6}, PostArticleInterval: 30, PostCommentInterval: 3, IsPublicFeed: true, TagsHash: []byte{3, 4}},
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the op-data is declared in the order of the json keys.
+			err := pkgservice.CheckOpDataSorted(tt.data)
+			if err != nil {
+				t.Errorf("CheckOpDataSorted() error = %v", err)
+			}
+
+			o, err := pkgservice.NewOplog(id, tDefaultTimestamp, id, tt.op, tt.data, nil, id, DBBoardOplogPrefix, DBBoardIdxOplogPrefix, DBBoardMerkleOplogPrefix, nil)
+			if err != nil {
+				t.Errorf("NewOplog() error = %v", err)
+				return
+			}
+			err = pkgservice.CheckOplogSignVerify(o)
+			if err != nil {
+				t.Errorf("CheckOplogSignVerify() error = %v", err)
+			}
+		})
+	}
+
+	// teardown test
+}
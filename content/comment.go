@@ -36,6 +36,8 @@ type Comment struct {
 	ArticleCreatorID *types.PttID `json:"aID"`
 
 	CommentType CommentType `json:"t"`
+
+	Import *ImportInfo `json:"i,omitempty"`
 }
 
 func NewComment(
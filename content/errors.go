@@ -30,4 +30,28 @@ var (
 	ErrInvalidOP = errors.New("invalid op")
 
 	ErrInvalidTitleLength = errors.New("invalid title length")
+
+	ErrInvalidReplyDepth = errors.New("invalid reply depth")
+
+	ErrInvalidReactionType = errors.New("invalid reaction type")
+
+	ErrInvalidBanType      = errors.New("invalid ban type")
+	ErrInvalidMuteDuration = errors.New("invalid mute duration")
+	ErrBanned              = errors.New("banned")
+	ErrMuted               = errors.New("muted")
+	ErrPostTooFrequent     = errors.New("post too frequent")
+	ErrInvalidPostInterval = errors.New("invalid post interval")
+
+	ErrInvalidPerms = errors.New("invalid perms")
+
+	ErrTooManyPins = errors.New("too many pinned articles")
+
+	ErrInvalidTag  = errors.New("invalid tag")
+	ErrTooManyTags = errors.New("too many tags")
+
+	ErrInvalidRevisionLimit = errors.New("invalid revision limit")
+
+	ErrInvalidExportFormat = errors.New("invalid export format")
+
+	ErrInvalidBBSArticle = errors.New("invalid bbs article")
 )
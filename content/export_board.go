@@ -0,0 +1,376 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"bytes"
+	"encoding/json"
+	htmltemplate "html/template"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatHTML     ExportFormat = "html"
+	ExportFormatMarkdown ExportFormat = "markdown"
+)
+
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormatHTML || f == ExportFormatMarkdown
+}
+
+/*
+ExportBoard is the board with all the alive articles, comments and replies,
+and is the content of board.json in the exported archive.
+*/
+type ExportBoard struct {
+	ID          *types.PttID
+	Title       string
+	CreatorID   *types.PttID
+	CreatorName string
+	ExportTS    types.Timestamp
+
+	Articles []*ExportArticle
+}
+
+type ExportArticle struct {
+	ID          *types.PttID
+	Title       string
+	CreatorID   *types.PttID
+	CreatorName string
+	CreateTS    types.Timestamp
+	UpdateTS    types.Timestamp
+
+	Lines []string
+	Media []*ExportMedia
+
+	NPush    int
+	NBoo     int
+	Comments []*ExportComment
+}
+
+type ExportComment struct {
+	ID          *types.PttID
+	CommentType CommentType
+	CreatorID   *types.PttID
+	CreatorName string
+	CreateTS    types.Timestamp
+
+	Lines []string
+	Media []*ExportMedia
+
+	Replies []*ExportReply
+}
+
+type ExportReply struct {
+	ID          *types.PttID
+	CreatorID   *types.PttID
+	CreatorName string
+	CreateTS    types.Timestamp
+
+	Lines []string
+	Media []*ExportMedia
+}
+
+/*
+AllMedia returns all the media referred by the articles, comments and replies.
+*/
+func (b *ExportBoard) AllMedia() []*ExportMedia {
+	allMedia := make([]*ExportMedia, 0)
+	for _, article := range b.Articles {
+		allMedia = append(allMedia, article.Media...)
+		for _, comment := range article.Comments {
+			allMedia = append(allMedia, comment.Media...)
+			for _, reply := range comment.Replies {
+				allMedia = append(allMedia, reply.Media...)
+			}
+		}
+	}
+
+	return allMedia
+}
+
+/*
+ExportMedia is the media in the exported archive, with Path relative to the root of the archive.
+*/
+type ExportMedia struct {
+	ID      *types.PttID
+	Type    pkgservice.MediaType
+	IsImage bool
+	Path    string
+
+	buf []byte
+}
+
+func mediaToExportMedia(media *pkgservice.Media) *ExportMedia {
+	idStr := exportIDString(media.ID)
+
+	var filename string
+	switch media.MediaType {
+	case pkgservice.MediaTypeJPEG:
+		filename = idStr + ".jpg"
+	case pkgservice.MediaTypeGIF:
+		filename = idStr + ".gif"
+	case pkgservice.MediaTypePNG:
+		filename = idStr + ".png"
+	default:
+		filename = idStr
+		name := mediaFilename(media)
+		if name != "" {
+			filename = idStr + "-" + name
+		}
+	}
+
+	return &ExportMedia{
+		ID:      media.ID,
+		Type:    media.MediaType,
+		IsImage: media.MediaType != pkgservice.MediaTypeFile,
+		Path:    ExportMediaDir + "/" + filename,
+		buf:     media.Buf,
+	}
+}
+
+/*
+mediaFilename gets the sanitized filename of the file-media.
+*/
+func mediaFilename(media *pkgservice.Media) string {
+	marshaled, err := json.Marshal(media.MediaData)
+	if err != nil {
+		return ""
+	}
+
+	data := &pkgservice.MediaDataFile{}
+	err = json.Unmarshal(marshaled, data)
+	if err != nil {
+		return ""
+	}
+
+	filename := filepath.Base(string(data.Filename))
+	if filename == "." || filename == string(filepath.Separator) {
+		return ""
+	}
+
+	return filename
+}
+
+func exportIDString(id *types.PttID) string {
+	idBytes, err := id.MarshalText()
+	if err != nil {
+		return ""
+	}
+
+	return string(idBytes)
+}
+
+func bufToLines(buf [][]byte) []string {
+	lines := make([]string, len(buf))
+	for i, each := range buf {
+		lines[i] = string(each)
+	}
+
+	return lines
+}
+
+func exportTSToString(ts types.Timestamp) string {
+	return ts.ToTime().Format(ExportTimeFormat)
+}
+
+func exportCommentTypeToString(commentType CommentType) string {
+	switch commentType {
+	case CommentTypePush:
+		return "推"
+	case CommentTypeBoo:
+		return "噓"
+	}
+
+	return "→"
+}
+
+var exportMarkdownReplacer = strings.NewReplacer(
+	"\\", "\\\\",
+	"`", "\\`",
+	"*", "\\*",
+	"_", "\\_",
+	"[", "\\[",
+	"]", "\\]",
+	"<", "&lt;",
+	">", "&gt;",
+	"#", "\\#",
+)
+
+func exportEscapeMarkdown(str string) string {
+	return exportMarkdownReplacer.Replace(str)
+}
+
+var exportFuncMap = map[string]interface{}{
+	"id":          exportIDString,
+	"ts":          exportTSToString,
+	"commentType": exportCommentTypeToString,
+	"base":        filepath.Base,
+	"md":          exportEscapeMarkdown,
+}
+
+/**********
+ * html
+ **********/
+
+const exportHTMLHead = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { max-width: 800px; margin: 0 auto; padding: 1em; font-family: sans-serif; line-height: 1.5; }
+.meta { color: #888; font-size: 0.9em; }
+.content p { margin: 0; }
+img { max-width: 100%; display: block; margin: 0.5em 0; }
+ul.comments, ul.replies { list-style: none; padding-left: 0; }
+ul.replies { padding-left: 2em; }
+</style>
+</head>
+<body>
+`
+
+const exportHTMLIndex = exportHTMLHead + `<h1>{{.Title}}</h1>
+<p class="meta">{{.CreatorName}} · exported at {{ts .ExportTS}}</p>
+<ul>
+{{range .Articles}}<li><a href="` + ExportArticleDir + `/{{id .ID}}.html">{{.Title}}</a> <span class="meta">{{.CreatorName}} · {{ts .CreateTS}} · 推 {{.NPush}} 噓 {{.NBoo}} · {{len .Comments}} comments</span></li>
+{{end}}</ul>
+</body>
+</html>
+`
+
+const exportHTMLArticle = `{{define "media"}}{{range .}}{{if .IsImage}}<img src="../{{.Path}}">{{else}}<p><a href="../{{.Path}}">{{base .Path}}</a></p>{{end}}
+{{end}}{{end}}{{define "lines"}}{{range .}}{{if .}}<p>{{.}}</p>{{else}}<br>{{end}}
+{{end}}{{end}}` + exportHTMLHead + `<p><a href="../index.html">←</a></p>
+<h1>{{.Title}}</h1>
+<p class="meta">{{.CreatorName}} · {{ts .CreateTS}}</p>
+<div class="content">
+{{template "lines" .Lines}}</div>
+{{template "media" .Media}}<hr>
+<ul class="comments">
+{{range .Comments}}<li><b>{{commentType .CommentType}} {{.CreatorName}}</b> <span class="meta">{{ts .CreateTS}}</span>
+<div class="content">{{template "lines" .Lines}}</div>
+{{template "media" .Media}}{{if .Replies}}<ul class="replies">
+{{range .Replies}}<li><b>{{.CreatorName}}</b> <span class="meta">{{ts .CreateTS}}</span>
+<div class="content">{{template "lines" .Lines}}</div>
+{{template "media" .Media}}</li>
+{{end}}</ul>
+{{end}}</li>
+{{end}}</ul>
+</body>
+</html>
+`
+
+var (
+	exportHTMLIndexTemplate   = htmltemplate.Must(htmltemplate.New("index").Funcs(exportFuncMap).Parse(exportHTMLIndex))
+	exportHTMLArticleTemplate = htmltemplate.Must(htmltemplate.New("article").Funcs(exportFuncMap).Parse(exportHTMLArticle))
+)
+
+func writeExportBoardHTML(dir string, b *ExportBoard) error {
+	buf := &bytes.Buffer{}
+	err := exportHTMLIndexTemplate.Execute(buf, b)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "index.html"), buf.Bytes(), 0600)
+	if err != nil {
+		return err
+	}
+
+	for _, article := range b.Articles {
+		buf.Reset()
+		err = exportHTMLArticleTemplate.Execute(buf, article)
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(filepath.Join(dir, ExportArticleDir, exportIDString(article.ID)+".html"), buf.Bytes(), 0600)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**********
+ * markdown
+ **********/
+
+const exportMarkdownIndex = `# {{md .Title}}
+
+{{md .CreatorName}} · exported at {{ts .ExportTS}}
+
+{{range .Articles}}* [{{md .Title}}](` + ExportArticleDir + `/{{id .ID}}.md) {{md .CreatorName}} · {{ts .CreateTS}} · 推 {{.NPush}} 噓 {{.NBoo}} · {{len .Comments}} comments
+{{end}}`
+
+const exportMarkdownArticle = `{{define "media"}}{{range .}}{{if .IsImage}}![{{base .Path}}](../{{.Path}})
+{{else}}[{{md (base .Path)}}](../{{.Path}})
+{{end}}{{end}}{{end}}[←](../README.md)
+
+# {{md .Title}}
+
+{{md .CreatorName}} · {{ts .CreateTS}}
+
+{{range .Lines}}{{md .}}  
+{{end}}
+{{template "media" .Media}}
+---
+
+{{range .Comments}}* **{{commentType .CommentType}} {{md .CreatorName}}**: {{range .Lines}}{{md .}} {{end}}({{ts .CreateTS}})
+{{range .Media}}  {{if .IsImage}}![{{base .Path}}](../{{.Path}}){{else}}[{{md (base .Path)}}](../{{.Path}}){{end}}
+{{end}}{{range .Replies}}    * **{{md .CreatorName}}**: {{range .Lines}}{{md .}} {{end}}({{ts .CreateTS}})
+{{range .Media}}      {{if .IsImage}}![{{base .Path}}](../{{.Path}}){{else}}[{{md (base .Path)}}](../{{.Path}}){{end}}
+{{end}}{{end}}{{end}}`
+
+var (
+	exportMarkdownIndexTemplate   = texttemplate.Must(texttemplate.New("index").Funcs(exportFuncMap).Parse(exportMarkdownIndex))
+	exportMarkdownArticleTemplate = texttemplate.Must(texttemplate.New("article").Funcs(exportFuncMap).Parse(exportMarkdownArticle))
+)
+
+func writeExportBoardMarkdown(dir string, b *ExportBoard) error {
+	buf := &bytes.Buffer{}
+	err := exportMarkdownIndexTemplate.Execute(buf, b)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "README.md"), buf.Bytes(), 0600)
+	if err != nil {
+		return err
+	}
+
+	for _, article := range b.Articles {
+		buf.Reset()
+		err = exportMarkdownArticleTemplate.Execute(buf, article)
+		if err != nil {
+			return err
+		}
+		err = ioutil.WriteFile(filepath.Join(dir, ExportArticleDir, exportIDString(article.ID)+".md"), buf.Bytes(), 0600)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func TestExportTSToString(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	origOffsetSecond := types.OffsetSecond
+	defer func() {
+		types.OffsetSecond = origOffsetSecond
+	}()
+
+	theTime := time.Date(2019, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+	// prepare test-cases
+	tests := []struct {
+		name         string
+		offsetSecond int64
+		want         string
+	}{
+		{name: "no-offset", offsetSecond: 0, want: "2019-01-02 03:04:05 UTC"},
+		{name: "offset", offsetSecond: 10000, want: "2019-01-02 03:04:05 UTC"},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			types.OffsetSecond = tt.offsetSecond
+
+			if got := exportTSToString(types.TimeToTimestamp(theTime)); got != tt.want {
+				t.Errorf("exportTSToString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
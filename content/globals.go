@@ -103,17 +103,53 @@ const (
 
 	ForceSyncMediaMsg
 	ForceSyncMediaAckMsg
+
+	// sync reply
+	SyncCreateReplyMsg
+	SyncCreateReplyAckMsg
+	SyncCreateReplyBlockMsg
+	SyncCreateReplyBlockAckMsg
+
+	ForceSyncReplyMsg
+	ForceSyncReplyAckMsg
+
+	// sync reaction
+	SyncCreateReactionMsg
+	SyncCreateReactionAckMsg
+
+	ForceSyncReactionMsg
+	ForceSyncReactionAckMsg
+
+	// sync ban
+	SyncCreateBanMsg
+	SyncCreateBanAckMsg
+
+	ForceSyncBanMsg
+	ForceSyncBanAckMsg
+
+	// sync pin
+	SyncCreatePinMsg
+	SyncCreatePinAckMsg
+
+	ForceSyncPinMsg
+	ForceSyncPinAckMsg
 )
 
 // db
 var (
 	dbKey *pttdb.LDBDatabase = nil
 
+	DBBoardName = "board"
+
 	dbBoardCore *pttdb.LDBDatabase = nil
 	dbBoard     *pttdb.LDBBatch    = nil
 
 	dbMeta *pttdb.LDBDatabase = nil
 
+	dbSearch *pttdb.SearchIndex = nil
+
+	dbTag *pttdb.TagIndex = nil
+
 	DBBoardIdxOplogPrefix    = []byte(".bdig")
 	DBBoardOplogPrefix       = []byte(".bdlg")
 	DBBoardMerkleOplogPrefix = []byte(".bdmk")
@@ -122,29 +158,49 @@ var (
 	DBBoardIdxPrefix               = []byte(".bdix")
 	DBBoardIdx2Prefix              = []byte(".bdi2")
 	DBBoardLastSeenPrefix          = []byte(".bdls")
+	DBBoardRevisionLimitPrefix     = []byte(".bdrl")
 	DBBoardArticleCreateTSPrefix   = []byte(".bdac")
 	DBBoardCommentCreateTSPrefix   = []byte(".bdcc")
 	DBArticlePrefix                = []byte(".aldb")
 	DBArticleIdxPrefix             = []byte(".alix")
 	DBArticleLastSeenPrefix        = []byte(".alls")
 	DBArticleCommentCreateTSPrefix = []byte(".alcc")
+	DBArticleRevisionPrefix        = []byte(".alrv")
 	DBPushPrefix                   = []byte(".alps")
 	DBBooPrefix                    = []byte(".albo")
 	DBCommentPrefix                = []byte(".ctdb")
 	DBCommentIdxPrefix             = []byte(".ctix")
 	DBReplyPrefix                  = []byte(".rpdb")
 	DBReplyIdxPrefix               = []byte(".rpix")
+	DBReactionPrefix               = []byte(".rcdb")
+	DBReactionIdxPrefix            = []byte(".rcix")
+	DBReactionUserPrefix           = []byte(".rcus")
+	DBReactionCountPrefix          = []byte(".rcct")
+	DBBanPrefix                    = []byte(".bndb")
+	DBBanIdxPrefix                 = []byte(".bnix")
+	DBBanUserPrefix                = []byte(".bnus")
+	DBPinPrefix                    = []byte(".pndb")
+	DBPinIdxPrefix                 = []byte(".pnix")
+	DBPinArticlePrefix             = []byte(".pnar")
 	DBImagePrefix                  = []byte(".imdb")
 	DBImageIdxPrefix               = []byte(".imix")
 	DBMediaPrefix                  = []byte(".madb")
 	DBMediaIdxPrefix               = []byte(".maix")
 	DBTitlePrefix                  = []byte(".tldb")
 	DBTitleIdxPrefix               = []byte(".tlix")
+
+	DBSearchTermPrefix = []byte(".srtm")
+	DBSearchDocPrefix  = []byte(".srdc")
+
+	DBTagPrefix    = []byte(".tgtg")
+	DBTagDocPrefix = []byte(".tgdc")
 )
 
 // fix
 var (
 	DBFix237Prefix = []byte(".f056") // 237 in base58
+
+	DBSearchBackfillPrefix = []byte(".srbf")
 )
 
 // max-masters
@@ -169,6 +225,11 @@ const (
 	NFirstLineInBlock = 1
 )
 
+// reply
+const (
+	MaxReplyDepth = 8
+)
+
 // image
 const (
 	MaxUploadImageSize   = 10485760 // 10MB
@@ -176,6 +237,27 @@ const (
 	MaxUploadImageHeight = 8192
 )
 
+// moderation
+const (
+	MaxMuteSeconds = 365 * 86400
+
+	MaxPostIntervalSeconds = 86400
+
+	MaxPinnedArticles = 10
+)
+
+// tag
+const (
+	MaxArticleTags = 5
+	MaxBoardTags   = 64
+)
+
+// revision
+const (
+	DefaultArticleRevisionLimit = 10
+	MaxArticleRevisionLimit     = 100
+)
+
 // count
 const (
 	PCommentCount = 12
@@ -185,7 +267,7 @@ func InitContent(dataDir string, keystoreDir string) error {
 	var err error
 
 	// db
-	dbBoardCore, err = pttdb.NewLDBDatabase("board", dataDir, 0, 0)
+	dbBoardCore, err = pttdb.NewLDBDatabase(DBBoardName, dataDir, 0, 0)
 	if err != nil {
 		return err
 	}
@@ -195,6 +277,10 @@ func InitContent(dataDir string, keystoreDir string) error {
 		return err
 	}
 
+	dbSearch = pttdb.NewSearchIndex(dbBoardCore, DBSearchTermPrefix, DBSearchDocPrefix)
+
+	dbTag = pttdb.NewTagIndex(dbBoardCore, DBTagPrefix, DBTagDocPrefix)
+
 	dbKey, err = pttdb.NewLDBDatabase("key", keystoreDir, 0, 0)
 	if err != nil {
 		return err
@@ -217,6 +303,42 @@ var (
 	}
 )
 
+// export
+const (
+	ExportDir = "export"
+
+	ExportArticleDir = "articles"
+	ExportMediaDir   = "media"
+
+	ExportBoardFilename = "board.json"
+
+	ExportTimeFormat = "2006-01-02 15:04:05 MST"
+)
+
+// bbs
+const (
+	BBSTimeFormat = "Mon Jan _2 15:04:05 2006"
+
+	BBSUTCOffsetSeconds = 8 * 60 * 60
+)
+
+var (
+	BBSHeaderAuthor = []byte("作者")
+	BBSHeaderBoard  = []byte("看板")
+	BBSHeaderTitle  = []byte("標題")
+	BBSHeaderTime   = []byte("時間")
+
+	BBSSeparator = []byte("─")
+
+	BBSSignature = []byte("※ 發信站")
+
+	BBSCommentTypes = map[string]CommentType{
+		"推": CommentTypePush,
+		"噓": CommentTypeBoo,
+		"→": CommentTypeNone,
+	}
+)
+
 // default-title
 func DefaultTitle(myID *types.PttID, creatorID *types.PttID, myName string) []byte {
 	log.Debug("DefaultTitle: start", "myID", myID, "creatorID", creatorID, "myName", myName, "currentLocale", pkgservice.CurrentLocale)
@@ -224,6 +346,14 @@ func DefaultTitle(myID *types.PttID, creatorID *types.PttID, myName string) []by
 }
 
 func TeardownContent() {
+	if dbSearch != nil {
+		dbSearch = nil
+	}
+
+	if dbTag != nil {
+		dbTag = nil
+	}
+
 	if dbBoard != nil {
 		dbBoard = nil
 	}
@@ -0,0 +1,50 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import "github.com/ailabstw/go-pttai/common/types"
+
+/*
+ImportInfo is the original author / create-ts of the imported article / comment (ex: from the BBS text dumps),
+because the imported contents are created and signed by the importer.
+
+ImportInfo is carried on the create-oplog in seconds (ImportCreateTS),
+as the nested keys of types.Timestamp are not in the sorted order for the signature.
+*/
+type ImportInfo struct {
+	Author   string          `json:"A"`
+	CreateTS types.Timestamp `json:"CT"`
+}
+
+func opDataToImportInfo(author string, createTS int64) *ImportInfo {
+	if author == "" && createTS == 0 {
+		return nil
+	}
+
+	return &ImportInfo{
+		Author:   author,
+		CreateTS: types.Timestamp{Ts: createTS},
+	}
+}
+
+func (i *ImportInfo) toOpData() (string, int64) {
+	if i == nil {
+		return "", 0
+	}
+
+	return i.Author, i.CreateTS.Ts
+}
@@ -0,0 +1,319 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+Pin is the pinned (announcement) article on the board, created by the master or the moderator with PermPin.
+
+The pins are synced as the board-oplogs, so that every member sees the same pinned articles in the same order.
+The pinned articles are ordered by Order (ascending), and then by the create-ts of the pin (descending).
+Each article has at most one alive pin, tracked by the article-key (entity-id, article-id).
+*/
+type Pin struct {
+	*pkgservice.BaseObject `json:"b"`
+
+	UpdateTS types.Timestamp `json:"UT"`
+
+	SyncInfo *pkgservice.BaseSyncInfo `json:"s,omitempty"`
+
+	ArticleID *types.PttID `json:"AID"`
+	Order     int          `json:"O"`
+}
+
+func NewPin(
+	createTS types.Timestamp,
+	creatorID *types.PttID,
+	entityID *types.PttID,
+
+	logID *types.PttID,
+
+	status types.Status,
+
+	articleID *types.PttID,
+	order int,
+
+) (*Pin, error) {
+
+	id, err := types.NewPttID()
+	if err != nil {
+		return nil, err
+	}
+
+	o := pkgservice.NewObject(id, createTS, creatorID, entityID, logID, status)
+
+	return &Pin{
+		BaseObject: o,
+
+		UpdateTS: createTS,
+
+		ArticleID: articleID,
+		Order:     order,
+	}, nil
+}
+
+func NewEmptyPin() *Pin {
+	return &Pin{BaseObject: &pkgservice.BaseObject{}}
+}
+
+func PinsToObjs(typedObjs []*Pin) []pkgservice.Object {
+	objs := make([]pkgservice.Object, len(typedObjs))
+	for i, obj := range typedObjs {
+		objs[i] = obj
+	}
+	return objs
+}
+
+func ObjsToPins(objs []pkgservice.Object) []*Pin {
+	typedObjs := make([]*Pin, len(objs))
+	for i, obj := range objs {
+		typedObjs[i] = obj.(*Pin)
+	}
+	return typedObjs
+}
+
+func (pm *ProtocolManager) SetPinDB(p *Pin) {
+
+	p.SetDB(dbBoard, pm.DBObjLock(), pm.Entity().GetID(), pm.dbPinPrefix, pm.dbPinIdxPrefix, nil, nil)
+}
+
+func (p *Pin) Save(isLocked bool) error {
+	var err error
+
+	if !isLocked {
+		err = p.Lock()
+		if err != nil {
+			return err
+		}
+		defer p.Unlock()
+	}
+
+	key, err := p.MarshalKey()
+	if err != nil {
+		return err
+	}
+	marshaled, err := p.Marshal()
+	if err != nil {
+		return err
+	}
+
+	idxKey, err := p.IdxKey()
+	if err != nil {
+		return err
+	}
+
+	idx := &pttdb.Index{Keys: [][]byte{key}, UpdateTS: p.UpdateTS}
+
+	kvs := []*pttdb.KeyVal{
+		&pttdb.KeyVal{K: key, V: marshaled},
+	}
+
+	_, err = p.DB().ForcePutAll(idxKey, idx, kvs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *Pin) NewEmptyObj() pkgservice.Object {
+	newObj := NewEmptyPin()
+	newObj.CloneDB(p.BaseObject)
+	return newObj
+}
+
+func (p *Pin) GetNewObjByID(id *types.PttID, isLocked bool) (pkgservice.Object, error) {
+	newP := p.NewEmptyObj()
+	newP.SetID(id)
+	err := newP.GetByID(isLocked)
+	if err != nil {
+		return nil, err
+	}
+	return newP, nil
+}
+
+func (p *Pin) SetUpdateTS(ts types.Timestamp) {
+	p.UpdateTS = ts
+}
+
+func (p *Pin) GetUpdateTS() types.Timestamp {
+	return p.UpdateTS
+}
+
+func (p *Pin) Get(isLocked bool) error {
+	var err error
+
+	if !isLocked {
+		err = p.RLock()
+		if err != nil {
+			return err
+		}
+		defer p.RUnlock()
+	}
+
+	key, err := p.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	val, err := p.DB().DBGet(key)
+	if err != nil {
+		return err
+	}
+
+	return p.Unmarshal(val)
+}
+
+func (p *Pin) GetByID(isLocked bool) error {
+	var err error
+
+	val, err := p.GetValueByID(isLocked)
+	if err != nil {
+		return err
+	}
+
+	return p.Unmarshal(val)
+}
+
+func (p *Pin) MarshalKey() ([]byte, error) {
+	return common.Concat([][]byte{p.FullDBPrefix(), p.ArticleID[:], p.ID[:]})
+}
+
+func (p *Pin) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+func (p *Pin) Unmarshal(theBytes []byte) error {
+	return json.Unmarshal(theBytes, p)
+}
+
+func (p *Pin) GetSyncInfo() pkgservice.SyncInfo {
+	if p.SyncInfo == nil {
+		return nil
+	}
+	return p.SyncInfo
+}
+
+func (p *Pin) SetSyncInfo(theSyncInfo pkgservice.SyncInfo) error {
+	if theSyncInfo == nil {
+		p.SyncInfo = nil
+		return nil
+	}
+
+	syncInfo, ok := theSyncInfo.(*pkgservice.BaseSyncInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+	p.SyncInfo = syncInfo
+
+	return nil
+}
+
+/**********
+ * Article-Key
+ **********/
+
+func (p *Pin) MarshalArticleKey() ([]byte, error) {
+	return common.Concat([][]byte{DBPinArticlePrefix, p.EntityID[:], p.ArticleID[:]})
+}
+
+func (p *Pin) SaveArticleKey() error {
+	key, err := p.MarshalArticleKey()
+	if err != nil {
+		return err
+	}
+
+	return p.DB().DB().Put(key, p.ID[:])
+}
+
+func (p *Pin) DeleteArticleKey() error {
+	key, err := p.MarshalArticleKey()
+	if err != nil {
+		return err
+	}
+
+	return p.DB().DB().Delete(key)
+}
+
+/*
+GetIDByArticle gets the id of the pin of the article.
+*/
+func (p *Pin) GetIDByArticle(articleID *types.PttID) (*types.PttID, error) {
+	key, err := common.Concat([][]byte{DBPinArticlePrefix, p.EntityID[:], articleID[:]})
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := p.DB().DBGet(key)
+	if err != nil {
+		return nil, err
+	}
+
+	id := &types.PttID{}
+	copy(id[:], val)
+
+	return id, nil
+}
+
+/*
+GetList gets all the pins (including the deleted ones) of the board.
+*/
+func (p *Pin) GetList() ([]*Pin, error) {
+	iter, err := p.GetObjIterWithObj(nil, pttdb.ListOrderNext, false)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	pins := make([]*Pin, 0)
+	for iter.Next() {
+		pin := NewEmptyPin()
+		pin.CloneDB(p.BaseObject)
+		err = pin.Unmarshal(iter.Value())
+		if err != nil {
+			continue
+		}
+		pins = append(pins, pin)
+	}
+
+	return pins, nil
+}
+
+/*
+DeleteAll deletes all the pins and the article-keys of the board.
+*/
+func (p *Pin) DeleteAll() error {
+	pins, err := p.GetList()
+	if err != nil {
+		return err
+	}
+
+	for _, pin := range pins {
+		pin.DeleteArticleKey()
+		pin.Delete(false)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+BackfillSearchIndex indexes the articles / comments / replies created before the search-index
+is available. The board is backfilled only once.
+*/
+func (pm *ProtocolManager) BackfillSearchIndex() error {
+	isBackfilled, err := pm.isBackfillSearchIndex()
+	if err != nil {
+		return err
+	}
+	if isBackfilled {
+		return nil
+	}
+
+	err = pm.backfillSearchIndexCore()
+	if err != nil {
+		return err
+	}
+
+	return pm.setBackfillSearchIndex()
+}
+
+func (pm *ProtocolManager) isBackfillSearchIndex() (bool, error) {
+	key, err := pm.marshalBackfillSearchIndexKey()
+	if err != nil {
+		return false, err
+	}
+	_, err = dbMeta.Get(key)
+	if err == leveldb.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (pm *ProtocolManager) marshalBackfillSearchIndexKey() ([]byte, error) {
+	entityID := pm.Entity().GetID()
+	return common.Concat([][]byte{DBSearchBackfillPrefix, entityID[:]})
+}
+
+func (pm *ProtocolManager) setBackfillSearchIndex() error {
+	key, err := pm.marshalBackfillSearchIndexKey()
+	if err != nil {
+		return err
+	}
+
+	return dbMeta.Put(key, pttdb.ValueTrue)
+}
+
+func (pm *ProtocolManager) backfillSearchIndexCore() error {
+	// articles
+	article := NewEmptyArticle()
+	pm.SetArticleDB(article)
+
+	iter, err := article.GetObjIterWithObj(nil, pttdb.ListOrderNext, false)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+
+	for iter.Next() {
+		each := NewEmptyArticle()
+		pm.SetArticleDB(each)
+		err = each.Unmarshal(iter.Value())
+		if err != nil || each.Status != types.StatusAlive {
+			continue
+		}
+
+		err = pm.indexArticle(each)
+		if err != nil {
+			log.Warn("backfillSearchIndexCore: unable to index article", "e", err, "entity", pm.Entity().IDString(), "article", each.ID)
+		}
+	}
+
+	// comments
+	comment := NewEmptyComment()
+	pm.SetCommentDB(comment)
+
+	iter2, err := comment.GetObjIterWithObj(nil, pttdb.ListOrderNext, false)
+	if err != nil {
+		return err
+	}
+	defer iter2.Release()
+
+	for iter2.Next() {
+		each := NewEmptyComment()
+		pm.SetCommentDB(each)
+		err = each.Unmarshal(iter2.Value())
+		if err != nil || each.Status != types.StatusAlive {
+			continue
+		}
+
+		err = pm.indexComment(each)
+		if err != nil {
+			log.Warn("backfillSearchIndexCore: unable to index comment", "e", err, "entity", pm.Entity().IDString(), "comment", each.ID)
+		}
+	}
+
+	// replies
+	reply := NewEmptyReply()
+	pm.SetReplyDB(reply)
+
+	iter3, err := reply.GetObjIterWithObj(nil, pttdb.ListOrderNext, false)
+	if err != nil {
+		return err
+	}
+	defer iter3.Release()
+
+	for iter3.Next() {
+		each := NewEmptyReply()
+		pm.SetReplyDB(each)
+		err = each.Unmarshal(iter3.Value())
+		if err != nil || each.Status != types.StatusAlive {
+			continue
+		}
+
+		err = pm.indexReply(each)
+		if err != nil {
+			log.Warn("backfillSearchIndexCore: unable to index reply", "e", err, "entity", pm.Entity().IDString(), "reply", each.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/p2p/discover"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+GetBanList gets the bans and the (not expired) mutes of the board.
+*/
+func (pm *ProtocolManager) GetBanList() ([]*Ban, error) {
+	ban := NewEmptyBan()
+	pm.SetBanDB(ban)
+
+	bans, err := ban.GetList()
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	aliveBans := make([]*Ban, 0, len(bans))
+	for _, each := range bans {
+		if each.Status != types.StatusAlive {
+			continue
+		}
+		if each.ExpireTS.Ts != 0 && !ts.IsLess(each.ExpireTS) {
+			continue
+		}
+		aliveBans = append(aliveBans, each)
+	}
+
+	return aliveBans, nil
+}
+
+/*
+checkBan checks whether the user is banned / muted on the content created at ts.
+*/
+func (pm *ProtocolManager) checkBan(userID *types.PttID, ts types.Timestamp) error {
+	ban, err := pm.getBanByUser(userID, BanTypeBan)
+	if err == nil && ban.IsEffective(ts) {
+		return ErrBanned
+	}
+
+	ban, err = pm.getBanByUser(userID, BanTypeMute)
+	if err == nil && ban.IsEffective(ts) {
+		return ErrMuted
+	}
+
+	return nil
+}
+
+/*
+getPostLimiter gets the rate-limiter and the min interval (set by the masters on the title of the board)
+of the articles (isArticle) or the comments / replies.
+*/
+func (pm *ProtocolManager) getPostLimiter(isArticle bool) (*pkgservice.RateLimiter, time.Duration, error) {
+	limiter := pm.commentLimiter
+	if isArticle {
+		limiter = pm.articleLimiter
+	}
+
+	theTitle, err := pm.GetTitle()
+	if err != nil {
+		return nil, 0, err
+	}
+	if theTitle == nil {
+		return limiter, 0, nil
+	}
+
+	seconds := theTitle.PostCommentInterval
+	if isArticle {
+		seconds = theTitle.PostArticleInterval
+	}
+
+	return limiter, time.Duration(seconds) * time.Second, nil
+}
+
+/*
+checkPost checks whether I am able to post the article / comment, with the posting rate limited per member
+if the interval is set on the board. The masters are not restricted.
+*/
+func (pm *ProtocolManager) checkPost(isArticle bool) error {
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if pm.IsMaster(myID, false) {
+		return nil
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	err = pm.checkBan(myID, ts)
+	if err != nil {
+		return err
+	}
+
+	limiter, interval, err := pm.getPostLimiter(isArticle)
+	if err != nil {
+		return err
+	}
+
+	if !limiter.AllowAt(myID.String(), ts.ToTime(), interval) {
+		return ErrPostTooFrequent
+	}
+
+	return nil
+}
+
+/*
+checkCreateLog checks the create-article / comment / reply oplog with the creator and the create-ts of the verified oplog,
+instead of the synced object from the peers:
+
+    1. the contents from the banned / muted members are rejected by all the nodes.
+    2. the posting rate (if set on the board) is limited by the masters, who sign the pending oplogs of the members.
+       The contents over the rate are not signed, and are not valid on all the nodes.
+
+ErrSkipOplog is returned if the oplog is rejected.
+*/
+func (pm *ProtocolManager) checkCreateLog(oplog *pkgservice.BaseOplog, isArticle bool, isPending bool) error {
+	creatorID := oplog.CreatorID
+	if pm.IsMaster(creatorID, false) {
+		return nil
+	}
+
+	err := pm.checkBan(creatorID, oplog.CreateTS)
+	if err != nil {
+		log.Warn("checkCreateLog: rejected", "e", err, "entity", pm.Entity().IDString(), "obj", oplog.ObjID, "creator", creatorID)
+		return pkgservice.ErrSkipOplog
+	}
+
+	if !isPending {
+		return nil
+	}
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.IsMaster(myID, false) {
+		return nil
+	}
+
+	// the bans / mutes after the create-ts are still effective on signing.
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	err = pm.checkBan(creatorID, ts)
+	if err != nil {
+		log.Warn("checkCreateLog: rejected", "e", err, "entity", pm.Entity().IDString(), "obj", oplog.ObjID, "creator", creatorID)
+		return pkgservice.ErrSkipOplog
+	}
+
+	limiter, interval, err := pm.getPostLimiter(isArticle)
+	if err != nil {
+		return err
+	}
+
+	if !limiter.AllowAt(creatorID.String(), oplog.CreateTS.ToTime(), interval) {
+		log.Warn("checkCreateLog: rejected", "e", ErrPostTooFrequent, "entity", pm.Entity().IDString(), "obj", oplog.ObjID, "creator", creatorID)
+		return pkgservice.ErrSkipOplog
+	}
+
+	return nil
+}
+
+/*
+IsSuspiciousID rejects the join-requests from the banned users,
+even if the users are with the still-valid join-keys.
+*/
+func (pm *ProtocolManager) IsSuspiciousID(id *types.PttID, nodeID *discover.NodeID) bool {
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return true
+	}
+
+	ban, err := pm.getBanByUser(id, BanTypeBan)
+	if err == nil && ban.IsEffective(ts) {
+		return true
+	}
+
+	return pm.BaseProtocolManager.IsSuspiciousID(id, nodeID)
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+DBCheckObjects includes the objects referring to blocks in addition to the media.
+*/
+func (pm *ProtocolManager) DBCheckObjects() []pkgservice.Object {
+	article := NewEmptyArticle()
+	pm.SetArticleDB(article)
+
+	comment := NewEmptyComment()
+	pm.SetCommentDB(comment)
+
+	reply := NewEmptyReply()
+	pm.SetReplyDB(reply)
+
+	return append(pm.BaseProtocolManager.DBCheckObjects(), article, comment, reply)
+}
+
+/*
+DBCheckBlockInfos includes the blocks of the article revisions,
+which are referred only by the revisions after the article is updated.
+*/
+func (pm *ProtocolManager) DBCheckBlockInfos(addBlockInfo func(objID *types.PttID, blockInfo *pkgservice.BlockInfo)) error {
+	entityID := pm.Entity().GetID()
+	prefix, err := common.Concat([][]byte{DBArticleRevisionPrefix, entityID[:]})
+	if err != nil {
+		return err
+	}
+
+	iter, err := dbBoardCore.NewIteratorWithPrefix(nil, prefix, pttdb.ListOrderNext)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+
+	for iter.Next() {
+		revision := &ArticleRevision{}
+		err = json.Unmarshal(iter.Value(), revision)
+		if err != nil {
+			continue
+		}
+
+		addBlockInfo(revision.ArticleID, revision.BlockInfo)
+	}
+
+	return nil
+}
@@ -30,6 +30,18 @@ func (pm *ProtocolManager) CleanObject() error {
 	comment := NewEmptyComment()
 	pm.SetCommentDB(comment)
 
+	reply := NewEmptyReply()
+	pm.SetReplyDB(reply)
+
+	reaction := NewEmptyReaction()
+	pm.SetReactionDB(reaction)
+
+	ban := NewEmptyBan()
+	pm.SetBanDB(ban)
+
+	pin := NewEmptyPin()
+	pm.SetPinDB(pin)
+
 	media := pkgservice.NewEmptyMedia()
 	pm.SetMediaDB(media)
 
@@ -50,7 +62,7 @@ func (pm *ProtocolManager) CleanObject() error {
 		}
 		pm.SetArticleDB(article)
 
-		article.DeleteAll(comment, false)
+		article.DeleteAll(comment, reply, reaction, false)
 	}
 
 	// comment
@@ -72,6 +84,50 @@ func (pm *ProtocolManager) CleanObject() error {
 		comment.DeleteAll(false)
 	}
 
+	// reply
+	iter, err = reply.GetObjIterWithObj(nil, pttdb.ListOrderNext, false)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+
+	for iter.Next() {
+		val = iter.Value()
+
+		err = json.Unmarshal(val, reply)
+		if err != nil {
+			continue
+		}
+		pm.SetReplyDB(reply)
+
+		reply.DeleteAll(false)
+	}
+
+	// reaction
+	iter, err = reaction.GetObjIterWithObj(nil, pttdb.ListOrderNext, false)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+
+	for iter.Next() {
+		val = iter.Value()
+
+		err = json.Unmarshal(val, reaction)
+		if err != nil {
+			continue
+		}
+		pm.SetReactionDB(reaction)
+
+		reaction.DeleteAllByTargetID(reaction.TargetID)
+	}
+
+	// ban
+	ban.DeleteAll()
+
+	// pin
+	pin.DeleteAll()
+
 	// media
 	iter, err = media.GetObjIterWithObj(nil, pttdb.ListOrderNext, false)
 	if err != nil {
@@ -29,9 +29,12 @@ type CreateArticle struct {
 	Title    []byte
 	Article  [][]byte
 	MediaIDs []*types.PttID
+	Tags     []string
+
+	Import *ImportInfo
 }
 
-func (pm *ProtocolManager) CreateArticle(title []byte, articleBytes [][]byte, mediaIDs []*types.PttID) (*Article, error) {
+func (pm *ProtocolManager) CreateArticle(title []byte, articleBytes [][]byte, mediaIDs []*types.PttID, tags []string) (*Article, error) {
 
 	myID := pm.Ptt().GetMyEntity().GetID()
 
@@ -39,12 +42,31 @@ func (pm *ProtocolManager) CreateArticle(title []byte, articleBytes [][]byte, me
 		return nil, types.ErrInvalidID
 	}
 
+	err := pm.checkPost(true)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err = pm.checkArticleTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
 	data := &CreateArticle{
 		Title:    title,
 		Article:  articleBytes,
 		MediaIDs: mediaIDs,
+		Tags:     tags,
 	}
 
+	return pm.createArticle(data)
+}
+
+/*
+createArticle creates the article without checking the posting rate,
+used by CreateArticle and importing the articles by the masters.
+*/
+func (pm *ProtocolManager) createArticle(data *CreateArticle) (*Article, error) {
 	theArticle, err := pm.CreateObject(
 		data,
 		BoardOpTypeCreateArticle,
@@ -98,6 +120,9 @@ func (pm *ProtocolManager) NewArticle(theData pkgservice.CreateData) (pkgservice
 	}
 	pm.SetArticleDB(theArticle)
 
+	theArticle.Tags = data.Tags
+	theArticle.Import = data.Import
+
 	return theArticle, opData, nil
 }
 
@@ -141,6 +166,9 @@ func (pm *ProtocolManager) increateArticle(theObj pkgservice.Object, theData pkg
 	opData.MediaIDs = data.MediaIDs
 
 	opData.TitleHash = types.Hash(obj.Title)
+	opData.TagsHash = hashTags(obj.Tags)
+
+	opData.ImportAuthor, opData.ImportCreateTS = obj.Import.toOpData()
 
 	return nil
 }
@@ -159,6 +187,23 @@ func (pm *ProtocolManager) postcreateArticle(theObj pkgservice.Object, oplog *pk
 	entity := pm.Entity().(*Board)
 	entity.SaveArticleCreateTS(oplog.UpdateTS)
 
+	err := pm.indexArticle(article)
+	if err != nil {
+		log.Warn("postcreateArticle: unable to index article", "e", err, "entity", pm.Entity().IDString(), "article", article.ID)
+	}
+
+	err = pm.indexArticleTags(article)
+	if err != nil {
+		log.Warn("postcreateArticle: unable to index article tags", "e", err, "entity", pm.Entity().IDString(), "article", article.ID)
+	}
+
+	err = pm.saveArticleRevision(article, oplog)
+	if err != nil {
+		log.Warn("postcreateArticle: unable to save article revision", "e", err, "entity", pm.Entity().IDString(), "article", article.ID)
+	}
+
+	pm.PostObjEvent(article, nil, oplog, types.StatusAlive)
+
 	if reflect.DeepEqual(article.CreatorID, myID) {
 		pm.SaveLastSeen(oplog.UpdateTS)
 		return nil
@@ -166,7 +211,7 @@ func (pm *ProtocolManager) postcreateArticle(theObj pkgservice.Object, oplog *pk
 
 	// I can get only my name and my friends' user name
 	accountSPM := pm.Entity().Service().(*Backend).accountBackend.SPM().(*account.ServiceProtocolManager)
-	_, err := accountSPM.GetUserNameByID(article.CreatorID)
+	_, err = accountSPM.GetUserNameByID(article.CreatorID)
 	if err != nil {
 		return nil
 	}
@@ -185,6 +230,7 @@ func (pm *ProtocolManager) postcreateArticle(theObj pkgservice.Object, oplog *pk
 	if err != nil {
 		return err
 	}
+	pm.Ptt().PostPttOplog(pttOplog)
 
 	return nil
 }
@@ -24,6 +24,11 @@ import (
 )
 
 func (pm *ProtocolManager) handleCreateArticleLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) ([]*pkgservice.BaseOplog, error) {
+	err := pm.checkCreateLog(oplog, true, false)
+	if err != nil {
+		return nil, err
+	}
+
 	obj := NewEmptyArticle()
 	pm.SetArticleDB(obj)
 
@@ -37,6 +42,11 @@ func (pm *ProtocolManager) handleCreateArticleLogs(oplog *pkgservice.BaseOplog,
 }
 
 func (pm *ProtocolManager) handlePendingCreateArticleLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) (types.Bool, []*pkgservice.BaseOplog, error) {
+	err := pm.checkCreateLog(oplog, true, true)
+	if err != nil {
+		return false, nil, err
+	}
+
 	obj := NewEmptyArticle()
 	pm.SetArticleDB(obj)
 
@@ -87,6 +97,8 @@ func (pm *ProtocolManager) newArticleWithOplog(oplog *pkgservice.BaseOplog, theO
 	pm.SetArticleDB(obj)
 	pkgservice.NewObjectWithOplog(obj, oplog)
 
+	obj.Import = opDataToImportInfo(opData.ImportAuthor, opData.ImportCreateTS)
+
 	blockInfo, err := pkgservice.NewBlockInfo(opData.BlockInfoID, opData.Hashs, opData.MediaIDs, oplog.CreatorID)
 	if err != nil {
 		return nil
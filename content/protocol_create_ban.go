@@ -0,0 +1,197 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type CreateBan struct {
+	UserID   *types.PttID
+	BanType  BanType
+	ExpireTS types.Timestamp
+}
+
+/*
+BanMember bans the user from the board: the member is deleted, is not able to join the board again,
+and the articles / comments from the user are rejected by all the members.
+
+BanMember is idempotent: returns the existing ban if the user is already banned.
+*/
+func (pm *ProtocolManager) BanMember(userID *types.PttID) (*Ban, error) {
+	ban, err := pm.getBanByUser(userID, BanTypeBan)
+	if err == nil && ban.Status == types.StatusAlive {
+		return ban, nil
+	}
+
+	return pm.createBan(userID, BanTypeBan, types.ZeroTimestamp)
+}
+
+/*
+MuteMember mutes the user on the board for seconds (by the masters or the moderators with PermMute).
+The articles / comments created by the user during the mute are rejected by all the members.
+
+The existing mute of the user is replaced.
+*/
+func (pm *ProtocolManager) MuteMember(userID *types.PttID, seconds int64) (*Ban, error) {
+	if seconds <= 0 || seconds > MaxMuteSeconds {
+		return nil, ErrInvalidMuteDuration
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+	expireTS := types.Timestamp{Ts: ts.Ts + seconds}
+
+	ban, err := pm.getBanByUser(userID, BanTypeMute)
+	if err == nil && ban.Status == types.StatusAlive {
+		err = pm.DeleteBan(ban.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pm.createBan(userID, BanTypeMute, expireTS)
+}
+
+func (pm *ProtocolManager) createBan(userID *types.PttID, banType BanType, expireTS types.Timestamp) (*Ban, error) {
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.isValidBanner(myID, banType) {
+		return nil, types.ErrInvalidID
+	}
+
+	if pm.IsMaster(userID, false) {
+		return nil, types.ErrInvalidID
+	}
+
+	data := &CreateBan{
+		UserID:   userID,
+		BanType:  banType,
+		ExpireTS: expireTS,
+	}
+
+	theBan, err := pm.CreateObject(
+		data,
+		BoardOpTypeCreateBan,
+
+		pm.boardOplogMerkle,
+
+		pm.NewBan,
+		pm.NewBoardOplogWithTS,
+		nil,
+
+		pm.SetBoardDB,
+		pm.broadcastBoardOplogsCore,
+		pm.broadcastBoardOplogCore,
+
+		pm.postcreateBan,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ban, ok := theBan.(*Ban)
+	if !ok {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	return ban, nil
+}
+
+func (pm *ProtocolManager) NewBan(theData pkgservice.CreateData) (pkgservice.Object, pkgservice.OpData, error) {
+
+	data, ok := theData.(*CreateBan)
+	if !ok {
+		return nil, nil, pkgservice.ErrInvalidData
+	}
+
+	if !data.BanType.IsValid() {
+		return nil, nil, ErrInvalidBanType
+	}
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	entityID := pm.Entity().GetID()
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opData := &BoardOpCreateBan{
+		UserID:   data.UserID,
+		BanType:  data.BanType,
+		ExpireTS: data.ExpireTS.Ts,
+	}
+
+	theBan, err := NewBan(ts, myID, entityID, nil, types.StatusInit, data.UserID, data.BanType, data.ExpireTS)
+	if err != nil {
+		return nil, nil, err
+	}
+	pm.SetBanDB(theBan)
+
+	return theBan, opData, nil
+}
+
+func (pm *ProtocolManager) postcreateBan(theObj pkgservice.Object, oplog *pkgservice.BaseOplog) error {
+
+	ban, ok := theObj.(*Ban)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	err := ban.SaveUserKey()
+	if err != nil {
+		log.Warn("postcreateBan: unable to save user-key", "e", err, "entity", pm.Entity().IDString(), "ban", ban.ID)
+	}
+
+	// the banned member is deleted by the master, and is not able to join again (IsSuspiciousID).
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if ban.BanType == BanTypeBan && pm.IsMaster(myID, false) && !reflect.DeepEqual(myID, ban.UserID) {
+		_, err = pm.DeleteMember(ban.UserID)
+		if err != nil {
+			log.Debug("postcreateBan: unable to delete member", "e", err, "entity", pm.Entity().IDString(), "user", ban.UserID)
+		}
+	}
+
+	pm.PostObjEvent(ban, ban.UserID, oplog, types.StatusAlive)
+
+	return nil
+}
+
+func (pm *ProtocolManager) getBanByUser(userID *types.PttID, banType BanType) (*Ban, error) {
+	ban := NewEmptyBan()
+	pm.SetBanDB(ban)
+
+	id, err := ban.GetIDByUser(userID, banType)
+	if err != nil {
+		return nil, err
+	}
+
+	ban.SetID(id)
+	err = ban.GetByID(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return ban, nil
+}
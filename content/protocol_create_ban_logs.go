@@ -0,0 +1,129 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) handleCreateBanLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) ([]*pkgservice.BaseOplog, error) {
+	obj := NewEmptyBan()
+	pm.SetBanDB(obj)
+
+	opData := &BoardOpCreateBan{}
+
+	log.Debug("handleCreateBanLogs: to HandleCreateObjectLog")
+	return pm.HandleCreateObjectLog(
+		oplog, obj, opData, info,
+		pm.existsInInfoCreateBan, pm.newBanWithOplog, pm.postcreateBan, pm.updateCreateBanInfo)
+}
+
+func (pm *ProtocolManager) handlePendingCreateBanLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) (types.Bool, []*pkgservice.BaseOplog, error) {
+	obj := NewEmptyBan()
+	pm.SetBanDB(obj)
+
+	opData := &BoardOpCreateBan{}
+
+	return pm.HandlePendingCreateObjectLog(
+		oplog, obj, opData, info,
+		pm.existsInInfoCreateBan, pm.newBanWithOplog, pm.postcreateBan, pm.updateCreateBanInfo)
+}
+
+func (pm *ProtocolManager) setNewestCreateBanLog(oplog *pkgservice.BaseOplog) (types.Bool, error) {
+	obj := NewEmptyBan()
+	pm.SetBanDB(obj)
+
+	return pm.SetNewestCreateObjectLog(oplog, obj)
+}
+
+func (pm *ProtocolManager) handleFailedCreateBanLog(oplog *pkgservice.BaseOplog) error {
+
+	obj := NewEmptyBan()
+	pm.SetBanDB(obj)
+
+	return pm.HandleFailedCreateObjectLog(oplog, obj, nil)
+}
+
+func (pm *ProtocolManager) handleFailedValidCreateBanLog(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) error {
+
+	obj := NewEmptyBan()
+	pm.SetBanDB(obj)
+
+	return pm.HandleFailedValidCreateObjectLog(oplog, obj, nil)
+}
+
+/**********
+ * Customize
+ **********/
+
+/*
+newBanWithOplog requires that the ban is created by the master.
+*/
+func (pm *ProtocolManager) newBanWithOplog(oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData) pkgservice.Object {
+
+	opData, ok := theOpData.(*BoardOpCreateBan)
+	if !ok {
+		return nil
+	}
+
+	if !opData.BanType.IsValid() || opData.UserID == nil {
+		return nil
+	}
+
+	if !pm.isValidBanner(oplog.CreatorID, opData.BanType) {
+		return nil
+	}
+
+	obj := NewEmptyBan()
+	pm.SetBanDB(obj)
+	pkgservice.NewObjectWithOplog(obj, oplog)
+
+	obj.UserID = opData.UserID
+	obj.BanType = opData.BanType
+	obj.ExpireTS = types.Timestamp{Ts: opData.ExpireTS}
+
+	return obj
+}
+
+func (pm *ProtocolManager) existsInInfoCreateBan(oplog *pkgservice.BaseOplog, theInfo pkgservice.ProcessInfo) (bool, error) {
+	info, ok := theInfo.(*ProcessBoardInfo)
+	if !ok {
+		return false, pkgservice.ErrInvalidData
+	}
+
+	objID := oplog.ObjID
+	_, ok = info.CreateBanInfo[*objID]
+	if ok {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (pm *ProtocolManager) updateCreateBanInfo(obj pkgservice.Object, oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData, theInfo pkgservice.ProcessInfo) error {
+	info, ok := theInfo.(*ProcessBoardInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	info.CreateBanInfo[*oplog.ObjID] = oplog
+
+	return nil
+}
@@ -29,10 +29,17 @@ type CreateComment struct {
 	CommentType CommentType
 	Comment     [][]byte
 	MediaIDs    []*types.PttID
+
+	Import *ImportInfo
 }
 
 func (pm *ProtocolManager) CreateComment(articleID *types.PttID, commentType CommentType, commentBytes []byte, mediaID *types.PttID) (*Comment, error) {
 
+	err := pm.checkPost(false)
+	if err != nil {
+		return nil, err
+	}
+
 	var mediaIDs []*types.PttID
 	if mediaID != nil {
 		mediaIDs = []*types.PttID{mediaID}
@@ -44,6 +51,14 @@ func (pm *ProtocolManager) CreateComment(articleID *types.PttID, commentType Com
 		MediaIDs:    mediaIDs,
 	}
 
+	return pm.createComment(data)
+}
+
+/*
+createComment creates the comment without checking the posting rate,
+used by CreateComment and importing the comments by the masters.
+*/
+func (pm *ProtocolManager) createComment(data *CreateComment) (*Comment, error) {
 	theComment, err := pm.CreateObject(
 		data,
 		BoardOpTypeCreateComment,
@@ -107,6 +122,8 @@ func (pm *ProtocolManager) NewComment(theData pkgservice.CreateData) (pkgservice
 	}
 	pm.SetCommentDB(theComment)
 
+	theComment.Import = data.Import
+
 	return theComment, opData, nil
 }
 
@@ -148,6 +165,8 @@ func (pm *ProtocolManager) increateComment(theObj pkgservice.Object, theData pkg
 	opData.Hashs = blockHashs
 	opData.MediaIDs = data.MediaIDs
 
+	opData.ImportAuthor, opData.ImportCreateTS = obj.Import.toOpData()
+
 	return nil
 }
 
@@ -166,6 +185,13 @@ func (pm *ProtocolManager) postcreateComment(theObj pkgservice.Object, oplog *pk
 
 	article.IncreaseComment(comment.ID, comment.CommentType, oplog.UpdateTS)
 
+	err := pm.indexComment(comment)
+	if err != nil {
+		log.Warn("postcreateComment: unable to index comment", "e", err, "entity", pm.Entity().IDString(), "comment", comment.ID)
+	}
+
+	pm.PostObjEvent(comment, comment.ArticleID, oplog, types.StatusAlive)
+
 	// ptt-oplog
 	myID := pm.Ptt().GetMyEntity().GetID()
 
@@ -191,6 +217,7 @@ func (pm *ProtocolManager) postcreateComment(theObj pkgservice.Object, oplog *pk
 	if err != nil {
 		return err
 	}
+	pm.Ptt().PostPttOplog(pttOplog)
 
 	return nil
 }
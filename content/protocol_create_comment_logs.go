@@ -24,6 +24,11 @@ import (
 )
 
 func (pm *ProtocolManager) handleCreateCommentLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) ([]*pkgservice.BaseOplog, error) {
+	err := pm.checkCreateLog(oplog, false, false)
+	if err != nil {
+		return nil, err
+	}
+
 	obj := NewEmptyComment()
 	pm.SetCommentDB(obj)
 
@@ -36,6 +41,11 @@ func (pm *ProtocolManager) handleCreateCommentLogs(oplog *pkgservice.BaseOplog,
 }
 
 func (pm *ProtocolManager) handlePendingCreateCommentLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) (types.Bool, []*pkgservice.BaseOplog, error) {
+	err := pm.checkCreateLog(oplog, false, true)
+	if err != nil {
+		return false, nil, err
+	}
+
 	obj := NewEmptyComment()
 	pm.SetCommentDB(obj)
 
@@ -86,6 +96,7 @@ func (pm *ProtocolManager) newCommentWithOplog(oplog *pkgservice.BaseOplog, theO
 	pkgservice.NewObjectWithOplog(obj, oplog)
 
 	obj.ArticleID = opData.ArticleID
+	obj.Import = opDataToImportInfo(opData.ImportAuthor, opData.ImportCreateTS)
 
 	// block info
 	blockInfo, err := pkgservice.NewBlockInfo(opData.BlockInfoID, opData.Hashs, opData.MediaIDs, oplog.CreatorID)
@@ -0,0 +1,168 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type CreatePin struct {
+	ArticleID *types.PttID
+	Order     int
+}
+
+/*
+PinArticle pins the article on the board with the order (by the masters or the moderators with PermPin).
+
+The existing pin of the article is replaced if the order is changed.
+*/
+func (pm *ProtocolManager) PinArticle(articleID *types.PttID, order int) (*Pin, error) {
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.HasPerm(myID, PermPin) {
+		return nil, types.ErrInvalidID
+	}
+
+	article := NewEmptyArticle()
+	pm.SetArticleDB(article)
+	article.SetID(articleID)
+	err := article.GetByID(false)
+	if err != nil {
+		return nil, err
+	}
+	if article.Status != types.StatusAlive {
+		return nil, types.ErrInvalidStatus
+	}
+
+	pin, err := pm.getPinByArticle(articleID)
+	if err == nil && pin.Status == types.StatusAlive {
+		if pin.Order == order {
+			return pin, nil
+		}
+
+		err = pm.DeletePin(pin.ID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		pins, err := pm.GetPinList()
+		if err != nil {
+			return nil, err
+		}
+		if len(pins) >= MaxPinnedArticles {
+			return nil, ErrTooManyPins
+		}
+	}
+
+	data := &CreatePin{
+		ArticleID: articleID,
+		Order:     order,
+	}
+
+	thePin, err := pm.CreateObject(
+		data,
+		BoardOpTypeCreatePin,
+
+		pm.boardOplogMerkle,
+
+		pm.NewPin,
+		pm.NewBoardOplogWithTS,
+		nil,
+
+		pm.SetBoardDB,
+		pm.broadcastBoardOplogsCore,
+		pm.broadcastBoardOplogCore,
+
+		pm.postcreatePin,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pin, ok := thePin.(*Pin)
+	if !ok {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	return pin, nil
+}
+
+func (pm *ProtocolManager) NewPin(theData pkgservice.CreateData) (pkgservice.Object, pkgservice.OpData, error) {
+
+	data, ok := theData.(*CreatePin)
+	if !ok {
+		return nil, nil, pkgservice.ErrInvalidData
+	}
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	entityID := pm.Entity().GetID()
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opData := &BoardOpCreatePin{
+		ArticleID: data.ArticleID,
+		Order:     data.Order,
+	}
+
+	thePin, err := NewPin(ts, myID, entityID, nil, types.StatusInit, data.ArticleID, data.Order)
+	if err != nil {
+		return nil, nil, err
+	}
+	pm.SetPinDB(thePin)
+
+	return thePin, opData, nil
+}
+
+func (pm *ProtocolManager) postcreatePin(theObj pkgservice.Object, oplog *pkgservice.BaseOplog) error {
+
+	pin, ok := theObj.(*Pin)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	err := pin.SaveArticleKey()
+	if err != nil {
+		log.Warn("postcreatePin: unable to save article-key", "e", err, "entity", pm.Entity().IDString(), "pin", pin.ID)
+	}
+
+	pm.PostObjEvent(pin, pin.ArticleID, oplog, types.StatusAlive)
+
+	return nil
+}
+
+func (pm *ProtocolManager) getPinByArticle(articleID *types.PttID) (*Pin, error) {
+	pin := NewEmptyPin()
+	pm.SetPinDB(pin)
+
+	id, err := pin.GetIDByArticle(articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	pin.SetID(id)
+	err = pin.GetByID(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return pin, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) handleCreatePinLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) ([]*pkgservice.BaseOplog, error) {
+	obj := NewEmptyPin()
+	pm.SetPinDB(obj)
+
+	opData := &BoardOpCreatePin{}
+
+	log.Debug("handleCreatePinLogs: to HandleCreateObjectLog")
+	return pm.HandleCreateObjectLog(
+		oplog, obj, opData, info,
+		pm.existsInInfoCreatePin, pm.newPinWithOplog, pm.postcreatePin, pm.updateCreatePinInfo)
+}
+
+func (pm *ProtocolManager) handlePendingCreatePinLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) (types.Bool, []*pkgservice.BaseOplog, error) {
+	obj := NewEmptyPin()
+	pm.SetPinDB(obj)
+
+	opData := &BoardOpCreatePin{}
+
+	return pm.HandlePendingCreateObjectLog(
+		oplog, obj, opData, info,
+		pm.existsInInfoCreatePin, pm.newPinWithOplog, pm.postcreatePin, pm.updateCreatePinInfo)
+}
+
+func (pm *ProtocolManager) setNewestCreatePinLog(oplog *pkgservice.BaseOplog) (types.Bool, error) {
+	obj := NewEmptyPin()
+	pm.SetPinDB(obj)
+
+	return pm.SetNewestCreateObjectLog(oplog, obj)
+}
+
+func (pm *ProtocolManager) handleFailedCreatePinLog(oplog *pkgservice.BaseOplog) error {
+
+	obj := NewEmptyPin()
+	pm.SetPinDB(obj)
+
+	return pm.HandleFailedCreateObjectLog(oplog, obj, nil)
+}
+
+func (pm *ProtocolManager) handleFailedValidCreatePinLog(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) error {
+
+	obj := NewEmptyPin()
+	pm.SetPinDB(obj)
+
+	return pm.HandleFailedValidCreateObjectLog(oplog, obj, nil)
+}
+
+/**********
+ * Customize
+ **********/
+
+/*
+newPinWithOplog requires that the pin is created by the master or the moderator with PermPin.
+*/
+func (pm *ProtocolManager) newPinWithOplog(oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData) pkgservice.Object {
+
+	opData, ok := theOpData.(*BoardOpCreatePin)
+	if !ok {
+		return nil
+	}
+
+	if opData.ArticleID == nil {
+		return nil
+	}
+
+	if !pm.HasPerm(oplog.CreatorID, PermPin) {
+		return nil
+	}
+
+	obj := NewEmptyPin()
+	pm.SetPinDB(obj)
+	pkgservice.NewObjectWithOplog(obj, oplog)
+
+	obj.ArticleID = opData.ArticleID
+	obj.Order = opData.Order
+
+	return obj
+}
+
+func (pm *ProtocolManager) existsInInfoCreatePin(oplog *pkgservice.BaseOplog, theInfo pkgservice.ProcessInfo) (bool, error) {
+	info, ok := theInfo.(*ProcessBoardInfo)
+	if !ok {
+		return false, pkgservice.ErrInvalidData
+	}
+
+	objID := oplog.ObjID
+	_, ok = info.CreatePinInfo[*objID]
+	if ok {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (pm *ProtocolManager) updateCreatePinInfo(obj pkgservice.Object, oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData, theInfo pkgservice.ProcessInfo) error {
+	info, ok := theInfo.(*ProcessBoardInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	info.CreatePinInfo[*oplog.ObjID] = oplog
+
+	return nil
+}
@@ -0,0 +1,199 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type CreateReaction struct {
+	ArticleID    *types.PttID
+	TargetID     *types.PttID
+	ReactionType ReactionType
+}
+
+/*
+React reacts to the article (targetID as nil or as the article-id) or to the comment (targetID as the comment-id).
+
+React is idempotent: returns the existing (pending or alive) reaction if the user already reacted with the same reaction-type.
+*/
+func (pm *ProtocolManager) React(articleID *types.PttID, targetID *types.PttID, reactionType ReactionType) (*Reaction, error) {
+
+	if !reactionType.IsValid() {
+		return nil, ErrInvalidReactionType
+	}
+
+	if targetID == nil {
+		targetID = articleID
+	}
+
+	// existing
+	myID := pm.Ptt().GetMyEntity().GetID()
+
+	reaction, err := pm.getReactionByUser(targetID, reactionType, myID)
+	if err == nil && reaction.Status <= types.StatusAlive {
+		return reaction, nil
+	}
+
+	data := &CreateReaction{
+		ArticleID:    articleID,
+		TargetID:     targetID,
+		ReactionType: reactionType,
+	}
+
+	theReaction, err := pm.CreateObject(
+		data,
+		BoardOpTypeCreateReaction,
+
+		pm.boardOplogMerkle,
+
+		pm.NewReaction,
+		pm.NewBoardOplogWithTS,
+		pm.increateReaction,
+
+		pm.SetBoardDB,
+		pm.broadcastBoardOplogsCore,
+		pm.broadcastBoardOplogCore,
+
+		pm.postcreateReaction,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	typedReaction, ok := theReaction.(*Reaction)
+	if !ok {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	return typedReaction, nil
+}
+
+func (pm *ProtocolManager) NewReaction(theData pkgservice.CreateData) (pkgservice.Object, pkgservice.OpData, error) {
+
+	data, ok := theData.(*CreateReaction)
+	if !ok {
+		return nil, nil, pkgservice.ErrInvalidData
+	}
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	entityID := pm.Entity().GetID()
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// article
+	article := NewEmptyArticle()
+	pm.SetArticleDB(article)
+	article.SetID(data.ArticleID)
+
+	err = article.GetByID(false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if article.Status != types.StatusAlive {
+		return nil, nil, types.ErrInvalidStatus
+	}
+
+	// comment
+	if !reflect.DeepEqual(data.TargetID, article.ID) {
+		comment := NewEmptyComment()
+		pm.SetCommentDB(comment)
+		comment.SetID(data.TargetID)
+
+		err = comment.GetByID(false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !reflect.DeepEqual(comment.ArticleID, article.ID) {
+			return nil, nil, pkgservice.ErrInvalidData
+		}
+	}
+
+	opData := &BoardOpCreateReaction{
+		ArticleID:    article.ID,
+		TargetID:     data.TargetID,
+		ReactionType: data.ReactionType,
+	}
+
+	theReaction, err := NewReaction(ts, myID, entityID, nil, types.StatusInit, article.ID, data.TargetID, data.ReactionType)
+	if err != nil {
+		return nil, nil, err
+	}
+	pm.SetReactionDB(theReaction)
+
+	return theReaction, opData, nil
+}
+
+/*
+increateReaction saves the user-key before the reaction is alive, so that React is idempotent while the reaction is still pending on the master.
+*/
+func (pm *ProtocolManager) increateReaction(theObj pkgservice.Object, theData pkgservice.CreateData, oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData) error {
+
+	reaction, ok := theObj.(*Reaction)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	return reaction.SaveUserKey()
+}
+
+func (pm *ProtocolManager) postcreateReaction(theObj pkgservice.Object, oplog *pkgservice.BaseOplog) error {
+
+	reaction, ok := theObj.(*Reaction)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	err := reaction.SaveUserKey()
+	if err != nil {
+		log.Warn("postcreateReaction: unable to save user-key", "e", err, "entity", pm.Entity().IDString(), "reaction", reaction.ID)
+	}
+
+	err = reaction.IncreaseCount()
+	if err != nil {
+		log.Warn("postcreateReaction: unable to increase count", "e", err, "entity", pm.Entity().IDString(), "reaction", reaction.ID)
+	}
+
+	pm.PostObjEvent(reaction, reaction.ArticleID, oplog, types.StatusAlive)
+
+	return nil
+}
+
+func (pm *ProtocolManager) getReactionByUser(targetID *types.PttID, reactionType ReactionType, userID *types.PttID) (*Reaction, error) {
+	reaction := NewEmptyReaction()
+	pm.SetReactionDB(reaction)
+
+	id, err := reaction.GetIDByUser(targetID, reactionType, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction.SetID(id)
+	err = reaction.GetByID(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return reaction, nil
+}
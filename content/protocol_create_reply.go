@@ -0,0 +1,284 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type CreateReply struct {
+	ArticleID *types.PttID
+	CommentID *types.PttID
+	ParentID  *types.PttID
+	Reply     [][]byte
+	MediaIDs  []*types.PttID
+}
+
+/*
+CreateReply creates the reply to the comment (parentID as nil) or to the reply (parentID as the reply-id) in the thread of the comment.
+*/
+func (pm *ProtocolManager) CreateReply(articleID *types.PttID, commentID *types.PttID, parentID *types.PttID, reply [][]byte, mediaID *types.PttID) (*Reply, error) {
+
+	err := pm.checkPost(false)
+	if err != nil {
+		return nil, err
+	}
+
+	var mediaIDs []*types.PttID
+	if mediaID != nil {
+		mediaIDs = []*types.PttID{mediaID}
+	}
+	data := &CreateReply{
+		ArticleID: articleID,
+		CommentID: commentID,
+		ParentID:  parentID,
+		Reply:     reply,
+		MediaIDs:  mediaIDs,
+	}
+
+	theReply, err := pm.CreateObject(
+		data,
+		BoardOpTypeCreateReply,
+
+		pm.boardOplogMerkle,
+
+		pm.NewReply,
+		pm.NewBoardOplogWithTS,
+		pm.increateReply,
+
+		pm.SetBoardDB,
+		pm.broadcastBoardOplogsCore,
+		pm.broadcastBoardOplogCore,
+
+		pm.postcreateReply,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	typedReply, ok := theReply.(*Reply)
+	if !ok {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	return typedReply, nil
+}
+
+func (pm *ProtocolManager) NewReply(theData pkgservice.CreateData) (pkgservice.Object, pkgservice.OpData, error) {
+
+	data, ok := theData.(*CreateReply)
+	if !ok {
+		return nil, nil, pkgservice.ErrInvalidData
+	}
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	entityID := pm.Entity().GetID()
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// parent
+	parentID, parentCreatorID, depth, err := pm.getReplyParent(data.ArticleID, data.CommentID, data.ParentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opData := &BoardOpCreateReply{}
+
+	theReply, err := NewReply(ts, myID, entityID, nil, types.StatusInit, data.ArticleID, data.CommentID, parentID, parentCreatorID, depth)
+	if err != nil {
+		return nil, nil, err
+	}
+	pm.SetReplyDB(theReply)
+
+	return theReply, opData, nil
+}
+
+/*
+getReplyParent gets the parent-id, the creator of the parent and the depth of the reply
+based on the locally stored comment (parentID as nil or the comment-id) or the parent reply.
+*/
+func (pm *ProtocolManager) getReplyParent(articleID *types.PttID, commentID *types.PttID, parentID *types.PttID) (*types.PttID, *types.PttID, uint8, error) {
+
+	// comment
+	comment := NewEmptyComment()
+	pm.SetCommentDB(comment)
+	comment.SetID(commentID)
+
+	err := comment.GetByID(false)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if !reflect.DeepEqual(comment.ArticleID, articleID) {
+		return nil, nil, 0, pkgservice.ErrInvalidData
+	}
+
+	if parentID == nil || reflect.DeepEqual(parentID, comment.ID) {
+		return comment.ID, comment.CreatorID, 1, nil
+	}
+
+	// parent reply
+	parent := NewEmptyReply()
+	pm.SetReplyDB(parent)
+	parent.SetID(parentID)
+
+	err = parent.GetByID(false)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if !reflect.DeepEqual(parent.CommentID, comment.ID) {
+		return nil, nil, 0, pkgservice.ErrInvalidData
+	}
+
+	depth := parent.Depth + 1
+	if depth > MaxReplyDepth {
+		return nil, nil, 0, ErrInvalidReplyDepth
+	}
+
+	return parent.ID, parent.CreatorID, depth, nil
+}
+
+/*
+resolveReplyParent sets the parent-creator of the reply from the local comment / parent reply,
+and the parent-id / depth of the reply need to be consistent with them.
+
+The parent-creator is kept nil (without error) if the comment / parent reply is not synced yet.
+*/
+func (pm *ProtocolManager) resolveReplyParent(reply *Reply) error {
+	if reply.ParentID == nil || reply.Depth < 1 || reply.Depth > MaxReplyDepth {
+		return ErrInvalidReplyDepth
+	}
+
+	parentID, parentCreatorID, depth, err := pm.getReplyParent(reply.ArticleID, reply.CommentID, reply.ParentID)
+	if err == leveldb.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(parentID, reply.ParentID) || depth != reply.Depth {
+		return pkgservice.ErrInvalidData
+	}
+
+	reply.ParentCreatorID = parentCreatorID
+
+	return nil
+}
+
+func (pm *ProtocolManager) increateReply(theObj pkgservice.Object, theData pkgservice.CreateData, oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData) error {
+
+	obj, ok := theObj.(*Reply)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	data, ok := theData.(*CreateReply)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	opData, ok := theOpData.(*BoardOpCreateReply)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	// block-info
+	blockID, blockHashs, err := pm.SplitContentBlocks(nil, obj.ID, data.Reply, NFirstLineInBlock)
+	if err != nil {
+		log.Error("increateReply: Unable to SplitContentBlocks", "e", err)
+		return err
+	}
+
+	blockInfo, err := pkgservice.NewBlockInfo(blockID, blockHashs, data.MediaIDs, obj.CreatorID)
+	if err != nil {
+		return err
+	}
+	blockInfo.SetIsAllGood()
+
+	theObj.SetBlockInfo(blockInfo)
+
+	// op-data
+	opData.ArticleID = obj.ArticleID
+	opData.CommentID = obj.CommentID
+	opData.ParentID = obj.ParentID
+	opData.Depth = obj.Depth
+	opData.BlockInfoID = blockID
+	opData.Hashs = blockHashs
+	opData.MediaIDs = data.MediaIDs
+
+	return nil
+}
+
+func (pm *ProtocolManager) postcreateReply(theObj pkgservice.Object, oplog *pkgservice.BaseOplog) error {
+
+	log.Debug("postcreateReply: start")
+
+	reply, ok := theObj.(*Reply)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	err := pm.indexReply(reply)
+	if err != nil {
+		log.Warn("postcreateReply: unable to index reply", "e", err, "entity", pm.Entity().IDString(), "reply", reply.ID)
+	}
+
+	pm.PostObjEvent(reply, reply.ArticleID, oplog, types.StatusAlive)
+
+	// ptt-oplog
+	myID := pm.Ptt().GetMyEntity().GetID()
+
+	if reflect.DeepEqual(reply.CreatorID, myID) {
+		article := NewEmptyArticle()
+		pm.SetArticleDB(article)
+		article.SetID(reply.ArticleID)
+
+		article.SaveLastSeen(oplog.UpdateTS)
+		return nil
+	}
+	if !reflect.DeepEqual(reply.ParentCreatorID, myID) {
+		return nil
+	}
+
+	opData := &pkgservice.PttOpCreateReply{
+		BoardID:   reply.EntityID,
+		ArticleID: reply.ArticleID,
+		CommentID: reply.CommentID,
+	}
+
+	pttOplog, err := pkgservice.NewPttOplog(reply.ID, reply.UpdateTS, oplog.CreatorID, pkgservice.PttOpTypeCreateReply, opData, myID)
+	if err != nil {
+		return err
+	}
+
+	err = pttOplog.Save(false, nil)
+	if err != nil {
+		return err
+	}
+	pm.Ptt().PostPttOplog(pttOplog)
+
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) handleCreateReplyLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) ([]*pkgservice.BaseOplog, error) {
+	err := pm.checkCreateLog(oplog, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := NewEmptyReply()
+	pm.SetReplyDB(obj)
+
+	opData := &BoardOpCreateReply{}
+
+	log.Debug("handleCreateReplyLogs: to HandleCreateObjectLog")
+	return pm.HandleCreateObjectLog(
+		oplog, obj, opData, info,
+		pm.existsInInfoCreateReply, pm.newReplyWithOplog, pm.postcreateReply, pm.updateCreateReplyInfo)
+}
+
+func (pm *ProtocolManager) handlePendingCreateReplyLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) (types.Bool, []*pkgservice.BaseOplog, error) {
+	err := pm.checkCreateLog(oplog, false, true)
+	if err != nil {
+		return false, nil, err
+	}
+
+	obj := NewEmptyReply()
+	pm.SetReplyDB(obj)
+
+	opData := &BoardOpCreateReply{}
+
+	return pm.HandlePendingCreateObjectLog(
+		oplog, obj, opData, info,
+		pm.existsInInfoCreateReply, pm.newReplyWithOplog, pm.postcreateReply, pm.updateCreateReplyInfo)
+}
+
+func (pm *ProtocolManager) setNewestCreateReplyLog(oplog *pkgservice.BaseOplog) (types.Bool, error) {
+	obj := NewEmptyReply()
+	pm.SetReplyDB(obj)
+
+	return pm.SetNewestCreateObjectLog(oplog, obj)
+}
+
+func (pm *ProtocolManager) handleFailedCreateReplyLog(oplog *pkgservice.BaseOplog) error {
+
+	obj := NewEmptyReply()
+	pm.SetReplyDB(obj)
+
+	return pm.HandleFailedCreateObjectLog(oplog, obj, nil)
+}
+
+func (pm *ProtocolManager) handleFailedValidCreateReplyLog(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) error {
+
+	obj := NewEmptyReply()
+	pm.SetReplyDB(obj)
+
+	return pm.HandleFailedValidCreateObjectLog(oplog, obj, nil)
+}
+
+/**********
+ * Customize
+ **********/
+
+func (pm *ProtocolManager) newReplyWithOplog(oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData) pkgservice.Object {
+
+	opData, ok := theOpData.(*BoardOpCreateReply)
+	if !ok {
+		return nil
+	}
+
+	// reply
+	obj := NewEmptyReply()
+	pm.SetReplyDB(obj)
+	pkgservice.NewObjectWithOplog(obj, oplog)
+
+	obj.ArticleID = opData.ArticleID
+	obj.CommentID = opData.CommentID
+	obj.ParentID = opData.ParentID
+	obj.Depth = opData.Depth
+
+	// parent: the parent may be not synced yet (the reply arrives before the parent),
+	// and is resolved when the reply is synced (updateSyncCreateReply), as the article of the comment.
+	err := pm.resolveReplyParent(obj)
+	if err != nil {
+		log.Warn("newReplyWithOplog: invalid parent", "e", err, "reply", oplog.ObjID, "parentID", opData.ParentID, "depth", opData.Depth)
+		return nil
+	}
+
+	// block info
+	blockInfo, err := pkgservice.NewBlockInfo(opData.BlockInfoID, opData.Hashs, opData.MediaIDs, oplog.CreatorID)
+	if err != nil {
+		return nil
+	}
+	pm.SetBlockInfoDB(blockInfo, obj.ID)
+	blockInfo.InitIsGood()
+	obj.SetBlockInfo(blockInfo)
+
+	return obj
+}
+
+func (pm *ProtocolManager) existsInInfoCreateReply(oplog *pkgservice.BaseOplog, theInfo pkgservice.ProcessInfo) (bool, error) {
+	info, ok := theInfo.(*ProcessBoardInfo)
+	if !ok {
+		return false, pkgservice.ErrInvalidData
+	}
+
+	objID := oplog.ObjID
+	_, ok = info.CreateReplyInfo[*objID]
+	if ok {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (pm *ProtocolManager) updateCreateReplyInfo(obj pkgservice.Object, oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData, theInfo pkgservice.ProcessInfo) error {
+	info, ok := theInfo.(*ProcessBoardInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	blockInfo := obj.GetBlockInfo()
+	if blockInfo == nil {
+		return pkgservice.ErrInvalidData
+	}
+
+	info.CreateReplyInfo[*oplog.ObjID] = oplog
+	info.ReplyBlockInfo[*blockInfo.ID] = oplog
+
+	return nil
+}
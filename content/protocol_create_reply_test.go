@@ -0,0 +1,116 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func TestProtocolManager_ReplyBeforeParent(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	dir, err := ioutil.TempDir("", "content")
+	if err != nil {
+		t.Fatalf("unable to create dir: e: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = InitContent(dir, dir)
+	if err != nil {
+		t.Fatalf("unable to InitContent: e: %v", err)
+	}
+	defer TeardownContent()
+
+	board := NewEmptyBoard()
+	board.ID, _ = types.NewPttID()
+	pm, err := NewProtocolManager(board, nil, &Backend{})
+	if err != nil {
+		t.Fatalf("unable to NewProtocolManager: e: %v", err)
+	}
+
+	articleID, _ := types.NewPttID()
+	commentCreatorID, _ := types.NewPttID()
+	parentCreatorID, _ := types.NewPttID()
+	creatorID, _ := types.NewPttID()
+
+	comment, _ := NewComment(tDefaultTimestamp, commentCreatorID, board.ID, nil, types.StatusAlive, articleID, nil, CommentTypePush)
+	pm.SetCommentDB(comment)
+	parent, _ := NewReply(tDefaultTimestamp, parentCreatorID, board.ID, nil, types.StatusAlive, articleID, comment.ID, comment.ID, commentCreatorID, 1)
+	pm.SetReplyDB(parent)
+
+	newOplog := func(opData *BoardOpCreateReply) *pkgservice.BaseOplog {
+		replyID, _ := types.NewPttID()
+		oplog, err := pkgservice.NewOplog(replyID, tDefaultTimestamp, creatorID, BoardOpTypeCreateReply, opData, nil, board.ID, DBBoardOplogPrefix, DBBoardIdxOplogPrefix, DBBoardMerkleOplogPrefix, nil)
+		if err != nil {
+			t.Fatalf("unable to NewOplog: e: %v", err)
+		}
+		return oplog
+	}
+
+	opData := &BoardOpCreateReply{ArticleID: articleID, CommentID: comment.ID, ParentID: parent.ID, Depth: 2, BlockInfoID: board.ID, Hashs: [][][]byte{{{1, 2}}}}
+
+	// run test: the reply arrives before the comment and the parent reply.
+	theReply := pm.newReplyWithOplog(newOplog(opData), opData)
+	reply, ok := theReply.(*Reply)
+	if !ok {
+		t.Fatalf("newReplyWithOplog() = %v, want the reply before the parent", theReply)
+	}
+	if reply.ParentCreatorID != nil || !reflect.DeepEqual(reply.ParentID, parent.ID) || reply.Depth != 2 {
+		t.Errorf("newReplyWithOplog() parent = (%v, %v, %v), want (%v, nil, 2)", reply.ParentID, reply.ParentCreatorID, reply.Depth, parent.ID)
+	}
+
+	// the parent is synced, and is resolved when the reply is synced.
+	err = comment.Save(false)
+	if err != nil {
+		t.Fatalf("unable to save comment: e: %v", err)
+	}
+	err = parent.Save(false)
+	if err != nil {
+		t.Fatalf("unable to save parent: e: %v", err)
+	}
+
+	peerCreatorID, _ := types.NewPttID()
+	fromReply := &Reply{BaseObject: &pkgservice.BaseObject{}, ParentID: parent.ID, ParentCreatorID: peerCreatorID, Depth: 2}
+	err = pm.updateSyncCreateReply(reply, fromReply)
+	if err != nil {
+		t.Errorf("updateSyncCreateReply() error = %v", err)
+	}
+	if !reflect.DeepEqual(reply.ParentCreatorID, parentCreatorID) {
+		t.Errorf("updateSyncCreateReply() parentCreatorID = %v, want %v", reply.ParentCreatorID, parentCreatorID)
+	}
+
+	// the parent is already synced: the depth needs to be consistent with the parent.
+	invalidOpData := &BoardOpCreateReply{ArticleID: articleID, CommentID: comment.ID, ParentID: parent.ID, Depth: 3, BlockInfoID: board.ID, Hashs: [][][]byte{{{1, 2}}}}
+	if theReply := pm.newReplyWithOplog(newOplog(invalidOpData), invalidOpData); theReply != nil {
+		t.Errorf("newReplyWithOplog() = %v, want nil with the invalid depth", theReply)
+	}
+
+	validOpData := &BoardOpCreateReply{ArticleID: articleID, CommentID: comment.ID, ParentID: parent.ID, Depth: 2, BlockInfoID: board.ID, Hashs: [][][]byte{{{1, 2}}}}
+	theReply = pm.newReplyWithOplog(newOplog(validOpData), validOpData)
+	reply, ok = theReply.(*Reply)
+	if !ok || !reflect.DeepEqual(reply.ParentCreatorID, parentCreatorID) {
+		t.Errorf("newReplyWithOplog() = %v, want the parent-creator %v", theReply, parentCreatorID)
+	}
+}
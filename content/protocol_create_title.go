@@ -47,7 +47,7 @@ func (pm *ProtocolManager) CreateTitle(title []byte) error {
 		pm.SetBoardDB,
 		pm.broadcastBoardOplogsCore,
 		pm.broadcastBoardOplogCore,
-		nil,
+		pm.postcreateTitle,
 	)
 	if err != nil {
 		return err
@@ -86,3 +86,15 @@ func (pm *ProtocolManager) NewTitle(theData pkgservice.CreateData) (pkgservice.O
 
 	return title, opData, nil
 }
+
+func (pm *ProtocolManager) postcreateTitle(theObj pkgservice.Object, oplog *pkgservice.BaseOplog) error {
+
+	title, ok := theObj.(*Title)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	pm.PostObjEvent(title, nil, oplog, types.StatusAlive)
+
+	return nil
+}
@@ -33,7 +33,7 @@ func (pm *ProtocolManager) handleCreateTitleLogs(oplog *pkgservice.BaseOplog, in
 
 	return pm.HandleCreateObjectLog(
 		oplog, obj, opData, info,
-		pm.existsInInfoCreateTitle, pm.newTitleWithOplog, nil, pm.updateCreateTitleInfo)
+		pm.existsInInfoCreateTitle, pm.newTitleWithOplog, pm.postcreateTitle, pm.updateCreateTitleInfo)
 }
 
 func (pm *ProtocolManager) handlePendingCreateTitleLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) (types.Bool, []*pkgservice.BaseOplog, error) {
@@ -46,7 +46,7 @@ func (pm *ProtocolManager) handlePendingCreateTitleLogs(oplog *pkgservice.BaseOp
 
 	return pm.HandlePendingCreateObjectLog(
 		oplog, obj, opData, info,
-		pm.existsInInfoCreateTitle, pm.newTitleWithOplog, nil, pm.updateCreateTitleInfo)
+		pm.existsInInfoCreateTitle, pm.newTitleWithOplog, pm.postcreateTitle, pm.updateCreateTitleInfo)
 }
 
 func (pm *ProtocolManager) setNewestCreateTitleLog(oplog *pkgservice.BaseOplog) (types.Bool, error) {
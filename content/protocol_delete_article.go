@@ -18,6 +18,7 @@ package content
 
 import (
 	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
@@ -68,8 +69,28 @@ func (pm *ProtocolManager) postdeleteArticle(id *types.PttID, oplog *pkgservice.
 	comment := NewEmptyComment()
 	pm.SetCommentDB(comment)
 
+	// reply
+	reply := NewEmptyReply()
+	pm.SetReplyDB(reply)
+
+	// reaction
+	reaction := NewEmptyReaction()
+	pm.SetReactionDB(reaction)
+
 	// postdelete
-	article.Postdelete(comment, true)
+	article.Postdelete(comment, reply, reaction, true)
+
+	err := pm.removeSearchIndex(article.ID)
+	if err != nil {
+		log.Warn("postdeleteArticle: unable to remove search-index", "e", err, "entity", pm.Entity().IDString(), "article", article.ID)
+	}
+
+	err = pm.removeTagIndex(article.ID)
+	if err != nil {
+		log.Warn("postdeleteArticle: unable to remove tag-index", "e", err, "entity", pm.Entity().IDString(), "article", article.ID)
+	}
+
+	pm.PostObjEvent(article, nil, oplog, types.StatusDeleted)
 
 	return nil
 }
@@ -49,6 +49,11 @@ func (pm *ProtocolManager) handlePendingDeleteArticleLogs(oplog *pkgservice.Base
 	obj := NewEmptyArticle()
 	pm.SetArticleDB(obj)
 
+	err := pm.checkPendingDeleteLog(oplog, obj)
+	if err != nil {
+		return false, nil, err
+	}
+
 	opData := &BoardOpDeleteArticle{}
 
 	return pm.HandlePendingDeleteObjectLog(
@@ -0,0 +1,125 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+LiftBan lifts the ban and the mute of the user.
+The moderators are able to lift only the mute.
+*/
+func (pm *ProtocolManager) LiftBan(userID *types.PttID) error {
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+
+	isLifted := false
+	for banType := BanTypeBan; banType < NBanType; banType++ {
+		if !pm.isValidBanner(myID, banType) {
+			continue
+		}
+
+		ban, err := pm.getBanByUser(userID, banType)
+		if err != nil || ban.Status != types.StatusAlive {
+			continue
+		}
+
+		err = pm.DeleteBan(ban.ID)
+		if err != nil {
+			return err
+		}
+		isLifted = true
+	}
+
+	if !isLifted {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (pm *ProtocolManager) DeleteBan(id *types.PttID) error {
+
+	ban := NewEmptyBan()
+	pm.SetBanDB(ban)
+	ban.SetID(id)
+
+	err := ban.GetByID(false)
+	if err != nil {
+		return err
+	}
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.isValidBanner(myID, ban.BanType) {
+		return types.ErrInvalidID
+	}
+
+	opData := &BoardOpDeleteBan{
+		UserID:  ban.UserID,
+		BanType: ban.BanType,
+	}
+
+	return pm.DeleteObject(
+		id,
+
+		BoardOpTypeDeleteBan,
+		ban,
+		opData,
+
+		pm.boardOplogMerkle,
+
+		pm.SetBoardDB,
+		pm.NewBoardOplog,
+		nil,
+		pm.setPendingDeleteBanSyncInfo,
+
+		pm.broadcastBoardOplogCore,
+		pm.postdeleteBan,
+	)
+}
+
+func (pm *ProtocolManager) setPendingDeleteBanSyncInfo(obj pkgservice.Object, status types.Status, oplog *pkgservice.BaseOplog) error {
+
+	syncInfo := &pkgservice.BaseSyncInfo{}
+	syncInfo.InitWithOplog(status, oplog)
+
+	obj.SetSyncInfo(syncInfo)
+
+	return nil
+}
+
+func (pm *ProtocolManager) postdeleteBan(id *types.PttID, oplog *pkgservice.BaseOplog, opData pkgservice.OpData, obj pkgservice.Object, blockInfo *pkgservice.BlockInfo) error {
+
+	ban, ok := obj.(*Ban)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	// user-key: the user may already be banned again with a newer ban.
+	userBanID, err := ban.GetIDByUser(ban.UserID, ban.BanType)
+	if err == nil && reflect.DeepEqual(userBanID, ban.ID) {
+		ban.DeleteUserKey()
+	}
+
+	pm.PostObjEvent(ban, ban.UserID, oplog, types.StatusDeleted)
+
+	return nil
+}
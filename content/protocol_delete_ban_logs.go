@@ -0,0 +1,128 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+handleDeleteBanLogs requires that the ban is lifted by the master (or the mute is lifted by the moderator).
+*/
+func (pm *ProtocolManager) handleDeleteBanLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) ([]*pkgservice.BaseOplog, error) {
+
+	if !pm.isValidDeleteBanLog(oplog) {
+		return nil, pkgservice.ErrInvalidOplog
+	}
+
+	obj := NewEmptyBan()
+	pm.SetBanDB(obj)
+
+	opData := &BoardOpDeleteBan{}
+
+	return pm.HandleDeleteObjectLog(
+		oplog,
+		info,
+		obj,
+		opData,
+
+		pm.boardOplogMerkle,
+
+		pm.SetBoardDB,
+		nil,
+		pm.postdeleteBan,
+		pm.updateBanDeleteInfo,
+	)
+}
+
+func (pm *ProtocolManager) handlePendingDeleteBanLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) (types.Bool, []*pkgservice.BaseOplog, error) {
+
+	if !pm.isValidDeleteBanLog(oplog) {
+		return false, nil, pkgservice.ErrInvalidOplog
+	}
+
+	obj := NewEmptyBan()
+	pm.SetBanDB(obj)
+
+	opData := &BoardOpDeleteBan{}
+
+	return pm.HandlePendingDeleteObjectLog(
+		oplog,
+		info,
+		obj,
+		opData,
+
+		pm.boardOplogMerkle,
+
+		pm.SetBoardDB,
+		nil,
+		pm.setPendingDeleteBanSyncInfo,
+		pm.updateBanDeleteInfo,
+	)
+}
+
+func (pm *ProtocolManager) setNewestDeleteBanLog(oplog *pkgservice.BaseOplog) (types.Bool, error) {
+
+	obj := NewEmptyBan()
+	pm.SetBanDB(obj)
+
+	return pm.SetNewestDeleteObjectLog(oplog, obj)
+}
+
+func (pm *ProtocolManager) handleFailedDeleteBanLog(oplog *pkgservice.BaseOplog) error {
+
+	obj := NewEmptyBan()
+	pm.SetBanDB(obj)
+
+	return pm.HandleFailedDeleteObjectLog(oplog, obj)
+}
+
+func (pm *ProtocolManager) handleFailedValidDeleteBanLog(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) error {
+
+	obj := NewEmptyBan()
+	pm.SetBanDB(obj)
+
+	return pm.HandleFailedValidDeleteObjectLog(oplog, obj, info, pm.updateBanDeleteInfo)
+}
+
+/**********
+ * Customize
+ **********/
+
+func (pm *ProtocolManager) updateBanDeleteInfo(obj pkgservice.Object, oplog *pkgservice.BaseOplog, theInfo pkgservice.ProcessInfo) error {
+
+	info, ok := theInfo.(*ProcessBoardInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	info.BanInfo[*oplog.ObjID] = oplog
+
+	return nil
+}
+
+func (pm *ProtocolManager) isValidDeleteBanLog(oplog *pkgservice.BaseOplog) bool {
+	opData := &BoardOpDeleteBan{}
+	err := oplog.GetData(opData)
+	if err != nil {
+		return false
+	}
+
+	return pm.isValidBanner(oplog.CreatorID, opData.BanType)
+}
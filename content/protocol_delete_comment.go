@@ -18,6 +18,7 @@ package content
 
 import (
 	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
@@ -59,5 +60,17 @@ func (pm *ProtocolManager) setPendingDeleteCommentSyncInfo(obj pkgservice.Object
 
 func (pm *ProtocolManager) postdeleteComment(id *types.PttID, oplog *pkgservice.BaseOplog, opData pkgservice.OpData, obj pkgservice.Object, blockInfo *pkgservice.BlockInfo) error {
 
+	comment, ok := obj.(*Comment)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	err := pm.removeSearchIndex(comment.ID)
+	if err != nil {
+		log.Warn("postdeleteComment: unable to remove search-index", "e", err, "entity", pm.Entity().IDString(), "comment", comment.ID)
+	}
+
+	pm.PostObjEvent(comment, comment.ArticleID, oplog, types.StatusDeleted)
+
 	return nil
 }
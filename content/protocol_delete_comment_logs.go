@@ -49,6 +49,11 @@ func (pm *ProtocolManager) handlePendingDeleteCommentLogs(oplog *pkgservice.Base
 	obj := NewEmptyComment()
 	pm.SetCommentDB(obj)
 
+	err := pm.checkPendingDeleteLog(oplog, obj)
+	if err != nil {
+		return false, nil, err
+	}
+
 	opData := &BoardOpDeleteComment{}
 
 	return pm.HandlePendingDeleteObjectLog(
@@ -0,0 +1,104 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+UnpinArticle unpins the article (by the masters or the moderators with PermPin).
+*/
+func (pm *ProtocolManager) UnpinArticle(articleID *types.PttID) error {
+
+	pin, err := pm.getPinByArticle(articleID)
+	if err != nil || pin.Status != types.StatusAlive {
+		return ErrNotFound
+	}
+
+	return pm.DeletePin(pin.ID)
+}
+
+func (pm *ProtocolManager) DeletePin(id *types.PttID) error {
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.HasPerm(myID, PermPin) {
+		return types.ErrInvalidID
+	}
+
+	pin := NewEmptyPin()
+	pm.SetPinDB(pin)
+	pin.SetID(id)
+
+	err := pin.GetByID(false)
+	if err != nil {
+		return err
+	}
+
+	opData := &BoardOpDeletePin{
+		ArticleID: pin.ArticleID,
+	}
+
+	return pm.DeleteObject(
+		id,
+
+		BoardOpTypeDeletePin,
+		pin,
+		opData,
+
+		pm.boardOplogMerkle,
+
+		pm.SetBoardDB,
+		pm.NewBoardOplog,
+		nil,
+		pm.setPendingDeletePinSyncInfo,
+
+		pm.broadcastBoardOplogCore,
+		pm.postdeletePin,
+	)
+}
+
+func (pm *ProtocolManager) setPendingDeletePinSyncInfo(obj pkgservice.Object, status types.Status, oplog *pkgservice.BaseOplog) error {
+
+	syncInfo := &pkgservice.BaseSyncInfo{}
+	syncInfo.InitWithOplog(status, oplog)
+
+	obj.SetSyncInfo(syncInfo)
+
+	return nil
+}
+
+func (pm *ProtocolManager) postdeletePin(id *types.PttID, oplog *pkgservice.BaseOplog, opData pkgservice.OpData, obj pkgservice.Object, blockInfo *pkgservice.BlockInfo) error {
+
+	pin, ok := obj.(*Pin)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	// article-key: the article may already be pinned again with a newer pin.
+	articlePinID, err := pin.GetIDByArticle(pin.ArticleID)
+	if err == nil && reflect.DeepEqual(articlePinID, pin.ID) {
+		pin.DeleteArticleKey()
+	}
+
+	pm.PostObjEvent(pin, pin.ArticleID, oplog, types.StatusDeleted)
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+handleDeletePinLogs requires that the pin is deleted by the master or the moderator with PermPin.
+*/
+func (pm *ProtocolManager) handleDeletePinLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) ([]*pkgservice.BaseOplog, error) {
+
+	if !pm.isValidDeletePinLog(oplog) {
+		return nil, pkgservice.ErrInvalidOplog
+	}
+
+	obj := NewEmptyPin()
+	pm.SetPinDB(obj)
+
+	opData := &BoardOpDeletePin{}
+
+	return pm.HandleDeleteObjectLog(
+		oplog,
+		info,
+		obj,
+		opData,
+
+		pm.boardOplogMerkle,
+
+		pm.SetBoardDB,
+		nil,
+		pm.postdeletePin,
+		pm.updatePinDeleteInfo,
+	)
+}
+
+func (pm *ProtocolManager) handlePendingDeletePinLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) (types.Bool, []*pkgservice.BaseOplog, error) {
+
+	if !pm.isValidDeletePinLog(oplog) {
+		return false, nil, pkgservice.ErrInvalidOplog
+	}
+
+	obj := NewEmptyPin()
+	pm.SetPinDB(obj)
+
+	opData := &BoardOpDeletePin{}
+
+	return pm.HandlePendingDeleteObjectLog(
+		oplog,
+		info,
+		obj,
+		opData,
+
+		pm.boardOplogMerkle,
+
+		pm.SetBoardDB,
+		nil,
+		pm.setPendingDeletePinSyncInfo,
+		pm.updatePinDeleteInfo,
+	)
+}
+
+func (pm *ProtocolManager) setNewestDeletePinLog(oplog *pkgservice.BaseOplog) (types.Bool, error) {
+
+	obj := NewEmptyPin()
+	pm.SetPinDB(obj)
+
+	return pm.SetNewestDeleteObjectLog(oplog, obj)
+}
+
+func (pm *ProtocolManager) handleFailedDeletePinLog(oplog *pkgservice.BaseOplog) error {
+
+	obj := NewEmptyPin()
+	pm.SetPinDB(obj)
+
+	return pm.HandleFailedDeleteObjectLog(oplog, obj)
+}
+
+func (pm *ProtocolManager) handleFailedValidDeletePinLog(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) error {
+
+	obj := NewEmptyPin()
+	pm.SetPinDB(obj)
+
+	return pm.HandleFailedValidDeleteObjectLog(oplog, obj, info, pm.updatePinDeleteInfo)
+}
+
+/**********
+ * Customize
+ **********/
+
+func (pm *ProtocolManager) updatePinDeleteInfo(obj pkgservice.Object, oplog *pkgservice.BaseOplog, theInfo pkgservice.ProcessInfo) error {
+
+	info, ok := theInfo.(*ProcessBoardInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	info.PinInfo[*oplog.ObjID] = oplog
+
+	return nil
+}
+
+func (pm *ProtocolManager) isValidDeletePinLog(oplog *pkgservice.BaseOplog) bool {
+	return pm.HasPerm(oplog.CreatorID, PermPin)
+}
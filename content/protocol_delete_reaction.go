@@ -0,0 +1,120 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+Unreact removes the reaction of the user with the reaction-type from the article (targetID as nil or as the article-id) or from the comment.
+*/
+func (pm *ProtocolManager) Unreact(articleID *types.PttID, targetID *types.PttID, reactionType ReactionType) error {
+
+	if !reactionType.IsValid() {
+		return ErrInvalidReactionType
+	}
+
+	if targetID == nil {
+		targetID = articleID
+	}
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+
+	reaction, err := pm.getReactionByUser(targetID, reactionType, myID)
+	if err != nil {
+		return ErrNotFound
+	}
+	if !reflect.DeepEqual(reaction.ArticleID, articleID) {
+		return pkgservice.ErrInvalidData
+	}
+
+	return pm.DeleteReaction(reaction.ID)
+}
+
+func (pm *ProtocolManager) DeleteReaction(id *types.PttID) error {
+
+	reaction := NewEmptyReaction()
+	pm.SetReactionDB(reaction)
+	reaction.SetID(id)
+
+	err := reaction.GetByID(false)
+	if err != nil {
+		return err
+	}
+
+	opData := &BoardOpDeleteReaction{
+		ArticleID:    reaction.ArticleID,
+		TargetID:     reaction.TargetID,
+		ReactionType: reaction.ReactionType,
+	}
+
+	return pm.DeleteObject(
+		id,
+
+		BoardOpTypeDeleteReaction,
+		reaction,
+		opData,
+
+		pm.boardOplogMerkle,
+
+		pm.SetBoardDB,
+		pm.NewBoardOplog,
+		nil,
+		pm.setPendingDeleteReactionSyncInfo,
+
+		pm.broadcastBoardOplogCore,
+		pm.postdeleteReaction,
+	)
+}
+
+func (pm *ProtocolManager) setPendingDeleteReactionSyncInfo(obj pkgservice.Object, status types.Status, oplog *pkgservice.BaseOplog) error {
+
+	syncInfo := &pkgservice.BaseSyncInfo{}
+	syncInfo.InitWithOplog(status, oplog)
+
+	obj.SetSyncInfo(syncInfo)
+
+	return nil
+}
+
+func (pm *ProtocolManager) postdeleteReaction(id *types.PttID, oplog *pkgservice.BaseOplog, opData pkgservice.OpData, obj pkgservice.Object, blockInfo *pkgservice.BlockInfo) error {
+
+	reaction, ok := obj.(*Reaction)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	// user-key: the user may already react again with a newer reaction.
+	userReactionID, err := reaction.GetIDByUser(reaction.TargetID, reaction.ReactionType, reaction.CreatorID)
+	if err == nil && reflect.DeepEqual(userReactionID, reaction.ID) {
+		reaction.DeleteUserKey()
+	}
+
+	err = reaction.RebuildCount(reaction.TargetID, reaction.ReactionType)
+	if err != nil {
+		log.Warn("postdeleteReaction: unable to rebuild count", "e", err, "entity", pm.Entity().IDString(), "reaction", reaction.ID)
+	}
+
+	pm.PostObjEvent(reaction, reaction.ArticleID, oplog, types.StatusDeleted)
+
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) DeleteReply(id *types.PttID) error {
+
+	reply := NewEmptyReply()
+	pm.SetReplyDB(reply)
+
+	opData := &BoardOpDeleteReply{}
+
+	return pm.DeleteObject(
+		id,
+
+		BoardOpTypeDeleteReply,
+		reply,
+		opData,
+
+		pm.boardOplogMerkle,
+
+		pm.SetBoardDB,
+		pm.NewBoardOplog,
+		nil,
+		pm.setPendingDeleteReplySyncInfo,
+
+		pm.broadcastBoardOplogCore,
+		pm.postdeleteReply,
+	)
+}
+
+func (pm *ProtocolManager) setPendingDeleteReplySyncInfo(obj pkgservice.Object, status types.Status, oplog *pkgservice.BaseOplog) error {
+
+	syncInfo := &pkgservice.BaseSyncInfo{}
+	syncInfo.InitWithOplog(status, oplog)
+
+	obj.SetSyncInfo(syncInfo)
+
+	return nil
+}
+
+func (pm *ProtocolManager) postdeleteReply(id *types.PttID, oplog *pkgservice.BaseOplog, opData pkgservice.OpData, obj pkgservice.Object, blockInfo *pkgservice.BlockInfo) error {
+
+	reply, ok := obj.(*Reply)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	err := pm.removeSearchIndex(reply.ID)
+	if err != nil {
+		log.Warn("postdeleteReply: unable to remove search-index", "e", err, "entity", pm.Entity().IDString(), "reply", reply.ID)
+	}
+
+	pm.PostObjEvent(reply, reply.ArticleID, oplog, types.StatusDeleted)
+
+	return nil
+}
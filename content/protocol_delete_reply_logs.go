@@ -0,0 +1,112 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) handleDeleteReplyLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) ([]*pkgservice.BaseOplog, error) {
+
+	obj := NewEmptyReply()
+	pm.SetReplyDB(obj)
+
+	opData := &BoardOpDeleteReply{}
+
+	return pm.HandleDeleteObjectLog(
+		oplog,
+		info,
+		obj,
+		opData,
+
+		pm.boardOplogMerkle,
+
+		pm.SetBoardDB,
+		pm.removeMediaInfoByBlockInfo,
+		pm.postdeleteReply,
+		pm.updateReplyDeleteInfo,
+	)
+}
+
+func (pm *ProtocolManager) handlePendingDeleteReplyLogs(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) (types.Bool, []*pkgservice.BaseOplog, error) {
+
+	obj := NewEmptyReply()
+	pm.SetReplyDB(obj)
+
+	err := pm.checkPendingDeleteLog(oplog, obj)
+	if err != nil {
+		return false, nil, err
+	}
+
+	opData := &BoardOpDeleteReply{}
+
+	return pm.HandlePendingDeleteObjectLog(
+		oplog,
+		info,
+		obj,
+		opData,
+
+		pm.boardOplogMerkle,
+
+		pm.SetBoardDB,
+		pm.removeMediaInfoByBlockInfo,
+		pm.setPendingDeleteReplySyncInfo,
+		pm.updateReplyDeleteInfo,
+	)
+}
+
+func (pm *ProtocolManager) setNewestDeleteReplyLog(oplog *pkgservice.BaseOplog) (types.Bool, error) {
+
+	obj := NewEmptyReply()
+	pm.SetReplyDB(obj)
+
+	return pm.SetNewestDeleteObjectLog(oplog, obj)
+}
+
+func (pm *ProtocolManager) handleFailedDeleteReplyLog(oplog *pkgservice.BaseOplog) error {
+
+	obj := NewEmptyReply()
+	pm.SetReplyDB(obj)
+
+	return pm.HandleFailedDeleteObjectLog(oplog, obj)
+}
+
+func (pm *ProtocolManager) handleFailedValidDeleteReplyLog(oplog *pkgservice.BaseOplog, info *ProcessBoardInfo) error {
+
+	obj := NewEmptyReply()
+	pm.SetReplyDB(obj)
+
+	return pm.HandleFailedValidDeleteObjectLog(oplog, obj, info, pm.updateReplyDeleteInfo)
+}
+
+/**********
+ * Customize
+ **********/
+
+func (pm *ProtocolManager) updateReplyDeleteInfo(obj pkgservice.Object, oplog *pkgservice.BaseOplog, theInfo pkgservice.ProcessInfo) error {
+
+	info, ok := theInfo.(*ProcessBoardInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	info.ReplyInfo[*oplog.ObjID] = oplog
+
+	return nil
+}
@@ -0,0 +1,318 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ailabstw/go-pttai/account"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+ExportBoard exports all the alive articles, comments, replies and media of the board
+to dir as a static html site or a markdown + json tree.
+*/
+func (pm *ProtocolManager) ExportBoard(dir string, format ExportFormat) (*ExportBoard, error) {
+	if !format.IsValid() {
+		return nil, ErrInvalidExportFormat
+	}
+
+	exportBoard, err := pm.getExportBoard()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pm.writeExportBoard(dir, format, exportBoard)
+	if err != nil {
+		return nil, err
+	}
+
+	return exportBoard, nil
+}
+
+func (pm *ProtocolManager) getExportBoard() (*ExportBoard, error) {
+	board := pm.Entity().(*Board)
+	userNames := make(map[types.PttID]string)
+
+	creatorName := pm.exportUserName(board.CreatorID, userNames)
+
+	title := board.Title
+	theTitle, err := pm.GetTitle()
+	if err == nil && theTitle != nil {
+		title = theTitle.Title
+	}
+	if len(title) == 0 && board.EntityType == pkgservice.EntityTypePersonal {
+		myID := pm.Ptt().GetMyEntity().GetID()
+		title = DefaultTitle(myID, board.CreatorID, creatorName)
+	}
+
+	exportTS, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := pm.GetArticleList(nil, 0, pttdb.ListOrderNext, false)
+	if err != nil {
+		return nil, err
+	}
+
+	exportArticles := make([]*ExportArticle, 0, len(articles))
+	for _, article := range articles {
+		if article.Status != types.StatusAlive {
+			continue
+		}
+
+		exportArticle, err := pm.getExportArticle(article, userNames)
+		if err != nil {
+			return nil, err
+		}
+
+		exportArticles = append(exportArticles, exportArticle)
+	}
+
+	return &ExportBoard{
+		ID:          board.ID,
+		Title:       string(title),
+		CreatorID:   board.CreatorID,
+		CreatorName: creatorName,
+		ExportTS:    exportTS,
+
+		Articles: exportArticles,
+	}, nil
+}
+
+func (pm *ProtocolManager) getExportArticle(article *Article, userNames map[types.PttID]string) (*ExportArticle, error) {
+	blockInfo := article.GetBlockInfo()
+
+	buf, err := pm.getContentBuf(blockInfo, article.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	media := pm.getExportMediaList(blockInfo)
+
+	nPush, nBoo := 0, 0
+	if article.NPush != nil {
+		nPush = int(article.NPush.Count())
+	}
+	if article.NBoo != nil {
+		nBoo = int(article.NBoo.Count())
+	}
+
+	comments, err := pm.getExportCommentList(article.ID, userNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportArticle{
+		ID:          article.ID,
+		Title:       string(article.Title),
+		CreatorID:   article.CreatorID,
+		CreatorName: pm.exportUserName(article.CreatorID, userNames),
+		CreateTS:    article.CreateTS,
+		UpdateTS:    article.UpdateTS,
+
+		Lines: bufToLines(buf),
+		Media: media,
+
+		NPush:    nPush,
+		NBoo:     nBoo,
+		Comments: comments,
+	}, nil
+}
+
+func (pm *ProtocolManager) getExportCommentList(articleID *types.PttID, userNames map[types.PttID]string) ([]*ExportComment, error) {
+
+	comment := NewEmptyComment()
+	pm.SetCommentDB(comment)
+	iter, err := comment.GetCrossObjIterWithObj(articleID[:], nil, pttdb.ListOrderNext, false)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	iterFunc := pttdb.GetFuncIter(iter, pttdb.ListOrderNext)
+
+	comments := make([]*ExportComment, 0)
+	var eachComment *Comment
+	for iterFunc() {
+		v := iter.Value()
+		eachComment = NewEmptyComment()
+		pm.SetCommentDB(eachComment)
+		err = eachComment.Unmarshal(v)
+		if err != nil {
+			continue
+		}
+
+		if eachComment.Status != types.StatusAlive {
+			continue
+		}
+
+		blockInfo := eachComment.GetBlockInfo()
+		buf, err := pm.getContentBuf(blockInfo, eachComment.ID)
+		if err != nil {
+			continue
+		}
+
+		replies, err := pm.getExportReplyList(articleID, eachComment.ID, userNames)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, &ExportComment{
+			ID:          eachComment.ID,
+			CommentType: eachComment.CommentType,
+			CreatorID:   eachComment.CreatorID,
+			CreatorName: pm.exportUserName(eachComment.CreatorID, userNames),
+			CreateTS:    eachComment.CreateTS,
+
+			Lines: bufToLines(buf),
+			Media: pm.getExportMediaList(blockInfo),
+
+			Replies: replies,
+		})
+	}
+
+	return comments, nil
+}
+
+func (pm *ProtocolManager) getExportReplyList(articleID *types.PttID, commentID *types.PttID, userNames map[types.PttID]string) ([]*ExportReply, error) {
+
+	replies, err := pm.GetReplyList(articleID, commentID, nil, 0, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+
+	exportReplies := make([]*ExportReply, 0, len(replies))
+	for _, reply := range replies {
+		if reply.Status != types.StatusAlive {
+			continue
+		}
+
+		buf, err := pm.getReplyBuf(reply)
+		if err != nil {
+			continue
+		}
+
+		exportReplies = append(exportReplies, &ExportReply{
+			ID:          reply.ID,
+			CreatorID:   reply.CreatorID,
+			CreatorName: pm.exportUserName(reply.CreatorID, userNames),
+			CreateTS:    reply.CreateTS,
+
+			Lines: bufToLines(buf),
+			Media: pm.getExportMediaList(reply.GetBlockInfo()),
+		})
+	}
+
+	return exportReplies, nil
+}
+
+/*
+getExportMediaList gets the media referred by the block-info.
+The media not synced yet are skipped.
+*/
+func (pm *ProtocolManager) getExportMediaList(blockInfo *pkgservice.BlockInfo) []*ExportMedia {
+	if blockInfo == nil || len(blockInfo.MediaIDs) == 0 {
+		return nil
+	}
+
+	exportMedia := make([]*ExportMedia, 0, len(blockInfo.MediaIDs))
+	for _, mediaID := range blockInfo.MediaIDs {
+		media, err := pm.GetMedia(mediaID)
+		if err != nil {
+			log.Warn("getExportMediaList: unable to get media", "mediaID", mediaID, "e", err)
+			continue
+		}
+		if media.Status != types.StatusAlive {
+			continue
+		}
+
+		exportMedia = append(exportMedia, mediaToExportMedia(media))
+	}
+
+	return exportMedia
+}
+
+func (pm *ProtocolManager) exportUserName(id *types.PttID, userNames map[types.PttID]string) string {
+	if id == nil {
+		return ""
+	}
+
+	name, ok := userNames[*id]
+	if ok {
+		return name
+	}
+
+	accountBackend := pm.Entity().Service().(*Backend).accountBackend
+	userName, err := accountBackend.GetRawUserNameByID(id)
+	if err != nil {
+		userName = account.NewEmptyUserName()
+	}
+
+	name = string(userName.Name)
+	if name == "" {
+		name = exportIDString(id)
+	}
+	userNames[*id] = name
+
+	return name
+}
+
+func (pm *ProtocolManager) writeExportBoard(dir string, format ExportFormat, exportBoard *ExportBoard) error {
+	err := os.MkdirAll(filepath.Join(dir, ExportArticleDir), 0700)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Join(dir, ExportMediaDir), 0700)
+	if err != nil {
+		return err
+	}
+
+	for _, media := range exportBoard.AllMedia() {
+		err = ioutil.WriteFile(filepath.Join(dir, filepath.FromSlash(media.Path)), media.buf, 0600)
+		if err != nil {
+			return err
+		}
+	}
+
+	marshaled, err := json.MarshalIndent(exportBoard, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, ExportBoardFilename), marshaled, 0600)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case ExportFormatHTML:
+		return writeExportBoardHTML(dir, exportBoard)
+	case ExportFormatMarkdown:
+		return writeExportBoardMarkdown(dir, exportBoard)
+	}
+
+	return ErrInvalidExportFormat
+}
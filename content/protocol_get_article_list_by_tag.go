@@ -0,0 +1,104 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+)
+
+func (pm *ProtocolManager) GetArticleListByTag(tag string, startID *types.PttID, limit int, listOrder pttdb.ListOrder) ([]*Article, error) {
+
+	entityID := pm.Entity().GetID()
+
+	var startSortKey []byte
+	if startID != nil {
+		article := NewEmptyArticle()
+		pm.SetArticleDB(article)
+		article.SetID(startID)
+
+		err := article.GetByID(false)
+		if err != nil {
+			return nil, err
+		}
+
+		startSortKey, err = article.MarshalTagSortKey()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	docIDs, err := dbTag.List(entityID[:], tag, startSortKey, limit, listOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	articles := make([]*Article, 0, len(docIDs))
+	for _, docID := range docIDs {
+		articleID := &types.PttID{}
+		copy(articleID[:], docID)
+
+		article := NewEmptyArticle()
+		pm.SetArticleDB(article)
+		article.SetID(articleID)
+
+		err = article.GetByID(false)
+		if err != nil {
+			continue
+		}
+
+		article.LastSeen, _ = article.LoadLastSeen()
+		article.CommentCreateTS, _ = article.LoadCommentCreateTS()
+		article.NPush, _ = article.LoadPush()
+		article.NBoo, _ = article.LoadBoo()
+
+		articles = append(articles, article)
+	}
+
+	return articles, nil
+}
+
+/*
+indexArticleTags indexes the article by the tags, in the same order as the article-list.
+*/
+func (pm *ProtocolManager) indexArticleTags(article *Article) error {
+	sortKey, err := article.MarshalTagSortKey()
+	if err != nil {
+		return err
+	}
+
+	return dbTag.Put(article.EntityID[:], article.ID[:], sortKey, article.Tags)
+}
+
+func (pm *ProtocolManager) removeTagIndex(id *types.PttID) error {
+	entityID := pm.Entity().GetID()
+
+	return dbTag.Delete(entityID[:], id[:])
+}
+
+/*
+MarshalTagSortKey returns the sort-key of the article in the tag-index (create-ts | id).
+*/
+func (a *Article) MarshalTagSortKey() ([]byte, error) {
+	marshalTimestamp, err := a.CreateTS.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return common.Concat([][]byte{marshalTimestamp, a.ID[:]})
+}
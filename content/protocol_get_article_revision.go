@@ -0,0 +1,136 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+GetArticleRevisionList gets the revisions of the article kept on this node, ordered by the update-ts.
+The last revision is the current content of the article.
+*/
+func (pm *ProtocolManager) GetArticleRevisionList(articleID *types.PttID) ([]*ArticleRevision, error) {
+	article, err := pm.getRevisionArticle(articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return article.GetRevisionList()
+}
+
+/*
+GetArticleRevision gets the revision and the lines of the content of the revision.
+*/
+func (pm *ProtocolManager) GetArticleRevision(articleID *types.PttID, revisionID *types.PttID) (*ArticleRevision, [][]byte, error) {
+	article, err := pm.getRevisionArticle(articleID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	revision, err := article.GetRevision(revisionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lines, err := pm.getContentBuf(revision.BlockInfo, articleID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return revision, lines, nil
+}
+
+/*
+GetArticleRevisionDiff gets the line-level diff of the contents from the revision to the revision.
+*/
+func (pm *ProtocolManager) GetArticleRevisionDiff(articleID *types.PttID, fromRevisionID *types.PttID, toRevisionID *types.PttID) ([]*pkgservice.LineDiff, error) {
+	_, fromLines, err := pm.GetArticleRevision(articleID, fromRevisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, toLines, err := pm.GetArticleRevision(articleID, toRevisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pkgservice.DiffLines(fromLines, toLines), nil
+}
+
+/*
+SetArticleRevisionLimit sets the number of the previous revisions kept for each article of the board on this node.
+The revisions over the limit are pruned at the next update of the article.
+
+The limit is saved locally (not through the oplog), and is not synced to the other nodes.
+*/
+func (pm *ProtocolManager) SetArticleRevisionLimit(limit int) error {
+	if limit < 0 || limit > MaxArticleRevisionLimit {
+		return ErrInvalidRevisionLimit
+	}
+
+	board := pm.Entity().(*Board)
+	if board.Status != types.StatusAlive {
+		return ErrInvalidBoard
+	}
+
+	return board.SaveArticleRevisionLimit(limit)
+}
+
+/*
+GetArticleRevisionLimit gets the revision-limit of the board on this node (default DefaultArticleRevisionLimit).
+*/
+func (pm *ProtocolManager) GetArticleRevisionLimit() (int, error) {
+	board := pm.Entity().(*Board)
+
+	return board.LoadArticleRevisionLimit()
+}
+
+func (pm *ProtocolManager) getRevisionArticle(articleID *types.PttID) (*Article, error) {
+	article := NewEmptyArticle()
+	pm.SetArticleDB(article)
+	article.SetID(articleID)
+
+	err := article.GetByID(false)
+	if err != nil {
+		return nil, err
+	}
+	if article.Status != types.StatusAlive {
+		return nil, pkgservice.ErrNotAlive
+	}
+
+	return article, nil
+}
+
+/*
+saveArticleRevision saves the current content of the article as the revision of the create / update oplog,
+and prunes the revisions over the revision-limit of the board.
+*/
+func (pm *ProtocolManager) saveArticleRevision(article *Article, oplog *pkgservice.BaseOplog) error {
+	_, err := article.SaveRevision(oplog)
+	if err != nil {
+		return err
+	}
+
+	limit, err := pm.GetArticleRevisionLimit()
+	if err != nil {
+		return err
+	}
+
+	return article.PruneRevisions(limit)
+}
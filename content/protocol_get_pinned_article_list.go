@@ -0,0 +1,85 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"sort"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+/*
+GetPinList gets the alive pins of the board, ordered by Order (ascending) and then by the create-ts (descending).
+*/
+func (pm *ProtocolManager) GetPinList() ([]*Pin, error) {
+	pin := NewEmptyPin()
+	pm.SetPinDB(pin)
+
+	pins, err := pin.GetList()
+	if err != nil {
+		return nil, err
+	}
+
+	alivePins := make([]*Pin, 0, len(pins))
+	for _, each := range pins {
+		if each.Status != types.StatusAlive {
+			continue
+		}
+		alivePins = append(alivePins, each)
+	}
+
+	sort.SliceStable(alivePins, func(i, j int) bool {
+		if alivePins[i].Order != alivePins[j].Order {
+			return alivePins[i].Order < alivePins[j].Order
+		}
+		return alivePins[j].CreateTS.IsLess(alivePins[i].CreateTS)
+	})
+
+	return alivePins, nil
+}
+
+/*
+GetPinnedArticleList gets the pinned articles (alive) with the corresponding pins, in the order of the pins.
+*/
+func (pm *ProtocolManager) GetPinnedArticleList() ([]*Pin, []*Article, error) {
+	pins, err := pm.GetPinList()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	thePins := make([]*Pin, 0, len(pins))
+	articles := make([]*Article, 0, len(pins))
+	for _, pin := range pins {
+		article := NewEmptyArticle()
+		pm.SetArticleDB(article)
+		article.SetID(pin.ArticleID)
+		err = article.GetByID(false)
+		if err != nil || article.Status != types.StatusAlive {
+			continue
+		}
+
+		article.LastSeen, _ = article.LoadLastSeen()
+		article.CommentCreateTS, _ = article.LoadCommentCreateTS()
+		article.NPush, _ = article.LoadPush()
+		article.NBoo, _ = article.LoadBoo()
+
+		thePins = append(thePins, pin)
+		articles = append(articles, article)
+	}
+
+	return thePins, articles, nil
+}
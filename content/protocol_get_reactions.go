@@ -0,0 +1,85 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+type ReactionCount struct {
+	ReactionType ReactionType `json:"R"`
+	Count        uint64       `json:"N"`
+	IsMine       bool         `json:"M"`
+}
+
+/*
+GetReactions gets the (estimated) counts of each reaction-type on the article (targetID as nil or as the article-id) or on the comment.
+
+Only the reaction-types with non-zero counts are returned.
+*/
+func (pm *ProtocolManager) GetReactions(articleID *types.PttID, targetID *types.PttID) ([]*ReactionCount, error) {
+
+	if targetID == nil {
+		targetID = articleID
+	}
+
+	// validate
+	if !reflect.DeepEqual(targetID, articleID) {
+		comment := NewEmptyComment()
+		pm.SetCommentDB(comment)
+		comment.SetID(targetID)
+
+		err := comment.GetByID(false)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(comment.ArticleID, articleID) {
+			return nil, types.ErrInvalidID
+		}
+	}
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	entityID := pm.Entity().GetID()
+
+	reaction := NewEmptyReaction()
+	pm.SetReactionDB(reaction)
+
+	reactionCounts := make([]*ReactionCount, 0, NReactionType)
+	for reactionType := ReactionTypeLike; reactionType < NReactionType; reactionType++ {
+		count, err := LoadReactionCount(entityID, targetID, reactionType)
+		if err != nil {
+			continue
+		}
+
+		n := count.Count()
+		if n == 0 {
+			continue
+		}
+
+		_, err = reaction.GetIDByUser(targetID, reactionType, myID)
+
+		reactionCounts = append(reactionCounts, &ReactionCount{
+			ReactionType: reactionType,
+			Count:        n,
+			IsMine:       err == nil,
+		})
+	}
+
+	return reactionCounts, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+)
+
+/*
+GetReplyList gets the replies in the thread of the comment, ordered by the create-ts.
+*/
+func (pm *ProtocolManager) GetReplyList(articleID *types.PttID, commentID *types.PttID, startID *types.PttID, limit int, listOrder pttdb.ListOrder) ([]*Reply, error) {
+
+	reply := NewEmptyReply()
+	pm.SetReplyDB(reply)
+	iter, err := reply.GetCrossObjIterWithObj(commentID[:], startID, listOrder, false)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	iterFunc := pttdb.GetFuncIter(iter, listOrder)
+
+	replies := make([]*Reply, 0)
+	var eachReply *Reply
+	for iterFunc() {
+		if limit > 0 && len(replies) >= limit {
+			break
+		}
+
+		v := iter.Value()
+		eachReply = NewEmptyReply()
+		pm.SetReplyDB(eachReply)
+		err = eachReply.Unmarshal(v)
+		if err != nil {
+			continue
+		}
+
+		if articleID != nil && !reflect.DeepEqual(eachReply.ArticleID, articleID) {
+			continue
+		}
+
+		replies = append(replies, eachReply)
+	}
+
+	return replies, nil
+}
+
+/*
+getReplyBuf gets the content of the reply.
+*/
+func (pm *ProtocolManager) getReplyBuf(reply *Reply) ([][]byte, error) {
+	if reply.Status > types.StatusAlive {
+		return DefaultDeletedComment, nil
+	}
+
+	return pm.getContentBuf(reply.GetBlockInfo(), reply.ID)
+}
@@ -37,6 +37,15 @@ type ProcessBoardInfo struct {
 	ReplyInfo       map[types.PttID]*pkgservice.BaseOplog
 	ReplyBlockInfo  map[types.PttID]*pkgservice.BaseOplog
 
+	CreateReactionInfo map[types.PttID]*pkgservice.BaseOplog
+	ReactionInfo       map[types.PttID]*pkgservice.BaseOplog
+
+	CreateBanInfo map[types.PttID]*pkgservice.BaseOplog
+	BanInfo       map[types.PttID]*pkgservice.BaseOplog
+
+	CreatePinInfo map[types.PttID]*pkgservice.BaseOplog
+	PinInfo       map[types.PttID]*pkgservice.BaseOplog
+
 	CreateMediaInfo map[types.PttID]*pkgservice.BaseOplog
 	MediaInfo       map[types.PttID]*pkgservice.BaseOplog
 	MediaBlockInfo  map[types.PttID]*pkgservice.BaseOplog
@@ -61,6 +70,15 @@ func NewProcessBoardInfo() *ProcessBoardInfo {
 		ReplyInfo:       make(map[types.PttID]*pkgservice.BaseOplog),
 		ReplyBlockInfo:  make(map[types.PttID]*pkgservice.BaseOplog),
 
+		CreateReactionInfo: make(map[types.PttID]*pkgservice.BaseOplog),
+		ReactionInfo:       make(map[types.PttID]*pkgservice.BaseOplog),
+
+		CreateBanInfo: make(map[types.PttID]*pkgservice.BaseOplog),
+		BanInfo:       make(map[types.PttID]*pkgservice.BaseOplog),
+
+		CreatePinInfo: make(map[types.PttID]*pkgservice.BaseOplog),
+		PinInfo:       make(map[types.PttID]*pkgservice.BaseOplog),
+
 		CreateMediaInfo: make(map[types.PttID]*pkgservice.BaseOplog),
 		MediaInfo:       make(map[types.PttID]*pkgservice.BaseOplog),
 		MediaBlockInfo:  make(map[types.PttID]*pkgservice.BaseOplog),
@@ -107,8 +125,22 @@ func (pm *ProtocolManager) processBoardLog(oplog *pkgservice.BaseOplog, processI
 		origLogs, err = pm.handleDeleteCommentLogs(oplog, info)
 
 	case BoardOpTypeCreateReply:
+		origLogs, err = pm.handleCreateReplyLogs(oplog, info)
 	case BoardOpTypeUpdateReply:
 	case BoardOpTypeDeleteReply:
+		origLogs, err = pm.handleDeleteReplyLogs(oplog, info)
+	case BoardOpTypeCreateReaction:
+		origLogs, err = pm.handleCreateReactionLogs(oplog, info)
+	case BoardOpTypeDeleteReaction:
+		origLogs, err = pm.handleDeleteReactionLogs(oplog, info)
+	case BoardOpTypeCreateBan:
+		origLogs, err = pm.handleCreateBanLogs(oplog, info)
+	case BoardOpTypeDeleteBan:
+		origLogs, err = pm.handleDeleteBanLogs(oplog, info)
+	case BoardOpTypeCreatePin:
+		origLogs, err = pm.handleCreatePinLogs(oplog, info)
+	case BoardOpTypeDeletePin:
+		origLogs, err = pm.handleDeletePinLogs(oplog, info)
 	}
 	return
 }
@@ -151,8 +183,22 @@ func (pm *ProtocolManager) processPendingBoardLog(oplog *pkgservice.BaseOplog, p
 		isToSign, origLogs, err = pm.handlePendingDeleteCommentLogs(oplog, info)
 
 	case BoardOpTypeCreateReply:
+		isToSign, origLogs, err = pm.handlePendingCreateReplyLogs(oplog, info)
 	case BoardOpTypeUpdateReply:
 	case BoardOpTypeDeleteReply:
+		isToSign, origLogs, err = pm.handlePendingDeleteReplyLogs(oplog, info)
+	case BoardOpTypeCreateReaction:
+		isToSign, origLogs, err = pm.handlePendingCreateReactionLogs(oplog, info)
+	case BoardOpTypeDeleteReaction:
+		isToSign, origLogs, err = pm.handlePendingDeleteReactionLogs(oplog, info)
+	case BoardOpTypeCreateBan:
+		isToSign, origLogs, err = pm.handlePendingCreateBanLogs(oplog, info)
+	case BoardOpTypeDeleteBan:
+		isToSign, origLogs, err = pm.handlePendingDeleteBanLogs(oplog, info)
+	case BoardOpTypeCreatePin:
+		isToSign, origLogs, err = pm.handlePendingCreatePinLogs(oplog, info)
+	case BoardOpTypeDeletePin:
+		isToSign, origLogs, err = pm.handlePendingDeletePinLogs(oplog, info)
 	}
 
 	return
@@ -204,9 +250,47 @@ func (pm *ProtocolManager) postprocessBoardOplogs(processInfo pkgservice.Process
 		deleteCommentLogs = pkgservice.ProcessInfoToLogs(info.CommentInfo, BoardOpTypeDeleteComment)
 	}
 
+	// reply
+	createReplyIDs := pkgservice.ProcessInfoToSyncIDList(info.CreateReplyInfo, BoardOpTypeCreateReply)
+	createReplyBlockIDs := pkgservice.ProcessInfoToSyncBlockIDList(info.ReplyBlockInfo, BoardOpTypeCreateReply)
+	pm.SyncReply(SyncCreateReplyMsg, createReplyIDs, peer)
+	pm.SyncBlock(SyncCreateReplyBlockMsg, createReplyBlockIDs, peer)
+
+	var deleteReplyLogs []*pkgservice.BaseOplog
+	if isPending {
+		deleteReplyLogs = pkgservice.ProcessInfoToLogs(info.ReplyInfo, BoardOpTypeDeleteReply)
+	}
+
+	// reaction
+	createReactionIDs := pkgservice.ProcessInfoToSyncIDList(info.CreateReactionInfo, BoardOpTypeCreateReaction)
+	pm.SyncReaction(SyncCreateReactionMsg, createReactionIDs, peer)
+
+	var deleteReactionLogs []*pkgservice.BaseOplog
+	if isPending {
+		deleteReactionLogs = pkgservice.ProcessInfoToLogs(info.ReactionInfo, BoardOpTypeDeleteReaction)
+	}
+
+	// ban
+	createBanIDs := pkgservice.ProcessInfoToSyncIDList(info.CreateBanInfo, BoardOpTypeCreateBan)
+	pm.SyncBan(SyncCreateBanMsg, createBanIDs, peer)
+
+	var deleteBanLogs []*pkgservice.BaseOplog
+	if isPending {
+		deleteBanLogs = pkgservice.ProcessInfoToLogs(info.BanInfo, BoardOpTypeDeleteBan)
+	}
+
+	// pin
+	createPinIDs := pkgservice.ProcessInfoToSyncIDList(info.CreatePinInfo, BoardOpTypeCreatePin)
+	pm.SyncPin(SyncCreatePinMsg, createPinIDs, peer)
+
+	var deletePinLogs []*pkgservice.BaseOplog
+	if isPending {
+		deletePinLogs = pkgservice.ProcessInfoToLogs(info.PinInfo, BoardOpTypeDeletePin)
+	}
+
 	// media
 	createMediaIDs := pkgservice.ProcessInfoToSyncIDList(info.CreateMediaInfo, BoardOpTypeCreateMedia)
-	createMediaBlockIDs := pkgservice.ProcessInfoToSyncBlockIDList(info.MediaBlockInfo, BoardOpTypeCreateMedia)
+	createMediaBlockIDs := pkgservice.ProcessInfoToSyncMediaBlockIDList(info.MediaBlockInfo, BoardOpTypeCreateMedia)
 	pm.SyncMedia(SyncCreateMediaMsg, createMediaIDs, peer)
 	pm.SyncBlock(SyncCreateMediaBlockMsg, createMediaBlockIDs, peer)
 
@@ -221,6 +305,10 @@ func (pm *ProtocolManager) postprocessBoardOplogs(processInfo pkgservice.Process
 			toBroadcastLogs,
 			deleteArticleLogs,
 			deleteCommentLogs,
+			deleteReplyLogs,
+			deleteReactionLogs,
+			deleteBanLogs,
+			deletePinLogs,
 			deleteMediaLogs,
 		}
 		toBroadcastLogs, err = pkgservice.ConcatLog(toBroadcastLogAry)
@@ -276,8 +364,22 @@ func (pm *ProtocolManager) SetNewestBoardOplog(oplog *pkgservice.BaseOplog) (err
 		isNewer, err = pm.setNewestDeleteCommentLog(oplog)
 
 	case BoardOpTypeCreateReply:
+		isNewer, err = pm.setNewestCreateReplyLog(oplog)
 	case BoardOpTypeUpdateReply:
 	case BoardOpTypeDeleteReply:
+		isNewer, err = pm.setNewestDeleteReplyLog(oplog)
+	case BoardOpTypeCreateReaction:
+		isNewer, err = pm.setNewestCreateReactionLog(oplog)
+	case BoardOpTypeDeleteReaction:
+		isNewer, err = pm.setNewestDeleteReactionLog(oplog)
+	case BoardOpTypeCreateBan:
+		isNewer, err = pm.setNewestCreateBanLog(oplog)
+	case BoardOpTypeDeleteBan:
+		isNewer, err = pm.setNewestDeleteBanLog(oplog)
+	case BoardOpTypeCreatePin:
+		isNewer, err = pm.setNewestCreatePinLog(oplog)
+	case BoardOpTypeDeletePin:
+		isNewer, err = pm.setNewestDeletePinLog(oplog)
 	}
 
 	oplog.IsNewer = isNewer
@@ -318,8 +420,22 @@ func (pm *ProtocolManager) HandleFailedBoardOplog(oplog *pkgservice.BaseOplog) (
 		err = pm.handleFailedDeleteCommentLog(oplog)
 
 	case BoardOpTypeCreateReply:
+		err = pm.handleFailedCreateReplyLog(oplog)
 	case BoardOpTypeUpdateReply:
 	case BoardOpTypeDeleteReply:
+		err = pm.handleFailedDeleteReplyLog(oplog)
+	case BoardOpTypeCreateReaction:
+		err = pm.handleFailedCreateReactionLog(oplog)
+	case BoardOpTypeDeleteReaction:
+		err = pm.handleFailedDeleteReactionLog(oplog)
+	case BoardOpTypeCreateBan:
+		err = pm.handleFailedCreateBanLog(oplog)
+	case BoardOpTypeDeleteBan:
+		err = pm.handleFailedDeleteBanLog(oplog)
+	case BoardOpTypeCreatePin:
+		err = pm.handleFailedCreatePinLog(oplog)
+	case BoardOpTypeDeletePin:
+		err = pm.handleFailedDeletePinLog(oplog)
 	}
 
 	return
@@ -363,8 +479,22 @@ func (pm *ProtocolManager) HandleFailedValidBoardOplog(oplog *pkgservice.BaseOpl
 		err = pm.handleFailedValidDeleteCommentLog(oplog, info)
 
 	case BoardOpTypeCreateReply:
+		err = pm.handleFailedValidCreateReplyLog(oplog, info)
 	case BoardOpTypeUpdateReply:
 	case BoardOpTypeDeleteReply:
+		err = pm.handleFailedValidDeleteReplyLog(oplog, info)
+	case BoardOpTypeCreateReaction:
+		err = pm.handleFailedValidCreateReactionLog(oplog, info)
+	case BoardOpTypeDeleteReaction:
+		err = pm.handleFailedValidDeleteReactionLog(oplog, info)
+	case BoardOpTypeCreateBan:
+		err = pm.handleFailedValidCreateBanLog(oplog, info)
+	case BoardOpTypeDeleteBan:
+		err = pm.handleFailedValidDeleteBanLog(oplog, info)
+	case BoardOpTypeCreatePin:
+		err = pm.handleFailedValidCreatePinLog(oplog, info)
+	case BoardOpTypeDeletePin:
+		err = pm.handleFailedValidDeletePinLog(oplog, info)
 	}
 
 	return
@@ -392,6 +522,26 @@ func (pm *ProtocolManager) postprocessFailedValidBoardOplogs(processInfo pkgserv
 
 	pm.ForceSyncComment(commentIDs, peer)
 
+	// reply
+	replyIDs := pkgservice.ProcessInfoToForceSyncIDList(info.ReplyInfo)
+
+	pm.ForceSyncReply(replyIDs, peer)
+
+	// reaction
+	reactionIDs := pkgservice.ProcessInfoToForceSyncIDList(info.ReactionInfo)
+
+	pm.ForceSyncReaction(reactionIDs, peer)
+
+	// ban
+	banIDs := pkgservice.ProcessInfoToForceSyncIDList(info.BanInfo)
+
+	pm.ForceSyncBan(banIDs, peer)
+
+	// pin
+	pinIDs := pkgservice.ProcessInfoToForceSyncIDList(info.PinInfo)
+
+	pm.ForceSyncPin(pinIDs, peer)
+
 	// media
 	mediaIDs := pkgservice.ProcessInfoToForceSyncIDList(info.MediaInfo)
 
@@ -0,0 +1,56 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import "github.com/ailabstw/go-pttai/common/types"
+
+/*
+ImportBBSArticle imports the article with the comments from the BBS text dump (by the masters only).
+
+The original author / create-ts are kept as the import-info of the article / comments.
+The posting rate is not checked, as the contents from the masters are not rate-limited by the other nodes either.
+*/
+func (pm *ProtocolManager) ImportBBSArticle(bbsArticle *BBSArticle) (*Article, error) {
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.IsMaster(myID, false) {
+		return nil, types.ErrInvalidID
+	}
+
+	article, err := pm.createArticle(&CreateArticle{
+		Title:   []byte(bbsArticle.Title),
+		Article: bbsArticle.Lines,
+		Import:  bbsArticle.ImportInfo(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, comment := range bbsArticle.Comments {
+		_, err = pm.createComment(&CreateComment{
+			ArticleID:   article.ID,
+			CommentType: comment.CommentType,
+			Comment:     [][]byte{[]byte(comment.Content)},
+			Import:      comment.ImportInfo(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return article, nil
+}
@@ -40,6 +40,25 @@ type ProtocolManager struct {
 	// comment
 	dbCommentPrefix    []byte
 	dbCommentIdxPrefix []byte
+
+	// reply
+	dbReplyPrefix    []byte
+	dbReplyIdxPrefix []byte
+
+	// reaction
+	dbReactionPrefix    []byte
+	dbReactionIdxPrefix []byte
+
+	// ban
+	dbBanPrefix    []byte
+	dbBanIdxPrefix []byte
+
+	// pin
+	dbPinPrefix    []byte
+	dbPinIdxPrefix []byte
+
+	articleLimiter *pkgservice.RateLimiter
+	commentLimiter *pkgservice.RateLimiter
 }
 
 func newBaseProtocolManager(pm *ProtocolManager, ptt pkgservice.Ptt, entity pkgservice.Entity, svc pkgservice.Service) *pkgservice.BaseProtocolManager {
@@ -124,6 +143,29 @@ func NewProtocolManager(b *Board, ptt pkgservice.Ptt, svc pkgservice.Service) (*
 	pm.dbCommentPrefix = append(DBCommentPrefix, entityID[:]...)
 	pm.dbCommentIdxPrefix = append(DBCommentIdxPrefix, entityID[:]...)
 
+	// reply
+	pm.dbReplyPrefix = append(DBReplyPrefix, entityID[:]...)
+	pm.dbReplyIdxPrefix = append(DBReplyIdxPrefix, entityID[:]...)
+
+	// reaction
+	pm.dbReactionPrefix = append(DBReactionPrefix, entityID[:]...)
+	pm.dbReactionIdxPrefix = append(DBReactionIdxPrefix, entityID[:]...)
+
+	// ban
+	pm.dbBanPrefix = append(DBBanPrefix, entityID[:]...)
+	pm.dbBanIdxPrefix = append(DBBanIdxPrefix, entityID[:]...)
+
+	// pin
+	pm.dbPinPrefix = append(DBPinPrefix, entityID[:]...)
+	pm.dbPinIdxPrefix = append(DBPinIdxPrefix, entityID[:]...)
+
+	// the intervals are set by the masters on the title of the board.
+	pm.articleLimiter = pkgservice.NewRateLimiter(0)
+	pm.commentLimiter = pkgservice.NewRateLimiter(0)
+
+	// moderator
+	pm.SetIsValidDeleter(pm.isValidDeleter)
+
 	return pm, nil
 }
 
@@ -145,6 +187,12 @@ func (pm *ProtocolManager) Start() error {
 		return err
 	}
 
+	err = pm.BackfillSearchIndex()
+	if err != nil {
+		log.Error("Start: unable to BackfillSearchIndex", "e", err, "entity", pm.Entity().IDString())
+		return err
+	}
+
 	// sync-wg
 	syncWG := pm.SyncWG()
 
@@ -114,6 +114,50 @@ func (pm *ProtocolManager) HandleMessage(op pkgservice.OpType, dataBytes []byte,
 	case ForceSyncCommentAckMsg:
 		err = pm.HandleForceSyncCommentAck(dataBytes, peer)
 
+	// reply
+	case SyncCreateReplyMsg:
+		err = pm.HandleSyncCreateReply(dataBytes, peer, SyncCreateReplyAckMsg)
+	case SyncCreateReplyAckMsg:
+		err = pm.HandleSyncCreateReplyAck(dataBytes, peer)
+	case SyncCreateReplyBlockMsg:
+		err = pm.HandleSyncReplyBlock(dataBytes, peer, SyncCreateReplyBlockAckMsg)
+	case SyncCreateReplyBlockAckMsg:
+		err = pm.HandleSyncCreateReplyBlockAck(dataBytes, peer)
+	case ForceSyncReplyMsg:
+		err = pm.HandleForceSyncReply(dataBytes, peer)
+	case ForceSyncReplyAckMsg:
+		err = pm.HandleForceSyncReplyAck(dataBytes, peer)
+
+	// reaction
+	case SyncCreateReactionMsg:
+		err = pm.HandleSyncCreateReaction(dataBytes, peer, SyncCreateReactionAckMsg)
+	case SyncCreateReactionAckMsg:
+		err = pm.HandleSyncCreateReactionAck(dataBytes, peer)
+	case ForceSyncReactionMsg:
+		err = pm.HandleForceSyncReaction(dataBytes, peer)
+	case ForceSyncReactionAckMsg:
+		err = pm.HandleForceSyncReactionAck(dataBytes, peer)
+
+	// ban
+	case SyncCreateBanMsg:
+		err = pm.HandleSyncCreateBan(dataBytes, peer, SyncCreateBanAckMsg)
+	case SyncCreateBanAckMsg:
+		err = pm.HandleSyncCreateBanAck(dataBytes, peer)
+	case ForceSyncBanMsg:
+		err = pm.HandleForceSyncBan(dataBytes, peer)
+	case ForceSyncBanAckMsg:
+		err = pm.HandleForceSyncBanAck(dataBytes, peer)
+
+	// pin
+	case SyncCreatePinMsg:
+		err = pm.HandleSyncCreatePin(dataBytes, peer, SyncCreatePinAckMsg)
+	case SyncCreatePinAckMsg:
+		err = pm.HandleSyncCreatePinAck(dataBytes, peer)
+	case ForceSyncPinMsg:
+		err = pm.HandleForceSyncPin(dataBytes, peer)
+	case ForceSyncPinAckMsg:
+		err = pm.HandleForceSyncPinAck(dataBytes, peer)
+
 	// media
 	case SyncCreateMediaMsg:
 		err = pm.HandleSyncCreateMedia(dataBytes, peer, SyncCreateMediaAckMsg)
@@ -0,0 +1,144 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+AddModerator grants the perms to the member. The existing perms of the member are replaced.
+*/
+func (pm *ProtocolManager) AddModerator(userID *types.PttID, perms pkgservice.MemberPerm) (*pkgservice.Member, error) {
+	if perms == 0 || perms&^PermModerator != 0 {
+		return nil, ErrInvalidPerms
+	}
+
+	return pm.UpdateMember(userID, perms)
+}
+
+func (pm *ProtocolManager) RemoveModerator(userID *types.PttID) error {
+	member, err := pm.GetMember(userID, false)
+	if err != nil {
+		return err
+	}
+
+	if member.GetPerms() == 0 {
+		return ErrNotFound
+	}
+
+	_, err = pm.UpdateMember(userID, 0)
+	return err
+}
+
+func (pm *ProtocolManager) GetModeratorList() ([]*pkgservice.Member, error) {
+	members, err := pm.GetMemberList(nil, 0, pttdb.ListOrderNext, false)
+	if err != nil {
+		return nil, err
+	}
+
+	moderators := make([]*pkgservice.Member, 0, len(members))
+	for _, member := range members {
+		if member.GetPerms() == 0 {
+			continue
+		}
+		moderators = append(moderators, member)
+	}
+
+	return moderators, nil
+}
+
+/*
+HasPerm checks whether the user is with the perm on the board.
+The masters are with all the perms.
+*/
+func (pm *ProtocolManager) HasPerm(id *types.PttID, perm pkgservice.MemberPerm) bool {
+	if pm.IsMaster(id, false) {
+		return true
+	}
+
+	member, err := pm.GetMember(id, false)
+	if err != nil {
+		return false
+	}
+
+	return member.GetPerms().Has(perm)
+}
+
+/*
+isValidDeleter allows the moderators to delete the articles / comments / mutes / pins from the others,
+in addition to the creator and the masters.
+*/
+func (pm *ProtocolManager) isValidDeleter(id *types.PttID, deleteOp pkgservice.OpType, origObj pkgservice.Object) bool {
+	if reflect.DeepEqual(id, origObj.GetCreatorID()) {
+		return true
+	}
+
+	switch deleteOp {
+	case BoardOpTypeDeleteArticle:
+		return pm.HasPerm(id, PermDeleteArticle)
+	case BoardOpTypeDeleteComment, BoardOpTypeDeleteReply:
+		return pm.HasPerm(id, PermDeleteComment)
+	case BoardOpTypeDeleteBan:
+		ban, ok := origObj.(*Ban)
+		if !ok {
+			return false
+		}
+		return pm.isValidBanner(id, ban.BanType)
+	case BoardOpTypeDeletePin:
+		return pm.HasPerm(id, PermPin)
+	}
+
+	return pm.IsMaster(id, false)
+}
+
+/*
+checkPendingDeleteLog checks the deleter of the pending delete-log before signing the log.
+
+ErrNewerOplog is returned if the object is not synced yet (as HandlePendingDeleteObjectLog),
+so that the log is checked again after the object is synced.
+*/
+func (pm *ProtocolManager) checkPendingDeleteLog(oplog *pkgservice.BaseOplog, obj pkgservice.Object) error {
+	origObj := obj.NewEmptyObj()
+	origObj.SetID(oplog.ObjID)
+	err := origObj.GetByID(false)
+	if err != nil {
+		return pkgservice.ErrNewerOplog
+	}
+
+	if !pm.isValidDeleter(oplog.CreatorID, oplog.Op, origObj) {
+		return pkgservice.ErrInvalidOplog
+	}
+
+	return nil
+}
+
+/*
+isValidBanner checks whether the user is able to create / lift the ban.
+Only the masters are able to ban the members, and the moderators with PermMute are able to mute the members.
+*/
+func (pm *ProtocolManager) isValidBanner(id *types.PttID, banType BanType) bool {
+	if pm.IsMaster(id, false) {
+		return true
+	}
+
+	return banType == BanTypeMute && pm.HasPerm(id, PermMute)
+}
@@ -0,0 +1,129 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+SearchArticles searches the alive articles (including the comments / replies of the articles) in the board.
+*/
+func (pm *ProtocolManager) SearchArticles(query string, limit int) ([]*Article, error) {
+
+	entityID := pm.Entity().GetID()
+
+	articles := make([]*Article, 0)
+	_, err := dbSearch.Search(entityID[:], query, limit, func(refID []byte) bool {
+		article := pm.getSearchArticle(refID)
+		if article == nil {
+			return false
+		}
+
+		articles = append(articles, article)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+func (pm *ProtocolManager) getSearchArticle(refID []byte) *Article {
+	articleID := &types.PttID{}
+	copy(articleID[:], refID)
+
+	article := NewEmptyArticle()
+	pm.SetArticleDB(article)
+	article.SetID(articleID)
+
+	err := article.GetByID(false)
+	if err != nil {
+		return nil
+	}
+	if article.Status != types.StatusAlive {
+		return nil
+	}
+
+	article.LastSeen, _ = article.LoadLastSeen()
+	article.CommentCreateTS, _ = article.LoadCommentCreateTS()
+	article.NPush, _ = article.LoadPush()
+	article.NBoo, _ = article.LoadBoo()
+
+	return article
+}
+
+/*
+indexArticle indexes the title and the content-blocks of the article.
+*/
+func (pm *ProtocolManager) indexArticle(article *Article) error {
+	texts, err := pm.getContentBuf(article.GetBlockInfo(), article.ID)
+	if err != nil {
+		return err
+	}
+	texts = append([][]byte{article.Title}, texts...)
+
+	return dbSearch.Put(article.EntityID[:], article.ID[:], article.ID[:], texts)
+}
+
+/*
+indexComment indexes the content-blocks of the comment, referring to the article.
+*/
+func (pm *ProtocolManager) indexComment(comment *Comment) error {
+	texts, err := pm.getContentBuf(comment.GetBlockInfo(), comment.ID)
+	if err != nil {
+		return err
+	}
+
+	return dbSearch.Put(comment.EntityID[:], comment.ID[:], comment.ArticleID[:], texts)
+}
+
+/*
+indexReply indexes the content-blocks of the reply, referring to the article.
+*/
+func (pm *ProtocolManager) indexReply(reply *Reply) error {
+	texts, err := pm.getContentBuf(reply.GetBlockInfo(), reply.ID)
+	if err != nil {
+		return err
+	}
+
+	return dbSearch.Put(reply.EntityID[:], reply.ID[:], reply.ArticleID[:], texts)
+}
+
+func (pm *ProtocolManager) removeSearchIndex(id *types.PttID) error {
+	entityID := pm.Entity().GetID()
+
+	return dbSearch.Delete(entityID[:], id[:])
+}
+
+func (pm *ProtocolManager) getContentBuf(blockInfo *pkgservice.BlockInfo, objID *types.PttID) ([][]byte, error) {
+	if blockInfo == nil {
+		return nil, pkgservice.ErrInvalidBlock
+	}
+	pm.SetBlockInfoDB(blockInfo, objID)
+
+	contentBlockList, err := pkgservice.GetContentBlockList(blockInfo, 0, false)
+	log.Debug("getContentBuf: after GetContentBlockList", "objID", objID, "e", err)
+	if err != nil {
+		return nil, err
+	}
+
+	return pkgservice.ContentBlocksToLines(contentBlockList), nil
+}
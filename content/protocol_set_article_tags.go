@@ -0,0 +1,128 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+SetArticleTags sets the tags of the article, keeping the content of the article.
+*/
+func (pm *ProtocolManager) SetArticleTags(articleID *types.PttID, tags []string) (*Article, error) {
+
+	article := NewEmptyArticle()
+	pm.SetArticleDB(article)
+	article.SetID(articleID)
+
+	err := article.GetByID(false)
+	if err != nil {
+		return nil, err
+	}
+	if article.Status != types.StatusAlive {
+		return nil, pkgservice.ErrNotAlive
+	}
+
+	blockInfo := article.GetBlockInfo()
+	articleBytes, err := pm.getContentBuf(blockInfo, article.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return pm.UpdateArticle(articleID, articleBytes, blockInfo.MediaIDs, tags)
+}
+
+/*
+checkArticleTags normalizes the tags of the article and checks that the tags are in the tag-vocabulary of the board
+(if the board has the tag-vocabulary).
+*/
+func (pm *ProtocolManager) checkArticleTags(tags []string) ([]string, error) {
+	tags, err := normalizeTags(tags, MaxArticleTags)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return tags, nil
+	}
+
+	boardTags, err := pm.GetBoardTags()
+	if err != nil {
+		return nil, err
+	}
+	if len(boardTags) == 0 {
+		return tags, nil
+	}
+
+	boardTagMap := make(map[string]bool)
+	for _, tag := range boardTags {
+		boardTagMap[tag] = true
+	}
+	for _, tag := range tags {
+		if !boardTagMap[tag] {
+			return nil, ErrInvalidTag
+		}
+	}
+
+	return tags, nil
+}
+
+/*
+normalizeTags normalizes and de-duplicates the tags, keeping the order of the tags.
+*/
+func normalizeTags(tags []string, maxTags int) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	tagMap := make(map[string]bool)
+	for _, tag := range tags {
+		tag = pttdb.NormalizeTag(tag)
+		if len(tag) == 0 {
+			return nil, ErrInvalidTag
+		}
+		if tagMap[tag] {
+			continue
+		}
+		tagMap[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > maxTags {
+		return nil, ErrTooManyTags
+	}
+
+	return normalized, nil
+}
+
+/*
+hashTags returns the hash of the tags in the op-data, nil if no tags.
+*/
+func hashTags(tags []string) []byte {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tagBytes := make([][]byte, len(tags))
+	for i, tag := range tags {
+		tagBytes[i] = append([]byte(tag), 0)
+	}
+
+	return types.Hash(tagBytes...)
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+/*
+SetBoardTags sets the tag-vocabulary of the board. Only the masters are allowed to set the tags.
+
+The tag-vocabulary is synced with the title of the board. The articles are allowed to have only the tags
+in the tag-vocabulary if the tag-vocabulary is not empty.
+*/
+func (pm *ProtocolManager) SetBoardTags(tags []string) error {
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.IsMaster(myID, false) {
+		return types.ErrInvalidID
+	}
+
+	tags, err := normalizeTags(tags, MaxBoardTags)
+	if err != nil {
+		return err
+	}
+
+	theTitle, err := pm.getOrCreateTitle()
+	if err != nil {
+		return err
+	}
+
+	data := theTitle.ToUpdateTitle()
+	data.Tags = tags
+
+	return pm.UpdateTitle(data)
+}
+
+/*
+GetBoardTags gets the tag-vocabulary of the board.
+*/
+func (pm *ProtocolManager) GetBoardTags() ([]string, error) {
+	theTitle, err := pm.GetTitle()
+	if err != nil {
+		return nil, err
+	}
+	if theTitle == nil || theTitle.Tags == nil {
+		return []string{}, nil
+	}
+
+	return theTitle.Tags, nil
+}
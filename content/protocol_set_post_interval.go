@@ -0,0 +1,64 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+/*
+SetPostInterval sets the min intervals (in seconds) between the articles and between the comments / replies
+of each member. Only the masters are allowed to set the intervals, and 0 means not limited (default).
+
+The intervals are synced with the title of the board, and are enforced by the masters on signing the oplogs of the members.
+*/
+func (pm *ProtocolManager) SetPostInterval(articleSeconds int64, commentSeconds int64) error {
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.IsMaster(myID, false) {
+		return types.ErrInvalidID
+	}
+
+	if articleSeconds < 0 || articleSeconds > MaxPostIntervalSeconds || commentSeconds < 0 || commentSeconds > MaxPostIntervalSeconds {
+		return ErrInvalidPostInterval
+	}
+
+	theTitle, err := pm.getOrCreateTitle()
+	if err != nil {
+		return err
+	}
+
+	data := theTitle.ToUpdateTitle()
+	data.PostArticleInterval = articleSeconds
+	data.PostCommentInterval = commentSeconds
+
+	return pm.UpdateTitle(data)
+}
+
+/*
+GetPostInterval gets the min intervals (in seconds) between the articles and between the comments / replies of each member.
+*/
+func (pm *ProtocolManager) GetPostInterval() (int64, int64, error) {
+	theTitle, err := pm.GetTitle()
+	if err != nil {
+		return 0, 0, err
+	}
+	if theTitle == nil {
+		return 0, 0, nil
+	}
+
+	return theTitle.PostArticleInterval, theTitle.PostCommentInterval, nil
+}
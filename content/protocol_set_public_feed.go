@@ -0,0 +1,70 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+/*
+SetPublicFeed sets whether the board is served as the public atom / rss feed by the http-servers.
+Only the masters are allowed to set the public-feed.
+
+The public-feed is synced with the title of the board, so that all the nodes of the board serve the same feed.
+*/
+func (pm *ProtocolManager) SetPublicFeed(isPublicFeed bool) error {
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.IsMaster(myID, false) {
+		return types.ErrInvalidID
+	}
+
+	board := pm.Entity().(*Board)
+	if board.Status != types.StatusAlive {
+		return ErrInvalidBoard
+	}
+
+	theTitle, err := pm.getOrCreateTitle()
+	if err != nil {
+		return err
+	}
+
+	data := theTitle.ToUpdateTitle()
+	data.IsPublicFeed = isPublicFeed
+
+	return pm.UpdateTitle(data)
+}
+
+/*
+IsPublicFeed returns whether the board is served as the public feed.
+Deleted boards are never served even if the public-feed is set.
+*/
+func (pm *ProtocolManager) IsPublicFeed() (bool, error) {
+	board := pm.Entity().(*Board)
+	if board.Status != types.StatusAlive {
+		return false, nil
+	}
+
+	theTitle, err := pm.GetTitle()
+	if err != nil {
+		return false, err
+	}
+	if theTitle == nil {
+		return false, nil
+	}
+
+	return theTitle.IsPublicFeed, nil
+}
@@ -29,7 +29,18 @@ func (pm *ProtocolManager) SetTitle(title []byte) error {
 		return pm.CreateTitle(title)
 	}
 
-	return pm.UpdateTitle(title)
+	theTitle, err := pm.GetTitle()
+	if err != nil {
+		return err
+	}
+
+	data := &UpdateTitle{}
+	if theTitle != nil {
+		data = theTitle.ToUpdateTitle()
+	}
+	data.Title = title
+
+	return pm.UpdateTitle(data)
 }
 
 func (pm *ProtocolManager) setTitleCheckIsExists() (bool, error) {
@@ -57,3 +68,33 @@ func (pm *ProtocolManager) setTitleCheckIsExists() (bool, error) {
 
 	return true, nil
 }
+
+/*
+getOrCreateTitle gets the title of the board as the base of the settings (ex: tags) of the board.
+The title is created with the board-title if not exists yet.
+*/
+func (pm *ProtocolManager) getOrCreateTitle() (*Title, error) {
+	theTitle, err := pm.GetTitle()
+	if err != nil {
+		return nil, err
+	}
+	if theTitle != nil {
+		return theTitle, nil
+	}
+
+	board := pm.Entity().(*Board)
+	err = pm.CreateTitle(board.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	theTitle, err = pm.GetTitle()
+	if err != nil {
+		return nil, err
+	}
+	if theTitle == nil {
+		return nil, ErrNotFound
+	}
+
+	return theTitle, nil
+}
@@ -92,7 +92,7 @@ func (pm *ProtocolManager) HandleSyncUpdateArticleBlockAck(dataBytes []byte, pee
 		pm.boardOplogMerkle,
 
 		pm.SetBoardDB,
-		nil,
+		pm.postupdateArticle,
 		pm.broadcastBoardOplogCore,
 	)
 }
@@ -69,6 +69,7 @@ func (pm *ProtocolManager) updateSyncCreateArticle(theToObj pkgservice.Object, t
 
 	toObj.BlockInfo = fromObj.BlockInfo
 	toObj.Title = fromObj.Title
+	toObj.Tags = fromObj.Tags
 
 	return nil
 }
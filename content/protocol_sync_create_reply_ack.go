@@ -0,0 +1,91 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"encoding/json"
+	"reflect"
+
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type SyncReplyAck struct {
+	Objs []*Reply `json:"o"`
+}
+
+func (pm *ProtocolManager) HandleSyncCreateReplyAck(dataBytes []byte, peer *pkgservice.PttPeer) error {
+
+	data := &SyncReplyAck{}
+	err := json.Unmarshal(dataBytes, data)
+	if err != nil {
+		return err
+	}
+
+	origObj := NewEmptyReply()
+	pm.SetReplyDB(origObj)
+	for _, obj := range data.Objs {
+		pm.SetReplyDB(obj)
+
+		pm.HandleSyncCreateObjectAck(
+			obj,
+			peer,
+			origObj,
+
+			pm.boardOplogMerkle,
+
+			pm.SetBoardDB,
+			pm.updateSyncCreateReply,
+			pm.postcreateReply,
+			pm.broadcastBoardOplogCore,
+		)
+	}
+
+	return nil
+}
+
+func (pm *ProtocolManager) updateSyncCreateReply(theToObj pkgservice.Object, theFromObj pkgservice.Object) error {
+	toObj, ok := theToObj.(*Reply)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	fromObj, ok := theFromObj.(*Reply)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	// the parent is from the oplog (validated with the local comment / parent reply),
+	// not from the peer.
+	if !reflect.DeepEqual(toObj.ParentID, fromObj.ParentID) || toObj.Depth != fromObj.Depth {
+		return pkgservice.ErrInvalidData
+	}
+
+	// the parent was not synced yet while handling the oplog.
+	if toObj.ParentCreatorID == nil {
+		err := pm.resolveReplyParent(toObj)
+		if err != nil {
+			return err
+		}
+	}
+	if toObj.ParentCreatorID == nil {
+		toObj.ParentCreatorID = fromObj.ParentCreatorID
+	}
+
+	toObj.BlockInfo = fromObj.BlockInfo
+
+	return nil
+}
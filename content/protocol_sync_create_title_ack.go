@@ -52,7 +52,7 @@ func (pm *ProtocolManager) HandleSyncCreateTitleAck(dataBytes []byte, peer *pkgs
 
 			pm.SetBoardDB,
 			pm.updateSyncCreateTitle,
-			nil,
+			pm.postcreateTitle,
 			pm.broadcastBoardOplogCore,
 		)
 	}
@@ -72,6 +72,7 @@ func (pm *ProtocolManager) updateSyncCreateTitle(theToObj pkgservice.Object, the
 	}
 
 	toObj.Title = fromObj.Title
+	toObj.Tags = fromObj.Tags
 
 	return nil
 }
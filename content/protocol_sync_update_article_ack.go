@@ -50,7 +50,7 @@ func (pm *ProtocolManager) HandleSyncUpdateArticleAck(dataBytes []byte, peer *pk
 
 			pm.SetBoardDB,
 			pm.updateSyncArticle,
-			nil,
+			pm.postupdateArticle,
 			pm.broadcastBoardOplogCore,
 		)
 	}
@@ -82,6 +82,11 @@ func (pm *ProtocolManager) updateSyncArticle(theToSyncInfo pkgservice.SyncInfo,
 		return pkgservice.ErrInvalidObject
 	}
 
+	// validate tags
+	if !reflect.DeepEqual(hashTags(fromObj.Tags), opData.TagsHash) {
+		return pkgservice.ErrInvalidObject
+	}
+
 	// logID
 	toLogID := toSyncInfo.GetLogID()
 	updateLogID := fromObj.GetUpdateLogID()
@@ -105,6 +110,7 @@ func (pm *ProtocolManager) updateSyncArticle(theToSyncInfo pkgservice.SyncInfo,
 
 	// title
 	toSyncInfo.Title = fromObj.Title
+	toSyncInfo.Tags = fromObj.Tags
 
 	return nil
 }
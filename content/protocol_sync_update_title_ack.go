@@ -50,7 +50,7 @@ func (pm *ProtocolManager) HandleSyncUpdateTitleAck(dataBytes []byte, peer *pkgs
 
 			pm.SetBoardDB,
 			pm.updateSyncTitle,
-			nil,
+			pm.postupdateTitle,
 			pm.broadcastBoardOplogCore,
 		)
 	}
@@ -92,7 +92,20 @@ func (pm *ProtocolManager) updateSyncTitle(theToSyncInfo pkgservice.SyncInfo, th
 		return pkgservice.ErrInvalidObject
 	}
 
+	// get tags
+	tags := fromObj.Tags
+
+	if !reflect.DeepEqual(opData.TagsHash, hashTags(tags)) {
+		return pkgservice.ErrInvalidObject
+	}
+
 	toSyncInfo.Title = title
+	toSyncInfo.Tags = tags
+
+	// settings from the signed op-data
+	toSyncInfo.PostArticleInterval = opData.PostArticleInterval
+	toSyncInfo.PostCommentInterval = opData.PostCommentInterval
+	toSyncInfo.IsPublicFeed = opData.IsPublicFeed
 
 	return nil
 }
@@ -25,18 +25,34 @@ import (
 type UpdateArticle struct {
 	Article  [][]byte       `json:"a"`
 	MediaIDs []*types.PttID `json:"m"`
+	Tags     []string       `json:"t"`
+
+	IsKeepTags bool `json:"k"`
 }
 
-func (pm *ProtocolManager) UpdateArticle(articleID *types.PttID, articleBytes [][]byte, mediaIDs []*types.PttID) (*Article, error) {
+/*
+UpdateArticle updates the content of the article. The tags of the article are kept if tags is nil.
+*/
+func (pm *ProtocolManager) UpdateArticle(articleID *types.PttID, articleBytes [][]byte, mediaIDs []*types.PttID, tags []string) (*Article, error) {
+
+	isKeepTags := tags == nil
+
+	var err error
+	if !isKeepTags {
+		tags, err = pm.checkArticleTags(tags)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	data := &UpdateArticle{Article: articleBytes, MediaIDs: mediaIDs}
+	data := &UpdateArticle{Article: articleBytes, MediaIDs: mediaIDs, Tags: tags, IsKeepTags: isKeepTags}
 
 	origObj := NewEmptyArticle()
 	pm.SetArticleDB(origObj)
 
 	opData := &BoardOpUpdateArticle{}
 
-	err := pm.UpdateObject(
+	err = pm.UpdateObject(
 		articleID,
 		data,
 		BoardOpTypeUpdateArticle,
@@ -50,7 +66,7 @@ func (pm *ProtocolManager) UpdateArticle(articleID *types.PttID, articleBytes []
 		pm.inupdateArticle,
 		nil,
 		pm.broadcastBoardOplogCore,
-		nil,
+		pm.postupdateArticle,
 	)
 	if err != nil {
 		return nil, err
@@ -97,7 +113,13 @@ func (pm *ProtocolManager) inupdateArticle(theObj pkgservice.Object, theData pkg
 	opData.Hashs = blockHashs
 	opData.MediaIDs = data.MediaIDs
 
+	tags := data.Tags
+	if data.IsKeepTags {
+		tags = obj.Tags
+	}
+
 	opData.TitleHash = types.Hash(obj.Title)
+	opData.TagsHash = hashTags(tags)
 
 	// sync-info
 	syncInfo := NewEmptySyncArticleInfo()
@@ -105,6 +127,34 @@ func (pm *ProtocolManager) inupdateArticle(theObj pkgservice.Object, theData pkg
 	syncInfo.SetBlockInfo(blockInfo)
 
 	syncInfo.Title = obj.Title
+	syncInfo.Tags = tags
 
 	return syncInfo, nil
 }
+
+func (pm *ProtocolManager) postupdateArticle(theObj pkgservice.Object, oplog *pkgservice.BaseOplog) error {
+
+	article, ok := theObj.(*Article)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	err := pm.indexArticle(article)
+	if err != nil {
+		log.Warn("postupdateArticle: unable to index article", "e", err, "entity", pm.Entity().IDString(), "article", article.ID)
+	}
+
+	err = pm.indexArticleTags(article)
+	if err != nil {
+		log.Warn("postupdateArticle: unable to index article tags", "e", err, "entity", pm.Entity().IDString(), "article", article.ID)
+	}
+
+	err = pm.saveArticleRevision(article, oplog)
+	if err != nil {
+		log.Warn("postupdateArticle: unable to save article revision", "e", err, "entity", pm.Entity().IDString(), "article", article.ID)
+	}
+
+	pm.PostObjEvent(article, nil, oplog, types.StatusAlive)
+
+	return nil
+}
@@ -41,7 +41,7 @@ func (pm *ProtocolManager) handleUpdateArticleLogs(oplog *pkgservice.BaseOplog,
 		pm.syncArticleInfoFromOplog,
 		pm.SetBoardDB,
 		nil,
-		nil,
+		pm.postupdateArticle,
 		pm.updateUpdateArticleInfo,
 	)
 }
@@ -65,7 +65,7 @@ func (pm *ProtocolManager) handlePendingUpdateArticleLogs(oplog *pkgservice.Base
 		pm.syncArticleInfoFromOplog,
 		pm.SetBoardDB,
 		nil,
-		nil,
+		pm.postupdateArticle,
 		pm.updateUpdateArticleInfo,
 	)
 }
@@ -23,18 +23,22 @@ import (
 )
 
 type UpdateTitle struct {
-	Title []byte `json:"t"`
+	Title []byte   `json:"t"`
+	Tags  []string `json:"g"`
+
+	PostArticleInterval int64 `json:"pa"`
+	PostCommentInterval int64 `json:"pc"`
+
+	IsPublicFeed bool `json:"pf"`
 }
 
-func (pm *ProtocolManager) UpdateTitle(title []byte) error {
+func (pm *ProtocolManager) UpdateTitle(data *UpdateTitle) error {
 	myID := pm.Ptt().GetMyEntity().GetID()
 
 	if !pm.IsMaster(myID, false) {
 		return types.ErrInvalidID
 	}
 
-	data := &UpdateTitle{Title: title}
-
 	origObj := NewEmptyTitle()
 	pm.SetTitleDB(origObj)
 
@@ -57,7 +61,7 @@ func (pm *ProtocolManager) UpdateTitle(title []byte) error {
 		pm.inupdateTitle,
 		nil,
 		pm.broadcastBoardOplogCore,
-		nil,
+		pm.postupdateTitle,
 	)
 	if err != nil {
 		return err
@@ -80,12 +84,34 @@ func (pm *ProtocolManager) inupdateTitle(obj pkgservice.Object, theData pkgservi
 
 	// op-data
 	opData.TitleHash = types.Hash(data.Title)
+	opData.TagsHash = hashTags(data.Tags)
+
+	// the settings are small enough to be carried on the op-data.
+	opData.PostArticleInterval = data.PostArticleInterval
+	opData.PostCommentInterval = data.PostCommentInterval
+	opData.IsPublicFeed = data.IsPublicFeed
 
 	// sync-info
 	syncInfo := NewEmptySyncTitleInfo()
 	syncInfo.InitWithOplog(oplog.ToStatus(), oplog)
 
 	syncInfo.Title = data.Title
+	syncInfo.Tags = data.Tags
+	syncInfo.PostArticleInterval = data.PostArticleInterval
+	syncInfo.PostCommentInterval = data.PostCommentInterval
+	syncInfo.IsPublicFeed = data.IsPublicFeed
 
 	return syncInfo, nil
 }
+
+func (pm *ProtocolManager) postupdateTitle(theObj pkgservice.Object, oplog *pkgservice.BaseOplog) error {
+
+	title, ok := theObj.(*Title)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	pm.PostObjEvent(title, nil, oplog, types.StatusAlive)
+
+	return nil
+}
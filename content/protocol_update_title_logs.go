@@ -39,7 +39,7 @@ func (pm *ProtocolManager) handleUpdateTitleLogs(oplog *pkgservice.BaseOplog, in
 		pm.syncTitleInfoFromOplog,
 		pm.SetBoardDB,
 		nil,
-		nil,
+		pm.postupdateTitle,
 		pm.updateUpdateTitleInfo,
 	)
 }
@@ -61,7 +61,7 @@ func (pm *ProtocolManager) handlePendingUpdateTitleLogs(oplog *pkgservice.BaseOp
 		pm.syncTitleInfoFromOplog,
 		pm.SetBoardDB,
 		nil,
-		nil,
+		pm.postupdateTitle,
 		pm.updateUpdateTitleInfo,
 	)
 }
@@ -0,0 +1,198 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type UploadFileFromMediaUpload struct {
+	Upload *pkgservice.MediaUpload
+}
+
+/*
+CreateFileUpload creates the resumable upload of the large file.
+*/
+func (pm *ProtocolManager) CreateFileUpload(filename []byte, size int64) (*pkgservice.MediaUpload, error) {
+	return pm.createMediaUpload(filename, size, pkgservice.MediaTypeFile, nil)
+}
+
+/*
+CreateAudioUpload creates the resumable upload of the audio with the meta-data (duration in milliseconds).
+*/
+func (pm *ProtocolManager) CreateAudioUpload(filename []byte, size int64, contentType string, codec string, duration int64) (*pkgservice.MediaUpload, error) {
+	mediaData, err := pkgservice.NewMediaDataAudio(filename, size, contentType, codec, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	return pm.createMediaUpload(filename, size, pkgservice.MediaTypeAudio, mediaData)
+}
+
+/*
+CreateVideoUpload creates the resumable upload of the video with the meta-data (duration in milliseconds).
+*/
+func (pm *ProtocolManager) CreateVideoUpload(filename []byte, size int64, contentType string, codec string, audioCodec string, duration int64, width uint16, height uint16) (*pkgservice.MediaUpload, error) {
+	mediaData, err := pkgservice.NewMediaDataVideo(filename, size, contentType, codec, audioCodec, duration, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return pm.createMediaUpload(filename, size, pkgservice.MediaTypeVideo, mediaData)
+}
+
+func (pm *ProtocolManager) createMediaUpload(filename []byte, size int64, mediaType pkgservice.MediaType, mediaData pkgservice.MediaData) (*pkgservice.MediaUpload, error) {
+	myID := pm.Ptt().GetMyEntity().GetID()
+
+	if pm.Entity().GetEntityType() == pkgservice.EntityTypePersonal && !pm.IsMaster(myID, false) {
+		return nil, types.ErrInvalidID
+	}
+
+	return pm.CreateMediaUpload(filename, size, mediaType, mediaData)
+}
+
+/*
+UploadFileChunk appends the chunk to the upload,
+and creates the file with the blocks of the upload after all the bytes are uploaded.
+*/
+func (pm *ProtocolManager) UploadFileChunk(uploadID *types.PttID, offset int64, buf []byte) (*pkgservice.MediaUpload, *pkgservice.Media, error) {
+	upload, err := pm.AppendMediaUpload(uploadID, offset, buf)
+	if err != nil {
+		return upload, nil, err
+	}
+
+	if !upload.IsComplete() {
+		return upload, nil, nil
+	}
+
+	media, err := pm.UploadFileFromMediaUpload(upload)
+	if err != nil {
+		return upload, nil, err
+	}
+
+	err = pm.FinishMediaUpload(upload.ID)
+	if err != nil {
+		log.Warn("UploadFileChunk: unable to finish media-upload", "uploadID", upload.ID, "e", err)
+	}
+
+	return upload, media, nil
+}
+
+func (pm *ProtocolManager) UploadFileFromMediaUpload(upload *pkgservice.MediaUpload) (*pkgservice.Media, error) {
+	myID := pm.Ptt().GetMyEntity().GetID()
+
+	if pm.Entity().GetEntityType() == pkgservice.EntityTypePersonal && !pm.IsMaster(myID, false) {
+		return nil, types.ErrInvalidID
+	}
+
+	data := &UploadFileFromMediaUpload{
+		Upload: upload,
+	}
+
+	theMedia, err := pm.CreateObject(
+		data,
+		BoardOpTypeCreateMedia,
+
+		pm.boardOplogMerkle,
+
+		pm.newMediaFromMediaUpload,
+		pm.NewBoardOplogWithTS,
+		pm.increateFileFromMediaUpload,
+
+		pm.SetBoardDB,
+		pm.broadcastBoardOplogsCore,
+		pm.broadcastBoardOplogCore,
+
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	media, ok := theMedia.(*pkgservice.Media)
+	if !ok {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	return media, nil
+}
+
+/*
+newMediaFromMediaUpload creates the media with the obj-id of the upload, which the blocks are saved with.
+*/
+func (pm *ProtocolManager) newMediaFromMediaUpload(theData pkgservice.CreateData) (pkgservice.Object, pkgservice.OpData, error) {
+	data, ok := theData.(*UploadFileFromMediaUpload)
+	if !ok {
+		return nil, nil, pkgservice.ErrInvalidData
+	}
+
+	obj, opData, err := pm.NewMedia(theData)
+	if err != nil {
+		return nil, nil, err
+	}
+	obj.SetID(data.Upload.ObjID)
+
+	return obj, opData, nil
+}
+
+func (pm *ProtocolManager) increateFileFromMediaUpload(theObj pkgservice.Object, theData pkgservice.CreateData, oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData) error {
+
+	obj, ok := theObj.(*pkgservice.Media)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	data, ok := theData.(*UploadFileFromMediaUpload)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	opData, ok := theOpData.(*pkgservice.OpCreateMedia)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	// media
+	switch data.Upload.MediaType {
+	case pkgservice.MediaTypeAudio, pkgservice.MediaTypeVideo:
+		obj.MediaData = data.Upload.MediaData
+		obj.MediaType = data.Upload.MediaType
+	default:
+		obj.MediaData = &pkgservice.MediaDataFile{
+			Filename: data.Upload.Filename,
+		}
+		obj.MediaType = pkgservice.MediaTypeFile
+	}
+
+	// block-info
+	blockInfo, err := pkgservice.NewBlockInfo(data.Upload.BlockInfoID, data.Upload.Hashs, nil, obj.CreatorID)
+	if err != nil {
+		return err
+	}
+	blockInfo.SetIsAllGood()
+
+	theObj.SetBlockInfo(blockInfo)
+
+	// op-data
+	opData.BlockInfoID = data.Upload.BlockInfoID
+	opData.NBlock = blockInfo.NBlock
+	opData.Hashs = data.Upload.Hashs
+
+	return nil
+}
@@ -18,14 +18,24 @@ package content
 
 import (
 	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
 type UploadImage struct {
 	FileType string
 	Bytes    []byte
+
+	MediaType pkgservice.MediaType
+	MediaData pkgservice.MediaData
 }
 
+/*
+UploadImage normalizes the image and creates the image with the thumbnails.
+
+The thumbnails are created as the jpeg media before the image (so the peers may get the thumbnails first),
+and the ids are in the Thumbnails of the media-data of the image.
+*/
 func (pm *ProtocolManager) UploadImage(fileType string, theBytes []byte) (*pkgservice.Media, error) {
 	myID := pm.Ptt().GetMyEntity().GetID()
 
@@ -33,11 +43,68 @@ func (pm *ProtocolManager) UploadImage(fileType string, theBytes []byte) (*pkgse
 		return nil, types.ErrInvalidID
 	}
 
+	// new image
+	newMediaType, newData, newBytes, err := pkgservice.NormalizeImage(theBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// thumbnails
+	mediaThumbnails, err := pm.uploadThumbnails(newBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch mediaData := newData.(type) {
+	case *pkgservice.MediaDataJPEG:
+		mediaData.Thumbnails = mediaThumbnails
+	case *pkgservice.MediaDataGIF:
+		mediaData.Thumbnails = mediaThumbnails
+	}
+
 	data := &UploadImage{
-		FileType: fileType,
-		Bytes:    theBytes,
+		FileType:  fileType,
+		Bytes:     newBytes,
+		MediaType: newMediaType,
+		MediaData: newData,
 	}
 
+	return pm.createImage(data)
+}
+
+func (pm *ProtocolManager) uploadThumbnails(theBytes []byte) ([]*pkgservice.MediaThumbnail, error) {
+	thumbnails, err := pkgservice.GenerateThumbnails(theBytes)
+	if err != nil {
+		log.Warn("UploadImage: unable to generate thumbnails", "e", err, "entity", pm.Entity().GetID())
+		return nil, nil
+	}
+
+	mediaThumbnails := make([]*pkgservice.MediaThumbnail, 0, len(thumbnails))
+	for _, thumbnail := range thumbnails {
+		data := &UploadImage{
+			FileType:  "image/jpeg",
+			Bytes:     thumbnail.Bytes,
+			MediaType: pkgservice.MediaTypeJPEG,
+			MediaData: &pkgservice.MediaDataJPEG{Width: thumbnail.Width, Height: thumbnail.Height},
+		}
+
+		media, err := pm.createImage(data)
+		if err != nil {
+			return nil, err
+		}
+
+		mediaThumbnails = append(mediaThumbnails, &pkgservice.MediaThumbnail{
+			Size:   thumbnail.Size,
+			ID:     media.ID,
+			Width:  thumbnail.Width,
+			Height: thumbnail.Height,
+		})
+	}
+
+	return mediaThumbnails, nil
+}
+
+func (pm *ProtocolManager) createImage(data *UploadImage) (*pkgservice.Media, error) {
 	theMedia, err := pm.CreateObject(
 		data,
 		BoardOpTypeCreateMedia,
@@ -83,18 +150,12 @@ func (pm *ProtocolManager) inuploadImage(theObj pkgservice.Object, theData pkgse
 		return pkgservice.ErrInvalidData
 	}
 
-	// new image
-	newMediaType, newData, newBytes, err := pkgservice.NormalizeImage(data.Bytes)
-	if err != nil {
-		return err
-	}
-
 	// media
-	obj.MediaData = newData
-	obj.MediaType = newMediaType
+	obj.MediaData = data.MediaData
+	obj.MediaType = data.MediaType
 
 	// block-info
-	blockID, blockHashs, err := pm.SplitMediaBlocks(obj.ID, newBytes)
+	blockID, blockHashs, err := pm.SplitMediaBlocks(obj.ID, data.Bytes)
 	if err != nil {
 		return err
 	}
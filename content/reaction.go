@@ -0,0 +1,421 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package content
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+Reaction is the reaction (like, love, ...) of the user to an article or a comment.
+
+The reactions are keyed by the target-id (article-id or comment-id),
+so that all the reactions of a target can be iterated to rebuild the counts.
+Each user has at most one reaction of each reaction-type on a target,
+tracked by the user-key (entity-id, target-id, reaction-type, creator-id).
+*/
+type Reaction struct {
+	*pkgservice.BaseObject `json:"b"`
+
+	UpdateTS types.Timestamp `json:"UT"`
+
+	SyncInfo *pkgservice.BaseSyncInfo `json:"s,omitempty"`
+
+	ArticleID    *types.PttID `json:"AID"`
+	TargetID     *types.PttID `json:"TID"`
+	ReactionType ReactionType `json:"R"`
+}
+
+func NewReaction(
+	createTS types.Timestamp,
+	creatorID *types.PttID,
+	entityID *types.PttID,
+
+	logID *types.PttID,
+
+	status types.Status,
+
+	articleID *types.PttID,
+	targetID *types.PttID,
+	reactionType ReactionType,
+
+) (*Reaction, error) {
+
+	id, err := types.NewPttID()
+	if err != nil {
+		return nil, err
+	}
+
+	o := pkgservice.NewObject(id, createTS, creatorID, entityID, logID, status)
+
+	return &Reaction{
+		BaseObject: o,
+
+		UpdateTS: createTS,
+
+		ArticleID:    articleID,
+		TargetID:     targetID,
+		ReactionType: reactionType,
+	}, nil
+}
+
+func NewEmptyReaction() *Reaction {
+	return &Reaction{BaseObject: &pkgservice.BaseObject{}}
+}
+
+func ReactionsToObjs(typedObjs []*Reaction) []pkgservice.Object {
+	objs := make([]pkgservice.Object, len(typedObjs))
+	for i, obj := range typedObjs {
+		objs[i] = obj
+	}
+	return objs
+}
+
+func ObjsToReactions(objs []pkgservice.Object) []*Reaction {
+	typedObjs := make([]*Reaction, len(objs))
+	for i, obj := range objs {
+		typedObjs[i] = obj.(*Reaction)
+	}
+	return typedObjs
+}
+
+func AliveReactions(typedObjs []*Reaction) []*Reaction {
+	objs := make([]*Reaction, 0, len(typedObjs))
+	for _, obj := range typedObjs {
+		if obj.Status == types.StatusAlive {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+func (pm *ProtocolManager) SetReactionDB(u *Reaction) {
+
+	u.SetDB(dbBoard, pm.DBObjLock(), pm.Entity().GetID(), pm.dbReactionPrefix, pm.dbReactionIdxPrefix, nil, nil)
+}
+
+func (r *Reaction) Save(isLocked bool) error {
+	var err error
+
+	if !isLocked {
+		err = r.Lock()
+		if err != nil {
+			return err
+		}
+		defer r.Unlock()
+	}
+
+	key, err := r.MarshalKey()
+	if err != nil {
+		return err
+	}
+	marshaled, err := r.Marshal()
+	if err != nil {
+		return err
+	}
+
+	idxKey, err := r.IdxKey()
+	if err != nil {
+		return err
+	}
+
+	idx := &pttdb.Index{Keys: [][]byte{key}, UpdateTS: r.UpdateTS}
+
+	kvs := []*pttdb.KeyVal{
+		&pttdb.KeyVal{K: key, V: marshaled},
+	}
+
+	_, err = r.DB().ForcePutAll(idxKey, idx, kvs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Reaction) NewEmptyObj() pkgservice.Object {
+	newObj := NewEmptyReaction()
+	newObj.CloneDB(r.BaseObject)
+	return newObj
+}
+
+func (r *Reaction) GetNewObjByID(id *types.PttID, isLocked bool) (pkgservice.Object, error) {
+	newU := r.NewEmptyObj()
+	newU.SetID(id)
+	err := newU.GetByID(isLocked)
+	if err != nil {
+		return nil, err
+	}
+	return newU, nil
+}
+
+func (r *Reaction) SetUpdateTS(ts types.Timestamp) {
+	r.UpdateTS = ts
+}
+
+func (r *Reaction) GetUpdateTS() types.Timestamp {
+	return r.UpdateTS
+}
+
+func (r *Reaction) Get(isLocked bool) error {
+	var err error
+
+	if !isLocked {
+		err = r.RLock()
+		if err != nil {
+			return err
+		}
+		defer r.RUnlock()
+	}
+
+	key, err := r.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	val, err := r.DB().DBGet(key)
+	if err != nil {
+		return err
+	}
+
+	return r.Unmarshal(val)
+}
+
+func (r *Reaction) GetByID(isLocked bool) error {
+	var err error
+
+	val, err := r.GetValueByID(isLocked)
+	if err != nil {
+		return err
+	}
+
+	return r.Unmarshal(val)
+}
+
+func (r *Reaction) MarshalKey() ([]byte, error) {
+	return common.Concat([][]byte{r.FullDBPrefix(), r.TargetID[:], r.ID[:]})
+}
+
+func (r *Reaction) Marshal() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *Reaction) Unmarshal(theBytes []byte) error {
+	return json.Unmarshal(theBytes, r)
+}
+
+func (r *Reaction) GetSyncInfo() pkgservice.SyncInfo {
+	if r.SyncInfo == nil {
+		return nil
+	}
+	return r.SyncInfo
+}
+
+func (r *Reaction) SetSyncInfo(theSyncInfo pkgservice.SyncInfo) error {
+	if theSyncInfo == nil {
+		r.SyncInfo = nil
+		return nil
+	}
+
+	syncInfo, ok := theSyncInfo.(*pkgservice.BaseSyncInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+	r.SyncInfo = syncInfo
+
+	return nil
+}
+
+/**********
+ * User-Key
+ **********/
+
+func (r *Reaction) MarshalUserKey() ([]byte, error) {
+	return common.Concat([][]byte{DBReactionUserPrefix, r.EntityID[:], r.TargetID[:], r.ReactionType.Marshal(), r.CreatorID[:]})
+}
+
+func (r *Reaction) SaveUserKey() error {
+	key, err := r.MarshalUserKey()
+	if err != nil {
+		return err
+	}
+
+	return r.DB().DB().Put(key, r.ID[:])
+}
+
+func (r *Reaction) DeleteUserKey() error {
+	key, err := r.MarshalUserKey()
+	if err != nil {
+		return err
+	}
+
+	return r.DB().DB().Delete(key)
+}
+
+/*
+GetIDByUser gets the id of the reaction of the user (creator-id) on the target with the reaction-type.
+*/
+func (r *Reaction) GetIDByUser(targetID *types.PttID, reactionType ReactionType, creatorID *types.PttID) (*types.PttID, error) {
+	key, err := common.Concat([][]byte{DBReactionUserPrefix, r.EntityID[:], targetID[:], reactionType.Marshal(), creatorID[:]})
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := r.DB().DBGet(key)
+	if err != nil {
+		return nil, err
+	}
+
+	id := &types.PttID{}
+	copy(id[:], val)
+
+	return id, nil
+}
+
+/**********
+ * Count
+ **********/
+
+func NewReactionCount(entityID *types.PttID, targetID *types.PttID, reactionType ReactionType, isNewBits bool) (*pkgservice.Count, error) {
+	dbPrefix, err := common.Concat([][]byte{DBReactionCountPrefix, reactionType.Marshal()})
+	if err != nil {
+		return nil, err
+	}
+
+	return pkgservice.NewCount(dbBoard, entityID, targetID, dbPrefix, PCommentCount, isNewBits)
+}
+
+func LoadReactionCount(entityID *types.PttID, targetID *types.PttID, reactionType ReactionType) (*pkgservice.Count, error) {
+	count, err := NewReactionCount(entityID, targetID, reactionType, false)
+	if err != nil {
+		return nil, err
+	}
+	err = count.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return count, nil
+}
+
+/*
+IncreaseCount adds the creator of the reaction to the count of the reaction-type on the target.
+*/
+func (r *Reaction) IncreaseCount() error {
+	count, err := LoadReactionCount(r.EntityID, r.TargetID, r.ReactionType)
+	if err != nil {
+		count, err = NewReactionCount(r.EntityID, r.TargetID, r.ReactionType, true)
+		if err != nil {
+			return err
+		}
+	}
+	count.Add(r.CreatorID[:])
+
+	return count.Save()
+}
+
+/*
+RebuildCount rebuilds the count of the reaction-type on the target from the alive reactions.
+
+The count is a linear-counting bit-vector which does not support removal,
+so we rebuild the count while the reaction is deleted.
+*/
+func (r *Reaction) RebuildCount(targetID *types.PttID, reactionType ReactionType) error {
+	count, err := NewReactionCount(r.EntityID, targetID, reactionType, true)
+	if err != nil {
+		return err
+	}
+
+	reactions, err := r.GetListByTarget(targetID)
+	if err != nil {
+		return err
+	}
+
+	nCount := 0
+	for _, reaction := range reactions {
+		if reaction.Status != types.StatusAlive || reaction.ReactionType != reactionType {
+			continue
+		}
+		count.Add(reaction.CreatorID[:])
+		nCount++
+	}
+
+	if nCount == 0 {
+		return count.Delete()
+	}
+
+	return count.Save()
+}
+
+/**********
+ * Target
+ **********/
+
+/*
+GetListByTarget gets all the reactions (including the deleted ones) of the target.
+*/
+func (r *Reaction) GetListByTarget(targetID *types.PttID) ([]*Reaction, error) {
+	iter, err := r.GetCrossObjIterWithObj(targetID[:], nil, pttdb.ListOrderNext, false)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	reactions := make([]*Reaction, 0)
+	for iter.Next() {
+		reaction := NewEmptyReaction()
+		reaction.CloneDB(r.BaseObject)
+		err = reaction.Unmarshal(iter.Value())
+		if err != nil {
+			continue
+		}
+		reactions = append(reactions, reaction)
+	}
+
+	return reactions, nil
+}
+
+/*
+DeleteAllByTargetID deletes all the reactions and the counts of the target.
+*/
+func (r *Reaction) DeleteAllByTargetID(targetID *types.PttID) error {
+	reactions, err := r.GetListByTarget(targetID)
+	if err != nil {
+		return err
+	}
+
+	for _, reaction := range reactions {
+		reaction.DeleteUserKey()
+		reaction.Delete(false)
+	}
+
+	var count *pkgservice.Count
+	for reactionType := ReactionTypeLike; reactionType < NReactionType; reactionType++ {
+		count, err = NewReactionCount(r.EntityID, targetID, reactionType, false)
+		if err != nil {
+			continue
+		}
+		count.Delete()
+	}
+
+	return nil
+}
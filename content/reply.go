@@ -17,12 +17,306 @@
 package content
 
 import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
+/*
+Reply is the reply to a comment or to another reply.
+
+The replies are keyed by the comment-id and the create-ts,
+so that all the replies of a comment can be paginated as a thread.
+ParentID / Depth describe the nested structure inside the thread.
+*/
 type Reply struct {
+	*pkgservice.BaseObject `json:"b"`
+
+	UpdateTS types.Timestamp `json:"UT"`
+
+	SyncInfo *pkgservice.BaseSyncInfo `json:"s,omitempty"`
+
+	ArticleID *types.PttID `json:"AID"`
+	CommentID *types.PttID `json:"CID"`
+
+	ParentID        *types.PttID `json:"PID"`
+	ParentCreatorID *types.PttID `json:"pID"`
+	Depth           uint8        `json:"D"`
+}
+
+func NewReply(
+	createTS types.Timestamp,
+	creatorID *types.PttID,
+	entityID *types.PttID,
+
+	logID *types.PttID,
+
+	status types.Status,
+
+	articleID *types.PttID,
+	commentID *types.PttID,
+	parentID *types.PttID,
+	parentCreatorID *types.PttID,
+	depth uint8,
+
+) (*Reply, error) {
+
+	id, err := types.NewPttID()
+	if err != nil {
+		return nil, err
+	}
+
+	o := pkgservice.NewObject(id, createTS, creatorID, entityID, logID, status)
+
+	return &Reply{
+		BaseObject: o,
+
+		UpdateTS: createTS,
+
+		ArticleID:       articleID,
+		CommentID:       commentID,
+		ParentID:        parentID,
+		ParentCreatorID: parentCreatorID,
+		Depth:           depth,
+	}, nil
+}
+
+func NewEmptyReply() *Reply {
+	return &Reply{BaseObject: &pkgservice.BaseObject{}}
+}
+
+func RepliesToObjs(typedObjs []*Reply) []pkgservice.Object {
+	objs := make([]pkgservice.Object, len(typedObjs))
+	for i, obj := range typedObjs {
+		objs[i] = obj
+	}
+	return objs
+}
+
+func ObjsToReplies(objs []pkgservice.Object) []*Reply {
+	typedObjs := make([]*Reply, len(objs))
+	for i, obj := range objs {
+		typedObjs[i] = obj.(*Reply)
+	}
+	return typedObjs
+}
+
+func AliveReplies(typedObjs []*Reply) []*Reply {
+	objs := make([]*Reply, 0, len(typedObjs))
+	for _, obj := range typedObjs {
+		if obj.Status == types.StatusAlive {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+func (pm *ProtocolManager) SetReplyDB(u *Reply) {
+
+	u.SetDB(dbBoard, pm.DBObjLock(), pm.Entity().GetID(), pm.dbReplyPrefix, pm.dbReplyIdxPrefix, pm.SetBlockInfoDB, pm.SetMediaDB)
+}
+
+func (r *Reply) Save(isLocked bool) error {
+	var err error
+
+	if !isLocked {
+		err = r.Lock()
+		if err != nil {
+			return err
+		}
+		defer r.Unlock()
+	}
+
+	key, err := r.MarshalKey()
+	if err != nil {
+		return err
+	}
+	marshaled, err := r.Marshal()
+	if err != nil {
+		return err
+	}
+
+	idxKey, err := r.IdxKey()
+	if err != nil {
+		return err
+	}
+
+	idx := &pttdb.Index{Keys: [][]byte{key}, UpdateTS: r.UpdateTS}
+
+	kvs := []*pttdb.KeyVal{
+		&pttdb.KeyVal{K: key, V: marshaled},
+	}
+
+	_, err = r.DB().ForcePutAll(idxKey, idx, kvs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *Reply) NewEmptyObj() pkgservice.Object {
+	newObj := NewEmptyReply()
+	newObj.CloneDB(r.BaseObject)
+	return newObj
+}
 
+func (r *Reply) GetNewObjByID(id *types.PttID, isLocked bool) (pkgservice.Object, error) {
+	newU := r.NewEmptyObj()
+	newU.SetID(id)
+	err := newU.GetByID(isLocked)
+	if err != nil {
+		return nil, err
+	}
+	return newU, nil
 }
 
-func NewReply() (*Reply, error) {
-    return &Reply{}, nil
+func (r *Reply) SetUpdateTS(ts types.Timestamp) {
+	r.UpdateTS = ts
+}
+
+func (r *Reply) GetUpdateTS() types.Timestamp {
+	return r.UpdateTS
+}
+
+func (r *Reply) Get(isLocked bool) error {
+	var err error
+
+	if !isLocked {
+		err = r.RLock()
+		if err != nil {
+			return err
+		}
+		defer r.RUnlock()
+	}
+
+	key, err := r.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	val, err := r.DB().DBGet(key)
+	if err != nil {
+		return err
+	}
+
+	return r.Unmarshal(val)
+}
+
+func (r *Reply) GetByID(isLocked bool) error {
+	var err error
+
+	val, err := r.GetValueByID(isLocked)
+	if err != nil {
+		return err
+	}
+
+	return r.Unmarshal(val)
+}
+
+func (r *Reply) MarshalKey() ([]byte, error) {
+	marshalTimestamp, err := r.CreateTS.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return common.Concat([][]byte{r.FullDBPrefix(), r.CommentID[:], marshalTimestamp, r.ID[:]})
+}
+
+func (r *Reply) Marshal() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *Reply) Unmarshal(theBytes []byte) error {
+	return json.Unmarshal(theBytes, r)
+}
+
+func (r *Reply) GetSyncInfo() pkgservice.SyncInfo {
+	if r.SyncInfo == nil {
+		return nil
+	}
+	return r.SyncInfo
+}
+
+func (r *Reply) SetSyncInfo(theSyncInfo pkgservice.SyncInfo) error {
+	if theSyncInfo == nil {
+		r.SyncInfo = nil
+		return nil
+	}
+
+	syncInfo, ok := theSyncInfo.(*pkgservice.BaseSyncInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+	r.SyncInfo = syncInfo
+
+	return nil
+}
+
+func (r *Reply) DeleteAll(isLocked bool) error {
+	var err error
+	if !isLocked {
+		err = r.Lock()
+		if err != nil {
+			return err
+		}
+		defer r.Unlock()
+	}
+
+	// block-info
+	blockInfo := r.GetBlockInfo()
+	setBlockInfoDB := r.SetBlockInfoDB()
+	setBlockInfoDB(blockInfo, r.ID)
+
+	blockInfo.Remove(false)
+
+	r.Delete(true)
+
+	return nil
+}
+
+func (r *Reply) GetAndDeleteAll(isLocked bool) error {
+	var err error
+	if !isLocked {
+		err = r.Lock()
+		if err != nil {
+			return err
+		}
+		defer r.Unlock()
+	}
+
+	err = r.GetByID(true)
+	if err != nil {
+		return err
+	}
+
+	return r.DeleteAll(true)
+}
+
+/*
+DeleteAllByCommentID deletes all the replies in the thread of the comment.
+*/
+func (r *Reply) DeleteAllByCommentID(commentID *types.PttID) error {
+	iter, err := r.GetCrossObjIterWithObj(commentID[:], nil, pttdb.ListOrderNext, false)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+
+	var id *types.PttID
+	for iter.Next() {
+		id, err = r.KeyToID(iter.Key())
+		if err != nil {
+			continue
+		}
+		r.SetID(id)
+		r.GetAndDeleteAll(false)
+
+		dbSearch.Delete(r.EntityID[:], id[:])
+	}
+
+	return nil
 }
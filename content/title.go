@@ -28,7 +28,13 @@ import (
 type SyncTitleInfo struct {
 	*pkgservice.BaseSyncInfo `json:"b"`
 
-	Title []byte `json:"T,omitempty"`
+	Title []byte   `json:"T,omitempty"`
+	Tags  []string `json:"tg,omitempty"`
+
+	PostArticleInterval int64 `json:"pa,omitempty"`
+	PostCommentInterval int64 `json:"pc,omitempty"`
+
+	IsPublicFeed bool `json:"pf,omitempty"`
 }
 
 func NewEmptySyncTitleInfo() *SyncTitleInfo {
@@ -44,6 +50,10 @@ func (s *SyncTitleInfo) ToObject(theObj pkgservice.Object) error {
 	s.BaseSyncInfo.ToObject(obj)
 
 	obj.Title = s.Title
+	obj.Tags = s.Tags
+	obj.PostArticleInterval = s.PostArticleInterval
+	obj.PostCommentInterval = s.PostCommentInterval
+	obj.IsPublicFeed = s.IsPublicFeed
 
 	return nil
 }
@@ -55,6 +65,17 @@ type Title struct {
 	SyncInfo *SyncTitleInfo `json:"s,omitempty"`
 
 	Title []byte `json:"T,omitempty"`
+
+	// Tags is the tag-vocabulary of the board.
+	Tags []string `json:"tg,omitempty"`
+
+	// PostArticleInterval / PostCommentInterval are the min intervals (in seconds) between the posts of each member.
+	// The posting rate is not limited if the interval is 0.
+	PostArticleInterval int64 `json:"pa,omitempty"`
+	PostCommentInterval int64 `json:"pc,omitempty"`
+
+	// IsPublicFeed is whether the board is served as the public atom / rss feed by the http-servers.
+	IsPublicFeed bool `json:"pf,omitempty"`
 }
 
 func NewTitle(
@@ -85,6 +106,21 @@ func NewEmptyTitle() *Title {
 	return &Title{BaseObject: &pkgservice.BaseObject{}}
 }
 
+/*
+ToUpdateTitle returns the current title, tags and settings of the board as the base of UpdateTitle.
+*/
+func (t *Title) ToUpdateTitle() *UpdateTitle {
+	return &UpdateTitle{
+		Title: t.Title,
+		Tags:  t.Tags,
+
+		PostArticleInterval: t.PostArticleInterval,
+		PostCommentInterval: t.PostCommentInterval,
+
+		IsPublicFeed: t.IsPublicFeed,
+	}
+}
+
 func TitlesToObjs(typedObjs []*Title) []pkgservice.Object {
 	objs := make([]pkgservice.Object, len(typedObjs))
 	for i, obj := range typedObjs {
@@ -46,6 +46,56 @@ func (c *CommentType) Marshal() []byte {
 	return theBytes[:]
 }
 
+// reaction type
+type ReactionType uint8
+
+const (
+	ReactionTypeLike ReactionType = iota
+	ReactionTypeLove
+	ReactionTypeHaha
+	ReactionTypeWow
+	ReactionTypeSad
+	ReactionTypeAngry
+
+	NReactionType
+)
+
+func (r ReactionType) IsValid() bool {
+	return r < NReactionType
+}
+
+func (r ReactionType) Marshal() []byte {
+	return []byte{uint8(r)}
+}
+
+// ban type
+type BanType uint8
+
+const (
+	BanTypeBan BanType = iota
+	BanTypeMute
+
+	NBanType
+)
+
+func (b BanType) IsValid() bool {
+	return b < NBanType
+}
+
+func (b BanType) Marshal() []byte {
+	return []byte{uint8(b)}
+}
+
+// moderator perms
+const (
+	PermDeleteArticle pkgservice.MemberPerm = 1 << iota
+	PermDeleteComment
+	PermMute
+	PermPin
+)
+
+const PermModerator = PermDeleteArticle | PermDeleteComment | PermMute | PermPin
+
 type ReplyInfo struct {
 	Op pkgservice.OpType
 
@@ -0,0 +1,149 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/me"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/stretchr/testify/assert"
+	baloo "gopkg.in/h2non/baloo.v3"
+)
+
+func TestContentArticleRevisionRepair(t *testing.T) {
+	skipIfMemoryDB(t)
+
+	NNodes = 1
+	isDebug := true
+
+	var bodyString string
+	var marshaledID []byte
+	var marshaledID2 []byte
+	var marshaledID3 []byte
+	var marshaledStr string
+	assert := assert.New(t)
+
+	setupTest(t)
+	defer teardownTest(t)
+
+	t0 := baloo.New("http://127.0.0.1:9450")
+
+	// 1. get
+	bodyString = `{"id": "testID", "method": "me_get", "params": []}`
+
+	me0_1 := &me.BackendMyInfo{}
+	testCore(t0, bodyString, me0_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me0_1.Status)
+
+	// 2. create-board
+	title := []byte("標題1")
+	marshaledStr = base64.StdEncoding.EncodeToString(title)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createBoard", "params": ["%v", true]}`, marshaledStr)
+
+	dataCreateBoard0_2 := &content.BackendCreateBoard{}
+	testCore(t0, bodyString, dataCreateBoard0_2, t, isDebug)
+	assert.Equal(types.StatusAlive, dataCreateBoard0_2.Status)
+
+	// 3. create-article
+	article0_3 := [][]byte{
+		[]byte("測試1"),
+		[]byte("測試2"),
+	}
+	article, _ := json.Marshal([]string{
+		base64.StdEncoding.EncodeToString(article0_3[0]),
+		base64.StdEncoding.EncodeToString(article0_3[1]),
+	})
+
+	marshaledID, _ = dataCreateBoard0_2.ID.MarshalText()
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題2"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), marshaledStr, string(article))
+
+	dataCreateArticle0_3 := &content.BackendCreateArticle{}
+	testCore(t0, bodyString, dataCreateArticle0_3, t, isDebug)
+	assert.Equal(dataCreateBoard0_2.ID, dataCreateArticle0_3.BoardID)
+
+	// 4. update-article
+	article, _ = json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("測試3")),
+	})
+
+	marshaledID2, _ = dataCreateArticle0_3.ArticleID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_updateArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), string(marshaledID2), string(article))
+
+	dataUpdateArticle0_4 := &content.BackendUpdateArticle{}
+	testCore(t0, bodyString, dataUpdateArticle0_4, t, isDebug)
+	assert.Equal(dataCreateArticle0_3.ArticleID, dataUpdateArticle0_4.ArticleID)
+
+	time.Sleep(5 * time.Second)
+
+	// 5. get-article-revision-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleRevisionList", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID2))
+
+	dataRevisionList0_5 := &struct {
+		Result []*content.BackendArticleRevision `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataRevisionList0_5, t, isDebug)
+	assert.Equal(2, len(dataRevisionList0_5.Result))
+	revision0_5_0 := dataRevisionList0_5.Result[0]
+	assert.Equal(false, revision0_5_0.IsCurrent)
+	assert.Equal(true, dataRevisionList0_5.Result[1].IsCurrent)
+
+	// 6. ptt-shutdown
+	bodyString = `{"id": "testID", "method": "ptt_shutdown", "params": []}`
+
+	resultString := `{"jsonrpc":"2.0","id":"testID","result":true}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	time.Sleep(5 * time.Second)
+
+	// 7. db-check: the blocks of the previous revision are not orphans.
+	out0_7, err := exec.Command(gpttBin, "db", "check", "--datadir", "./test.out/.test0").CombinedOutput()
+	t.Logf("7. db-check: %s", out0_7)
+	assert.Equal(nil, err)
+	assert.Equal(false, strings.Contains(string(out0_7), pkgservice.DBCheckIssueTypeOrphanBlock.String()))
+
+	// 8. db-repair
+	out0_8, err := exec.Command(gpttBin, "db", "repair", "--datadir", "./test.out/.test0").CombinedOutput()
+	t.Logf("8. db-repair: %s", out0_8)
+	assert.Equal(nil, err)
+
+	// 9. start-node
+	startNode(t, 0, 0, false)
+
+	time.Sleep(15 * time.Second)
+
+	// 10. get-article-revision
+	marshaledID3, _ = revision0_5_0.ID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleRevision", "params": ["%v", "%v", "%v"]}`, string(marshaledID), string(marshaledID2), string(marshaledID3))
+
+	revision0_10 := &content.BackendGetArticleRevision{}
+	testCore(t0, bodyString, revision0_10, t, isDebug)
+	assert.Equal(article0_3, revision0_10.Article)
+}
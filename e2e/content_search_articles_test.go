@@ -0,0 +1,130 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/me"
+	"github.com/stretchr/testify/assert"
+	baloo "gopkg.in/h2non/baloo.v3"
+)
+
+func TestContentSearchArticles(t *testing.T) {
+	NNodes = 1
+	isDebug := true
+
+	var bodyString string
+	var marshaledID []byte
+	var marshaledID2 []byte
+	var marshaledStr string
+	assert := assert.New(t)
+
+	setupTest(t)
+	defer teardownTest(t)
+
+	t0 := baloo.New("http://127.0.0.1:9450")
+
+	// 1. get
+	bodyString = `{"id": "testID", "method": "me_get", "params": []}`
+
+	me0_1 := &me.BackendMyInfo{}
+	testCore(t0, bodyString, me0_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me0_1.Status)
+
+	// 2. create-board
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題1"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createBoard", "params": ["%v", true]}`, marshaledStr)
+
+	dataCreateBoard0_2 := &content.BackendCreateBoard{}
+	testCore(t0, bodyString, dataCreateBoard0_2, t, isDebug)
+	assert.Equal(types.StatusAlive, dataCreateBoard0_2.Status)
+
+	marshaledID, _ = dataCreateBoard0_2.ID.MarshalText()
+
+	// 3. create-article / create-comment / delete-article:
+	//    the comments are still in the search-index, referring to the deleted articles.
+	article, _ := json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("今天")),
+	})
+	comment := base64.StdEncoding.EncodeToString([]byte("天氣很好"))
+
+	for i := 0; i < 3; i++ {
+		marshaledStr = base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("標題%v", i+2)))
+
+		bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), marshaledStr, string(article))
+
+		dataCreateArticle0_3 := &content.BackendCreateArticle{}
+		testCore(t0, bodyString, dataCreateArticle0_3, t, isDebug)
+		assert.Equal(dataCreateBoard0_2.ID, dataCreateArticle0_3.BoardID)
+
+		marshaledID2, _ = dataCreateArticle0_3.ArticleID.MarshalText()
+
+		bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createComment", "params": ["%v", "%v", 0, "%v", ""]}`, string(marshaledID), string(marshaledID2), comment)
+
+		dataCreateComment0_3 := &content.BackendCreateComment{}
+		testCore(t0, bodyString, dataCreateComment0_3, t, isDebug)
+		assert.Equal(dataCreateArticle0_3.ArticleID, dataCreateComment0_3.ArticleID)
+
+		bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_deleteArticle", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID2))
+
+		dataDeleteArticle0_3 := &content.BackendDeleteArticle{}
+		testCore(t0, bodyString, dataDeleteArticle0_3, t, isDebug)
+	}
+
+	// 4. create-article
+	article, _ = json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("天氣不錯")),
+	})
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題5"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), marshaledStr, string(article))
+
+	dataCreateArticle0_4 := &content.BackendCreateArticle{}
+	testCore(t0, bodyString, dataCreateArticle0_4, t, isDebug)
+	assert.Equal(dataCreateBoard0_2.ID, dataCreateArticle0_4.BoardID)
+
+	time.Sleep(5 * time.Second)
+
+	// 5. search-articles: the deleted articles are filtered before the limit.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_searchArticles", "params": ["%v", "天氣", 1]}`, string(marshaledID))
+
+	dataSearchArticles0_5 := &struct {
+		Result []*content.BackendGetArticle `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataSearchArticles0_5, t, isDebug)
+	assert.Equal(1, len(dataSearchArticles0_5.Result))
+	if len(dataSearchArticles0_5.Result) == 1 {
+		assert.Equal(dataCreateArticle0_4.ArticleID, dataSearchArticles0_5.Result[0].ID)
+	}
+
+	// 6. search-articles: limit <= 0 as the max limit.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_searchArticles", "params": ["%v", "天氣", 0]}`, string(marshaledID))
+
+	dataSearchArticles0_6 := &struct {
+		Result []*content.BackendGetArticle `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataSearchArticles0_6, t, isDebug)
+	assert.Equal(1, len(dataSearchArticles0_6.Result))
+}
@@ -0,0 +1,292 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/me"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/stretchr/testify/assert"
+	baloo "gopkg.in/h2non/baloo.v3"
+)
+
+func TestFriendArticleRevision(t *testing.T) {
+	NNodes = 2
+	isDebug := true
+
+	var bodyString string
+	var marshaledID []byte
+	var marshaledID2 []byte
+	var marshaledID3 []byte
+	var marshaledID4 []byte
+	var marshaledStr string
+	var resultString string
+	assert := assert.New(t)
+
+	setupTest(t)
+	defer teardownTest(t)
+
+	t0 := baloo.New("http://127.0.0.1:9450")
+	t1 := baloo.New("http://127.0.0.1:9451")
+
+	// 1. get
+	bodyString = `{"id": "testID", "method": "me_get", "params": []}`
+
+	me0_1 := &me.BackendMyInfo{}
+	testCore(t0, bodyString, me0_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me0_1.Status)
+
+	me1_1 := &me.BackendMyInfo{}
+	testCore(t1, bodyString, me1_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me1_1.Status)
+
+	// 2. show-url
+	bodyString = `{"id": "testID", "method": "me_showURL", "params": []}`
+
+	dataShowURL1_2 := &pkgservice.BackendJoinURL{}
+	testCore(t1, bodyString, dataShowURL1_2, t, isDebug)
+	url1_2 := dataShowURL1_2.URL
+
+	// 3. join-friend
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinFriend", "params": ["%v"]}`, url1_2)
+
+	dataJoinFriend0_3 := &pkgservice.BackendJoinRequest{}
+	testCore(t0, bodyString, dataJoinFriend0_3, t, isDebug)
+	assert.Equal(me1_1.ID, dataJoinFriend0_3.CreatorID)
+	assert.Equal(me1_1.NodeID, dataJoinFriend0_3.NodeID)
+
+	// wait 20
+	t.Logf("wait 20 seconds for hand-shaking")
+	time.Sleep(20 * time.Second)
+
+	// 4. get-friend-list
+	bodyString = `{"id": "testID", "method": "friend_getFriendList", "params": ["", 0]}`
+
+	dataGetFriendList0_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetFriendList0_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList0_4.Result))
+	friend0_4 := dataGetFriendList0_4.Result[0]
+	assert.Equal(types.StatusAlive, friend0_4.Status)
+	assert.Equal(me1_1.ID, friend0_4.FriendID)
+
+	dataGetFriendList1_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetFriendList1_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList1_4.Result))
+	friend1_4 := dataGetFriendList1_4.Result[0]
+	assert.Equal(types.StatusAlive, friend1_4.Status)
+	assert.Equal(me0_1.ID, friend1_4.FriendID)
+	assert.Equal(friend0_4.ID, friend1_4.ID)
+
+	// 5. create-board
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題1"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createBoard", "params": ["%v", true]}`, marshaledStr)
+
+	dataCreateBoard0_5 := &content.BackendCreateBoard{}
+	testCore(t0, bodyString, dataCreateBoard0_5, t, isDebug)
+	assert.Equal(types.StatusAlive, dataCreateBoard0_5.Status)
+	assert.Equal(me0_1.ID, dataCreateBoard0_5.CreatorID)
+
+	// 6. show-board-url
+	marshaledID, _ = dataCreateBoard0_5.ID.MarshalText()
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_showBoardURL", "params": ["%v"]}`, string(marshaledID))
+
+	dataShowBoardURL0_6 := &pkgservice.BackendJoinURL{}
+	testCore(t0, bodyString, dataShowBoardURL0_6, t, isDebug)
+	url0_6 := dataShowBoardURL0_6.URL
+
+	// 7. join-board
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinBoard", "params": ["%v"]}`, url0_6)
+
+	dataJoinBoard1_7 := &pkgservice.BackendJoinRequest{}
+	testCore(t1, bodyString, dataJoinBoard1_7, t, isDebug)
+
+	// wait 10
+	t.Logf("wait 10 seconds for joining the board")
+	time.Sleep(10 * time.Second)
+
+	// 8. get-board
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getBoard", "params": ["%v"]}`, string(marshaledID))
+
+	board1_8 := &content.BackendGetBoard{}
+	testCore(t1, bodyString, board1_8, t, isDebug)
+	assert.Equal(dataCreateBoard0_5.ID, board1_8.ID)
+	assert.Equal(types.StatusAlive, board1_8.Status)
+
+	// 9. create-article
+	article0_9 := [][]byte{
+		[]byte("測試1"),
+		[]byte("測試2"),
+	}
+	article, _ := json.Marshal([]string{
+		base64.StdEncoding.EncodeToString(article0_9[0]),
+		base64.StdEncoding.EncodeToString(article0_9[1]),
+	})
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題2"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), marshaledStr, string(article))
+
+	dataCreateArticle0_9 := &content.BackendCreateArticle{}
+	testCore(t0, bodyString, dataCreateArticle0_9, t, isDebug)
+	assert.Equal(dataCreateBoard0_5.ID, dataCreateArticle0_9.BoardID)
+	marshaledID2, _ = dataCreateArticle0_9.ArticleID.MarshalText()
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 10. update-article
+	article0_10 := [][]byte{
+		[]byte("測試1"),
+		[]byte("測試3"),
+		[]byte("測試4"),
+	}
+	article, _ = json.Marshal([]string{
+		base64.StdEncoding.EncodeToString(article0_10[0]),
+		base64.StdEncoding.EncodeToString(article0_10[1]),
+		base64.StdEncoding.EncodeToString(article0_10[2]),
+	})
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_updateArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), string(marshaledID2), string(article))
+
+	dataUpdateArticle0_10 := &content.BackendUpdateArticle{}
+	testCore(t0, bodyString, dataUpdateArticle0_10, t, isDebug)
+	assert.Equal(dataCreateArticle0_9.ArticleID, dataUpdateArticle0_10.ArticleID)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 11. get-article-revision-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleRevisionList", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID2))
+
+	dataRevisionList0_11 := &struct {
+		Result []*content.BackendArticleRevision `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataRevisionList0_11, t, isDebug)
+	assert.Equal(2, len(dataRevisionList0_11.Result))
+	assert.Equal(false, dataRevisionList0_11.Result[0].IsCurrent)
+	assert.Equal(true, dataRevisionList0_11.Result[1].IsCurrent)
+
+	dataRevisionList1_11 := &struct {
+		Result []*content.BackendArticleRevision `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataRevisionList1_11, t, isDebug)
+	assert.Equal(2, len(dataRevisionList1_11.Result))
+	revision1_11_0 := dataRevisionList1_11.Result[0]
+	assert.Equal(false, revision1_11_0.IsCurrent)
+	assert.Equal(me0_1.ID, revision1_11_0.UpdaterID)
+	assert.Equal(2, revision1_11_0.NBlock)
+	revision1_11_1 := dataRevisionList1_11.Result[1]
+	assert.Equal(true, revision1_11_1.IsCurrent)
+	assert.Equal(me0_1.ID, revision1_11_1.UpdaterID)
+	assert.Equal(true, revision1_11_0.UpdateTS.IsLess(revision1_11_1.UpdateTS))
+
+	// 12. get-article-revision
+	marshaledID3, _ = revision1_11_0.ID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleRevision", "params": ["%v", "%v", "%v"]}`, string(marshaledID), string(marshaledID2), string(marshaledID3))
+
+	revision1_12 := &content.BackendGetArticleRevision{}
+	testCore(t1, bodyString, revision1_12, t, isDebug)
+	assert.Equal(article0_9, revision1_12.Article)
+
+	marshaledID4, _ = revision1_11_1.ID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleRevision", "params": ["%v", "%v", "%v"]}`, string(marshaledID), string(marshaledID2), string(marshaledID4))
+
+	revision1_12_1 := &content.BackendGetArticleRevision{}
+	testCore(t1, bodyString, revision1_12_1, t, isDebug)
+	assert.Equal(article0_10, revision1_12_1.Article)
+
+	// 13. get-article-revision-diff
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleRevisionDiff", "params": ["%v", "%v", "%v", "%v"]}`, string(marshaledID), string(marshaledID2), string(marshaledID3), string(marshaledID4))
+
+	dataRevisionDiff1_13 := &struct {
+		Result []*pkgservice.LineDiff `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataRevisionDiff1_13, t, isDebug)
+	assert.Equal([]*pkgservice.LineDiff{
+		{Op: pkgservice.DiffOpEqual, Line: []byte("測試1"), FromLine: 0, ToLine: 0},
+		{Op: pkgservice.DiffOpDelete, Line: []byte("測試2"), FromLine: 1, ToLine: -1},
+		{Op: pkgservice.DiffOpInsert, Line: []byte("測試3"), FromLine: -1, ToLine: 1},
+		{Op: pkgservice.DiffOpInsert, Line: []byte("測試4"), FromLine: -1, ToLine: 2},
+	}, dataRevisionDiff1_13.Result)
+
+	// 14. set-article-revision-limit: node-local
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_setArticleRevisionLimit", "params": ["%v", 101]}`, string(marshaledID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"invalid revision limit"}}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_setArticleRevisionLimit", "params": ["%v", 0]}`, string(marshaledID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":true}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleRevisionLimit", "params": ["%v"]}`, string(marshaledID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":0}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":10}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	// 15. update-article
+	article, _ = json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("測試5")),
+	})
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_updateArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), string(marshaledID2), string(article))
+
+	dataUpdateArticle0_15 := &content.BackendUpdateArticle{}
+	testCore(t0, bodyString, dataUpdateArticle0_15, t, isDebug)
+	assert.Equal(dataCreateArticle0_9.ArticleID, dataUpdateArticle0_15.ArticleID)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 16. get-article-revision-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleRevisionList", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID2))
+
+	dataRevisionList0_16 := &struct {
+		Result []*content.BackendArticleRevision `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataRevisionList0_16, t, isDebug)
+	assert.Equal(3, len(dataRevisionList0_16.Result))
+	assert.Equal(true, dataRevisionList0_16.Result[2].IsCurrent)
+
+	dataRevisionList1_16 := &struct {
+		Result []*content.BackendArticleRevision `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataRevisionList1_16, t, isDebug)
+	assert.Equal(1, len(dataRevisionList1_16.Result))
+	assert.Equal(true, dataRevisionList1_16.Result[0].IsCurrent)
+}
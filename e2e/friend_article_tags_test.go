@@ -0,0 +1,266 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/me"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/stretchr/testify/assert"
+	baloo "gopkg.in/h2non/baloo.v3"
+)
+
+func TestFriendArticleTags(t *testing.T) {
+	NNodes = 2
+	isDebug := true
+
+	var bodyString string
+	var marshaledID []byte
+	var marshaledID2 []byte
+	var marshaledID3 []byte
+	var marshaledStr string
+	var resultString string
+	assert := assert.New(t)
+
+	setupTest(t)
+	defer teardownTest(t)
+
+	t0 := baloo.New("http://127.0.0.1:9450")
+	t1 := baloo.New("http://127.0.0.1:9451")
+
+	// 1. get
+	bodyString = `{"id": "testID", "method": "me_get", "params": []}`
+
+	me0_1 := &me.BackendMyInfo{}
+	testCore(t0, bodyString, me0_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me0_1.Status)
+
+	me1_1 := &me.BackendMyInfo{}
+	testCore(t1, bodyString, me1_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me1_1.Status)
+
+	// 2. show-url
+	bodyString = `{"id": "testID", "method": "me_showURL", "params": []}`
+
+	dataShowURL1_2 := &pkgservice.BackendJoinURL{}
+	testCore(t1, bodyString, dataShowURL1_2, t, isDebug)
+	url1_2 := dataShowURL1_2.URL
+
+	// 3. join-friend
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinFriend", "params": ["%v"]}`, url1_2)
+
+	dataJoinFriend0_3 := &pkgservice.BackendJoinRequest{}
+	testCore(t0, bodyString, dataJoinFriend0_3, t, isDebug)
+	assert.Equal(me1_1.ID, dataJoinFriend0_3.CreatorID)
+	assert.Equal(me1_1.NodeID, dataJoinFriend0_3.NodeID)
+
+	// wait 20
+	t.Logf("wait 20 seconds for hand-shaking")
+	time.Sleep(20 * time.Second)
+
+	// 4. get-friend-list
+	bodyString = `{"id": "testID", "method": "friend_getFriendList", "params": ["", 0]}`
+
+	dataGetFriendList0_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetFriendList0_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList0_4.Result))
+	friend0_4 := dataGetFriendList0_4.Result[0]
+	assert.Equal(types.StatusAlive, friend0_4.Status)
+	assert.Equal(me1_1.ID, friend0_4.FriendID)
+
+	dataGetFriendList1_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetFriendList1_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList1_4.Result))
+	friend1_4 := dataGetFriendList1_4.Result[0]
+	assert.Equal(types.StatusAlive, friend1_4.Status)
+	assert.Equal(me0_1.ID, friend1_4.FriendID)
+	assert.Equal(friend0_4.ID, friend1_4.ID)
+
+	// 5. create-board
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題1"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createBoard", "params": ["%v", true]}`, marshaledStr)
+
+	dataCreateBoard0_5 := &content.BackendCreateBoard{}
+	testCore(t0, bodyString, dataCreateBoard0_5, t, isDebug)
+	assert.Equal(types.StatusAlive, dataCreateBoard0_5.Status)
+	assert.Equal(me0_1.ID, dataCreateBoard0_5.CreatorID)
+
+	// 6. show-board-url
+	marshaledID, _ = dataCreateBoard0_5.ID.MarshalText()
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_showBoardURL", "params": ["%v"]}`, string(marshaledID))
+
+	dataShowBoardURL0_6 := &pkgservice.BackendJoinURL{}
+	testCore(t0, bodyString, dataShowBoardURL0_6, t, isDebug)
+	url0_6 := dataShowBoardURL0_6.URL
+
+	// 7. join-board
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinBoard", "params": ["%v"]}`, url0_6)
+
+	dataJoinBoard1_7 := &pkgservice.BackendJoinRequest{}
+	testCore(t1, bodyString, dataJoinBoard1_7, t, isDebug)
+
+	// wait 10
+	t.Logf("wait 10 seconds for joining the board")
+	time.Sleep(10 * time.Second)
+
+	// 8. get-board
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getBoard", "params": ["%v"]}`, string(marshaledID))
+
+	board1_8 := &content.BackendGetBoard{}
+	testCore(t1, bodyString, board1_8, t, isDebug)
+	assert.Equal(dataCreateBoard0_5.ID, board1_8.ID)
+	assert.Equal(types.StatusAlive, board1_8.Status)
+
+	// 9. set-board-tags
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_setBoardTags", "params": ["%v", ["News", " tech ", "news"]]}`, string(marshaledID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"invalid id"}}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":["news","tech"]}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	// 10. create-article
+	article, _ := json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("測試1")),
+	})
+
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題1"))
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), marshaledStr, string(article))
+
+	dataCreateArticle0_10_0 := &content.BackendCreateArticle{}
+	testCore(t0, bodyString, dataCreateArticle0_10_0, t, isDebug)
+	marshaledID2, _ = dataCreateArticle0_10_0.ArticleID.MarshalText()
+
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題2"))
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), marshaledStr, string(article))
+
+	dataCreateArticle0_10_1 := &content.BackendCreateArticle{}
+	testCore(t0, bodyString, dataCreateArticle0_10_1, t, isDebug)
+	marshaledID3, _ = dataCreateArticle0_10_1.ArticleID.MarshalText()
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 11. get-board-tags
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getBoardTags", "params": ["%v"]}`, string(marshaledID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":["news","tech"]}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	// 12. set-article-tags
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_setArticleTags", "params": ["%v", "%v", ["NEWS"]]}`, string(marshaledID), string(marshaledID2))
+
+	dataSetArticleTags0_12_0 := &content.BackendUpdateArticle{}
+	testCore(t0, bodyString, dataSetArticleTags0_12_0, t, isDebug)
+	assert.Equal(dataCreateArticle0_10_0.ArticleID, dataSetArticleTags0_12_0.ArticleID)
+
+	// set-article-tags: not the creator or the master.
+	_, err := testCore(t1, bodyString, &content.BackendUpdateArticle{}, t, isDebug)
+	assert.NotEqual(0, err.Code)
+
+	// set-article-tags: not in the tag-vocabulary.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_setArticleTags", "params": ["%v", "%v", ["sports"]]}`, string(marshaledID), string(marshaledID3))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"invalid tag"}}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_setArticleTags", "params": ["%v", "%v", ["tech", "news"]]}`, string(marshaledID), string(marshaledID3))
+
+	dataSetArticleTags0_12_1 := &content.BackendUpdateArticle{}
+	testCore(t0, bodyString, dataSetArticleTags0_12_1, t, isDebug)
+	assert.Equal(dataCreateArticle0_10_1.ArticleID, dataSetArticleTags0_12_1.ArticleID)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 13. get-article-list-by-tag
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleListByTag", "params": ["%v", "news", "", 0, 2]}`, string(marshaledID))
+
+	dataGetArticleListByTag0_13 := &struct {
+		Result []*content.BackendGetArticle `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetArticleListByTag0_13, t, isDebug)
+	assert.Equal(2, len(dataGetArticleListByTag0_13.Result))
+	assert.Equal(dataCreateArticle0_10_0.ArticleID, dataGetArticleListByTag0_13.Result[0].ID)
+	assert.Equal([]string{"news"}, dataGetArticleListByTag0_13.Result[0].Tags)
+	assert.Equal(dataCreateArticle0_10_1.ArticleID, dataGetArticleListByTag0_13.Result[1].ID)
+	assert.Equal([]string{"tech", "news"}, dataGetArticleListByTag0_13.Result[1].Tags)
+
+	dataGetArticleListByTag1_13 := &struct {
+		Result []*content.BackendGetArticle `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetArticleListByTag1_13, t, isDebug)
+	assert.Equal(2, len(dataGetArticleListByTag1_13.Result))
+	assert.Equal(dataCreateArticle0_10_0.ArticleID, dataGetArticleListByTag1_13.Result[0].ID)
+	assert.Equal([]string{"news"}, dataGetArticleListByTag1_13.Result[0].Tags)
+	assert.Equal(dataCreateArticle0_10_1.ArticleID, dataGetArticleListByTag1_13.Result[1].ID)
+	assert.Equal([]string{"tech", "news"}, dataGetArticleListByTag1_13.Result[1].Tags)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleListByTag", "params": ["%v", "Tech", "", 0, 2]}`, string(marshaledID))
+
+	dataGetArticleListByTag1_13_1 := &struct {
+		Result []*content.BackendGetArticle `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetArticleListByTag1_13_1, t, isDebug)
+	assert.Equal(1, len(dataGetArticleListByTag1_13_1.Result))
+	assert.Equal(dataCreateArticle0_10_1.ArticleID, dataGetArticleListByTag1_13_1.Result[0].ID)
+
+	// 14. set-article-tags: remove the tags.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_setArticleTags", "params": ["%v", "%v", []]}`, string(marshaledID), string(marshaledID2))
+
+	dataSetArticleTags0_14 := &content.BackendUpdateArticle{}
+	testCore(t0, bodyString, dataSetArticleTags0_14, t, isDebug)
+	assert.Equal(dataCreateArticle0_10_0.ArticleID, dataSetArticleTags0_14.ArticleID)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 15. get-article-list-by-tag
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleListByTag", "params": ["%v", "news", "", 0, 2]}`, string(marshaledID))
+
+	dataGetArticleListByTag1_15 := &struct {
+		Result []*content.BackendGetArticle `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetArticleListByTag1_15, t, isDebug)
+	assert.Equal(1, len(dataGetArticleListByTag1_15.Result))
+	assert.Equal(dataCreateArticle0_10_1.ArticleID, dataGetArticleListByTag1_15.Result[0].ID)
+
+	// 16. get-article
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticle", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID2))
+
+	article1_16 := &content.BackendGetArticle{}
+	testCore(t1, bodyString, article1_16, t, isDebug)
+	assert.Equal(types.StatusAlive, article1_16.Status)
+	assert.Equal(0, len(article1_16.Tags))
+}
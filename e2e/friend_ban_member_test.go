@@ -0,0 +1,323 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/me"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/stretchr/testify/assert"
+	baloo "gopkg.in/h2non/baloo.v3"
+)
+
+func TestFriendBanMember(t *testing.T) {
+	NNodes = 2
+	isDebug := true
+
+	var bodyString string
+	var marshaledID []byte
+	var marshaledID2 []byte
+	var marshaledStr string
+	var resultString string
+	assert := assert.New(t)
+
+	setupTest(t)
+	defer teardownTest(t)
+
+	t0 := baloo.New("http://127.0.0.1:9450")
+	t1 := baloo.New("http://127.0.0.1:9451")
+
+	// 1. get
+	bodyString = `{"id": "testID", "method": "me_get", "params": []}`
+
+	me0_1 := &me.BackendMyInfo{}
+	testCore(t0, bodyString, me0_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me0_1.Status)
+
+	me1_1 := &me.BackendMyInfo{}
+	testCore(t1, bodyString, me1_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me1_1.Status)
+
+	// 2. show-url
+	bodyString = `{"id": "testID", "method": "me_showURL", "params": []}`
+
+	dataShowURL1_2 := &pkgservice.BackendJoinURL{}
+	testCore(t1, bodyString, dataShowURL1_2, t, isDebug)
+	url1_2 := dataShowURL1_2.URL
+
+	// 3. join-friend
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinFriend", "params": ["%v"]}`, url1_2)
+
+	dataJoinFriend0_3 := &pkgservice.BackendJoinRequest{}
+	testCore(t0, bodyString, dataJoinFriend0_3, t, isDebug)
+	assert.Equal(me1_1.ID, dataJoinFriend0_3.CreatorID)
+	assert.Equal(me1_1.NodeID, dataJoinFriend0_3.NodeID)
+
+	// wait 20
+	t.Logf("wait 20 seconds for hand-shaking")
+	time.Sleep(20 * time.Second)
+
+	// 4. get-friend-list
+	bodyString = `{"id": "testID", "method": "friend_getFriendList", "params": ["", 0]}`
+
+	dataGetFriendList0_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetFriendList0_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList0_4.Result))
+	friend0_4 := dataGetFriendList0_4.Result[0]
+	assert.Equal(types.StatusAlive, friend0_4.Status)
+	assert.Equal(me1_1.ID, friend0_4.FriendID)
+
+	dataGetFriendList1_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetFriendList1_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList1_4.Result))
+	friend1_4 := dataGetFriendList1_4.Result[0]
+	assert.Equal(types.StatusAlive, friend1_4.Status)
+	assert.Equal(me0_1.ID, friend1_4.FriendID)
+	assert.Equal(friend0_4.ID, friend1_4.ID)
+
+	// 5. create-board
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題1"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createBoard", "params": ["%v", true]}`, marshaledStr)
+
+	dataCreateBoard0_5 := &content.BackendCreateBoard{}
+	testCore(t0, bodyString, dataCreateBoard0_5, t, isDebug)
+	assert.Equal(types.StatusAlive, dataCreateBoard0_5.Status)
+	assert.Equal(me0_1.ID, dataCreateBoard0_5.CreatorID)
+
+	// 6. show-board-url
+	marshaledID, _ = dataCreateBoard0_5.ID.MarshalText()
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_showBoardURL", "params": ["%v"]}`, string(marshaledID))
+
+	dataShowBoardURL0_6 := &pkgservice.BackendJoinURL{}
+	testCore(t0, bodyString, dataShowBoardURL0_6, t, isDebug)
+	url0_6 := dataShowBoardURL0_6.URL
+
+	// 7. join-board
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinBoard", "params": ["%v"]}`, url0_6)
+
+	dataJoinBoard1_7 := &pkgservice.BackendJoinRequest{}
+	testCore(t1, bodyString, dataJoinBoard1_7, t, isDebug)
+
+	// wait 10
+	t.Logf("wait 10 seconds for joining the board")
+	time.Sleep(10 * time.Second)
+
+	// 8. get-board
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getBoard", "params": ["%v"]}`, string(marshaledID))
+
+	board1_8 := &content.BackendGetBoard{}
+	testCore(t1, bodyString, board1_8, t, isDebug)
+	assert.Equal(dataCreateBoard0_5.ID, board1_8.ID)
+	assert.Equal(types.StatusAlive, board1_8.Status)
+
+	// 9. create-article
+	article, _ := json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("測試1")),
+	})
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題2"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), marshaledStr, string(article))
+
+	dataCreateArticle0_9 := &content.BackendCreateArticle{}
+	testCore(t0, bodyString, dataCreateArticle0_9, t, isDebug)
+	assert.Equal(dataCreateBoard0_5.ID, dataCreateArticle0_9.BoardID)
+	marshaledID2, _ = dataCreateArticle0_9.ArticleID.MarshalText()
+
+	// 10. mute-member
+	marshaledUserID, _ := me1_1.ID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_muteMember", "params": ["%v", "%v", 0]}`, string(marshaledID), string(marshaledUserID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"invalid mute duration"}}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_muteMember", "params": ["%v", "%v", 600]}`, string(marshaledID), string(marshaledUserID))
+
+	dataMute0_10 := &content.BackendBan{}
+	testCore(t0, bodyString, dataMute0_10, t, isDebug)
+	assert.Equal(me1_1.ID, dataMute0_10.UserID)
+	assert.Equal(me0_1.ID, dataMute0_10.CreatorID)
+	assert.Equal(content.BanTypeMute, dataMute0_10.BanType)
+	assert.Equal(dataMute0_10.CreateTS.Ts+600, dataMute0_10.ExpireTS.Ts)
+
+	// mute-member: not the master.
+	marshaledMasterID, _ := me0_1.ID.MarshalText()
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_muteMember", "params": ["%v", "%v", 600]}`, string(marshaledID), string(marshaledMasterID))
+
+	_, err := testCore(t1, bodyString, &content.BackendBan{}, t, isDebug)
+	assert.NotEqual(0, err.Code)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 11. get-ban-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getBanList", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetBanList0_11 := &struct {
+		Result []*content.BackendBan `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetBanList0_11, t, isDebug)
+	assert.Equal(1, len(dataGetBanList0_11.Result))
+	assert.Equal(dataMute0_10, dataGetBanList0_11.Result[0])
+
+	dataGetBanList1_11 := &struct {
+		Result []*content.BackendBan `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetBanList1_11, t, isDebug)
+	assert.Equal(dataGetBanList0_11, dataGetBanList1_11)
+
+	// 12. create-comment: muted.
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("這是comment"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createComment", "params": ["%v", "%v", 0, "%v", ""]}`, string(marshaledID), string(marshaledID2), marshaledStr)
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"muted"}}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	// 13. lift-ban
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_liftBan", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledUserID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":true}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	// 13.1 set-board-post-interval
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_setBoardPostInterval", "params": ["%v", 0, 86401]}`, string(marshaledID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"invalid post interval"}}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_setBoardPostInterval", "params": ["%v", 0, 60]}`, string(marshaledID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"invalid id"}}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	dataSetPostInterval0_13 := &content.BackendPostInterval{}
+	testCore(t0, bodyString, dataSetPostInterval0_13, t, isDebug)
+	assert.Equal(int64(0), dataSetPostInterval0_13.PostArticleInterval)
+	assert.Equal(int64(60), dataSetPostInterval0_13.PostCommentInterval)
+
+	// 13.1.1 set-board-public-feed
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_setBoardPublicFeed", "params": ["%v", true]}`, string(marshaledID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":true}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 13.2 get-board-post-interval
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getBoardPostInterval", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetPostInterval1_13 := &content.BackendPostInterval{}
+	testCore(t1, bodyString, dataGetPostInterval1_13, t, isDebug)
+	assert.Equal(dataSetPostInterval0_13, dataGetPostInterval1_13)
+
+	// 13.3 is-board-public-feed
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_isBoardPublicFeed", "params": ["%v"]}`, string(marshaledID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":true}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	// 14. get-ban-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getBanList", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetBanList1_14 := &struct {
+		Result []*content.BackendBan `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetBanList1_14, t, isDebug)
+	assert.Equal(0, len(dataGetBanList1_14.Result))
+
+	// 15. create-comment
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createComment", "params": ["%v", "%v", 0, "%v", ""]}`, string(marshaledID), string(marshaledID2), marshaledStr)
+
+	dataCreateComment1_15 := &content.BackendCreateComment{}
+	testCore(t1, bodyString, dataCreateComment1_15, t, isDebug)
+	assert.Equal(dataCreateArticle0_9.ArticleID, dataCreateComment1_15.ArticleID)
+
+	// 15.1 create-comment: within the post interval.
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"post too frequent"}}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	// wait 35
+	t.Logf("wait 35 seconds for sync")
+	time.Sleep(35 * time.Second)
+
+	// 16. get-article-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleList", "params": ["%v", "", 0, 2]}`, string(marshaledID))
+
+	dataGetArticleList0_16 := &struct {
+		Result []*content.BackendGetArticle `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetArticleList0_16, t, isDebug)
+	assert.Equal(1, len(dataGetArticleList0_16.Result))
+	assert.Equal(1, dataGetArticleList0_16.Result[0].NPush)
+
+	// 17. ban-member
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_banMember", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledUserID))
+
+	dataBan0_17 := &content.BackendBan{}
+	testCore(t0, bodyString, dataBan0_17, t, isDebug)
+	assert.Equal(me1_1.ID, dataBan0_17.UserID)
+	assert.Equal(content.BanTypeBan, dataBan0_17.BanType)
+	assert.Equal(types.ZeroTimestamp, dataBan0_17.ExpireTS)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 18. get-ban-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getBanList", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetBanList0_18 := &struct {
+		Result []*content.BackendBan `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetBanList0_18, t, isDebug)
+	assert.Equal(1, len(dataGetBanList0_18.Result))
+	assert.Equal(dataBan0_17, dataGetBanList0_18.Result[0])
+
+	// 19. get-board: the banned member is deleted.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getBoard", "params": ["%v"]}`, string(marshaledID))
+
+	board0_19 := &content.BackendGetBoard{}
+	testCore(t0, bodyString, board0_19, t, isDebug)
+	assert.Equal(types.StatusAlive, board0_19.Status)
+
+	board1_19 := &content.BackendGetBoard{}
+	testCore(t1, bodyString, board1_19, t, isDebug)
+	assert.Equal(types.StatusDeleted, board1_19.Status)
+
+	// 20. create-comment: not able to post on the deleted board.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createComment", "params": ["%v", "%v", 0, "%v", ""]}`, string(marshaledID), string(marshaledID2), marshaledStr)
+
+	_, err = testCore(t1, bodyString, &content.BackendCreateComment{}, t, isDebug)
+	assert.NotEqual(0, err.Code)
+}
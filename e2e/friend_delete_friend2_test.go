@@ -33,6 +33,8 @@ import (
 )
 
 func TestFriendDeleteFriend2(t *testing.T) {
+	skipIfMemoryDB(t)
+
 	NNodes = 2
 	isDebug := true
 
@@ -32,6 +32,8 @@ import (
 )
 
 func TestFriendForceSyncMember(t *testing.T) {
+	skipIfMemoryDB(t)
+
 	NNodes = 4
 	isDebug := true
 
@@ -0,0 +1,260 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/group"
+	"github.com/ailabstw/go-pttai/me"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/stretchr/testify/assert"
+	baloo "gopkg.in/h2non/baloo.v3"
+)
+
+func TestFriendGroupChat(t *testing.T) {
+	NNodes = 2
+	isDebug := true
+
+	var bodyString string
+	var marshaledID []byte
+	var marshaledID2 []byte
+	var marshaledID3 []byte
+	var marshaledStr string
+	assert := assert.New(t)
+
+	setupTest(t)
+	defer teardownTest(t)
+
+	t0 := baloo.New("http://127.0.0.1:9450")
+	t1 := baloo.New("http://127.0.0.1:9451")
+
+	// 1. get
+	bodyString = `{"id": "testID", "method": "me_get", "params": []}`
+
+	me0_1 := &me.BackendMyInfo{}
+	testCore(t0, bodyString, me0_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me0_1.Status)
+
+	me1_1 := &me.BackendMyInfo{}
+	testCore(t1, bodyString, me1_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me1_1.Status)
+
+	// 2. show-url
+	bodyString = `{"id": "testID", "method": "me_showURL", "params": []}`
+
+	dataShowURL1_2 := &pkgservice.BackendJoinURL{}
+	testCore(t1, bodyString, dataShowURL1_2, t, isDebug)
+	url1_2 := dataShowURL1_2.URL
+
+	// 3. join-friend
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinFriend", "params": ["%v"]}`, url1_2)
+
+	dataJoinFriend0_3 := &pkgservice.BackendJoinRequest{}
+	testCore(t0, bodyString, dataJoinFriend0_3, t, isDebug)
+	assert.Equal(me1_1.ID, dataJoinFriend0_3.CreatorID)
+	assert.Equal(me1_1.NodeID, dataJoinFriend0_3.NodeID)
+
+	// wait 20
+	t.Logf("wait 20 seconds for hand-shaking")
+	time.Sleep(20 * time.Second)
+
+	// 4. get-friend-list
+	bodyString = `{"id": "testID", "method": "friend_getFriendList", "params": ["", 0]}`
+
+	dataGetFriendList0_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetFriendList0_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList0_4.Result))
+	friend0_4 := dataGetFriendList0_4.Result[0]
+	assert.Equal(types.StatusAlive, friend0_4.Status)
+	assert.Equal(me1_1.ID, friend0_4.FriendID)
+
+	dataGetFriendList1_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetFriendList1_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList1_4.Result))
+	friend1_4 := dataGetFriendList1_4.Result[0]
+	assert.Equal(types.StatusAlive, friend1_4.Status)
+	assert.Equal(me0_1.ID, friend1_4.FriendID)
+	assert.Equal(friend0_4.ID, friend1_4.ID)
+
+	// 5. create-group
+	title0_5 := []byte("群組1")
+	marshaledStr = base64.StdEncoding.EncodeToString(title0_5)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "group_createGroup", "params": ["%v"]}`, marshaledStr)
+
+	dataCreateGroup0_5 := &group.BackendCreateGroup{}
+	testCore(t0, bodyString, dataCreateGroup0_5, t, isDebug)
+	assert.Equal(types.StatusAlive, dataCreateGroup0_5.Status)
+	assert.Equal(title0_5, dataCreateGroup0_5.Title)
+	assert.Equal(me0_1.ID, dataCreateGroup0_5.CreatorID)
+
+	// 6. show-group-url
+	marshaledID, _ = dataCreateGroup0_5.ID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "group_showGroupURL", "params": ["%v"]}`, string(marshaledID))
+
+	dataShowGroupURL0_6 := &pkgservice.BackendJoinURL{}
+	testCore(t0, bodyString, dataShowGroupURL0_6, t, isDebug)
+	url0_6 := dataShowGroupURL0_6.URL
+
+	// 7. join-group
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinGroup", "params": ["%v"]}`, url0_6)
+
+	dataJoinGroup1_7 := &pkgservice.BackendJoinRequest{}
+	testCore(t1, bodyString, dataJoinGroup1_7, t, isDebug)
+	assert.Equal(me0_1.ID, dataJoinGroup1_7.CreatorID)
+
+	// wait 15
+	t.Logf("wait 15 seconds for hand-shaking")
+	time.Sleep(15 * time.Second)
+
+	// 8. get-group
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "group_getGroup", "params": ["%v"]}`, string(marshaledID))
+
+	group0_8 := &group.BackendGetGroup{}
+	testCore(t0, bodyString, group0_8, t, isDebug)
+	assert.Equal(types.StatusAlive, group0_8.Status)
+	assert.Equal(title0_5, group0_8.Title)
+
+	group1_8 := &group.BackendGetGroup{}
+	testCore(t1, bodyString, group1_8, t, isDebug)
+	assert.Equal(types.StatusAlive, group1_8.Status)
+	assert.Equal(title0_5, group1_8.Title)
+	assert.Equal(me0_1.ID, group1_8.CreatorID)
+
+	// 9. get-member-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "group_getMemberList", "params": ["%v", "", 0, 2]}`, string(marshaledID))
+
+	dataGetMemberList0_9 := &struct {
+		Result []*pkgservice.Member `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetMemberList0_9, t, isDebug)
+	assert.Equal(2, len(dataGetMemberList0_9.Result))
+
+	dataGetMemberList1_9 := &struct {
+		Result []*pkgservice.Member `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetMemberList1_9, t, isDebug)
+	assert.Equal(2, len(dataGetMemberList1_9.Result))
+
+	// 10. create-group-message
+	message0_10 := [][]byte{[]byte("這是message0")}
+	message, _ := json.Marshal([]string{
+		base64.StdEncoding.EncodeToString(message0_10[0]),
+	})
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "group_createGroupMessage", "params": ["%v", %v, []]}`, string(marshaledID), string(message))
+
+	dataCreateMessage0_10 := &group.BackendCreateMessage{}
+	testCore(t0, bodyString, dataCreateMessage0_10, t, isDebug)
+	assert.Equal(dataCreateGroup0_5.ID, dataCreateMessage0_10.GroupID)
+	assert.Equal(1, dataCreateMessage0_10.NBlock)
+
+	message1_10 := [][]byte{[]byte("這是message1")}
+	message, _ = json.Marshal([]string{
+		base64.StdEncoding.EncodeToString(message1_10[0]),
+	})
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "group_createGroupMessage", "params": ["%v", %v, []]}`, string(marshaledID), string(message))
+
+	dataCreateMessage1_10 := &group.BackendCreateMessage{}
+	testCore(t1, bodyString, dataCreateMessage1_10, t, isDebug)
+	assert.Equal(dataCreateGroup0_5.ID, dataCreateMessage1_10.GroupID)
+	assert.Equal(1, dataCreateMessage1_10.NBlock)
+
+	// wait 35
+	t.Logf("wait 35 seconds for sync")
+	time.Sleep(35 * time.Second)
+
+	// 11. get-group-message-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "group_getGroupMessageList", "params": ["%v", "", 0, 2]}`, string(marshaledID))
+
+	dataGetMessageList0_11 := &struct {
+		Result []*group.BackendGetMessage `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetMessageList0_11, t, isDebug)
+	assert.Equal(2, len(dataGetMessageList0_11.Result))
+	message0_11_0 := dataGetMessageList0_11.Result[0]
+	message0_11_1 := dataGetMessageList0_11.Result[1]
+	assert.Equal(dataCreateMessage0_10.MessageID, message0_11_0.ID)
+	assert.Equal(me0_1.ID, message0_11_0.CreatorID)
+	assert.Equal(types.StatusAlive, message0_11_0.Status)
+	assert.Equal(dataCreateMessage1_10.MessageID, message0_11_1.ID)
+	assert.Equal(me1_1.ID, message0_11_1.CreatorID)
+	assert.Equal(types.StatusAlive, message0_11_1.Status)
+
+	dataGetMessageList1_11 := &struct {
+		Result []*group.BackendGetMessage `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetMessageList1_11, t, isDebug)
+	assert.Equal(dataGetMessageList0_11, dataGetMessageList1_11)
+
+	// 12. get-group-message-block-list
+	marshaledID2, _ = dataCreateMessage0_10.MessageID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "group_getGroupMessageBlockList", "params": ["%v", "%v", 0]}`, string(marshaledID), string(marshaledID2))
+
+	dataGetMessageBlockList1_12 := &struct {
+		Result []*group.BackendMessageBlock `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetMessageBlockList1_12, t, isDebug)
+	assert.Equal(1, len(dataGetMessageBlockList1_12.Result))
+	assert.Equal(message0_10, dataGetMessageBlockList1_12.Result[0].Buf)
+
+	marshaledID3, _ = dataCreateMessage1_10.MessageID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "group_getGroupMessageBlockList", "params": ["%v", "%v", 0]}`, string(marshaledID), string(marshaledID3))
+
+	dataGetMessageBlockList0_12 := &struct {
+		Result []*group.BackendMessageBlock `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetMessageBlockList0_12, t, isDebug)
+	assert.Equal(1, len(dataGetMessageBlockList0_12.Result))
+	assert.Equal(message1_10, dataGetMessageBlockList0_12.Result[0].Buf)
+
+	// 13. set-title
+	title0_13 := []byte("群組2")
+	marshaledStr = base64.StdEncoding.EncodeToString(title0_13)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "group_setTitle", "params": ["%v", "%v"]}`, string(marshaledID), marshaledStr)
+
+	group0_13 := &group.BackendGetGroup{}
+	testCore(t0, bodyString, group0_13, t, isDebug)
+	assert.Equal(title0_13, group0_13.Title)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 14. get-group
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "group_getGroup", "params": ["%v"]}`, string(marshaledID))
+
+	group1_14 := &group.BackendGetGroup{}
+	testCore(t1, bodyString, group1_14, t, isDebug)
+	assert.Equal(title0_13, group1_14.Title)
+}
@@ -34,6 +34,8 @@ import (
 )
 
 func TestFriendLeaveBoard5(t *testing.T) {
+	skipIfMemoryDB(t)
+
 	NNodes = 2
 	isDebug := true
 
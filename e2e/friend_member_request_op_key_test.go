@@ -32,6 +32,8 @@ import (
 )
 
 func TestFriendMemberRequestOpKey(t *testing.T) {
+	skipIfMemoryDB(t)
+
 	NNodes = 2
 	isDebug := true
 
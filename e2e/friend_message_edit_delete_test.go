@@ -0,0 +1,238 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/me"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/stretchr/testify/assert"
+	baloo "gopkg.in/h2non/baloo.v3"
+)
+
+func TestFriendMessageEditDelete(t *testing.T) {
+	NNodes = 2
+	isDebug := true
+
+	var bodyString string
+	var marshaledID []byte
+	var marshaledID2 []byte
+	var marshaledID3 []byte
+	var resultString string
+	assert := assert.New(t)
+
+	setupTest(t)
+	defer teardownTest(t)
+
+	t0 := baloo.New("http://127.0.0.1:9450")
+	t1 := baloo.New("http://127.0.0.1:9451")
+
+	// 1. get
+	bodyString = `{"id": "testID", "method": "me_get", "params": []}`
+
+	me0_1 := &me.BackendMyInfo{}
+	testCore(t0, bodyString, me0_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me0_1.Status)
+
+	me1_1 := &me.BackendMyInfo{}
+	testCore(t1, bodyString, me1_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me1_1.Status)
+
+	// 2. show-url
+	bodyString = `{"id": "testID", "method": "me_showURL", "params": []}`
+
+	dataShowURL1_2 := &pkgservice.BackendJoinURL{}
+	testCore(t1, bodyString, dataShowURL1_2, t, isDebug)
+	url1_2 := dataShowURL1_2.URL
+
+	// 3. join-friend
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinFriend", "params": ["%v"]}`, url1_2)
+
+	dataJoinFriend0_3 := &pkgservice.BackendJoinRequest{}
+	testCore(t0, bodyString, dataJoinFriend0_3, t, isDebug)
+	assert.Equal(me1_1.ID, dataJoinFriend0_3.CreatorID)
+	assert.Equal(me1_1.NodeID, dataJoinFriend0_3.NodeID)
+
+	// wait 20
+	t.Logf("wait 20 seconds for hand-shaking")
+	time.Sleep(20 * time.Second)
+
+	// 4. get-friend-list
+	bodyString = `{"id": "testID", "method": "friend_getFriendList", "params": ["", 0]}`
+
+	dataGetFriendList0_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetFriendList0_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList0_4.Result))
+	friend0_4 := dataGetFriendList0_4.Result[0]
+	assert.Equal(types.StatusAlive, friend0_4.Status)
+	assert.Equal(me1_1.ID, friend0_4.FriendID)
+
+	dataGetFriendList1_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetFriendList1_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList1_4.Result))
+	friend1_4 := dataGetFriendList1_4.Result[0]
+	assert.Equal(types.StatusAlive, friend1_4.Status)
+	assert.Equal(me0_1.ID, friend1_4.FriendID)
+	assert.Equal(friend0_4.ID, friend1_4.ID)
+
+	// 5. create-message
+	marshaledID, _ = friend0_4.ID.MarshalText()
+
+	msg, _ := json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("測試0")),
+	})
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_createMessage", "params": ["%v", %v, []]}`, string(marshaledID), string(msg))
+
+	dataCreateMessage0_5 := &friend.BackendCreateMessage{}
+	testCore(t0, bodyString, dataCreateMessage0_5, t, isDebug)
+	assert.Equal(friend0_4.ID, dataCreateMessage0_5.FriendID)
+
+	msg, _ = json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("測試1")),
+	})
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_createMessage", "params": ["%v", %v, []]}`, string(marshaledID), string(msg))
+
+	dataCreateMessage1_5 := &friend.BackendCreateMessage{}
+	testCore(t1, bodyString, dataCreateMessage1_5, t, isDebug)
+	assert.Equal(friend0_4.ID, dataCreateMessage1_5.FriendID)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 6. get-message-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getMessageList", "params": ["%v", "", 0, 2]}`, string(marshaledID))
+
+	dataGetMessageList0_6 := &struct {
+		Result []*friend.BackendGetMessage `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetMessageList0_6, t, isDebug)
+	assert.Equal(2, len(dataGetMessageList0_6.Result))
+	message0_6_0 := dataGetMessageList0_6.Result[0]
+	assert.Equal(dataCreateMessage0_5.MessageID, message0_6_0.ID)
+	assert.Equal(false, message0_6_0.IsEdited)
+	assert.Equal(types.ZeroTimestamp, message0_6_0.EditTS)
+	message0_6_1 := dataGetMessageList0_6.Result[1]
+	assert.Equal(dataCreateMessage1_5.MessageID, message0_6_1.ID)
+	assert.Equal(false, message0_6_1.IsEdited)
+
+	dataGetMessageList1_6 := &struct {
+		Result []*friend.BackendGetMessage `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetMessageList1_6, t, isDebug)
+	assert.Equal(2, len(dataGetMessageList1_6.Result))
+
+	// 7. update-message: not the creator.
+	marshaledID2, _ = dataCreateMessage0_5.MessageID.MarshalText()
+
+	msg, _ = json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("測試0-1")),
+	})
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_updateMessage", "params": ["%v", "%v", %v, []]}`, string(marshaledID), string(marshaledID2), string(msg))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"invalid id"}}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	// 8. update-message
+	dataUpdateMessage0_8 := &friend.BackendUpdateMessage{}
+	testCore(t0, bodyString, dataUpdateMessage0_8, t, isDebug)
+	assert.Equal(dataCreateMessage0_5.MessageID, dataUpdateMessage0_8.MessageID)
+	assert.Equal(1, dataUpdateMessage0_8.NBlock)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 9. get-message-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getMessageList", "params": ["%v", "", 0, 2]}`, string(marshaledID))
+
+	dataGetMessageList1_9 := &struct {
+		Result []*friend.BackendGetMessage `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetMessageList1_9, t, isDebug)
+	assert.Equal(2, len(dataGetMessageList1_9.Result))
+	message1_9_0 := dataGetMessageList1_9.Result[0]
+	assert.Equal(dataCreateMessage0_5.MessageID, message1_9_0.ID)
+	assert.Equal(types.StatusAlive, message1_9_0.Status)
+	assert.Equal(true, message1_9_0.IsEdited)
+	assert.Equal(message1_9_0.UpdateTS, message1_9_0.EditTS)
+	assert.Equal(true, message1_9_0.CreateTS.IsLess(message1_9_0.EditTS))
+	assert.Equal(dataUpdateMessage0_8.BlockID, message1_9_0.BlockID)
+
+	// 10. get-message-block-list
+	marshaledID3, _ = message1_9_0.BlockID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getMessageBlockList", "params": ["%v", "%v", "%v", 0, 0, 10]}`, string(marshaledID), string(marshaledID2), string(marshaledID3))
+
+	dataGetMessageBlockList1_10 := &struct {
+		Result []*friend.BackendMessageBlock `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetMessageBlockList1_10, t, isDebug)
+	assert.Equal(1, len(dataGetMessageBlockList1_10.Result))
+	assert.Equal([][]byte{[]byte("測試0-1")}, dataGetMessageBlockList1_10.Result[0].Buf)
+
+	// 11. delete-message: not the creator.
+	marshaledID2, _ = dataCreateMessage1_5.MessageID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_deleteMessage", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID2))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"invalid id"}}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	// 12. delete-message
+	dataDeleteMessage1_12 := &friend.BackendDeleteMessage{}
+	_, err := testCore(t1, bodyString, dataDeleteMessage1_12, t, isDebug)
+	assert.Equal(0, err.Code)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 13. get-message-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getMessageList", "params": ["%v", "", 0, 2]}`, string(marshaledID))
+
+	dataGetMessageList0_13 := &struct {
+		Result []*friend.BackendGetMessage `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetMessageList0_13, t, isDebug)
+	assert.Equal(2, len(dataGetMessageList0_13.Result))
+	assert.Equal(types.StatusAlive, dataGetMessageList0_13.Result[0].Status)
+	assert.Equal(true, dataGetMessageList0_13.Result[0].IsEdited)
+	assert.Equal(dataCreateMessage1_5.MessageID, dataGetMessageList0_13.Result[1].ID)
+	assert.Equal(types.StatusDeleted, dataGetMessageList0_13.Result[1].Status)
+
+	dataGetMessageList1_13 := &struct {
+		Result []*friend.BackendGetMessage `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetMessageList1_13, t, isDebug)
+	assert.Equal(2, len(dataGetMessageList1_13.Result))
+	assert.Equal(types.StatusDeleted, dataGetMessageList1_13.Result[1].Status)
+}
@@ -0,0 +1,219 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/me"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/stretchr/testify/assert"
+	baloo "gopkg.in/h2non/baloo.v3"
+)
+
+func TestFriendMessageReceipts(t *testing.T) {
+	NNodes = 2
+	isDebug := true
+
+	var bodyString string
+	var marshaledID []byte
+	assert := assert.New(t)
+
+	setupTest(t)
+	defer teardownTest(t)
+
+	t0 := baloo.New("http://127.0.0.1:9450")
+	t1 := baloo.New("http://127.0.0.1:9451")
+
+	// 1. get
+	bodyString = `{"id": "testID", "method": "me_get", "params": []}`
+
+	me0_1 := &me.BackendMyInfo{}
+	testCore(t0, bodyString, me0_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me0_1.Status)
+
+	me1_1 := &me.BackendMyInfo{}
+	testCore(t1, bodyString, me1_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me1_1.Status)
+
+	// 2. show-url
+	bodyString = `{"id": "testID", "method": "me_showURL", "params": []}`
+
+	dataShowURL1_2 := &pkgservice.BackendJoinURL{}
+	testCore(t1, bodyString, dataShowURL1_2, t, isDebug)
+	url1_2 := dataShowURL1_2.URL
+
+	// 3. join-friend
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinFriend", "params": ["%v"]}`, url1_2)
+
+	dataJoinFriend0_3 := &pkgservice.BackendJoinRequest{}
+	testCore(t0, bodyString, dataJoinFriend0_3, t, isDebug)
+	assert.Equal(me1_1.ID, dataJoinFriend0_3.CreatorID)
+	assert.Equal(me1_1.NodeID, dataJoinFriend0_3.NodeID)
+
+	// wait 20
+	t.Logf("wait 20 seconds for hand-shaking")
+	time.Sleep(20 * time.Second)
+
+	// 4. get-friend-list
+	bodyString = `{"id": "testID", "method": "friend_getFriendList", "params": ["", 0]}`
+
+	dataGetFriendList0_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetFriendList0_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList0_4.Result))
+	friend0_4 := dataGetFriendList0_4.Result[0]
+	assert.Equal(types.StatusAlive, friend0_4.Status)
+	assert.Equal(me1_1.ID, friend0_4.FriendID)
+
+	dataGetFriendList1_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetFriendList1_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList1_4.Result))
+	friend1_4 := dataGetFriendList1_4.Result[0]
+	assert.Equal(types.StatusAlive, friend1_4.Status)
+	assert.Equal(me0_1.ID, friend1_4.FriendID)
+	assert.Equal(friend0_4.ID, friend1_4.ID)
+
+	// 5. get-message-receipts
+	marshaledID, _ = friend0_4.ID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getMessageReceipts", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetMessageReceipts0_5 := &friend.BackendMessageReceipts{}
+	testCore(t0, bodyString, dataGetMessageReceipts0_5, t, isDebug)
+	assert.Equal(friend0_4.ID, dataGetMessageReceipts0_5.FriendID)
+	assert.Equal(types.ZeroTimestamp, dataGetMessageReceipts0_5.ReadTS)
+
+	// 6. create-message
+	msg, _ := json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("測試1")),
+	})
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_createMessage", "params": ["%v", %v, []]}`, string(marshaledID), string(msg))
+
+	dataCreateMessage0_6 := &friend.BackendCreateMessage{}
+	testCore(t0, bodyString, dataCreateMessage0_6, t, isDebug)
+	assert.Equal(friend0_4.ID, dataCreateMessage0_6.FriendID)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 7. get-message-list: delivered, not read.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getMessageList", "params": ["%v", "", 0, 2]}`, string(marshaledID))
+
+	dataGetMessageList1_7 := &struct {
+		Result []*friend.BackendGetMessage `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetMessageList1_7, t, isDebug)
+	assert.Equal(1, len(dataGetMessageList1_7.Result))
+	message1_7 := dataGetMessageList1_7.Result[0]
+	assert.Equal(dataCreateMessage0_6.MessageID, message1_7.ID)
+	assert.Equal(message1_7.CreateTS, message1_7.DeliveredTS)
+	assert.Equal(types.ZeroTimestamp, message1_7.ReadTS)
+
+	dataGetMessageList0_7 := &struct {
+		Result []*friend.BackendGetMessage `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetMessageList0_7, t, isDebug)
+	assert.Equal(1, len(dataGetMessageList0_7.Result))
+	message0_7 := dataGetMessageList0_7.Result[0]
+	assert.Equal(dataCreateMessage0_6.MessageID, message0_7.ID)
+	assert.Equal(message0_7.CreateTS, message0_7.DeliveredTS)
+	assert.Equal(types.ZeroTimestamp, message0_7.ReadTS)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getMessageReceipts", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetMessageReceipts0_7 := &friend.BackendMessageReceipts{}
+	testCore(t0, bodyString, dataGetMessageReceipts0_7, t, isDebug)
+	assert.Equal(message0_7.CreateTS, dataGetMessageReceipts0_7.DeliveredTS)
+	assert.Equal(types.ZeroTimestamp, dataGetMessageReceipts0_7.ReadTS)
+
+	// 8. mark-friend-seen
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_markFriendSeen", "params": ["%v"]}`, string(marshaledID))
+
+	ts1_8 := &types.Timestamp{}
+	testCore(t1, bodyString, ts1_8, t, isDebug)
+	assert.Equal(true, message1_7.CreateTS.IsLess(*ts1_8))
+
+	// wait 5
+	t.Logf("wait 5 seconds for the receipt")
+	time.Sleep(5 * time.Second)
+
+	// 9. get-message-list: read.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getMessageList", "params": ["%v", "", 0, 2]}`, string(marshaledID))
+
+	dataGetMessageList0_9 := &struct {
+		Result []*friend.BackendGetMessage `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetMessageList0_9, t, isDebug)
+	assert.Equal(1, len(dataGetMessageList0_9.Result))
+	message0_9 := dataGetMessageList0_9.Result[0]
+	assert.Equal(*ts1_8, message0_9.ReadTS)
+	assert.Equal(*ts1_8, message0_9.DeliveredTS)
+
+	dataGetMessageList1_9 := &struct {
+		Result []*friend.BackendGetMessage `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetMessageList1_9, t, isDebug)
+	assert.Equal(1, len(dataGetMessageList1_9.Result))
+	assert.Equal(*ts1_8, dataGetMessageList1_9.Result[0].ReadTS)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getMessageReceipts", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetMessageReceipts0_9 := &friend.BackendMessageReceipts{}
+	testCore(t0, bodyString, dataGetMessageReceipts0_9, t, isDebug)
+	assert.Equal(*ts1_8, dataGetMessageReceipts0_9.DeliveredTS)
+	assert.Equal(*ts1_8, dataGetMessageReceipts0_9.ReadTS)
+
+	// 10. create-message: not read yet.
+	msg, _ = json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("測試2")),
+	})
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_createMessage", "params": ["%v", %v, []]}`, string(marshaledID), string(msg))
+
+	dataCreateMessage0_10 := &friend.BackendCreateMessage{}
+	testCore(t0, bodyString, dataCreateMessage0_10, t, isDebug)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 11. get-message-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getMessageList", "params": ["%v", "", 0, 2]}`, string(marshaledID))
+
+	dataGetMessageList0_11 := &struct {
+		Result []*friend.BackendGetMessage `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetMessageList0_11, t, isDebug)
+	assert.Equal(2, len(dataGetMessageList0_11.Result))
+	assert.Equal(*ts1_8, dataGetMessageList0_11.Result[0].ReadTS)
+	message0_11_1 := dataGetMessageList0_11.Result[1]
+	assert.Equal(dataCreateMessage0_10.MessageID, message0_11_1.ID)
+	assert.Equal(message0_11_1.CreateTS, message0_11_1.DeliveredTS)
+	assert.Equal(types.ZeroTimestamp, message0_11_1.ReadTS)
+}
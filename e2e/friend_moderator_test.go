@@ -0,0 +1,297 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/me"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/stretchr/testify/assert"
+	baloo "gopkg.in/h2non/baloo.v3"
+)
+
+func TestFriendModerator(t *testing.T) {
+	NNodes = 2
+	isDebug := true
+
+	var bodyString string
+	var marshaledID []byte
+	var marshaledID2 []byte
+	var marshaledID3 []byte
+	var marshaledStr string
+	var resultString string
+	assert := assert.New(t)
+
+	setupTest(t)
+	defer teardownTest(t)
+
+	t0 := baloo.New("http://127.0.0.1:9450")
+	t1 := baloo.New("http://127.0.0.1:9451")
+
+	// 1. get
+	bodyString = `{"id": "testID", "method": "me_get", "params": []}`
+
+	me0_1 := &me.BackendMyInfo{}
+	testCore(t0, bodyString, me0_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me0_1.Status)
+
+	me1_1 := &me.BackendMyInfo{}
+	testCore(t1, bodyString, me1_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me1_1.Status)
+
+	// 2. show-url
+	bodyString = `{"id": "testID", "method": "me_showURL", "params": []}`
+
+	dataShowURL1_2 := &pkgservice.BackendJoinURL{}
+	testCore(t1, bodyString, dataShowURL1_2, t, isDebug)
+	url1_2 := dataShowURL1_2.URL
+
+	// 3. join-friend
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinFriend", "params": ["%v"]}`, url1_2)
+
+	dataJoinFriend0_3 := &pkgservice.BackendJoinRequest{}
+	testCore(t0, bodyString, dataJoinFriend0_3, t, isDebug)
+	assert.Equal(me1_1.ID, dataJoinFriend0_3.CreatorID)
+	assert.Equal(me1_1.NodeID, dataJoinFriend0_3.NodeID)
+
+	// wait 20
+	t.Logf("wait 20 seconds for hand-shaking")
+	time.Sleep(20 * time.Second)
+
+	// 4. get-friend-list
+	bodyString = `{"id": "testID", "method": "friend_getFriendList", "params": ["", 0]}`
+
+	dataGetFriendList0_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetFriendList0_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList0_4.Result))
+	friend0_4 := dataGetFriendList0_4.Result[0]
+	assert.Equal(types.StatusAlive, friend0_4.Status)
+	assert.Equal(me1_1.ID, friend0_4.FriendID)
+
+	dataGetFriendList1_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetFriendList1_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList1_4.Result))
+	friend1_4 := dataGetFriendList1_4.Result[0]
+	assert.Equal(types.StatusAlive, friend1_4.Status)
+	assert.Equal(me0_1.ID, friend1_4.FriendID)
+	assert.Equal(friend0_4.ID, friend1_4.ID)
+
+	// 5. create-board
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題1"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createBoard", "params": ["%v", true]}`, marshaledStr)
+
+	dataCreateBoard0_5 := &content.BackendCreateBoard{}
+	testCore(t0, bodyString, dataCreateBoard0_5, t, isDebug)
+	assert.Equal(types.StatusAlive, dataCreateBoard0_5.Status)
+	assert.Equal(me0_1.ID, dataCreateBoard0_5.CreatorID)
+
+	// 6. show-board-url
+	marshaledID, _ = dataCreateBoard0_5.ID.MarshalText()
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_showBoardURL", "params": ["%v"]}`, string(marshaledID))
+
+	dataShowBoardURL0_6 := &pkgservice.BackendJoinURL{}
+	testCore(t0, bodyString, dataShowBoardURL0_6, t, isDebug)
+	url0_6 := dataShowBoardURL0_6.URL
+
+	// 7. join-board
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinBoard", "params": ["%v"]}`, url0_6)
+
+	dataJoinBoard1_7 := &pkgservice.BackendJoinRequest{}
+	testCore(t1, bodyString, dataJoinBoard1_7, t, isDebug)
+
+	// wait 10
+	t.Logf("wait 10 seconds for joining the board")
+	time.Sleep(10 * time.Second)
+
+	// 8. get-board
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getBoard", "params": ["%v"]}`, string(marshaledID))
+
+	board1_8 := &content.BackendGetBoard{}
+	testCore(t1, bodyString, board1_8, t, isDebug)
+	assert.Equal(dataCreateBoard0_5.ID, board1_8.ID)
+	assert.Equal(types.StatusAlive, board1_8.Status)
+
+	// 9. create-article
+	article, _ := json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("測試1")),
+	})
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題2"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), marshaledStr, string(article))
+
+	dataCreateArticle0_9 := &content.BackendCreateArticle{}
+	testCore(t0, bodyString, dataCreateArticle0_9, t, isDebug)
+	assert.Equal(dataCreateBoard0_5.ID, dataCreateArticle0_9.BoardID)
+
+	// 10. create-comment
+	marshaledID2, _ = dataCreateArticle0_9.ArticleID.MarshalText()
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("這是comment"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createComment", "params": ["%v", "%v", 0, "%v", ""]}`, string(marshaledID), string(marshaledID2), marshaledStr)
+
+	dataCreateComment0_10 := &content.BackendCreateComment{}
+	testCore(t0, bodyString, dataCreateComment0_10, t, isDebug)
+	assert.Equal(dataCreateArticle0_9.ArticleID, dataCreateComment0_10.ArticleID)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 11. pin-article: not the moderator.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_pinArticle", "params": ["%v", "%v", 1]}`, string(marshaledID), string(marshaledID2))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"invalid id"}}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	// 12. add-moderator
+	marshaledUserID, _ := me1_1.ID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_addModerator", "params": ["%v", "%v", 16]}`, string(marshaledID), string(marshaledUserID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"invalid perms"}}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	perms := content.PermDeleteComment | content.PermPin
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_addModerator", "params": ["%v", "%v", %v]}`, string(marshaledID), string(marshaledUserID), perms)
+
+	dataAddModerator0_12 := &content.BackendModerator{}
+	testCore(t0, bodyString, dataAddModerator0_12, t, isDebug)
+	assert.Equal(me1_1.ID, dataAddModerator0_12.UserID)
+	assert.Equal(perms, dataAddModerator0_12.Perms)
+
+	// add-moderator: not the master.
+	_, err := testCore(t1, bodyString, &content.BackendModerator{}, t, isDebug)
+	assert.NotEqual(0, err.Code)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 13. get-moderator-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getModeratorList", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetModeratorList0_13 := &struct {
+		Result []*content.BackendModerator `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetModeratorList0_13, t, isDebug)
+	assert.Equal(1, len(dataGetModeratorList0_13.Result))
+	assert.Equal(me1_1.ID, dataGetModeratorList0_13.Result[0].UserID)
+	assert.Equal(perms, dataGetModeratorList0_13.Result[0].Perms)
+
+	dataGetModeratorList1_13 := &struct {
+		Result []*content.BackendModerator `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetModeratorList1_13, t, isDebug)
+	assert.Equal(dataGetModeratorList0_13, dataGetModeratorList1_13)
+
+	// 14. pin-article by the moderator
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_pinArticle", "params": ["%v", "%v", 1]}`, string(marshaledID), string(marshaledID2))
+
+	dataPin1_14 := &content.BackendPin{}
+	testCore(t1, bodyString, dataPin1_14, t, isDebug)
+	assert.Equal(dataCreateArticle0_9.ArticleID, dataPin1_14.ArticleID)
+	assert.Equal(me1_1.ID, dataPin1_14.CreatorID)
+
+	// 15. delete-comment by the moderator
+	marshaledID3, _ = dataCreateComment0_10.CommentID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_deleteComment", "params": ["%v", "%v", "%v"]}`, string(marshaledID), string(marshaledID2), string(marshaledID3))
+
+	dataDeleteComment1_15 := &content.BackendDeleteComment{}
+	_, err = testCore(t1, bodyString, dataDeleteComment1_15, t, isDebug)
+	assert.Equal(0, err.Code)
+
+	// 16. delete-article: no PermDeleteArticle.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_deleteArticle", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID2))
+
+	_, err = testCore(t1, bodyString, &content.BackendDeleteArticle{}, t, isDebug)
+	assert.NotEqual(0, err.Code)
+
+	// wait 35
+	t.Logf("wait 35 seconds for sync")
+	time.Sleep(35 * time.Second)
+
+	// 17. get-pinned-article-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getPinnedArticleList", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetPinnedArticleList0_17 := &struct {
+		Result []*content.BackendPinnedArticle `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetPinnedArticleList0_17, t, isDebug)
+	assert.Equal(1, len(dataGetPinnedArticleList0_17.Result))
+	assert.Equal(dataCreateArticle0_9.ArticleID, dataGetPinnedArticleList0_17.Result[0].ID)
+	assert.Equal(me1_1.ID, dataGetPinnedArticleList0_17.Result[0].PinnerID)
+
+	// 18. get-raw-comment
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getRawComment", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID3))
+
+	comment0_18 := &content.Comment{}
+	testCore(t0, bodyString, comment0_18, t, isDebug)
+	assert.Equal(types.StatusDeleted, comment0_18.Status)
+
+	comment1_18 := &content.Comment{}
+	testCore(t1, bodyString, comment1_18, t, isDebug)
+	assert.Equal(types.StatusDeleted, comment1_18.Status)
+
+	// 19. get-article-list: the article is alive.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleList", "params": ["%v", "", 0, 2]}`, string(marshaledID))
+
+	dataGetArticleList0_19 := &struct {
+		Result []*content.BackendGetArticle `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetArticleList0_19, t, isDebug)
+	assert.Equal(1, len(dataGetArticleList0_19.Result))
+	assert.Equal(types.StatusAlive, dataGetArticleList0_19.Result[0].Status)
+
+	// 20. remove-moderator
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_removeModerator", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledUserID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":true}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 21. get-moderator-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getModeratorList", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetModeratorList1_21 := &struct {
+		Result []*content.BackendModerator `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetModeratorList1_21, t, isDebug)
+	assert.Equal(0, len(dataGetModeratorList1_21.Result))
+
+	// 22. unpin-article: not the moderator anymore.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_unpinArticle", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID2))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"invalid id"}}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+}
@@ -32,6 +32,8 @@ import (
 )
 
 func TestFriendMsgFail(t *testing.T) {
+	skipIfMemoryDB(t)
+
 	NNodes = 2
 	isDebug := true
 	ServiceExpireOplog = "5"
@@ -32,6 +32,8 @@ import (
 )
 
 func TestFriendMsgRestart(t *testing.T) {
+	skipIfMemoryDB(t)
+
 	NNodes = 2
 	isDebug := true
 
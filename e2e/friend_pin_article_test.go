@@ -0,0 +1,290 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/me"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/stretchr/testify/assert"
+	baloo "gopkg.in/h2non/baloo.v3"
+)
+
+func TestFriendPinArticle(t *testing.T) {
+	NNodes = 2
+	isDebug := true
+
+	var bodyString string
+	var marshaledID []byte
+	var marshaledID2 []byte
+	var marshaledID3 []byte
+	var marshaledID4 []byte
+	var marshaledStr string
+	var resultString string
+	assert := assert.New(t)
+
+	setupTest(t)
+	defer teardownTest(t)
+
+	t0 := baloo.New("http://127.0.0.1:9450")
+	t1 := baloo.New("http://127.0.0.1:9451")
+
+	// 1. get
+	bodyString = `{"id": "testID", "method": "me_get", "params": []}`
+
+	me0_1 := &me.BackendMyInfo{}
+	testCore(t0, bodyString, me0_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me0_1.Status)
+
+	me1_1 := &me.BackendMyInfo{}
+	testCore(t1, bodyString, me1_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me1_1.Status)
+
+	// 2. show-url
+	bodyString = `{"id": "testID", "method": "me_showURL", "params": []}`
+
+	dataShowURL1_2 := &pkgservice.BackendJoinURL{}
+	testCore(t1, bodyString, dataShowURL1_2, t, isDebug)
+	url1_2 := dataShowURL1_2.URL
+
+	// 3. join-friend
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinFriend", "params": ["%v"]}`, url1_2)
+
+	dataJoinFriend0_3 := &pkgservice.BackendJoinRequest{}
+	testCore(t0, bodyString, dataJoinFriend0_3, t, isDebug)
+	assert.Equal(me1_1.ID, dataJoinFriend0_3.CreatorID)
+	assert.Equal(me1_1.NodeID, dataJoinFriend0_3.NodeID)
+
+	// wait 20
+	t.Logf("wait 20 seconds for hand-shaking")
+	time.Sleep(20 * time.Second)
+
+	// 4. get-friend-list
+	bodyString = `{"id": "testID", "method": "friend_getFriendList", "params": ["", 0]}`
+
+	dataGetFriendList0_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetFriendList0_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList0_4.Result))
+	friend0_4 := dataGetFriendList0_4.Result[0]
+	assert.Equal(types.StatusAlive, friend0_4.Status)
+	assert.Equal(me1_1.ID, friend0_4.FriendID)
+
+	dataGetFriendList1_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetFriendList1_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList1_4.Result))
+	friend1_4 := dataGetFriendList1_4.Result[0]
+	assert.Equal(types.StatusAlive, friend1_4.Status)
+	assert.Equal(me0_1.ID, friend1_4.FriendID)
+	assert.Equal(friend0_4.ID, friend1_4.ID)
+
+	// 5. create-board
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題1"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createBoard", "params": ["%v", true]}`, marshaledStr)
+
+	dataCreateBoard0_5 := &content.BackendCreateBoard{}
+	testCore(t0, bodyString, dataCreateBoard0_5, t, isDebug)
+	assert.Equal(types.StatusAlive, dataCreateBoard0_5.Status)
+	assert.Equal(me0_1.ID, dataCreateBoard0_5.CreatorID)
+
+	// 6. show-board-url
+	marshaledID, _ = dataCreateBoard0_5.ID.MarshalText()
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_showBoardURL", "params": ["%v"]}`, string(marshaledID))
+
+	dataShowBoardURL0_6 := &pkgservice.BackendJoinURL{}
+	testCore(t0, bodyString, dataShowBoardURL0_6, t, isDebug)
+	url0_6 := dataShowBoardURL0_6.URL
+
+	// 7. join-board
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinBoard", "params": ["%v"]}`, url0_6)
+
+	dataJoinBoard1_7 := &pkgservice.BackendJoinRequest{}
+	testCore(t1, bodyString, dataJoinBoard1_7, t, isDebug)
+
+	// wait 10
+	t.Logf("wait 10 seconds for joining the board")
+	time.Sleep(10 * time.Second)
+
+	// 8. get-board
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getBoard", "params": ["%v"]}`, string(marshaledID))
+
+	board1_8 := &content.BackendGetBoard{}
+	testCore(t1, bodyString, board1_8, t, isDebug)
+	assert.Equal(dataCreateBoard0_5.ID, board1_8.ID)
+	assert.Equal(types.StatusAlive, board1_8.Status)
+
+	// 9. create-article
+	article, _ := json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("測試1")),
+	})
+
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題1"))
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), marshaledStr, string(article))
+
+	dataCreateArticle0_9_0 := &content.BackendCreateArticle{}
+	testCore(t0, bodyString, dataCreateArticle0_9_0, t, isDebug)
+	marshaledID2, _ = dataCreateArticle0_9_0.ArticleID.MarshalText()
+
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題2"))
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), marshaledStr, string(article))
+
+	dataCreateArticle0_9_1 := &content.BackendCreateArticle{}
+	testCore(t0, bodyString, dataCreateArticle0_9_1, t, isDebug)
+	marshaledID3, _ = dataCreateArticle0_9_1.ArticleID.MarshalText()
+
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題3"))
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), marshaledStr, string(article))
+
+	dataCreateArticle0_9_2 := &content.BackendCreateArticle{}
+	testCore(t0, bodyString, dataCreateArticle0_9_2, t, isDebug)
+	marshaledID4, _ = dataCreateArticle0_9_2.ArticleID.MarshalText()
+
+	// 10. pin-article
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_pinArticle", "params": ["%v", "%v", 2]}`, string(marshaledID), string(marshaledID2))
+
+	dataPin0_10_0 := &content.BackendPin{}
+	testCore(t0, bodyString, dataPin0_10_0, t, isDebug)
+	assert.Equal(dataCreateArticle0_9_0.ArticleID, dataPin0_10_0.ArticleID)
+	assert.Equal(2, dataPin0_10_0.Order)
+
+	// pin-article again: idempotent
+	dataPin0_10_1 := &content.BackendPin{}
+	testCore(t0, bodyString, dataPin0_10_1, t, isDebug)
+	assert.Equal(dataPin0_10_0.ID, dataPin0_10_1.ID)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_pinArticle", "params": ["%v", "%v", 1]}`, string(marshaledID), string(marshaledID3))
+
+	dataPin0_10_2 := &content.BackendPin{}
+	testCore(t0, bodyString, dataPin0_10_2, t, isDebug)
+	assert.Equal(dataCreateArticle0_9_1.ArticleID, dataPin0_10_2.ArticleID)
+	assert.Equal(1, dataPin0_10_2.Order)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 11. get-pinned-article-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getPinnedArticleList", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetPinnedArticleList0_11 := &struct {
+		Result []*content.BackendPinnedArticle `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetPinnedArticleList0_11, t, isDebug)
+	assert.Equal(2, len(dataGetPinnedArticleList0_11.Result))
+	pinned0_11_0 := dataGetPinnedArticleList0_11.Result[0]
+	assert.Equal(dataCreateArticle0_9_1.ArticleID, pinned0_11_0.ID)
+	assert.Equal(dataPin0_10_2.ID, pinned0_11_0.PinID)
+	assert.Equal(1, pinned0_11_0.Order)
+	assert.Equal(me0_1.ID, pinned0_11_0.PinnerID)
+	assert.Equal([]byte("標題2"), pinned0_11_0.Title)
+	pinned0_11_1 := dataGetPinnedArticleList0_11.Result[1]
+	assert.Equal(dataCreateArticle0_9_0.ArticleID, pinned0_11_1.ID)
+	assert.Equal(2, pinned0_11_1.Order)
+
+	dataGetPinnedArticleList1_11 := &struct {
+		Result []*content.BackendPinnedArticle `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetPinnedArticleList1_11, t, isDebug)
+	assert.Equal(2, len(dataGetPinnedArticleList1_11.Result))
+	assert.Equal(dataCreateArticle0_9_1.ArticleID, dataGetPinnedArticleList1_11.Result[0].ID)
+	assert.Equal(dataCreateArticle0_9_0.ArticleID, dataGetPinnedArticleList1_11.Result[1].ID)
+
+	// 12. pin-article: re-order
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_pinArticle", "params": ["%v", "%v", 0]}`, string(marshaledID), string(marshaledID2))
+
+	dataPin0_12 := &content.BackendPin{}
+	testCore(t0, bodyString, dataPin0_12, t, isDebug)
+	assert.NotEqual(dataPin0_10_0.ID, dataPin0_12.ID)
+	assert.Equal(0, dataPin0_12.Order)
+
+	// 13. unpin-article: not pinned.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_unpinArticle", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID4))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"not found"}}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 14. get-pinned-article-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getPinnedArticleList", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetPinnedArticleList1_14 := &struct {
+		Result []*content.BackendPinnedArticle `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetPinnedArticleList1_14, t, isDebug)
+	assert.Equal(2, len(dataGetPinnedArticleList1_14.Result))
+	assert.Equal(dataCreateArticle0_9_0.ArticleID, dataGetPinnedArticleList1_14.Result[0].ID)
+	assert.Equal(dataPin0_12.ID, dataGetPinnedArticleList1_14.Result[0].PinID)
+	assert.Equal(0, dataGetPinnedArticleList1_14.Result[0].Order)
+	assert.Equal(dataCreateArticle0_9_1.ArticleID, dataGetPinnedArticleList1_14.Result[1].ID)
+
+	// 15. unpin-article
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_unpinArticle", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID3))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":true}`
+	testBodyEqualCore(t0, bodyString, resultString, t)
+
+	// 16. delete-article: the pinned article is not shown.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_deleteArticle", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID2))
+
+	dataDeleteArticle0_16 := &content.BackendDeleteArticle{}
+	_, err := testCore(t0, bodyString, dataDeleteArticle0_16, t, isDebug)
+	assert.Equal(0, err.Code)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 17. get-pinned-article-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getPinnedArticleList", "params": ["%v"]}`, string(marshaledID))
+
+	dataGetPinnedArticleList0_17 := &struct {
+		Result []*content.BackendPinnedArticle `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetPinnedArticleList0_17, t, isDebug)
+	assert.Equal(0, len(dataGetPinnedArticleList0_17.Result))
+
+	dataGetPinnedArticleList1_17 := &struct {
+		Result []*content.BackendPinnedArticle `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetPinnedArticleList1_17, t, isDebug)
+	assert.Equal(0, len(dataGetPinnedArticleList1_17.Result))
+
+	// 18. get-article-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getArticleList", "params": ["%v", "", 0, 2]}`, string(marshaledID))
+
+	dataGetArticleList1_18 := &struct {
+		Result []*content.BackendGetArticle `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetArticleList1_18, t, isDebug)
+	assert.Equal(3, len(dataGetArticleList1_18.Result))
+	assert.Equal(types.StatusDeleted, dataGetArticleList1_18.Result[0].Status)
+}
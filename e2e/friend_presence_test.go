@@ -0,0 +1,195 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/me"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/stretchr/testify/assert"
+	baloo "gopkg.in/h2non/baloo.v3"
+)
+
+func TestFriendPresence(t *testing.T) {
+	NNodes = 2
+	isDebug := true
+
+	var bodyString string
+	var marshaledID []byte
+	var resultString string
+	assert := assert.New(t)
+
+	setupTest(t)
+	defer teardownTest(t)
+
+	t0 := baloo.New("http://127.0.0.1:9450")
+	t1 := baloo.New("http://127.0.0.1:9451")
+
+	// 1. get
+	bodyString = `{"id": "testID", "method": "me_get", "params": []}`
+
+	me0_1 := &me.BackendMyInfo{}
+	testCore(t0, bodyString, me0_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me0_1.Status)
+
+	me1_1 := &me.BackendMyInfo{}
+	testCore(t1, bodyString, me1_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me1_1.Status)
+
+	// 2. show-url
+	bodyString = `{"id": "testID", "method": "me_showURL", "params": []}`
+
+	dataShowURL1_2 := &pkgservice.BackendJoinURL{}
+	testCore(t1, bodyString, dataShowURL1_2, t, isDebug)
+	url1_2 := dataShowURL1_2.URL
+
+	// 3. join-friend
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinFriend", "params": ["%v"]}`, url1_2)
+
+	dataJoinFriend0_3 := &pkgservice.BackendJoinRequest{}
+	testCore(t0, bodyString, dataJoinFriend0_3, t, isDebug)
+	assert.Equal(me1_1.ID, dataJoinFriend0_3.CreatorID)
+	assert.Equal(me1_1.NodeID, dataJoinFriend0_3.NodeID)
+
+	// wait 20
+	t.Logf("wait 20 seconds for hand-shaking")
+	time.Sleep(20 * time.Second)
+
+	// 4. get-friend-list
+	bodyString = `{"id": "testID", "method": "friend_getFriendList", "params": ["", 0]}`
+
+	dataGetFriendList0_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetFriendList0_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList0_4.Result))
+	friend0_4 := dataGetFriendList0_4.Result[0]
+	assert.Equal(types.StatusAlive, friend0_4.Status)
+	assert.Equal(me1_1.ID, friend0_4.FriendID)
+
+	dataGetFriendList1_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetFriendList1_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList1_4.Result))
+	friend1_4 := dataGetFriendList1_4.Result[0]
+	assert.Equal(types.StatusAlive, friend1_4.Status)
+	assert.Equal(me0_1.ID, friend1_4.FriendID)
+	assert.Equal(friend0_4.ID, friend1_4.ID)
+
+	// 5. get-presence
+	marshaledID, _ = friend0_4.ID.MarshalText()
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getPresence", "params": ["%v"]}`, string(marshaledID))
+
+	presence0_5 := &friend.BackendPresence{}
+	testCore(t0, bodyString, presence0_5, t, isDebug)
+	assert.Equal(friend0_4.ID, presence0_5.FriendID)
+	assert.Equal(friend.PresenceStatusOnline, presence0_5.Status)
+	assert.Equal(false, presence0_5.IsTyping)
+
+	presence1_5 := &friend.BackendPresence{}
+	testCore(t1, bodyString, presence1_5, t, isDebug)
+	assert.Equal(friend.PresenceStatusOnline, presence1_5.Status)
+	assert.Equal(false, presence1_5.IsTyping)
+
+	// 6. set-presence
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_setPresence", "params": [%v]}`, friend.PresenceStatusAway)
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":true}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	// wait 3
+	t.Logf("wait 3 seconds for the presence")
+	time.Sleep(3 * time.Second)
+
+	// 7. get-presence
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getPresence", "params": ["%v"]}`, string(marshaledID))
+
+	presence0_7 := &friend.BackendPresence{}
+	testCore(t0, bodyString, presence0_7, t, isDebug)
+	assert.Equal(friend.PresenceStatusAway, presence0_7.Status)
+	assert.Equal(false, presence0_7.IsTyping)
+
+	// 8. set-typing
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_setTyping", "params": ["%v"]}`, string(marshaledID))
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":true}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	// set-typing again: dropped by rate-limit.
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":false}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	// wait 3
+	t.Logf("wait 3 seconds for the presence")
+	time.Sleep(3 * time.Second)
+
+	// 9. get-presence
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getPresence", "params": ["%v"]}`, string(marshaledID))
+
+	presence0_9 := &friend.BackendPresence{}
+	testCore(t0, bodyString, presence0_9, t, isDebug)
+	assert.Equal(friend.PresenceStatusAway, presence0_9.Status)
+	assert.Equal(true, presence0_9.IsTyping)
+
+	presence1_9 := &friend.BackendPresence{}
+	testCore(t1, bodyString, presence1_9, t, isDebug)
+	assert.Equal(friend.PresenceStatusOnline, presence1_9.Status)
+	assert.Equal(false, presence1_9.IsTyping)
+
+	// 10. set-presence: offline is not settable.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_setPresence", "params": [%v]}`, friend.PresenceStatusOffline)
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","error":{"code":-32000,"message":"invalid data"}}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	// wait 12
+	t.Logf("wait 12 seconds for typing expired")
+	time.Sleep(12 * time.Second)
+
+	// 11. get-presence
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getPresence", "params": ["%v"]}`, string(marshaledID))
+
+	presence0_11 := &friend.BackendPresence{}
+	testCore(t0, bodyString, presence0_11, t, isDebug)
+	assert.Equal(friend.PresenceStatusAway, presence0_11.Status)
+	assert.Equal(false, presence0_11.IsTyping)
+
+	// 12. set-presence
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_setPresence", "params": [%v]}`, friend.PresenceStatusOnline)
+
+	resultString = `{"jsonrpc":"2.0","id":"testID","result":true}`
+	testBodyEqualCore(t1, bodyString, resultString, t)
+
+	// wait 3
+	t.Logf("wait 3 seconds for the presence")
+	time.Sleep(3 * time.Second)
+
+	// 13. get-presence
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "friend_getPresence", "params": ["%v"]}`, string(marshaledID))
+
+	presence0_13 := &friend.BackendPresence{}
+	testCore(t0, bodyString, presence0_13, t, isDebug)
+	assert.Equal(friend.PresenceStatusOnline, presence0_13.Status)
+	assert.Equal(false, presence0_13.IsTyping)
+}
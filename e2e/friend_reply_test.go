@@ -0,0 +1,275 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package e2e
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/me"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/stretchr/testify/assert"
+	baloo "gopkg.in/h2non/baloo.v3"
+)
+
+func TestFriendReply(t *testing.T) {
+	NNodes = 2
+	isDebug := true
+
+	var bodyString string
+	var marshaledID []byte
+	var marshaledID2 []byte
+	var marshaledID3 []byte
+	var marshaledID4 []byte
+	var marshaledStr string
+	assert := assert.New(t)
+
+	setupTest(t)
+	defer teardownTest(t)
+
+	t0 := baloo.New("http://127.0.0.1:9450")
+	t1 := baloo.New("http://127.0.0.1:9451")
+
+	// 1. get
+	bodyString = `{"id": "testID", "method": "me_get", "params": []}`
+
+	me0_1 := &me.BackendMyInfo{}
+	testCore(t0, bodyString, me0_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me0_1.Status)
+
+	me1_1 := &me.BackendMyInfo{}
+	testCore(t1, bodyString, me1_1, t, isDebug)
+	assert.Equal(types.StatusAlive, me1_1.Status)
+
+	// 2. show-url
+	bodyString = `{"id": "testID", "method": "me_showURL", "params": []}`
+
+	dataShowURL1_2 := &pkgservice.BackendJoinURL{}
+	testCore(t1, bodyString, dataShowURL1_2, t, isDebug)
+	url1_2 := dataShowURL1_2.URL
+
+	// 3. join-friend
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinFriend", "params": ["%v"]}`, url1_2)
+
+	dataJoinFriend0_3 := &pkgservice.BackendJoinRequest{}
+	testCore(t0, bodyString, dataJoinFriend0_3, t, isDebug)
+	assert.Equal(me1_1.ID, dataJoinFriend0_3.CreatorID)
+	assert.Equal(me1_1.NodeID, dataJoinFriend0_3.NodeID)
+
+	// wait 20
+	t.Logf("wait 20 seconds for hand-shaking")
+	time.Sleep(20 * time.Second)
+
+	// 4. get-friend-list
+	bodyString = `{"id": "testID", "method": "friend_getFriendList", "params": ["", 0]}`
+
+	dataGetFriendList0_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetFriendList0_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList0_4.Result))
+	friend0_4 := dataGetFriendList0_4.Result[0]
+	assert.Equal(types.StatusAlive, friend0_4.Status)
+	assert.Equal(me1_1.ID, friend0_4.FriendID)
+
+	dataGetFriendList1_4 := &struct {
+		Result []*friend.BackendGetFriend `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetFriendList1_4, t, isDebug)
+	assert.Equal(1, len(dataGetFriendList1_4.Result))
+	friend1_4 := dataGetFriendList1_4.Result[0]
+	assert.Equal(types.StatusAlive, friend1_4.Status)
+	assert.Equal(me0_1.ID, friend1_4.FriendID)
+	assert.Equal(friend0_4.ID, friend1_4.ID)
+
+	// 5. create-board
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題1"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createBoard", "params": ["%v", true]}`, marshaledStr)
+
+	dataCreateBoard0_5 := &content.BackendCreateBoard{}
+	testCore(t0, bodyString, dataCreateBoard0_5, t, isDebug)
+	assert.Equal(types.StatusAlive, dataCreateBoard0_5.Status)
+	assert.Equal(me0_1.ID, dataCreateBoard0_5.CreatorID)
+
+	// 6. show-board-url
+	marshaledID, _ = dataCreateBoard0_5.ID.MarshalText()
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_showBoardURL", "params": ["%v"]}`, string(marshaledID))
+
+	dataShowBoardURL0_6 := &pkgservice.BackendJoinURL{}
+	testCore(t0, bodyString, dataShowBoardURL0_6, t, isDebug)
+	url0_6 := dataShowBoardURL0_6.URL
+
+	// 7. join-board
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "me_joinBoard", "params": ["%v"]}`, url0_6)
+
+	dataJoinBoard1_7 := &pkgservice.BackendJoinRequest{}
+	testCore(t1, bodyString, dataJoinBoard1_7, t, isDebug)
+
+	// wait 10
+	t.Logf("wait 10 seconds for joining the board")
+	time.Sleep(10 * time.Second)
+
+	// 8. get-board
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getBoard", "params": ["%v"]}`, string(marshaledID))
+
+	board1_8 := &content.BackendGetBoard{}
+	testCore(t1, bodyString, board1_8, t, isDebug)
+	assert.Equal(dataCreateBoard0_5.ID, board1_8.ID)
+	assert.Equal(types.StatusAlive, board1_8.Status)
+
+	// 9. create-article
+	article, _ := json.Marshal([]string{
+		base64.StdEncoding.EncodeToString([]byte("測試1")),
+	})
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("標題2"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createArticle", "params": ["%v", "%v", %v, []]}`, string(marshaledID), marshaledStr, string(article))
+
+	dataCreateArticle0_9 := &content.BackendCreateArticle{}
+	testCore(t0, bodyString, dataCreateArticle0_9, t, isDebug)
+	assert.Equal(dataCreateBoard0_5.ID, dataCreateArticle0_9.BoardID)
+
+	// 10. create-comment
+	marshaledID2, _ = dataCreateArticle0_9.ArticleID.MarshalText()
+	marshaledStr = base64.StdEncoding.EncodeToString([]byte("這是comment"))
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createComment", "params": ["%v", "%v", 0, "%v", ""]}`, string(marshaledID), string(marshaledID2), marshaledStr)
+
+	dataCreateComment0_10 := &content.BackendCreateComment{}
+	testCore(t0, bodyString, dataCreateComment0_10, t, isDebug)
+	assert.Equal(dataCreateArticle0_9.ArticleID, dataCreateComment0_10.ArticleID)
+
+	// wait 10
+	t.Logf("wait 10 seconds for sync")
+	time.Sleep(10 * time.Second)
+
+	// 11. create-reply to the comment
+	marshaledID3, _ = dataCreateComment0_10.CommentID.MarshalText()
+	reply1_11 := [][]byte{[]byte("這是reply1")}
+	reply, _ := json.Marshal([]string{
+		base64.StdEncoding.EncodeToString(reply1_11[0]),
+	})
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createReply", "params": ["%v", "%v", "%v", "", %v, ""]}`, string(marshaledID), string(marshaledID2), string(marshaledID3), string(reply))
+
+	dataCreateReply1_11 := &content.BackendCreateReply{}
+	testCore(t1, bodyString, dataCreateReply1_11, t, isDebug)
+	assert.Equal(dataCreateComment0_10.CommentID, dataCreateReply1_11.CommentID)
+
+	// 12. create-reply to the reply
+	marshaledID4, _ = dataCreateReply1_11.ReplyID.MarshalText()
+	reply1_12 := [][]byte{[]byte("這是reply2")}
+	reply, _ = json.Marshal([]string{
+		base64.StdEncoding.EncodeToString(reply1_12[0]),
+	})
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_createReply", "params": ["%v", "%v", "%v", "%v", %v, ""]}`, string(marshaledID), string(marshaledID2), string(marshaledID3), string(marshaledID4), string(reply))
+
+	dataCreateReply1_12 := &content.BackendCreateReply{}
+	testCore(t1, bodyString, dataCreateReply1_12, t, isDebug)
+	assert.Equal(dataCreateComment0_10.CommentID, dataCreateReply1_12.CommentID)
+
+	// wait 35
+	t.Logf("wait 35 seconds for sync")
+	time.Sleep(35 * time.Second)
+
+	// 13. get-reply-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getReplyList", "params": ["%v", "%v", "%v", "", 0, 2]}`, string(marshaledID), string(marshaledID2), string(marshaledID3))
+
+	dataGetReplyList0_13 := &struct {
+		Result []*content.BackendGetReply `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetReplyList0_13, t, isDebug)
+	assert.Equal(2, len(dataGetReplyList0_13.Result))
+	reply0_13_0 := dataGetReplyList0_13.Result[0]
+	reply0_13_1 := dataGetReplyList0_13.Result[1]
+	assert.Equal(dataCreateReply1_11.ReplyID, reply0_13_0.ID)
+	assert.Equal(me1_1.ID, reply0_13_0.CreatorID)
+	assert.Equal(dataCreateComment0_10.CommentID, reply0_13_0.ParentID)
+	assert.Equal(uint8(1), reply0_13_0.Depth)
+	assert.Equal(reply1_11, reply0_13_0.Buf)
+	assert.Equal(types.StatusAlive, reply0_13_0.Status)
+	assert.Equal(dataCreateReply1_12.ReplyID, reply0_13_1.ID)
+	assert.Equal(dataCreateReply1_11.ReplyID, reply0_13_1.ParentID)
+	assert.Equal(uint8(2), reply0_13_1.Depth)
+	assert.Equal(reply1_12, reply0_13_1.Buf)
+	assert.Equal(types.StatusAlive, reply0_13_1.Status)
+
+	dataGetReplyList1_13 := &struct {
+		Result []*content.BackendGetReply `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetReplyList1_13, t, isDebug)
+	assert.Equal(dataGetReplyList0_13, dataGetReplyList1_13)
+
+	// 14. get-raw-reply: the parent-creator is from the local parent.
+	marshaledStr2, _ := dataCreateReply1_12.ReplyID.MarshalText()
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getRawReply", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledStr2))
+
+	reply0_14 := &content.Reply{}
+	testCore(t0, bodyString, reply0_14, t, isDebug)
+	assert.Equal(dataCreateReply1_11.ReplyID, reply0_14.ParentID)
+	assert.Equal(me1_1.ID, reply0_14.ParentCreatorID)
+
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getRawReply", "params": ["%v", "%v"]}`, string(marshaledID), string(marshaledID4))
+
+	reply0_14_1 := &content.Reply{}
+	testCore(t0, bodyString, reply0_14_1, t, isDebug)
+	assert.Equal(dataCreateComment0_10.CommentID, reply0_14_1.ParentID)
+	assert.Equal(me0_1.ID, reply0_14_1.ParentCreatorID)
+
+	// 15. delete-reply: the comment-id is not matched.
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_deleteReply", "params": ["%v", "%v", "%v", "%v"]}`, string(marshaledID), string(marshaledID2), string(marshaledID2), string(marshaledStr2))
+
+	dataDeleteReply1_15 := &content.BackendDeleteReply{}
+	_, err := testCore(t1, bodyString, dataDeleteReply1_15, t, isDebug)
+	assert.Equal("invalid id", err.Msg)
+
+	// 16. delete-reply
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_deleteReply", "params": ["%v", "%v", "%v", "%v"]}`, string(marshaledID), string(marshaledID2), string(marshaledID3), string(marshaledStr2))
+
+	dataDeleteReply1_16 := &content.BackendDeleteReply{}
+	_, err = testCore(t1, bodyString, dataDeleteReply1_16, t, isDebug)
+	assert.Equal(0, err.Code)
+
+	// wait 35
+	t.Logf("wait 35 seconds for sync")
+	time.Sleep(35 * time.Second)
+
+	// 17. get-reply-list
+	bodyString = fmt.Sprintf(`{"id": "testID", "method": "content_getReplyList", "params": ["%v", "%v", "%v", "", 0, 2]}`, string(marshaledID), string(marshaledID2), string(marshaledID3))
+
+	dataGetReplyList0_17 := &struct {
+		Result []*content.BackendGetReply `json:"result"`
+	}{}
+	testListCore(t0, bodyString, dataGetReplyList0_17, t, isDebug)
+	assert.Equal(2, len(dataGetReplyList0_17.Result))
+	assert.Equal(types.StatusAlive, dataGetReplyList0_17.Result[0].Status)
+	assert.Equal(types.StatusDeleted, dataGetReplyList0_17.Result[1].Status)
+
+	dataGetReplyList1_17 := &struct {
+		Result []*content.BackendGetReply `json:"result"`
+	}{}
+	testListCore(t1, bodyString, dataGetReplyList1_17, t, isDebug)
+	assert.Equal(dataGetReplyList0_17, dataGetReplyList1_17)
+}
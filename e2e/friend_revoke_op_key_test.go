@@ -33,6 +33,8 @@ import (
 )
 
 func TestFriendRevokeOpKey(t *testing.T) {
+	skipIfMemoryDB(t)
+
 	NNodes = 2
 	isDebug := true
 
@@ -33,6 +33,7 @@ import (
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/content"
 	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 	signalserver "github.com/ailabstw/pttai-signal-server"
 	"github.com/gorilla/mux"
@@ -53,6 +54,8 @@ var (
 	NNodes         = 5
 	TimeoutSeconds = 240 * time.Second
 
+	gpttBin = "../build/bin/gptt"
+
 	origHandler log.Handler
 
 	nilPttID           *types.PttID
@@ -127,9 +130,7 @@ func startNode(t *testing.T, idx int, offsetSecond int64, isNewLog bool) {
 	httpaddr := fmt.Sprintf("127.0.0.1:%d", 9700+idx)
 
 	Ctxs[idx], Cancels[idx] = context.WithTimeout(context.Background(), TimeoutSeconds)
-	Nodes[idx] = exec.CommandContext(
-		Ctxs[idx],
-		"../build/bin/gptt",
+	args := []string{
 		"--exthttpaddr", "http://localhost:9776",
 		"--verbosity", "4",
 		"--datadir", dir,
@@ -145,6 +146,16 @@ func startNode(t *testing.T, idx int, offsetSecond int64, isNewLog bool) {
 		"--serviceexpireoplog", ServiceExpireOplog,
 		"--offset-second", strconv.FormatInt(offsetSecond, 10),
 		"--e2e",
+	}
+	// PTT_E2E_DBENGINE=memory runs the nodes against RAM.
+	// The data does not survive restarting the nodes.
+	if dbEngine := os.Getenv("PTT_E2E_DBENGINE"); dbEngine != "" {
+		args = append(args, "--dbengine", dbEngine)
+	}
+	Nodes[idx] = exec.CommandContext(
+		Ctxs[idx],
+		gpttBin,
+		args...,
 	)
 	filename := fmt.Sprintf("./test.out/log.err.%d.txt", idx)
 	var err error
@@ -169,7 +180,22 @@ func startNode(t *testing.T, idx int, offsetSecond int64, isNewLog bool) {
 
 }
 
+/*
+skipIfMemoryDB skips the scenarios restarting the nodes (or reading the datadir from another process)
+with PTT_E2E_DBENGINE=memory, because the data does not survive restarting the processes.
+*/
+func skipIfMemoryDB(t *testing.T) {
+	if os.Getenv("PTT_E2E_DBENGINE") == pttdb.StoreTypeMemory {
+		t.Skip("skip: the data does not survive restarting the nodes with PTT_E2E_DBENGINE=memory")
+	}
+}
+
 func setupTest(t *testing.T) {
+	// the binary is not committed, and is built by "make gptt" (or "make e2e").
+	if _, err := os.Stat(gpttBin); err != nil {
+		t.Fatalf("unable to find %v (run \"make gptt\" first): e: %v", gpttBin, err)
+	}
+
 	content.InitLocaleInfo()
 
 	os.RemoveAll("./test.out")
@@ -31,6 +31,8 @@ import (
 )
 
 func TestMeExpireOpKey(t *testing.T) {
+	skipIfMemoryDB(t)
+
 	NNodes = 1
 	isDebug := true
 
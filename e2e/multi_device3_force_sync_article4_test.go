@@ -35,6 +35,8 @@ import (
 )
 
 func TestMultiDevice3ForceSyncArticle4(t *testing.T) {
+	skipIfMemoryDB(t)
+
 	NNodes = 3
 	isDebug := true
 
@@ -17,8 +17,11 @@
 package friend
 
 import (
+	"context"
+
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/ailabstw/go-pttai/rpc"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
@@ -42,6 +45,19 @@ func (api *PrivateAPI) CreateMessage(entityID string, message [][]byte, mediaIDs
 	)
 }
 
+func (api *PrivateAPI) UpdateMessage(entityID string, messageID string, message [][]byte, mediaIDs []string) (*BackendUpdateMessage, error) {
+	return api.b.UpdateMessage(
+		[]byte(entityID),
+		[]byte(messageID),
+		message,
+		mediaIDs,
+	)
+}
+
+func (api *PrivateAPI) DeleteMessage(entityID string, messageID string) (*BackendDeleteMessage, error) {
+	return api.b.DeleteMessage([]byte(entityID), []byte(messageID))
+}
+
 func (api *PrivateAPI) DeleteFriend(entityID string) (bool, error) {
 	return api.b.DeleteFriend([]byte(entityID))
 }
@@ -104,10 +120,48 @@ func (api *PrivateAPI) GetMessageList(entityID string, startingMessageID string,
 	)
 }
 
+func (api *PrivateAPI) GetMessageReceipts(entityID string) (*BackendMessageReceipts, error) {
+	return api.b.GetMessageReceipts([]byte(entityID))
+}
+
+/**********
+ * Presence
+ **********/
+
+func (api *PrivateAPI) SetPresence(status PresenceStatus) (bool, error) {
+	return api.b.SetPresence(status)
+}
+
+func (api *PrivateAPI) SetTyping(entityID string) (bool, error) {
+	return api.b.SetTyping([]byte(entityID))
+}
+
+func (api *PrivateAPI) GetPresence(entityID string) (*BackendPresence, error) {
+	return api.b.GetPresence([]byte(entityID))
+}
+
+/*
+SubscribePresence notifies the presence and typing of the friend (friend_subscribe with "subscribePresence").
+*/
+func (api *PrivateAPI) SubscribePresence(ctx context.Context, entityID string) (*rpc.Subscription, error) {
+	return api.b.SubscribePresence(ctx, []byte(entityID))
+}
+
+func (api *PrivateAPI) SearchMessages(entityID string, query string, limit int) ([]*BackendGetMessage, error) {
+	return api.b.SearchMessages([]byte(entityID), query, limit)
+}
+
 func (api *PrivateAPI) GetMessageBlockList(entityID string, messageID string, dummy0 string, dummy1 pkgservice.ContentType, dummy2 uint32, limit uint32) ([]*BackendMessageBlock, error) {
 	return api.b.GetMessageBlockList([]byte(entityID), []byte(messageID), limit)
 }
 
+/*
+SubscribeMessages notifies the newly-created messages of the friend (friend_subscribe with "subscribeMessages").
+*/
+func (api *PrivateAPI) SubscribeMessages(ctx context.Context, entityID string) (*rpc.Subscription, error) {
+	return api.b.SubscribeMessages(ctx, []byte(entityID))
+}
+
 /**********
  * FriendOplog
  **********/
@@ -17,11 +17,14 @@
 package friend
 
 import (
+	"sync"
+
 	"github.com/ailabstw/go-pttai/account"
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/content"
 	"github.com/ailabstw/go-pttai/rpc"
 	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/ethereum/go-ethereum/event"
 )
 
 type Backend struct {
@@ -29,6 +32,12 @@ type Backend struct {
 
 	accountBackend *account.Backend
 	contentBackend *content.Backend
+
+	// presence
+	lockMyPresence   sync.RWMutex
+	myPresenceStatus PresenceStatus
+
+	presenceFeed event.Feed
 }
 
 func NewBackend(ctx *pkgservice.ServiceContext, cfg *Config, id *types.PttID, ptt pkgservice.Ptt, accountBackend *account.Backend, contentBackend *content.Backend) (*Backend, error) {
@@ -42,6 +51,8 @@ func NewBackend(ctx *pkgservice.ServiceContext, cfg *Config, id *types.PttID, pt
 	backend := &Backend{
 		accountBackend: accountBackend,
 		contentBackend: contentBackend,
+
+		myPresenceStatus: PresenceStatusOnline,
 	}
 
 	// spm
@@ -87,3 +98,18 @@ func (b *Backend) APIs() []rpc.API {
 func (b *Backend) Name() string {
 	return "friend"
 }
+
+func (b *Backend) MyPresenceStatus() PresenceStatus {
+	b.lockMyPresence.RLock()
+	defer b.lockMyPresence.RUnlock()
+
+	return b.myPresenceStatus
+}
+
+func (b *Backend) PostPresenceEvent(ev *PresenceEvent) int {
+	return b.presenceFeed.Send(ev)
+}
+
+func (b *Backend) SubscribePresenceEvent(ch chan<- *PresenceEvent) event.Subscription {
+	return b.presenceFeed.Subscribe(ch)
+}
@@ -17,10 +17,14 @@
 package friend
 
 import (
+	"context"
+	"reflect"
+
 	"github.com/ailabstw/go-pttai/account"
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/ailabstw/go-pttai/rpc"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
@@ -246,6 +250,62 @@ func (b *Backend) CreateMessage(entityIDBytes []byte, message [][]byte, mediaIDS
 	return messageToBackendCreateMessage(theMessage), nil
 }
 
+func (b *Backend) UpdateMessage(entityIDBytes []byte, messageIDBytes []byte, message [][]byte, mediaIDStrs []string) (*BackendUpdateMessage, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	messageID, err := types.UnmarshalTextPttID(messageIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	lenMediaIDs := len(mediaIDStrs)
+	var mediaIDs []*types.PttID = nil
+	var eachMediaID *types.PttID
+	if len(mediaIDStrs) != 0 {
+		mediaIDs = make([]*types.PttID, lenMediaIDs)
+		for i, mediaIDStr := range mediaIDStrs {
+			eachMediaID, err = types.UnmarshalTextPttID([]byte(mediaIDStr), false)
+			if err != nil {
+				return nil, err
+			}
+			mediaIDs[i] = eachMediaID
+		}
+	}
+
+	theMessage, err := pm.UpdateMessage(messageID, message, mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return messageToBackendUpdateMessage(theMessage), nil
+}
+
+func (b *Backend) DeleteMessage(entityIDBytes []byte, messageIDBytes []byte) (*BackendDeleteMessage, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	messageID, err := types.UnmarshalTextPttID(messageIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pm.DeleteMessage(messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendDeleteMessage{}, nil
+}
+
 func (b *Backend) GetMessageList(entityIDBytes []byte, startIDBytes []byte, limit int, listOrder pttdb.ListOrder) ([]*BackendGetMessage, error) {
 
 	thePM, err := b.EntityIDToPM(entityIDBytes)
@@ -261,9 +321,42 @@ func (b *Backend) GetMessageList(entityIDBytes []byte, startIDBytes []byte, limi
 
 	messageList, err := pm.GetMessageList(startID, limit, listOrder, true)
 
+	f, err := pm.GetMessageReceipts()
+	if err != nil {
+		return nil, err
+	}
+	myID := b.Ptt().GetMyEntity().GetID()
+
 	backendMessageList := make([]*BackendGetMessage, len(messageList))
 	for i, message := range messageList {
-		backendMessageList[i] = messageToBackendGetMessage(message)
+		backendMessageList[i] = messageToBackendGetMessage(message, f, myID)
+	}
+
+	return backendMessageList, nil
+}
+
+func (b *Backend) SearchMessages(entityIDBytes []byte, query string, limit int) ([]*BackendGetMessage, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	messageList, err := pm.SearchMessages(query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := pm.GetMessageReceipts()
+	if err != nil {
+		return nil, err
+	}
+	myID := b.Ptt().GetMyEntity().GetID()
+
+	backendMessageList := make([]*BackendGetMessage, len(messageList))
+	for i, message := range messageList {
+		backendMessageList[i] = messageToBackendGetMessage(message, f, myID)
 	}
 
 	return backendMessageList, nil
@@ -312,7 +405,33 @@ func (b *Backend) MarkFriendSeen(entityIDBytes []byte) (types.Timestamp, error)
 	}
 	pm := thePM.(*ProtocolManager)
 
-	return pm.SaveLastSeen(types.ZeroTimestamp)
+	ts, err := pm.SaveLastSeen(types.ZeroTimestamp)
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+
+	err = pm.SendMessageReceipt(nil)
+	if err != nil {
+		log.Warn("MarkFriendSeen: unable to send receipt", "e", err, "entity", pm.Entity().IDString())
+	}
+
+	return ts, nil
+}
+
+func (b *Backend) GetMessageReceipts(entityIDBytes []byte) (*BackendMessageReceipts, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	f, err := pm.GetMessageReceipts()
+	if err != nil {
+		return nil, err
+	}
+
+	return friendToBackendMessageReceipts(f), nil
 }
 
 func (b *Backend) MarkFriendListSeen() (types.Timestamp, error) {
@@ -347,3 +466,106 @@ func (b *Backend) GetFriendListSeen() (types.Timestamp, error) {
 
 	return ts, nil
 }
+
+/*
+SetPresence sets my presence and notifies all the friends.
+*/
+func (b *Backend) SetPresence(status PresenceStatus) (bool, error) {
+	if status == PresenceStatusOffline || status >= NPresenceStatus {
+		return false, pkgservice.ErrInvalidData
+	}
+
+	b.lockMyPresence.Lock()
+	b.myPresenceStatus = status
+	b.lockMyPresence.Unlock()
+
+	entities := b.SPM().Entities()
+	for _, entity := range entities {
+		pm := entity.PM().(*ProtocolManager)
+		err := pm.SendPresence(nil, false)
+		if err != nil && err != pkgservice.ErrRateLimited {
+			log.Warn("SetPresence: unable to send presence", "e", err, "entity", pm.Entity().IDString())
+		}
+	}
+
+	return true, nil
+}
+
+/*
+SetTyping notifies the friend that I am typing. Returns false if the signal is dropped by rate-limit.
+*/
+func (b *Backend) SetTyping(entityIDBytes []byte) (bool, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.SendPresence(nil, true)
+	if err == pkgservice.ErrRateLimited {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetPresence(entityIDBytes []byte) (*BackendPresence, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return pm.GetPresence(), nil
+}
+
+/*
+SubscribePresence creates the rpc-subscription notifying the presence-events of the friend.
+*/
+func (b *Backend) SubscribePresence(ctx context.Context, entityIDBytes []byte) (*rpc.Subscription, error) {
+	entity, err := b.EntityIDToEntity(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	entityID := entity.GetID()
+
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		events := make(chan *PresenceEvent, PresenceEventChanSize)
+		sub := b.SubscribePresenceEvent(events)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-events:
+				if !reflect.DeepEqual(ev.FriendID, entityID) {
+					continue
+				}
+				notifier.Notify(rpcSub.ID, ev.BackendPresence)
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+func (b *Backend) SubscribeMessages(ctx context.Context, entityIDBytes []byte) (*rpc.Subscription, error) {
+
+	return b.SubscribeEntityObjEvent(ctx, entityIDBytes)
+}
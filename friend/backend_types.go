@@ -17,6 +17,8 @@
 package friend
 
 import (
+	"reflect"
+
 	"github.com/ailabstw/go-pttai/account"
 	"github.com/ailabstw/go-pttai/common/types"
 	pkgservice "github.com/ailabstw/go-pttai/service"
@@ -75,31 +77,110 @@ func messageToBackendCreateMessage(m *Message) *BackendCreateMessage {
 	}
 }
 
+type BackendUpdateMessage struct {
+	FriendID  *types.PttID `json:"FID"`
+	MessageID *types.PttID `json:"AID"`
+	BlockID   *types.PttID `json:"cID"`
+	NBlock    int          `json:"NB"`
+}
+
+func messageToBackendUpdateMessage(m *Message) *BackendUpdateMessage {
+	syncInfo := m.GetSyncInfo()
+	blockInfo := m.GetBlockInfo()
+	if syncInfo != nil && syncInfo.GetStatus() <= types.StatusAlive {
+		blockInfo = syncInfo.GetBlockInfo()
+	}
+
+	return &BackendUpdateMessage{
+		FriendID:  m.EntityID,
+		MessageID: m.ID,
+		BlockID:   blockInfo.ID,
+		NBlock:    blockInfo.NBlock,
+	}
+}
+
+type BackendDeleteMessage struct {
+}
+
 type BackendGetMessage struct {
-	ID        *types.PttID
-	CreateTS  types.Timestamp //`json:"CT"`
-	UpdateTS  types.Timestamp //`json:"UT"`
-	CreatorID *types.PttID    //`json:"CID"`
-	FriendID  *types.PttID    //`json:"FID"`
-	BlockID   *types.PttID    //`json:"cID"`
-	NBlock    int             //`json:"N"`
-	Status    types.Status    `json:"S"`
+	ID          *types.PttID
+	CreateTS    types.Timestamp //`json:"CT"`
+	UpdateTS    types.Timestamp //`json:"UT"`
+	CreatorID   *types.PttID    //`json:"CID"`
+	FriendID    *types.PttID    //`json:"FID"`
+	BlockID     *types.PttID    //`json:"cID"`
+	NBlock      int             //`json:"N"`
+	Status      types.Status    `json:"S"`
+	DeliveredTS types.Timestamp `json:"DT"`
+	ReadTS      types.Timestamp `json:"RT"`
+	IsEdited    bool            `json:"E"`
+	EditTS      types.Timestamp `json:"ET"`
 }
 
-func messageToBackendGetMessage(m *Message) *BackendGetMessage {
+/*
+messageToBackendGetMessage converts the message with the receipts of the friend.
+DeliveredTS / ReadTS are ZeroTimestamp if the message is not delivered / read yet.
+*/
+func messageToBackendGetMessage(m *Message, f *Friend, myID *types.PttID) *BackendGetMessage {
+
+	deliveredTS, readTS := types.ZeroTimestamp, types.ZeroTimestamp
+	if reflect.DeepEqual(m.CreatorID, myID) {
+		if !f.PeerDeliveredTS.IsLess(m.CreateTS) {
+			deliveredTS = f.PeerDeliveredTS
+		}
+		if !f.PeerReadTS.IsLess(m.CreateTS) {
+			readTS = f.PeerReadTS
+		}
+	} else {
+		deliveredTS = m.CreateTS
+		if !f.LastSeen.IsLess(m.CreateTS) {
+			readTS = f.LastSeen
+		}
+	}
+
+	isEdited := m.UpdateLogID != nil
+	editTS := types.ZeroTimestamp
+	if isEdited {
+		editTS = m.UpdateTS
+	}
 
 	return &BackendGetMessage{
-		ID:        m.ID,
-		CreateTS:  m.CreateTS,
-		UpdateTS:  m.UpdateTS,
-		CreatorID: m.CreatorID,
-		FriendID:  m.EntityID,
-		BlockID:   m.BlockInfo.ID,
-		NBlock:    m.BlockInfo.NBlock,
-		Status:    m.Status,
+		ID:          m.ID,
+		CreateTS:    m.CreateTS,
+		UpdateTS:    m.UpdateTS,
+		CreatorID:   m.CreatorID,
+		FriendID:    m.EntityID,
+		BlockID:     m.BlockInfo.ID,
+		NBlock:      m.BlockInfo.NBlock,
+		Status:      m.Status,
+		DeliveredTS: deliveredTS,
+		ReadTS:      readTS,
+		IsEdited:    isEdited,
+		EditTS:      editTS,
 	}
 }
 
+type BackendMessageReceipts struct {
+	FriendID    *types.PttID    `json:"FID"`
+	DeliveredTS types.Timestamp `json:"DT"`
+	ReadTS      types.Timestamp `json:"RT"`
+}
+
+func friendToBackendMessageReceipts(f *Friend) *BackendMessageReceipts {
+	return &BackendMessageReceipts{
+		FriendID:    f.ID,
+		DeliveredTS: f.PeerDeliveredTS,
+		ReadTS:      f.PeerReadTS,
+	}
+}
+
+type BackendPresence struct {
+	FriendID *types.PttID    `json:"FID"`
+	Status   PresenceStatus  `json:"S"`
+	IsTyping bool            `json:"T"`
+	UpdateTS types.Timestamp `json:"UT"`
+}
+
 type BackendMessageBlock struct {
 	V         types.Version
 	ID        *types.PttID
@@ -49,6 +49,10 @@ type Friend struct {
 	// get from other dbs
 	LastSeen        types.Timestamp `json:"-"`
 	MessageCreateTS types.Timestamp `json:"-"`
+
+	DeliveredTS     types.Timestamp `json:"-"`
+	PeerDeliveredTS types.Timestamp `json:"-"`
+	PeerReadTS      types.Timestamp `json:"-"`
 }
 
 func NewEmptyFriend() *Friend {
@@ -32,6 +32,9 @@ const (
 
 	FriendOpTypeCreateMedia
 
+	FriendOpTypeUpdateMessage
+	FriendOpTypeDeleteMessage
+
 	NFriendOpType
 )
 
@@ -50,6 +53,17 @@ type FriendOpCreateMessage struct {
 	MediaIDs []*types.PttID `json:"ms,omitempty"`
 }
 
+type FriendOpUpdateMessage struct {
+	BlockInfoID *types.PttID `json:"BID"`
+	Hashs       [][][]byte   `json:"H"`
+	NBlock      int          `json:"NB"`
+
+	MediaIDs []*types.PttID `json:"ms,omitempty"`
+}
+
+type FriendOpDeleteMessage struct {
+}
+
 type FriendOpCreateMedia struct {
 	BlockInfoID *types.PttID `json:"BID"` // resized content-block-id
 	Hashs       [][][]byte   `json:"H"`
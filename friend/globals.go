@@ -42,6 +42,8 @@ var (
 
 	dbMeta *pttdb.LDBDatabase = nil
 
+	dbSearch *pttdb.SearchIndex = nil
+
 	DBFriendIdxPrefix         = []byte(".frix")
 	DBFriendIdx2Prefix        = []byte(".fri2")
 	DBFriendPrefix            = []byte(".frdb")
@@ -58,6 +60,15 @@ var (
 	DBMessageCreateTS2Prefix   = []byte(".mcdb")
 
 	DBFriendListSeenPrefix = []byte(".frsn")
+
+	DBDeliveredPrefix     = []byte(".frdl")
+	DBPeerDeliveredPrefix = []byte(".frpd")
+	DBPeerReadPrefix      = []byte(".frpr")
+
+	DBSearchTermPrefix = []byte(".mstm")
+	DBSearchDocPrefix  = []byte(".msdc")
+
+	DBSearchBackfillPrefix = []byte(".msbf")
 )
 
 // protocol
@@ -95,6 +106,19 @@ const (
 	// init friend info
 	InitFriendInfoMsg
 	InitFriendInfoAckMsg
+
+	// receipt
+	MessageReceiptMsg
+
+	// ephemeral
+	PresenceMsg
+
+	// update message
+	SyncUpdateMessageMsg
+	SyncUpdateMessageAckMsg
+
+	SyncUpdateMessageBlockMsg
+	SyncUpdateMessageBlockAckMsg
 )
 
 // max-masters
@@ -119,6 +143,15 @@ const (
 	NFirstLineInBlock = 20
 )
 
+// presence
+var (
+	ExpireTypingSeconds int64 = 10
+)
+
+const (
+	PresenceEventChanSize = 100
+)
+
 func InitFriend(dataDir string) error {
 	var err error
 
@@ -130,6 +163,7 @@ func InitFriend(dataDir string) error {
 	if err != nil {
 		return err
 	}
+	dbSearch = pttdb.NewSearchIndex(dbFriendCore, DBSearchTermPrefix, DBSearchDocPrefix)
 
 	dbMeta, err = pttdb.NewLDBDatabase("friendmeta", dataDir, 0, 0)
 	if err != nil {
@@ -157,6 +191,9 @@ func TeardownFriend() {
 	if dbFriend != nil {
 		dbFriend = nil
 	}
+	if dbSearch != nil {
+		dbSearch = nil
+	}
 
 	if dbMeta != nil {
 		dbMeta.Close()
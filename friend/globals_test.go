@@ -16,11 +16,17 @@
 
 package friend
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
 
 const ()
 
-var ()
+var (
+	tDefaultTimestamp = types.Timestamp{Ts: 1234567890, NanoTs: 0}
+)
 
 func setupTest(t *testing.T) {
 }
@@ -0,0 +1,54 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import "github.com/ailabstw/go-pttai/common/types"
+
+type PresenceStatus uint8
+
+const (
+	PresenceStatusOffline PresenceStatus = iota
+	PresenceStatusOnline
+	PresenceStatusAway
+
+	NPresenceStatus
+)
+
+/*
+PresenceData is the ephemeral signal of presence, sent with CodeTypeEphemeral
+and never stored in db.
+*/
+type PresenceData struct {
+	Status   PresenceStatus `json:"S"`
+	IsTyping bool           `json:"T"`
+}
+
+/*
+Presence is the in-memory presence of the friend.
+*/
+type Presence struct {
+	Status   PresenceStatus
+	TypingTS types.Timestamp
+	UpdateTS types.Timestamp
+}
+
+/*
+PresenceEvent is posted to the subscribers when the presence of the friend changes.
+*/
+type PresenceEvent struct {
+	*BackendPresence
+}
@@ -0,0 +1,102 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import (
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+BackfillSearchIndex indexes the messages created before the search-index is available.
+The friend is backfilled only once.
+*/
+func (pm *ProtocolManager) BackfillSearchIndex() error {
+	isBackfilled, err := pm.isBackfillSearchIndex()
+	if err != nil {
+		return err
+	}
+	if isBackfilled {
+		return nil
+	}
+
+	err = pm.backfillSearchIndexCore()
+	if err != nil {
+		return err
+	}
+
+	return pm.setBackfillSearchIndex()
+}
+
+func (pm *ProtocolManager) isBackfillSearchIndex() (bool, error) {
+	key, err := pm.marshalBackfillSearchIndexKey()
+	if err != nil {
+		return false, err
+	}
+	_, err = dbMeta.Get(key)
+	if err == leveldb.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (pm *ProtocolManager) marshalBackfillSearchIndexKey() ([]byte, error) {
+	entityID := pm.Entity().GetID()
+	return common.Concat([][]byte{DBSearchBackfillPrefix, entityID[:]})
+}
+
+func (pm *ProtocolManager) setBackfillSearchIndex() error {
+	key, err := pm.marshalBackfillSearchIndexKey()
+	if err != nil {
+		return err
+	}
+
+	return dbMeta.Put(key, pttdb.ValueTrue)
+}
+
+func (pm *ProtocolManager) backfillSearchIndexCore() error {
+	message := NewEmptyMessage()
+	pm.SetMessageDB(message)
+
+	iter, err := message.GetObjIterWithObj(nil, pttdb.ListOrderNext, false)
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+
+	for iter.Next() {
+		each := NewEmptyMessage()
+		pm.SetMessageDB(each)
+		err = each.Unmarshal(iter.Value())
+		if err != nil || each.Status != types.StatusAlive {
+			continue
+		}
+
+		err = pm.indexMessage(each)
+		if err != nil {
+			log.Warn("backfillSearchIndexCore: unable to index message", "e", err, "entity", pm.Entity().IDString(), "message", each.ID)
+		}
+	}
+
+	return nil
+}
@@ -140,12 +140,29 @@ func (pm *ProtocolManager) postcreateMessage(theObj pkgservice.Object, oplog *pk
 	entity := pm.Entity().(*Friend)
 	entity.SaveMessageCreateTS(oplog.UpdateTS)
 
+	message, ok := theObj.(*Message)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	err := pm.indexMessage(message)
+	if err != nil {
+		log.Warn("postcreateMessage: unable to index message", "e", err, "entity", pm.Entity().IDString(), "message", message.ID)
+	}
+
 	myID := pm.Ptt().GetMyEntity().GetID()
 	creatorID := theObj.GetCreatorID()
 
 	if reflect.DeepEqual(myID, creatorID) {
 		pm.SaveLastSeen(oplog.UpdateTS)
+	} else {
+		err = pm.MarkMessageDelivered(message)
+		if err != nil {
+			log.Warn("postcreateMessage: unable to mark message delivered", "e", err, "entity", pm.Entity().IDString(), "message", message.ID)
+		}
 	}
 
+	pm.PostObjEvent(theObj, nil, oplog, types.StatusAlive)
+
 	return nil
 }
@@ -0,0 +1,81 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) DeleteMessage(id *types.PttID) error {
+
+	err := pm.checkMessageCreator(id)
+	if err != nil {
+		return err
+	}
+
+	message := NewEmptyMessage()
+	pm.SetMessageDB(message)
+
+	opData := &FriendOpDeleteMessage{}
+
+	return pm.DeleteObject(
+		id,
+
+		FriendOpTypeDeleteMessage,
+		message,
+		opData,
+
+		pm.friendOplogMerkle,
+
+		pm.SetFriendDB,
+		pm.NewFriendOplog,
+		nil,
+		pm.setPendingDeleteMessageSyncInfo,
+
+		pm.broadcastFriendOplogCore,
+		pm.postdeleteMessage,
+	)
+}
+
+func (pm *ProtocolManager) setPendingDeleteMessageSyncInfo(obj pkgservice.Object, status types.Status, oplog *pkgservice.BaseOplog) error {
+
+	syncInfo := &pkgservice.BaseSyncInfo{}
+	syncInfo.InitWithOplog(status, oplog)
+
+	obj.SetSyncInfo(syncInfo)
+
+	return nil
+}
+
+func (pm *ProtocolManager) postdeleteMessage(id *types.PttID, oplog *pkgservice.BaseOplog, opData pkgservice.OpData, obj pkgservice.Object, blockInfo *pkgservice.BlockInfo) error {
+
+	message, ok := obj.(*Message)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	err := pm.removeSearchIndex(message.ID)
+	if err != nil {
+		log.Warn("postdeleteMessage: unable to remove search-index", "e", err, "entity", pm.Entity().IDString(), "message", message.ID)
+	}
+
+	pm.PostObjEvent(message, nil, oplog, types.StatusDeleted)
+
+	return nil
+}
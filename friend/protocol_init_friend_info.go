@@ -178,6 +178,7 @@ func (pm *ProtocolManager) postcreateFriend(entity pkgservice.Entity) error {
 	if err != nil {
 		return err
 	}
+	pm.Ptt().PostPttOplog(oplog)
 
 	return nil
 }
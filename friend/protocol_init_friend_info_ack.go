@@ -205,6 +205,7 @@ func (pm *ProtocolManager) HandleInitFriendInfoAckCore(
 	if err != nil {
 		return err
 	}
+	pm.Ptt().PostPttOplog(pttOplog)
 
 	log.Debug("HandleInitFriendInfoAck: done")
 
@@ -17,6 +17,8 @@
 package friend
 
 import (
+	"sync"
+
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/log"
 	pkgservice "github.com/ailabstw/go-pttai/service"
@@ -32,6 +34,10 @@ type ProtocolManager struct {
 	// message
 	dbMessagePrefix    []byte
 	dbMessageIdxPrefix []byte
+
+	// presence
+	lockPresence sync.RWMutex
+	presence     *Presence
 }
 
 func NewProtocolManager(f *Friend, ptt pkgservice.Ptt, svc pkgservice.Service) (*ProtocolManager, error) {
@@ -115,6 +121,12 @@ func (pm *ProtocolManager) Start() error {
 
 	pm.LoadPeers()
 
+	err = pm.BackfillSearchIndex()
+	if err != nil {
+		log.Error("Start: unable to BackfillSearchIndex", "e", err, "entity", pm.Entity().IDString())
+		return err
+	}
+
 	// oplog-merkle-tree
 	syncWG := pm.SyncWG()
 	syncWG.Add(1)
@@ -140,6 +152,9 @@ func (pm *ProtocolManager) Sync(peer *pkgservice.PttPeer) error {
 		return nil
 	}
 
+	pm.SendMessageReceipt(peer)
+	pm.SendPresence(peer, false)
+
 	err := pm.SyncOplog(peer, pm.MasterMerkle(), pkgservice.SyncMasterOplogMsg)
 
 	log.Debug("Sync: after SyncOplog", "entity", pm.Entity().IDString(), "peer", peer, "e", err)
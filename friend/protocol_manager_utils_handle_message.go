@@ -38,6 +38,19 @@ func (pm *ProtocolManager) HandleNonRegisteredMessage(op pkgservice.OpType, data
 	return err
 }
 
+func (pm *ProtocolManager) HandleEphemeralMessage(op pkgservice.OpType, dataBytes []byte, peer *pkgservice.PttPeer) error {
+
+	var err error
+	switch op {
+	case PresenceMsg:
+		err = pm.HandlePresence(dataBytes, peer)
+	default:
+		err = pkgservice.ErrInvalidMsgCode
+	}
+
+	return err
+}
+
 func (pm *ProtocolManager) HandleMessage(op pkgservice.OpType, dataBytes []byte, peer *pkgservice.PttPeer) error {
 
 	log.Debug("friend.HandleMessage: start", "op", op, "AddFriendOplogMsg", AddFriendOplogMsg)
@@ -92,6 +105,19 @@ func (pm *ProtocolManager) HandleMessage(op pkgservice.OpType, dataBytes []byte,
 	case SyncCreateMessageBlockAckMsg:
 		err = pm.HandleSyncCreateMessageBlockAck(dataBytes, peer)
 
+	case SyncUpdateMessageMsg:
+		err = pm.HandleSyncUpdateMessage(dataBytes, peer, SyncUpdateMessageAckMsg)
+	case SyncUpdateMessageAckMsg:
+		err = pm.HandleSyncUpdateMessageAck(dataBytes, peer)
+	case SyncUpdateMessageBlockMsg:
+		err = pm.HandleSyncUpdateMessageBlock(dataBytes, peer)
+	case SyncUpdateMessageBlockAckMsg:
+		err = pm.HandleSyncUpdateMessageBlockAck(dataBytes, peer)
+
+	// receipt
+	case MessageReceiptMsg:
+		err = pm.HandleMessageReceipt(dataBytes, peer)
+
 	default:
 		log.Error("invalid op", "op", op, "InitFriendInfoMsg", InitFriendInfoMsg)
 		err = pkgservice.ErrInvalidMsgCode
@@ -0,0 +1,165 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type MessageReceipt struct {
+	DeliveredTS types.Timestamp `json:"D"`
+	ReadTS      types.Timestamp `json:"R"`
+}
+
+/*
+SendMessageReceipt sends my delivered / read watermarks to the peers of the friend.
+peer == nil means sending to all the friend-peers.
+*/
+func (pm *ProtocolManager) SendMessageReceipt(peer *pkgservice.PttPeer) error {
+	f := pm.Entity().(*Friend)
+
+	var peerList []*pkgservice.PttPeer
+	if peer == nil {
+		peerList = pm.friendPeerList()
+	} else if reflect.DeepEqual(peer.UserID, f.FriendID) {
+		peerList = []*pkgservice.PttPeer{peer}
+	}
+	if len(peerList) == 0 {
+		return nil
+	}
+
+	deliveredTS, err := f.loadReceiptTS(DBDeliveredPrefix)
+	if err != nil {
+		return err
+	}
+
+	readTS, err := f.LoadLastSeen()
+	if err != nil {
+		return err
+	}
+
+	data := &MessageReceipt{
+		DeliveredTS: deliveredTS,
+		ReadTS:      readTS,
+	}
+
+	return pm.SendDataToPeers(MessageReceiptMsg, data, peerList)
+}
+
+func (pm *ProtocolManager) HandleMessageReceipt(dataBytes []byte, peer *pkgservice.PttPeer) error {
+	f := pm.Entity().(*Friend)
+	if !reflect.DeepEqual(peer.UserID, f.FriendID) {
+		return nil
+	}
+
+	data := &MessageReceipt{}
+	err := json.Unmarshal(dataBytes, data)
+	if err != nil {
+		return err
+	}
+
+	// read implies delivered.
+	deliveredTS := data.DeliveredTS
+	if deliveredTS.IsLess(data.ReadTS) {
+		deliveredTS = data.ReadTS
+	}
+
+	err = f.LoadReceipts()
+	if err != nil {
+		return err
+	}
+
+	if f.PeerDeliveredTS.IsLess(deliveredTS) {
+		err = f.SavePeerDeliveredTS(deliveredTS)
+		if err != nil {
+			return err
+		}
+	}
+
+	if f.PeerReadTS.IsLess(data.ReadTS) {
+		err = f.SavePeerReadTS(data.ReadTS)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Debug("HandleMessageReceipt: done", "entity", pm.Entity().IDString(), "delivered", f.PeerDeliveredTS, "read", f.PeerReadTS)
+
+	return nil
+}
+
+/*
+MarkMessageDelivered updates my delivered-watermark when receiving a message from the friend
+and reports it to the friend.
+*/
+func (pm *ProtocolManager) MarkMessageDelivered(message *Message) error {
+	f := pm.Entity().(*Friend)
+
+	deliveredTS, err := f.loadReceiptTS(DBDeliveredPrefix)
+	if err != nil {
+		return err
+	}
+	if !deliveredTS.IsLess(message.CreateTS) {
+		return nil
+	}
+
+	err = f.SaveDeliveredTS(message.CreateTS)
+	if err != nil {
+		return err
+	}
+
+	return pm.SendMessageReceipt(nil)
+}
+
+/*
+GetMessageReceipts loads the delivered / read watermarks of the friend, and my LastSeen.
+*/
+func (pm *ProtocolManager) GetMessageReceipts() (*Friend, error) {
+	f := pm.Entity().(*Friend)
+
+	err := f.LoadReceipts()
+	if err != nil {
+		return nil, err
+	}
+
+	f.LastSeen, err = f.LoadLastSeen()
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (pm *ProtocolManager) friendPeerList() []*pkgservice.PttPeer {
+	f := pm.Entity().(*Friend)
+
+	peerList := pm.Peers().PeerList(false)
+
+	friendPeerList := make([]*pkgservice.PttPeer, 0, len(peerList))
+	for _, peer := range peerList {
+		if reflect.DeepEqual(peer.UserID, f.FriendID) {
+			friendPeerList = append(friendPeerList, peer)
+		}
+	}
+
+	return friendPeerList
+}
@@ -0,0 +1,133 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+SendPresence sends my presence (and whether I am typing) to the peers of the friend.
+peer == nil means sending to all the friend-peers.
+*/
+func (pm *ProtocolManager) SendPresence(peer *pkgservice.PttPeer, isTyping bool) error {
+	f := pm.Entity().(*Friend)
+
+	var peerList []*pkgservice.PttPeer
+	if peer == nil {
+		peerList = pm.friendPeerList()
+	} else if reflect.DeepEqual(peer.UserID, f.FriendID) {
+		peerList = []*pkgservice.PttPeer{peer}
+	}
+
+	backend, ok := pm.Entity().Service().(*Backend)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	data := &PresenceData{
+		Status:   backend.MyPresenceStatus(),
+		IsTyping: isTyping,
+	}
+
+	return pm.SendEphemeralToPeers(PresenceMsg, data, peerList)
+}
+
+func (pm *ProtocolManager) HandlePresence(dataBytes []byte, peer *pkgservice.PttPeer) error {
+	f := pm.Entity().(*Friend)
+	if !reflect.DeepEqual(peer.UserID, f.FriendID) {
+		return nil
+	}
+
+	data := &PresenceData{}
+	err := json.Unmarshal(dataBytes, data)
+	if err != nil {
+		return err
+	}
+	if data.Status >= NPresenceStatus {
+		return pkgservice.ErrInvalidData
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	pm.lockPresence.Lock()
+	if pm.presence == nil {
+		pm.presence = &Presence{}
+	}
+	pm.presence.Status = data.Status
+	pm.presence.UpdateTS = ts
+	// the presence without typing (ex: from Sync) does not stop the typing, which expires by ExpireTypingSeconds.
+	if data.IsTyping {
+		pm.presence.TypingTS = ts
+	}
+	pm.lockPresence.Unlock()
+
+	backendPresence := pm.GetPresence()
+	if backend, ok := pm.Entity().Service().(*Backend); ok {
+		backend.PostPresenceEvent(&PresenceEvent{BackendPresence: backendPresence})
+	}
+
+	return nil
+}
+
+/*
+GetPresence gets the presence of the friend.
+The friend is offline if none of the peers of the friend is connected,
+and is typing only within ExpireTypingSeconds from the latest typing-signal.
+*/
+func (pm *ProtocolManager) GetPresence() *BackendPresence {
+	backendPresence := &BackendPresence{
+		FriendID: pm.Entity().GetID(),
+		Status:   PresenceStatusOffline,
+	}
+
+	if len(pm.friendPeerList()) == 0 {
+		return backendPresence
+	}
+
+	pm.lockPresence.RLock()
+	defer pm.lockPresence.RUnlock()
+
+	if pm.presence == nil {
+		backendPresence.Status = PresenceStatusOnline
+		return backendPresence
+	}
+
+	backendPresence.Status = pm.presence.Status
+	backendPresence.UpdateTS = pm.presence.UpdateTS
+
+	if pm.presence.TypingTS.IsEqual(types.ZeroTimestamp) {
+		return backendPresence
+	}
+
+	now, err := types.GetTimestamp()
+	if err != nil {
+		return backendPresence
+	}
+	expireTS := pm.presence.TypingTS
+	expireTS.Ts += ExpireTypingSeconds
+	backendPresence.IsTyping = now.IsLess(expireTS)
+
+	return backendPresence
+}
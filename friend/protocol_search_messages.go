@@ -0,0 +1,89 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+SearchMessages searches the alive messages with the friend.
+*/
+func (pm *ProtocolManager) SearchMessages(query string, limit int) ([]*Message, error) {
+
+	entityID := pm.Entity().GetID()
+
+	messages := make([]*Message, 0)
+	_, err := dbSearch.Search(entityID[:], query, limit, func(refID []byte) bool {
+		messageID := &types.PttID{}
+		copy(messageID[:], refID)
+
+		message := NewEmptyMessage()
+		pm.SetMessageDB(message)
+		message.SetID(messageID)
+
+		err := message.GetByID(false)
+		if err != nil {
+			return false
+		}
+		if message.Status != types.StatusAlive {
+			return false
+		}
+
+		messages = append(messages, message)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+/*
+indexMessage indexes the content-blocks of the message.
+*/
+func (pm *ProtocolManager) indexMessage(message *Message) error {
+	blockInfo := message.GetBlockInfo()
+	if blockInfo == nil {
+		return pkgservice.ErrInvalidBlock
+	}
+	pm.SetBlockInfoDB(blockInfo, message.ID)
+
+	contentBlockList, err := pkgservice.GetContentBlockList(blockInfo, 0, false)
+	log.Debug("indexMessage: after GetContentBlockList", "message", message.ID, "e", err)
+	if err != nil {
+		return err
+	}
+
+	texts := make([][]byte, 0)
+	for _, contentBlock := range contentBlockList {
+		texts = append(texts, contentBlock.Buf...)
+	}
+
+	entityID := pm.Entity().GetID()
+
+	return dbSearch.Put(entityID[:], message.ID[:], message.ID[:], texts)
+}
+
+func (pm *ProtocolManager) removeSearchIndex(id *types.PttID) error {
+	entityID := pm.Entity().GetID()
+
+	return dbSearch.Delete(entityID[:], id[:])
+}
@@ -68,3 +68,41 @@ func (pm *ProtocolManager) HandleSyncCreateMessageBlockAck(dataBytes []byte, pee
 		pm.broadcastFriendOplogCore,
 	)
 }
+
+/**********
+ * Sync Update Message
+ **********/
+
+func (pm *ProtocolManager) HandleSyncUpdateMessage(dataBytes []byte, peer *pkgservice.PttPeer, syncAckMsg pkgservice.OpType) error {
+
+	obj := NewEmptyMessage()
+	pm.SetMessageDB(obj)
+
+	return pm.HandleSyncUpdateObject(dataBytes, peer, obj, syncAckMsg)
+}
+
+func (pm *ProtocolManager) HandleSyncUpdateMessageBlock(dataBytes []byte, peer *pkgservice.PttPeer) error {
+
+	obj := NewEmptyMessage()
+	pm.SetMessageDB(obj)
+
+	return pm.HandleSyncBlock(dataBytes, peer, obj, SyncUpdateMessageBlockAckMsg)
+}
+
+func (pm *ProtocolManager) HandleSyncUpdateMessageBlockAck(dataBytes []byte, peer *pkgservice.PttPeer) error {
+
+	obj := NewEmptyMessage()
+	pm.SetMessageDB(obj)
+
+	return pm.HandleSyncUpdateBlockAck(
+		dataBytes,
+		peer,
+		obj,
+
+		pm.friendOplogMerkle,
+
+		pm.SetFriendDB,
+		pm.postupdateMessage,
+		pm.broadcastFriendOplogCore,
+	)
+}
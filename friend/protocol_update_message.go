@@ -0,0 +1,148 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type UpdateMessage struct {
+	Msg      [][]byte       `json:"m"`
+	MediaIDs []*types.PttID `json:"ms"`
+}
+
+func (pm *ProtocolManager) UpdateMessage(messageID *types.PttID, msg [][]byte, mediaIDs []*types.PttID) (*Message, error) {
+
+	err := pm.checkMessageCreator(messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &UpdateMessage{Msg: msg, MediaIDs: mediaIDs}
+
+	origObj := NewEmptyMessage()
+	pm.SetMessageDB(origObj)
+
+	opData := &FriendOpUpdateMessage{}
+
+	err = pm.UpdateObject(
+		messageID,
+		data,
+		FriendOpTypeUpdateMessage,
+		origObj,
+		opData,
+
+		pm.friendOplogMerkle,
+
+		pm.SetFriendDB,
+		pm.NewFriendOplog,
+		pm.inupdateMessage,
+		nil,
+		pm.broadcastFriendOplogCore,
+		pm.postupdateMessage,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("UpdateMessage: done", "entity", pm.Entity().IDString())
+
+	return origObj, nil
+}
+
+/*
+checkMessageCreator checks that the message is created by me.
+Both sides of the friend are masters, so the master-check in UpdateObject / DeleteObject is not enough.
+*/
+func (pm *ProtocolManager) checkMessageCreator(messageID *types.PttID) error {
+	myID := pm.Ptt().GetMyEntity().GetID()
+
+	message := NewEmptyMessage()
+	pm.SetMessageDB(message)
+	message.SetID(messageID)
+
+	err := message.GetByID(false)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(message.CreatorID, myID) {
+		return types.ErrInvalidID
+	}
+
+	return nil
+}
+
+func (pm *ProtocolManager) inupdateMessage(theObj pkgservice.Object, theData pkgservice.UpdateData, oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData) (pkgservice.SyncInfo, error) {
+
+	data, ok := theData.(*UpdateMessage)
+	if !ok {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	opData, ok := theOpData.(*FriendOpUpdateMessage)
+	if !ok {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	// block-info
+	blockInfoID, blockHashs, err := pm.SplitContentBlocks(nil, oplog.ObjID, data.Msg, NFirstLineInBlock)
+	if err != nil {
+		log.Error("inupdateMessage: Unable to SplitContentBlocks", "e", err)
+		return nil, err
+	}
+
+	blockInfo, err := pkgservice.NewBlockInfo(blockInfoID, blockHashs, data.MediaIDs, oplog.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	blockInfo.SetIsAllGood()
+
+	// op-data
+	opData.BlockInfoID = blockInfoID
+	opData.NBlock = blockInfo.NBlock
+	opData.Hashs = blockHashs
+	opData.MediaIDs = data.MediaIDs
+
+	// sync-info
+	syncInfo := &pkgservice.BaseSyncInfo{}
+	syncInfo.InitWithOplog(oplog.ToStatus(), oplog)
+	syncInfo.SetBlockInfo(blockInfo)
+
+	return syncInfo, nil
+}
+
+func (pm *ProtocolManager) postupdateMessage(theObj pkgservice.Object, oplog *pkgservice.BaseOplog) error {
+
+	message, ok := theObj.(*Message)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	err := pm.indexMessage(message)
+	if err != nil {
+		log.Warn("postupdateMessage: unable to index message", "e", err, "entity", pm.Entity().IDString(), "message", message.ID)
+	}
+
+	pm.PostObjEvent(message, nil, oplog, types.StatusAlive)
+
+	return nil
+}
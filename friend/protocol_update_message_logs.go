@@ -0,0 +1,137 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) handleUpdateMessageLogs(oplog *pkgservice.BaseOplog, info *ProcessFriendInfo) ([]*pkgservice.BaseOplog, error) {
+	obj := NewEmptyMessage()
+	pm.SetMessageDB(obj)
+
+	opData := &FriendOpUpdateMessage{}
+
+	log.Debug("handleUpdateMessageLogs: to HandleUpdateObjectLog", "entity", pm.Entity().IDString(), "IsSync", oplog.IsSync)
+
+	return pm.HandleUpdateObjectLog(
+		oplog,
+		opData,
+
+		obj,
+		info,
+		pm.friendOplogMerkle,
+
+		pm.syncMessageInfoFromOplog,
+		pm.SetFriendDB,
+		nil,
+		pm.postupdateMessage,
+		pm.updateUpdateMessageInfo,
+	)
+}
+
+func (pm *ProtocolManager) handlePendingUpdateMessageLogs(oplog *pkgservice.BaseOplog, info *ProcessFriendInfo) (types.Bool, []*pkgservice.BaseOplog, error) {
+	obj := NewEmptyMessage()
+	pm.SetMessageDB(obj)
+
+	opData := &FriendOpUpdateMessage{}
+
+	log.Debug("handlePendingUpdateMessageLogs: to HandlePendingUpdateObjectLog", "entity", pm.Entity().IDString())
+
+	return pm.HandlePendingUpdateObjectLog(
+		oplog,
+		opData,
+
+		obj,
+		info,
+		pm.friendOplogMerkle,
+
+		pm.syncMessageInfoFromOplog,
+		pm.SetFriendDB,
+		nil,
+		pm.postupdateMessage,
+		pm.updateUpdateMessageInfo,
+	)
+}
+
+func (pm *ProtocolManager) setNewestUpdateMessageLog(oplog *pkgservice.BaseOplog) (types.Bool, error) {
+	obj := NewEmptyMessage()
+	pm.SetMessageDB(obj)
+
+	return pm.SetNewestUpdateObjectLog(oplog, obj)
+}
+
+func (pm *ProtocolManager) handleFailedUpdateMessageLog(oplog *pkgservice.BaseOplog) error {
+
+	obj := NewEmptyMessage()
+	pm.SetMessageDB(obj)
+
+	return pm.HandleFailedUpdateObjectLog(oplog, obj)
+}
+
+func (pm *ProtocolManager) handleFailedValidUpdateMessageLog(oplog *pkgservice.BaseOplog, info *ProcessFriendInfo) error {
+
+	obj := NewEmptyMessage()
+	pm.SetMessageDB(obj)
+
+	return pm.HandleFailedValidUpdateObjectLog(oplog, obj, info, pm.updateUpdateMessageInfo)
+}
+
+/**********
+ * Customize
+ **********/
+
+func (pm *ProtocolManager) syncMessageInfoFromOplog(oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData) (pkgservice.SyncInfo, error) {
+
+	opData, ok := theOpData.(*FriendOpUpdateMessage)
+	if !ok {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	syncInfo := &pkgservice.BaseSyncInfo{}
+	syncInfo.InitWithOplog(types.StatusInternalSync, oplog)
+
+	blockInfo, err := pkgservice.NewBlockInfo(opData.BlockInfoID, opData.Hashs, opData.MediaIDs, oplog.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	pm.SetBlockInfoDB(blockInfo, oplog.ObjID)
+	blockInfo.InitIsGood()
+	syncInfo.SetBlockInfo(blockInfo)
+
+	return syncInfo, nil
+}
+
+func (pm *ProtocolManager) updateUpdateMessageInfo(obj pkgservice.Object, oplog *pkgservice.BaseOplog, theOpData pkgservice.OpData, origSyncInfo pkgservice.SyncInfo, theInfo pkgservice.ProcessInfo) error {
+
+	info, ok := theInfo.(*ProcessFriendInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	opData, ok := theOpData.(*FriendOpUpdateMessage)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	info.MessageInfo[*oplog.ObjID] = oplog
+	info.BlockInfo[*opData.BlockInfoID] = oplog
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package friend
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+Receipts are kept as watermarks instead of per-message states:
+DeliveredTS is the create-ts of the newest message received from the friend,
+PeerDeliveredTS / PeerReadTS are the watermarks reported back by the friend.
+Every message created no later than the watermark is considered delivered / read.
+*/
+
+func (f *Friend) SaveDeliveredTS(ts types.Timestamp) error {
+	f.DeliveredTS = ts
+
+	return f.saveReceiptTS(DBDeliveredPrefix, ts)
+}
+
+func (f *Friend) SavePeerDeliveredTS(ts types.Timestamp) error {
+	f.PeerDeliveredTS = ts
+
+	return f.saveReceiptTS(DBPeerDeliveredPrefix, ts)
+}
+
+func (f *Friend) SavePeerReadTS(ts types.Timestamp) error {
+	f.PeerReadTS = ts
+
+	return f.saveReceiptTS(DBPeerReadPrefix, ts)
+}
+
+/*
+LoadReceipts loads DeliveredTS, PeerDeliveredTS and PeerReadTS from db.
+*/
+func (f *Friend) LoadReceipts() error {
+	var err error
+
+	f.DeliveredTS, err = f.loadReceiptTS(DBDeliveredPrefix)
+	if err != nil {
+		return err
+	}
+
+	f.PeerDeliveredTS, err = f.loadReceiptTS(DBPeerDeliveredPrefix)
+	if err != nil {
+		return err
+	}
+
+	f.PeerReadTS, err = f.loadReceiptTS(DBPeerReadPrefix)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (f *Friend) saveReceiptTS(prefix []byte, ts types.Timestamp) error {
+	key, err := f.MarshalReceiptKey(prefix)
+	if err != nil {
+		return err
+	}
+	val := &pttdb.DBable{
+		UpdateTS: ts,
+	}
+	marshaled, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+
+	_, err = dbFriendCore.TryPut(key, marshaled, ts)
+	if err != nil && err != pttdb.ErrInvalidUpdateTS {
+		return err
+	}
+
+	return nil
+}
+
+func (f *Friend) loadReceiptTS(prefix []byte) (types.Timestamp, error) {
+	key, err := f.MarshalReceiptKey(prefix)
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+	data, err := dbFriendCore.Get(key)
+	if err != nil {
+		if err == leveldb.ErrNotFound {
+			err = nil
+		}
+		return types.ZeroTimestamp, err
+	}
+
+	val := &pttdb.DBable{}
+	err = json.Unmarshal(data, val)
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+
+	return val.UpdateTS, nil
+}
+
+func (f *Friend) MarshalReceiptKey(prefix []byte) ([]byte, error) {
+	return common.Concat([][]byte{prefix, f.ID[:]})
+}
@@ -0,0 +1,143 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	"context"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/ailabstw/go-pttai/rpc"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type PrivateAPI struct {
+	b *Backend
+}
+
+func NewPrivateAPI(b *Backend) *PrivateAPI {
+	return &PrivateAPI{b}
+}
+
+func (api *PrivateAPI) CreateGroup(title []byte) (*BackendCreateGroup, error) {
+	return api.b.CreateGroup(title)
+}
+
+func (api *PrivateAPI) CreateGroupMessage(entityID string, message [][]byte, mediaIDs []string) (*BackendCreateMessage, error) {
+	return api.b.CreateGroupMessage(
+		[]byte(entityID),
+		message,
+		mediaIDs,
+	)
+}
+
+/*
+SetTitle renames the group.
+*/
+func (api *PrivateAPI) SetTitle(entityID string, title []byte) (*BackendGetGroup, error) {
+	return api.b.SetTitle([]byte(entityID), title)
+}
+
+func (api *PrivateAPI) DeleteGroup(entityID string) (bool, error) {
+	return api.b.DeleteGroup([]byte(entityID))
+}
+
+func (api *PrivateAPI) LeaveGroup(entityID string) (bool, error) {
+	return api.b.LeaveEntity([]byte(entityID))
+}
+
+/*
+DeleteMember kicks the member out of the group.
+*/
+func (api *PrivateAPI) DeleteMember(entityID string, userID string) (bool, error) {
+	return api.b.DeleteMember([]byte(entityID), []byte(userID))
+}
+
+func (api *PrivateAPI) GetJoinKeyInfos(entityID string) ([]*pkgservice.KeyInfo, error) {
+	return api.b.GetJoinKeys([]byte(entityID))
+}
+
+func (api *PrivateAPI) GetRawGroup(entityID string) (*Group, error) {
+	return api.b.GetRawGroup([]byte(entityID))
+}
+
+func (api *PrivateAPI) ForceSync(entityID string) (bool, error) {
+	return api.b.ForceSync([]byte(entityID))
+}
+
+func (api *PrivateAPI) MarkGroupSeen(entityID string) (types.Timestamp, error) {
+	return api.b.MarkGroupSeen([]byte(entityID))
+}
+
+type PublicAPI struct {
+	b *Backend
+}
+
+func NewPublicAPI(b *Backend) *PublicAPI {
+	return &PublicAPI{b}
+}
+
+func (api *PublicAPI) GetGroup(entityID string) (*BackendGetGroup, error) {
+	return api.b.GetGroup([]byte(entityID))
+}
+
+func (api *PublicAPI) GetGroupList(startingGroupID string, limit int, listOrder pttdb.ListOrder) ([]*BackendGetGroup, error) {
+	return api.b.GetGroupList([]byte(startingGroupID), limit, listOrder)
+}
+
+func (api *PublicAPI) GetGroupMessageList(entityID string, startingMessageID string, limit int, listOrder pttdb.ListOrder) ([]*BackendGetMessage, error) {
+	return api.b.GetGroupMessageList(
+		[]byte(entityID),
+		[]byte(startingMessageID),
+		limit,
+		listOrder,
+	)
+}
+
+func (api *PublicAPI) GetGroupMessageBlockList(entityID string, messageID string, limit uint32) ([]*BackendMessageBlock, error) {
+	return api.b.GetGroupMessageBlockList([]byte(entityID), []byte(messageID), limit)
+}
+
+/*
+ShowGroupURL shows the join-url of the group to invite the others. Only the master of the group is able to show the url.
+*/
+func (api *PublicAPI) ShowGroupURL(entityID string) (*pkgservice.BackendJoinURL, error) {
+	return api.b.ShowGroupURL([]byte(entityID))
+}
+
+/*
+SubscribeGroup notifies the title / message events of the group (group_subscribe with "subscribeGroup").
+*/
+func (api *PublicAPI) SubscribeGroup(ctx context.Context, entityID string) (*rpc.Subscription, error) {
+	return api.b.SubscribeGroup(ctx, []byte(entityID))
+}
+
+/**********
+ * GroupOplog
+ **********/
+
+func (api *PrivateAPI) GetGroupOplogList(entityID string, logID string, limit int, listOrder pttdb.ListOrder) ([]*GroupOplog, error) {
+	return api.b.GetGroupOplogList([]byte(entityID), []byte(logID), limit, listOrder)
+}
+
+/**********
+ * Member
+ **********/
+
+func (api *PrivateAPI) GetMemberList(entityID string, startID string, limit int, listOrder pttdb.ListOrder) ([]*pkgservice.Member, error) {
+	return api.b.GetMemberList([]byte(entityID), []byte(startID), limit, listOrder)
+}
@@ -0,0 +1,365 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	"context"
+
+	"github.com/ailabstw/go-pttai/account"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/ailabstw/go-pttai/rpc"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/**********
+ * Group
+ **********/
+
+func (b *Backend) CreateGroup(title []byte) (*BackendCreateGroup, error) {
+
+	group, err := b.SPM().(*ServiceProtocolManager).CreateGroup(title)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupToBackendCreateGroup(group), nil
+}
+
+func (b *Backend) DeleteGroup(entityIDBytes []byte) (bool, error) {
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	err = pm.DeleteGroup()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Backend) GetGroup(entityIDBytes []byte) (*BackendGetGroup, error) {
+
+	group, err := b.GetRawGroup(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	userName, err := b.accountBackend.GetRawUserNameByID(group.CreatorID)
+	if err != nil {
+		userName = account.NewEmptyUserName()
+	}
+	theTitle, err := b.GetRawTitleByID(group.ID)
+
+	return groupToBackendGetGroup(group, userName.Name, theTitle), nil
+}
+
+func (b *Backend) GetRawGroup(entityIDBytes []byte) (*Group, error) {
+
+	entity, err := b.EntityIDToEntity(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	group := entity.(*Group)
+
+	return group, nil
+}
+
+func (b *Backend) GetGroupList(startingIDBytes []byte, limit int, listOrder pttdb.ListOrder) ([]*BackendGetGroup, error) {
+
+	startID, err := types.UnmarshalTextPttID(startingIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	groupList, err := b.SPM().(*ServiceProtocolManager).GetGroupList(startID, limit, listOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	accountBackend := b.accountBackend
+	backendGroupList := make([]*BackendGetGroup, len(groupList))
+	var userName *account.UserName
+	var title *Title
+	for i, g := range groupList {
+		userName, err = accountBackend.GetRawUserNameByID(g.CreatorID)
+		if err != nil {
+			userName = account.NewEmptyUserName()
+		}
+		title, err = b.GetRawTitleByID(g.ID)
+		backendGroupList[i] = groupToBackendGetGroup(g, userName.Name, title)
+	}
+
+	return backendGroupList, nil
+}
+
+func (b *Backend) ShowGroupURL(entityIDBytes []byte) (*pkgservice.BackendJoinURL, error) {
+
+	theEntity, err := b.EntityIDToEntity(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	group := theEntity.(*Group)
+	pm := group.PM().(*ProtocolManager)
+
+	nodeID := b.Ptt().MyNodeID()
+	myID := b.Ptt().GetMyEntity().GetID()
+
+	if !pm.IsMaster(myID, false) {
+		return nil, types.ErrInvalidID
+	}
+
+	keyInfo, err := pm.GetJoinKey()
+	log.Debug("ShowGroupURL: after get join key", "e", err)
+	if err != nil {
+		return nil, err
+	}
+
+	theTitle, err := pm.GetTitle()
+	log.Debug("ShowGroupURL: after get title", "e", err)
+	if err != nil {
+		return nil, err
+	}
+	title := group.Title
+	if theTitle != nil {
+		title = theTitle.Title
+	}
+
+	return pkgservice.MarshalBackendJoinURL(group.CreatorID, nodeID, keyInfo, title, pkgservice.PathJoinGroup)
+}
+
+func (b *Backend) GetJoinKeys(entityIDBytes []byte) ([]*pkgservice.KeyInfo, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return pm.JoinKeyList(), nil
+}
+
+func (b *Backend) SubscribeGroup(ctx context.Context, entityIDBytes []byte) (*rpc.Subscription, error) {
+
+	return b.SubscribeEntityObjEvent(ctx, entityIDBytes)
+}
+
+func (b *Backend) MarkGroupSeen(entityIDBytes []byte) (types.Timestamp, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return pm.SaveLastSeen(types.ZeroTimestamp)
+}
+
+/**********
+ * Member
+ **********/
+
+func (b *Backend) DeleteMember(entityIDBytes []byte, userIDBytes []byte) (bool, error) {
+
+	userID, err := types.UnmarshalTextPttID(userIDBytes, false)
+	if err != nil {
+		return false, err
+	}
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return pm.DeleteMember(userID)
+}
+
+/**********
+ * Title
+ **********/
+
+func (b *Backend) SetTitle(entityIDBytes []byte, title []byte) (*BackendGetGroup, error) {
+
+	entity, err := b.EntityIDToEntity(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	group := entity.(*Group)
+	pm := group.PM().(*ProtocolManager)
+
+	err = pm.SetTitle(title)
+	if err != nil {
+		return nil, err
+	}
+
+	userName, err := b.accountBackend.GetRawUserNameByID(group.CreatorID)
+	if err != nil {
+		userName = account.NewEmptyUserName()
+	}
+
+	theTitle, err := b.GetRawTitle(entityIDBytes)
+	if err == leveldb.ErrNotFound {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return groupToBackendGetGroup(group, userName.Name, theTitle), nil
+}
+
+func (b *Backend) GetRawTitle(entityIDBytes []byte) (*Title, error) {
+
+	entityID, err := types.UnmarshalTextPttID(entityIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.GetRawTitleByID(entityID)
+}
+
+func (b *Backend) GetRawTitleByID(entityID *types.PttID) (*Title, error) {
+
+	entity := b.SPM().Entity(entityID)
+	if entity == nil {
+		return nil, types.ErrInvalidID
+	}
+	pm := entity.PM().(*ProtocolManager)
+
+	return pm.GetTitle()
+}
+
+/**********
+ * Message
+ **********/
+
+func (b *Backend) CreateGroupMessage(entityIDBytes []byte, message [][]byte, mediaIDStrs []string) (*BackendCreateMessage, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	lenMediaIDs := len(mediaIDStrs)
+	var mediaIDs []*types.PttID = nil
+	var eachMediaID *types.PttID
+	if len(mediaIDStrs) != 0 {
+		mediaIDs = make([]*types.PttID, lenMediaIDs)
+		for i, mediaIDStr := range mediaIDStrs {
+			eachMediaID, err = types.UnmarshalTextPttID([]byte(mediaIDStr), false)
+			if err != nil {
+				return nil, err
+			}
+			mediaIDs[i] = eachMediaID
+		}
+	}
+
+	theMessage, err := pm.CreateMessage(message, mediaIDs)
+	log.Debug("CreateGroupMessage: after CreateMessage", "e", err)
+	if err != nil {
+		return nil, err
+	}
+
+	return messageToBackendCreateMessage(theMessage), nil
+}
+
+func (b *Backend) GetGroupMessageList(entityIDBytes []byte, startIDBytes []byte, limit int, listOrder pttdb.ListOrder) ([]*BackendGetMessage, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	startID, err := types.UnmarshalTextPttID(startIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	messageList, err := pm.GetMessageList(startID, limit, listOrder, true)
+	if err != nil {
+		return nil, err
+	}
+
+	backendMessageList := make([]*BackendGetMessage, len(messageList))
+	for i, message := range messageList {
+		backendMessageList[i] = messageToBackendGetMessage(message)
+	}
+
+	return backendMessageList, nil
+}
+
+func (b *Backend) GetGroupMessageBlockList(entityIDBytes []byte, msgIDBytes []byte, limit uint32) ([]*BackendMessageBlock, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	msgID, err := types.UnmarshalTextPttID(msgIDBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if msgID == nil {
+		return nil, types.ErrInvalidID
+	}
+
+	msg, contentBlocks, err := pm.GetMessageBlockList(msgID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	blockInfo := msg.GetBlockInfo()
+	if blockInfo == nil {
+		return nil, pkgservice.ErrInvalidBlock
+	}
+	blockInfoID := blockInfo.ID
+
+	backendMsgBlocks := make([]*BackendMessageBlock, len(contentBlocks))
+	for i, contentBlock := range contentBlocks {
+		backendMsgBlocks[i] = contentBlockToBackendMessageBlock(msg, blockInfoID, contentBlock)
+	}
+
+	return backendMsgBlocks, nil
+}
+
+/**********
+ * GroupOplog
+ **********/
+
+func (b *Backend) GetGroupOplogList(entityIDBytes []byte, logIDBytes []byte, limit int, listOrder pttdb.ListOrder) ([]*GroupOplog, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	logID, err := types.UnmarshalTextPttID(logIDBytes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return pm.GetGroupOplogList(logID, limit, listOrder, types.StatusAlive)
+}
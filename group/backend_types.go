@@ -0,0 +1,160 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type BackendCreateGroup struct {
+	ID        *types.PttID
+	CreateTS  types.Timestamp `json:"CT"`
+	UpdateTS  types.Timestamp `json:"UT"`
+	CreatorID *types.PttID    `json:"CID"`
+	UpdaterID *types.PttID    `json:"UID"`
+
+	Status types.Status `json:"S"`
+
+	Title []byte `json:"T"`
+}
+
+func groupToBackendCreateGroup(g *Group) *BackendCreateGroup {
+	return &BackendCreateGroup{
+		ID:        g.ID,
+		CreateTS:  g.CreateTS,
+		UpdateTS:  g.UpdateTS,
+		CreatorID: g.CreatorID,
+		UpdaterID: g.UpdaterID,
+		Status:    g.Status,
+		Title:     g.Title,
+	}
+}
+
+type BackendGetGroup struct {
+	ID              *types.PttID
+	Title           []byte
+	Status          types.Status `json:"S"`
+	CreateTS        types.Timestamp
+	UpdateTS        types.Timestamp
+	JoinTS          types.Timestamp `json:"JT"`
+	MessageCreateTS types.Timestamp
+	LastSeen        types.Timestamp
+	CreatorID       *types.PttID `json:"C"`
+	CreatorName     []byte       `json:"CN"`
+}
+
+func groupToBackendGetGroup(g *Group, creatorName []byte, theTitle *Title) *BackendGetGroup {
+	title := g.Title
+	if theTitle != nil {
+		title = theTitle.Title
+	}
+
+	return &BackendGetGroup{
+		ID:              g.ID,
+		Title:           title,
+		Status:          g.Status,
+		CreateTS:        g.CreateTS,
+		UpdateTS:        g.UpdateTS,
+		JoinTS:          g.JoinTS,
+		MessageCreateTS: g.MessageCreateTS,
+		LastSeen:        g.LastSeen,
+		CreatorID:       g.CreatorID,
+		CreatorName:     creatorName,
+	}
+}
+
+type BackendCreateMessage struct {
+	GroupID   *types.PttID `json:"GID"`
+	MessageID *types.PttID `json:"AID"`
+	BlockID   *types.PttID `json:"cID"`
+	NBlock    int          `json:"NB"`
+}
+
+func messageToBackendCreateMessage(m *Message) *BackendCreateMessage {
+
+	return &BackendCreateMessage{
+		GroupID:   m.EntityID,
+		MessageID: m.ID,
+		BlockID:   m.BlockInfo.ID,
+		NBlock:    m.BlockInfo.NBlock,
+	}
+}
+
+type BackendGetMessage struct {
+	ID        *types.PttID
+	CreateTS  types.Timestamp //`json:"CT"`
+	UpdateTS  types.Timestamp //`json:"UT"`
+	CreatorID *types.PttID    //`json:"CID"`
+	GroupID   *types.PttID    //`json:"GID"`
+	BlockID   *types.PttID    //`json:"cID"`
+	NBlock    int             //`json:"N"`
+	Status    types.Status    `json:"S"`
+}
+
+func messageToBackendGetMessage(m *Message) *BackendGetMessage {
+
+	return &BackendGetMessage{
+		ID:        m.ID,
+		CreateTS:  m.CreateTS,
+		UpdateTS:  m.UpdateTS,
+		CreatorID: m.CreatorID,
+		GroupID:   m.EntityID,
+		BlockID:   m.BlockInfo.ID,
+		NBlock:    m.BlockInfo.NBlock,
+		Status:    m.Status,
+	}
+}
+
+type BackendMessageBlock struct {
+	V         types.Version
+	ID        *types.PttID
+	MessageID *types.PttID `json:"AID"`
+	ObjID     *types.PttID `json:"RID"`
+	BlockID   uint32       `json:"BID"`
+
+	Status types.Status `json:"S"`
+
+	CreateTS types.Timestamp `json:"CT"`
+	UpdateTS types.Timestamp `json:"UT"`
+
+	CreatorID *types.PttID `json:"CID"`
+	UpdaterID *types.PttID `json:"UID"`
+
+	Buf [][]byte `json:"B"`
+}
+
+func contentBlockToBackendMessageBlock(msg *Message, blockInfoID *types.PttID, contentBlock *pkgservice.ContentBlock) *BackendMessageBlock {
+
+	objID := msg.ID
+	return &BackendMessageBlock{
+		V:         types.CurrentVersion,
+		ID:        blockInfoID,
+		MessageID: objID,
+		ObjID:     objID,
+		BlockID:   contentBlock.BlockID,
+		Status:    msg.Status,
+
+		CreateTS: msg.CreateTS,
+		UpdateTS: msg.UpdateTS,
+
+		CreatorID: msg.CreatorID,
+		UpdaterID: msg.UpdaterID,
+
+		Buf: contentBlock.Buf,
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	"path/filepath"
+
+	"github.com/ailabstw/go-pttai/node"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+var (
+	DefaultConfig = Config{
+		DataDir: filepath.Join(node.DefaultDataDir(), "group"),
+	}
+)
+
+const (
+	_ pkgservice.OpType = iota + pkgservice.NMsg
+	// group-oplog
+	AddGroupOplogMsg
+	AddGroupOplogsMsg
+
+	AddPendingGroupOplogMsg
+	AddPendingGroupOplogsMsg
+
+	SyncGroupOplogMsg
+	SyncGroupOplogAckMsg
+	SyncGroupOplogNewOplogsMsg
+	SyncGroupOplogNewOplogsAckMsg
+
+	InvalidSyncGroupOplogMsg
+
+	ForceSyncGroupOplogMsg
+	ForceSyncGroupOplogAckMsg
+	ForceSyncGroupOplogByMerkleMsg
+	ForceSyncGroupOplogByMerkleAckMsg
+	ForceSyncGroupOplogByOplogAckMsg
+
+	SyncPendingGroupOplogMsg
+	SyncPendingGroupOplogAckMsg
+
+	// sync title
+	SyncCreateTitleMsg
+	SyncCreateTitleAckMsg
+
+	SyncUpdateTitleMsg
+	SyncUpdateTitleAckMsg
+
+	ForceSyncTitleMsg
+	ForceSyncTitleAckMsg
+
+	// sync message
+	SyncCreateMessageMsg
+	SyncCreateMessageAckMsg
+	SyncCreateMessageBlockMsg
+	SyncCreateMessageBlockAckMsg
+)
+
+var (
+	dbGroupCore *pttdb.LDBDatabase = nil
+	dbGroup     *pttdb.LDBBatch    = nil
+
+	DBGroupIdxOplogPrefix    = []byte(".gpig")
+	DBGroupOplogPrefix       = []byte(".gplg")
+	DBGroupMerkleOplogPrefix = []byte(".gpmk")
+
+	DBGroupPrefix                = []byte(".gpdb")
+	DBGroupIdxPrefix             = []byte(".gpix")
+	DBGroupLastSeenPrefix        = []byte(".gpls")
+	DBGroupMessageCreateTSPrefix = []byte(".gpmc")
+	DBMessagePrefix              = []byte(".gmdb")
+	DBMessageIdxPrefix           = []byte(".gmix")
+	DBTitlePrefix                = []byte(".tldb")
+	DBTitleIdxPrefix             = []byte(".tlix")
+)
+
+const (
+	MaxMasters = 1
+)
+
+const (
+	MaxSyncRandomSeconds = 30
+	MinSyncRandomSeconds = 15
+)
+
+var (
+	RenewOpKeySeconds  int64 = 86400
+	ExpireOpKeySeconds int64 = 259200
+)
+
+const (
+	NFirstLineInBlock = 1
+)
+
+func InitGroup(dataDir string) error {
+	var err error
+
+	// db
+	dbGroupCore, err = pttdb.NewLDBDatabase("group", dataDir, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	dbGroup, err = pttdb.NewLDBBatch(dbGroupCore)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func TeardownGroup() {
+	if dbGroup != nil {
+		dbGroup = nil
+	}
+
+	if dbGroupCore != nil {
+		dbGroupCore.Close()
+		dbGroupCore = nil
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type Group struct {
+	*pkgservice.BaseEntity `json:"e"`
+
+	UpdateTS types.Timestamp `json:"UT"`
+
+	Title []byte `json:"T,omitempty"`
+
+	// get from other dbs
+	LastSeen        types.Timestamp `json:"-"`
+	MessageCreateTS types.Timestamp `json:"-"`
+
+	GroupMerkle *pkgservice.Merkle `json:"-"`
+}
+
+func NewEmptyGroup() *Group {
+	return &Group{BaseEntity: &pkgservice.BaseEntity{SyncInfo: &pkgservice.BaseSyncInfo{}}}
+}
+
+func NewGroup(myID *types.PttID, ts types.Timestamp, ptt pkgservice.Ptt, service pkgservice.Service, spm pkgservice.ServiceProtocolManager, dbLock *types.LockMap) (*Group, error) {
+
+	id, err := pkgservice.NewPttIDWithMyID(myID)
+	if err != nil {
+		return nil, err
+	}
+
+	e := pkgservice.NewBaseEntity(id, ts, myID, types.StatusInit, dbGroup, dbLock)
+
+	g := &Group{
+		BaseEntity: e,
+		UpdateTS:   ts,
+	}
+
+	err = g.Init(ptt, service, spm)
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (g *Group) GetUpdateTS() types.Timestamp {
+	return g.UpdateTS
+}
+
+func (g *Group) SetUpdateTS(ts types.Timestamp) {
+	g.UpdateTS = ts
+}
+
+func (g *Group) Init(ptt pkgservice.Ptt, service pkgservice.Service, spm pkgservice.ServiceProtocolManager) error {
+
+	g.SetDB(dbGroup, spm.GetDBLock())
+
+	err := g.InitPM(ptt, service)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *Group) InitPM(ptt pkgservice.Ptt, service pkgservice.Service) error {
+	pm, err := NewProtocolManager(g, ptt, service)
+	if err != nil {
+		log.Error("InitPM: unable to NewProtocolManager", "e", err)
+		return err
+	}
+
+	g.BaseEntity.Init(pm, ptt, service)
+
+	return nil
+}
+
+func (g *Group) IdxKey() ([]byte, error) {
+	return common.Concat([][]byte{DBGroupIdxPrefix, g.ID[:]})
+}
+
+func (g *Group) MarshalKey() ([]byte, error) {
+	marshalTimestamp, err := g.JoinTS.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return common.Concat([][]byte{DBGroupPrefix, marshalTimestamp, g.ID[:]})
+
+}
+
+func (g *Group) Marshal() ([]byte, error) {
+	return json.Marshal(g)
+}
+
+func (g *Group) Unmarshal(theBytes []byte) error {
+	err := json.Unmarshal(theBytes, g)
+	if err != nil {
+		return err
+	}
+
+	// postprocess
+
+	return nil
+}
+
+func (g *Group) Save(isLocked bool) error {
+	if !isLocked {
+		err := g.Lock()
+		if err != nil {
+			return err
+		}
+		defer g.Unlock()
+	}
+
+	key, err := g.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := g.Marshal()
+	if err != nil {
+		return err
+	}
+
+	idxKey, err := g.IdxKey()
+	if err != nil {
+		return err
+	}
+
+	idx := &pttdb.Index{Keys: [][]byte{key}, UpdateTS: g.UpdateTS}
+
+	kvs := []*pttdb.KeyVal{
+		&pttdb.KeyVal{
+			K: key,
+			V: marshaled,
+		},
+	}
+
+	_, err = dbGroup.ForcePutAll(idxKey, idx, kvs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *Group) SaveLastSeen(ts types.Timestamp) error {
+	g.LastSeen = ts
+
+	key, err := g.MarshalLastSeenKey()
+	if err != nil {
+		return err
+	}
+	val := &pttdb.DBable{
+		UpdateTS: ts,
+	}
+	marshaled, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+
+	_, err = dbGroupCore.TryPut(key, marshaled, ts)
+	if err != nil && err != pttdb.ErrInvalidUpdateTS {
+		return err
+	}
+
+	return nil
+}
+
+func (g *Group) LoadLastSeen() (types.Timestamp, error) {
+	key, err := g.MarshalLastSeenKey()
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+	data, err := dbGroupCore.Get(key)
+	if err != nil {
+		if err == leveldb.ErrNotFound {
+			err = nil
+		}
+		return types.ZeroTimestamp, err
+	}
+
+	val := &pttdb.DBable{}
+	err = json.Unmarshal(data, val)
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+
+	return val.UpdateTS, nil
+}
+
+func (g *Group) MarshalLastSeenKey() ([]byte, error) {
+	return common.Concat([][]byte{DBGroupLastSeenPrefix, g.ID[:]})
+}
+
+func (g *Group) SaveMessageCreateTS(ts types.Timestamp) error {
+	g.MessageCreateTS = ts
+
+	key, err := g.MarshalMessageCreateTSKey()
+	if err != nil {
+		return err
+	}
+	val := &pttdb.DBable{
+		UpdateTS: ts,
+	}
+	marshaled, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+
+	_, err = dbGroupCore.TryPut(key, marshaled, ts)
+	if err != nil && err != pttdb.ErrInvalidUpdateTS {
+		return err
+	}
+
+	return nil
+}
+
+func (g *Group) LoadMessageCreateTS() (types.Timestamp, error) {
+	key, err := g.MarshalMessageCreateTSKey()
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+	data, err := dbGroupCore.Get(key)
+	if err != nil {
+		if err == leveldb.ErrNotFound {
+			err = nil
+		}
+		return types.ZeroTimestamp, err
+	}
+
+	val := &pttdb.DBable{}
+	err = json.Unmarshal(data, val)
+	if err != nil {
+		return types.ZeroTimestamp, err
+	}
+
+	return val.UpdateTS, nil
+}
+
+func (g *Group) MarshalMessageCreateTSKey() ([]byte, error) {
+	return common.Concat([][]byte{DBGroupMessageCreateTSPrefix, g.ID[:]})
+}
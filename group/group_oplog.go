@@ -0,0 +1,106 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type GroupOplog struct {
+	*pkgservice.BaseOplog `json:"O"`
+}
+
+func (o *GroupOplog) GetBaseOplog() *pkgservice.BaseOplog {
+	return o.BaseOplog
+}
+
+func NewGroupOplog(objID *types.PttID, ts types.Timestamp, doerID *types.PttID, op pkgservice.OpType, opData pkgservice.OpData, userID *types.PttID, dbLock *types.LockMap) (*GroupOplog, error) {
+
+	oplog, err := pkgservice.NewOplog(objID, ts, doerID, op, opData, dbGroup, userID, DBGroupOplogPrefix, DBGroupIdxOplogPrefix, DBGroupMerkleOplogPrefix, dbLock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GroupOplog{
+		BaseOplog: oplog,
+	}, nil
+}
+
+func (pm *ProtocolManager) NewGroupOplog(objID *types.PttID, op pkgservice.OpType, opData pkgservice.OpData) (pkgservice.Oplog, error) {
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("NewGroupOplog: to NewGroupOplogWithTS", "objID", objID)
+
+	return pm.NewGroupOplogWithTS(objID, ts, op, opData)
+}
+
+func (pm *ProtocolManager) NewGroupOplogWithTS(objID *types.PttID, ts types.Timestamp, op pkgservice.OpType, opData pkgservice.OpData) (pkgservice.Oplog, error) {
+
+	log.Debug("NewGroupOplogWithTS: start", "objID", objID)
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	entityID := pm.Entity().GetID()
+
+	oplog, err := NewGroupOplog(objID, ts, myID, op, opData, entityID, pm.dbGroupLock)
+	if err != nil {
+		return nil, err
+	}
+	pm.SetGroupDB(oplog.BaseOplog)
+	return oplog, nil
+}
+
+func (spm *ServiceProtocolManager) NewGroupOplogWithTS(entityID *types.PttID, ts types.Timestamp, op pkgservice.OpType, opData pkgservice.OpData) (pkgservice.Oplog, error) {
+
+	myID := spm.Ptt().GetMyEntity().GetID()
+	log.Debug("spm.NewGroupOplogWithTS: start", "ts", ts)
+
+	return NewGroupOplog(entityID, ts, myID, op, opData, entityID, spm.GetDBLogLock())
+}
+
+func (pm *ProtocolManager) SetGroupDB(oplog *pkgservice.BaseOplog) {
+	userID := pm.Entity().GetID()
+	oplog.SetDB(dbGroup, userID, DBGroupOplogPrefix, DBGroupIdxOplogPrefix, DBGroupMerkleOplogPrefix, pm.dbGroupLock)
+}
+
+func OplogsToGroupOplogs(logs []*pkgservice.BaseOplog) []*GroupOplog {
+	typedLogs := make([]*GroupOplog, len(logs))
+	for i, log := range logs {
+		typedLogs[i] = &GroupOplog{BaseOplog: log}
+	}
+	return typedLogs
+}
+
+func GroupOplogsToOplogs(typedLogs []*GroupOplog) []*pkgservice.BaseOplog {
+	logs := make([]*pkgservice.BaseOplog, len(typedLogs))
+	for i, log := range typedLogs {
+		logs[i] = log.BaseOplog
+	}
+	return logs
+}
+
+func OplogToGroupOplog(oplog *pkgservice.BaseOplog) *GroupOplog {
+	if oplog == nil {
+		return nil
+	}
+	return &GroupOplog{BaseOplog: oplog}
+}
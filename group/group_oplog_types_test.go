@@ -0,0 +1,84 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func TestGroupOplog_SignVerify(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	id, _ := types.NewPttID()
+	id2, _ := types.NewPttID()
+
+	// prepare test-cases
+	tests := []struct {
+		name string
+		op   pkgservice.OpType
+		data interface{}
+	}{
+		{
+			name: "create-group",
+			op:   GroupOpTypeCreateGroup,
+			data: &GroupOpCreateGroup{Title: []byte("title")},
+		},
+		{
+			name: "create-title",
+			op:   GroupOpTypeCreateTitle,
+			data: &GroupOpCreateTitle{TitleHash: []byte{1, 2}},
+		},
+		{
+			name: "update-title",
+			op:   GroupOpTypeUpdateTitle,
+			data: &GroupOpUpdateTitle{TitleHash: []byte{1, 2}},
+		},
+		{
+			name: "create-message",
+			op:   GroupOpTypeCreateMessage,
+			data: &GroupOpCreateMessage{BlockInfoID: id2, Hashs: [][][]byte{{{1, 2}}}, NBlock: 1, MediaIDs: []*types.PttID{id2}},
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the op-data is declared in the order of the json keys.
+			err := pkgservice.CheckOpDataSorted(tt.data)
+			if err != nil {
+				t.Errorf("CheckOpDataSorted() error = %v", err)
+			}
+
+			o, err := pkgservice.NewOplog(id, tDefaultTimestamp, id, tt.op, tt.data, nil, id, DBGroupOplogPrefix, DBGroupIdxOplogPrefix, DBGroupMerkleOplogPrefix, nil)
+			if err != nil {
+				t.Errorf("NewOplog() error = %v", err)
+				return
+			}
+			err = pkgservice.CheckOplogSignVerify(o)
+			if err != nil {
+				t.Errorf("CheckOplogSignVerify() error = %v", err)
+			}
+		})
+	}
+
+	// teardown test
+}
@@ -0,0 +1,80 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type CreateGroup struct {
+	Title []byte `json:"T"`
+}
+
+func (spm *ServiceProtocolManager) CreateGroup(title []byte) (*Group, error) {
+
+	data := &CreateGroup{
+		Title: title,
+	}
+
+	entity, err := spm.CreateEntity(data, GroupOpTypeCreateGroup, spm.NewGroup, spm.NewGroupOplogWithTS, nil, spm.postcreateGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	group, ok := entity.(*Group)
+	if !ok {
+		return nil, pkgservice.ErrInvalidEntity
+	}
+
+	return group, nil
+}
+
+func (spm *ServiceProtocolManager) NewGroup(theData pkgservice.CreateData, ptt pkgservice.Ptt, service pkgservice.Service) (pkgservice.Entity, pkgservice.OpData, error) {
+
+	data, ok := theData.(*CreateGroup)
+	if !ok {
+		return nil, nil, pkgservice.ErrInvalidData
+	}
+
+	myID := spm.Ptt().GetMyEntity().GetID()
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	group, err := NewGroup(myID, ts, ptt, service, spm, spm.GetDBLock())
+	if err != nil {
+		return nil, nil, err
+	}
+	group.EntityType = pkgservice.EntityTypePrivate
+	group.Title = data.Title
+
+	return group, &GroupOpCreateGroup{Title: data.Title}, nil
+}
+
+func (spm *ServiceProtocolManager) postcreateGroup(entity pkgservice.Entity) error {
+
+	err := spm.Ptt().GetMyEntity().CreateEntityOplog(entity)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
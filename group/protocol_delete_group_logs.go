@@ -0,0 +1,91 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) handleDeleteGroupLogs(oplog *pkgservice.BaseOplog, info *ProcessGroupInfo) ([]*pkgservice.BaseOplog, error) {
+
+	opData := &GroupOpDeleteGroup{}
+
+	log.Debug("handleDeleteGroupLogs: start", "entity", pm.Entity().IDString())
+
+	return pm.HandleDeleteEntityLog(
+		oplog,
+		info,
+
+		opData,
+		types.StatusTerminal,
+
+		pm.groupOplogMerkle,
+
+		pm.SetGroupDB,
+		nil,
+		pm.updateGroupDeleteInfo,
+	)
+}
+
+func (pm *ProtocolManager) handlePendingDeleteGroupLogs(oplog *pkgservice.BaseOplog, info *ProcessGroupInfo) (types.Bool, []*pkgservice.BaseOplog, error) {
+
+	opData := &GroupOpDeleteGroup{}
+
+	return pm.HandlePendingDeleteEntityLog(
+		oplog,
+		info,
+
+		types.StatusInternalTerminal,
+		types.StatusPendingTerminal,
+		GroupOpTypeDeleteGroup,
+		opData,
+
+		pm.groupOplogMerkle,
+
+		pm.SetGroupDB,
+		pm.setPendingDeleteGroupSyncInfo,
+		pm.updateGroupDeleteInfo,
+	)
+}
+
+func (pm *ProtocolManager) setNewestDeleteGroupLog(oplog *pkgservice.BaseOplog) (types.Bool, error) {
+
+	return false, nil
+}
+
+func (pm *ProtocolManager) handleFailedDeleteGroupLog(oplog *pkgservice.BaseOplog) error {
+
+	return pm.HandleFailedDeleteEntityLog(oplog)
+}
+
+/**********
+ * Customize
+ **********/
+
+func (pm *ProtocolManager) updateGroupDeleteInfo(oplog *pkgservice.BaseOplog, theInfo pkgservice.ProcessInfo) error {
+
+	info, ok := theInfo.(*ProcessGroupInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	info.GroupInfo[*oplog.ObjID] = oplog
+
+	return nil
+}
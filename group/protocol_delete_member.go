@@ -0,0 +1,51 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+/*
+DeleteMember kicks the member out of the group.
+
+The op-key is renewed right after the kick so that the kicked member is unable to read the new messages.
+*/
+func (pm *ProtocolManager) DeleteMember(id *types.PttID) (bool, error) {
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if !pm.IsMaster(myID, false) || reflect.DeepEqual(myID, id) {
+		return false, types.ErrInvalidID
+	}
+
+	if !pm.IsMember(id, false) {
+		return false, ErrInvalidMember
+	}
+
+	isOk, err := pm.BaseProtocolManager.DeleteMember(id)
+	if err != nil {
+		return false, err
+	}
+
+	select {
+	case pm.ForceOpKey() <- struct{}{}:
+	case <-pm.QuitSync():
+	}
+
+	return isOk, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) CreateGroupOplog(objID *types.PttID, ts types.Timestamp, op pkgservice.OpType, data interface{}) (*GroupOplog, error) {
+
+	myID := pm.Entity().GetID()
+
+	oplog, err := NewGroupOplog(objID, ts, myID, op, data, myID, pm.dbGroupLock)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pm.SignOplog(oplog.BaseOplog)
+	if err != nil {
+		return nil, err
+	}
+
+	return oplog, nil
+}
+
+/**********
+ * BroadcastGroupOplog
+ **********/
+
+func (pm *ProtocolManager) BroadcastGroupOplog(oplog *GroupOplog) error {
+	return pm.broadcastGroupOplogCore(oplog.BaseOplog)
+}
+
+func (pm *ProtocolManager) broadcastGroupOplogCore(oplog *pkgservice.BaseOplog) error {
+	return pm.BroadcastOplog(oplog, AddGroupOplogMsg, AddPendingGroupOplogMsg)
+}
+
+/**********
+ * BroadcastGroupOplogs
+ **********/
+
+func (pm *ProtocolManager) BroadcastGroupOplogs(opKeyLogs []*GroupOplog) error {
+	oplogs := GroupOplogsToOplogs(opKeyLogs)
+	return pm.broadcastGroupOplogsCore(oplogs)
+}
+
+func (pm *ProtocolManager) broadcastGroupOplogsCore(oplogs []*pkgservice.BaseOplog) error {
+	return pm.BroadcastOplogs(oplogs, AddGroupOplogsMsg, AddPendingGroupOplogsMsg)
+}
+
+/**********
+ * SetGroupOplogIsSync
+ **********/
+
+func (pm *ProtocolManager) SetGroupOplogIsSync(oplog *GroupOplog, isBroadcast bool) (bool, error) {
+	return pm.SetOplogIsSync(oplog.BaseOplog, isBroadcast, pm.broadcastGroupOplogCore)
+}
@@ -0,0 +1,229 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type ProcessGroupInfo struct {
+	CreateTitleInfo map[types.PttID]*pkgservice.BaseOplog
+	TitleInfo       map[types.PttID]*pkgservice.BaseOplog
+
+	CreateMessageInfo map[types.PttID]*pkgservice.BaseOplog
+	MessageBlockInfo  map[types.PttID]*pkgservice.BaseOplog
+
+	GroupInfo map[types.PttID]*pkgservice.BaseOplog
+}
+
+func NewProcessGroupInfo() *ProcessGroupInfo {
+	return &ProcessGroupInfo{
+		CreateTitleInfo: make(map[types.PttID]*pkgservice.BaseOplog),
+		TitleInfo:       make(map[types.PttID]*pkgservice.BaseOplog),
+
+		CreateMessageInfo: make(map[types.PttID]*pkgservice.BaseOplog),
+		MessageBlockInfo:  make(map[types.PttID]*pkgservice.BaseOplog),
+
+		GroupInfo: make(map[types.PttID]*pkgservice.BaseOplog),
+	}
+}
+
+/**********
+ * Process Oplog
+ **********/
+
+func (pm *ProtocolManager) processGroupLog(oplog *pkgservice.BaseOplog, processInfo pkgservice.ProcessInfo) (origLogs []*pkgservice.BaseOplog, err error) {
+	info, ok := processInfo.(*ProcessGroupInfo)
+	if !ok {
+		return nil, pkgservice.ErrInvalidData
+	}
+
+	switch oplog.Op {
+	case GroupOpTypeDeleteGroup:
+		origLogs, err = pm.handleDeleteGroupLogs(oplog, info)
+
+	case GroupOpTypeCreateTitle:
+		origLogs, err = pm.handleCreateTitleLogs(oplog, info)
+	case GroupOpTypeUpdateTitle:
+		origLogs, err = pm.handleUpdateTitleLogs(oplog, info)
+
+	case GroupOpTypeCreateMessage:
+		origLogs, err = pm.handleCreateMessageLogs(oplog, info)
+	}
+	return
+}
+
+/**********
+ * Process Pending Oplog
+ **********/
+
+func (pm *ProtocolManager) processPendingGroupLog(oplog *pkgservice.BaseOplog, processInfo pkgservice.ProcessInfo) (isToSign types.Bool, origLogs []*pkgservice.BaseOplog, err error) {
+	info, ok := processInfo.(*ProcessGroupInfo)
+	if !ok {
+		return false, nil, pkgservice.ErrInvalidData
+	}
+
+	switch oplog.Op {
+	case GroupOpTypeDeleteGroup:
+		isToSign, origLogs, err = pm.handlePendingDeleteGroupLogs(oplog, info)
+
+	case GroupOpTypeCreateTitle:
+		isToSign, origLogs, err = pm.handlePendingCreateTitleLogs(oplog, info)
+	case GroupOpTypeUpdateTitle:
+		isToSign, origLogs, err = pm.handlePendingUpdateTitleLogs(oplog, info)
+
+	case GroupOpTypeCreateMessage:
+		isToSign, origLogs, err = pm.handlePendingCreateMessageLogs(oplog, info)
+	}
+
+	return
+}
+
+/**********
+ * Postprocess Oplog
+ **********/
+
+func (pm *ProtocolManager) postprocessGroupOplogs(processInfo pkgservice.ProcessInfo, toBroadcastLogs []*pkgservice.BaseOplog, peer *pkgservice.PttPeer, isPending bool) (err error) {
+	info, ok := processInfo.(*ProcessGroupInfo)
+	if !ok {
+		err = pkgservice.ErrInvalidData
+	}
+
+	// title
+	createTitleIDs := pkgservice.ProcessInfoToSyncIDList(info.CreateTitleInfo, GroupOpTypeCreateTitle)
+
+	updateTitleIDs := pkgservice.ProcessInfoToSyncIDList(info.TitleInfo, GroupOpTypeUpdateTitle)
+
+	pm.SyncTitle(SyncCreateTitleMsg, createTitleIDs, peer)
+	pm.SyncTitle(SyncUpdateTitleMsg, updateTitleIDs, peer)
+
+	// message
+	createMessageIDs := pkgservice.ProcessInfoToSyncIDList(info.CreateMessageInfo, GroupOpTypeCreateMessage)
+	createMessageBlockIDs := pkgservice.ProcessInfoToSyncBlockIDList(info.MessageBlockInfo, GroupOpTypeCreateMessage)
+	pm.SyncMessage(SyncCreateMessageMsg, createMessageIDs, peer)
+	pm.SyncBlock(SyncCreateMessageBlockMsg, createMessageBlockIDs, peer)
+
+	// broadcast
+	myID := pm.Ptt().GetMyEntity().GetID()
+	if isPending || pm.IsMaster(myID, false) {
+		pm.broadcastGroupOplogsCore(toBroadcastLogs)
+	}
+
+	// post-delete-group
+	if !isPending && len(info.GroupInfo) > 0 {
+		pm.postdeleteGroup(nil, false)
+	}
+
+	return
+}
+
+/**********
+ * Set Newest Oplog
+ **********/
+
+func (pm *ProtocolManager) SetNewestGroupOplog(oplog *pkgservice.BaseOplog) (err error) {
+	var isNewer types.Bool
+
+	switch oplog.Op {
+	case GroupOpTypeDeleteGroup:
+
+	case GroupOpTypeCreateTitle:
+		isNewer, err = pm.setNewestCreateTitleLog(oplog)
+	case GroupOpTypeUpdateTitle:
+		isNewer, err = pm.setNewestUpdateTitleLog(oplog)
+
+	case GroupOpTypeCreateMessage:
+		isNewer, err = pm.setNewestCreateMessageLog(oplog)
+	}
+
+	oplog.IsNewer = isNewer
+
+	return
+}
+
+/**********
+ * Handle Failed Oplog
+ **********/
+
+func (pm *ProtocolManager) HandleFailedGroupOplog(oplog *pkgservice.BaseOplog) (err error) {
+
+	switch oplog.Op {
+	case GroupOpTypeDeleteGroup:
+
+	case GroupOpTypeCreateTitle:
+		err = pm.handleFailedCreateTitleLog(oplog)
+	case GroupOpTypeUpdateTitle:
+		err = pm.handleFailedUpdateTitleLog(oplog)
+
+	case GroupOpTypeCreateMessage:
+		err = pm.handleFailedCreateMessageLog(oplog)
+	}
+
+	return
+}
+
+/**********
+ * Handle Failed Valid Oplog
+ **********/
+
+func (pm *ProtocolManager) HandleFailedValidGroupOplog(oplog *pkgservice.BaseOplog, processInfo pkgservice.ProcessInfo) (err error) {
+
+	info, ok := processInfo.(*ProcessGroupInfo)
+	if !ok {
+		err = pkgservice.ErrInvalidData
+	}
+
+	switch oplog.Op {
+	case GroupOpTypeDeleteGroup:
+
+	case GroupOpTypeCreateTitle:
+		err = pm.handleFailedValidCreateTitleLog(oplog, info)
+	case GroupOpTypeUpdateTitle:
+		err = pm.handleFailedValidUpdateTitleLog(oplog, info)
+
+	case GroupOpTypeCreateMessage:
+		err = pm.handleFailedValidCreateMessageLog(oplog, info)
+	}
+
+	return
+}
+
+func (pm *ProtocolManager) postprocessFailedValidGroupOplogs(processInfo pkgservice.ProcessInfo, peer *pkgservice.PttPeer) error {
+
+	info, ok := processInfo.(*ProcessGroupInfo)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	// title
+	titleIDs := pkgservice.ProcessInfoToForceSyncIDList(info.TitleInfo)
+
+	pm.ForceSyncTitle(titleIDs, peer)
+
+	return nil
+}
+
+/**********
+ * Postsync Oplog
+ **********/
+
+func (pm *ProtocolManager) postsyncGroupOplogs(peer *pkgservice.PttPeer) (err error) {
+	err = pm.SyncPendingGroupOplog(peer)
+
+	return
+}
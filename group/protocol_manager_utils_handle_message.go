@@ -0,0 +1,95 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package group
+
+import (
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) HandleMessage(op pkgservice.OpType, dataBytes []byte, peer *pkgservice.PttPeer) error {
+
+	var err error
+
+	switch op {
+	// group oplog
+	case SyncGroupOplogMsg:
+		err = pm.HandleSyncGroupOplog(dataBytes, peer)
+
+	case ForceSyncGroupOplogByMerkleMsg:
+		return pm.HandleForceSyncGroupOplogByMerkle(dataBytes, peer)
+	case ForceSyncGroupOplogByMerkleAckMsg:
+		return pm.HandleForceSyncGroupOplogByMerkleAck(dataBytes, peer)
+	case ForceSyncGroupOplogByOplogAckMsg:
+		return pm.HandleForceSyncGroupOplogByOplogAck(dataBytes, peer)
+	case InvalidSyncGroupOplogMsg:
+		err = pm.HandleSyncGroupOplogInvalid(dataBytes, peer)
+
+	case ForceSyncGroupOplogMsg:
+		err = pm.HandleForceSyncGroupOplog(dataBytes, peer)
+	case ForceSyncGroupOplogAckMsg:
+		err = pm.HandleForceSyncGroupOplogAck(dataBytes, peer)
+
+	case SyncGroupOplogAckMsg:
+		err = pm.HandleSyncGroupOplogAck(dataBytes, peer)
+	case SyncGroupOplogNewOplogsMsg:
+		err = pm.HandleSyncNewGroupOplog(dataBytes, peer)
+	case SyncGroupOplogNewOplogsAckMsg:
+		err = pm.HandleSyncNewGroupOplogAck(dataBytes, peer)
+	case SyncPendingGroupOplogMsg:
+		err = pm.HandleSyncPendingGroupOplog(dataBytes, peer)
+	case SyncPendingGroupOplogAckMsg:
+		err = pm.HandleSyncPendingGroupOplogAck(dataBytes, peer)
+
+	case AddGroupOplogMsg:
+		err = pm.HandleAddGroupOplog(dataBytes, peer)
+	case AddGroupOplogsMsg:
+		err = pm.HandleAddGroupOplogs(dataBytes, peer)
+	case AddPendingGroupOplogMsg:
+		err = pm.HandleAddPendingGroupOplog(dataBytes, peer)
+	case AddPendingGroupOplogsMsg:
+		err = pm.HandleAddPendingGroupOplogs(dataBytes, peer)
+
+	// title
+	case SyncCreateTitleMsg:
+		err = pm.HandleSyncCreateTitle(dataBytes, peer, SyncCreateTitleAckMsg)
+	case SyncCreateTitleAckMsg:
+		err = pm.HandleSyncCreateTitleAck(dataBytes, peer)
+	case SyncUpdateTitleMsg:
+		err = pm.HandleSyncUpdateTitle(dataBytes, peer, SyncUpdateTitleAckMsg)
+	case SyncUpdateTitleAckMsg:
+		err = pm.HandleSyncUpdateTitleAck(dataBytes, peer)
+	case ForceSyncTitleMsg:
+		err = pm.HandleForceSyncTitle(dataBytes, peer)
+	case ForceSyncTitleAckMsg:
+		err = pm.HandleForceSyncTitleAck(dataBytes, peer)
+
+	// message
+	case SyncCreateMessageMsg:
+		err = pm.HandleSyncCreateMessage(dataBytes, peer, SyncCreateMessageAckMsg)
+	case SyncCreateMessageAckMsg:
+		err = pm.HandleSyncCreateMessageAck(dataBytes, peer)
+	case SyncCreateMessageBlockMsg:
+		err = pm.HandleSyncMessageBlock(dataBytes, peer)
+	case SyncCreateMessageBlockAckMsg:
+		err = pm.HandleSyncCreateMessageBlockAck(dataBytes, peer)
+
+	default:
+		err = pkgservice.ErrInvalidMsgCode
+	}
+
+	return err
+}
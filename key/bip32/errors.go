@@ -21,4 +21,5 @@ import "errors"
 var (
 	ErrInvalidChild = errors.New("invalid child")
 	ErrInvalidKey   = errors.New("invalid key")
+	ErrInvalidSeed  = errors.New("invalid seed")
 )
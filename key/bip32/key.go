@@ -186,3 +186,26 @@ func PubKeyToExtKey(pub *ecdsa.PublicKey, chainCode []byte) (*ExtendedKey, error
 		isPrivate: false,
 	}, nil
 }
+
+/*
+NewMasterKey generates the master extended-key from the seed (ex: the bip39 seed).
+*/
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	hmac512 := hmac.New(sha512.New, MasterKeySeed)
+	hmac512.Write(seed)
+	lr := hmac512.Sum(nil)
+
+	secretKey := lr[:len(lr)/2]
+	chainCode := lr[len(lr)/2:]
+
+	keyNum := new(big.Int).SetBytes(secretKey)
+	if keyNum.Cmp(crypto.S256().Params().N) >= 0 || keyNum.Sign() == 0 {
+		return nil, ErrInvalidSeed
+	}
+
+	return &ExtendedKey{
+		key:       secretKey,
+		chainCode: chainCode,
+		isPrivate: true,
+	}, nil
+}
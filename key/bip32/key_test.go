@@ -18,6 +18,7 @@ package bip32
 
 import (
 	"crypto/ecdsa"
+	"encoding/hex"
 	"reflect"
 	"testing"
 
@@ -439,3 +440,29 @@ func TestExtendedKey_ToPubkey(t *testing.T) {
 
 	// teardown test
 }
+
+func TestNewMasterKey(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	// BIP-0032 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	wantKey, _ := hex.DecodeString("e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35")
+	wantChainCode, _ := hex.DecodeString("873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508")
+
+	// run test
+	got, err := NewMasterKey(seed)
+	if err != nil {
+		t.Errorf("NewMasterKey() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(got.key, wantKey) {
+		t.Errorf("NewMasterKey() key = %x, want %x", got.key, wantKey)
+	}
+	if !reflect.DeepEqual(got.chainCode, wantChainCode) {
+		t.Errorf("NewMasterKey() chainCode = %x, want %x", got.chainCode, wantChainCode)
+	}
+
+	// teardown test
+}
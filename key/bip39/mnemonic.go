@@ -0,0 +1,143 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package bip39 implements the mnemonic code for generating deterministic keys (BIP-0039).
+package bip39
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+/*
+NewEntropy generates the random entropy with bitSize bits.
+bitSize needs to be a multiple of 32 in [MinEntropyBitSize, MaxEntropyBitSize].
+*/
+func NewEntropy(bitSize int) ([]byte, error) {
+	if !isValidEntropyBitSize(bitSize) {
+		return nil, ErrInvalidEntropy
+	}
+
+	entropy := make([]byte, bitSize/8)
+	_, err := rand.Read(entropy)
+	if err != nil {
+		return nil, err
+	}
+
+	return entropy, nil
+}
+
+/*
+NewMnemonic converts the entropy to the mnemonic, with the checksum as the first bitSize / 32 bits of sha256(entropy).
+*/
+func NewMnemonic(entropy []byte) (string, error) {
+	bitSize := len(entropy) * 8
+	if !isValidEntropyBitSize(bitSize) {
+		return "", ErrInvalidEntropy
+	}
+
+	checksumBitSize := bitSize / 32
+	nWords := (bitSize + checksumBitSize) / NBitsWordIndex
+
+	// entropy || checksum
+	checksum := sha256.Sum256(entropy)
+	data := new(big.Int).SetBytes(entropy)
+	data.Lsh(data, uint(checksumBitSize))
+	data.Or(data, big.NewInt(int64(checksum[0]>>uint(8-checksumBitSize))))
+
+	mask := big.NewInt(1<<NBitsWordIndex - 1)
+	words := make([]string, nWords)
+	idx := new(big.Int)
+	for i := nWords - 1; i >= 0; i-- {
+		idx.And(data, mask)
+		words[i] = EnglishWordList[idx.Int64()]
+		data.Rsh(data, NBitsWordIndex)
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+/*
+MnemonicToEntropy converts the mnemonic back to the entropy, and validates the checksum.
+*/
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	nWords := len(words)
+	if nWords%3 != 0 {
+		return nil, ErrInvalidMnemonic
+	}
+
+	totalBitSize := nWords * NBitsWordIndex
+	checksumBitSize := totalBitSize / 33
+	bitSize := totalBitSize - checksumBitSize
+	if !isValidEntropyBitSize(bitSize) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	data := new(big.Int)
+	for _, word := range words {
+		idx, ok := wordIndexes[word]
+		if !ok {
+			return nil, ErrInvalidMnemonic
+		}
+
+		data.Lsh(data, NBitsWordIndex)
+		data.Or(data, big.NewInt(int64(idx)))
+	}
+
+	checksumMask := big.NewInt(1<<uint(checksumBitSize) - 1)
+	checksumBits := new(big.Int).And(data, checksumMask).Int64()
+	data.Rsh(data, uint(checksumBitSize))
+
+	entropy := make([]byte, bitSize/8)
+	dataBytes := data.Bytes()
+	copy(entropy[len(entropy)-len(dataBytes):], dataBytes)
+
+	checksum := sha256.Sum256(entropy)
+	if int64(checksum[0]>>uint(8-checksumBitSize)) != checksumBits {
+		return nil, ErrInvalidChecksum
+	}
+
+	return entropy, nil
+}
+
+func IsValidMnemonic(mnemonic string) bool {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err == nil
+}
+
+/*
+NewSeed validates the mnemonic and derives the seed by PBKDF2-HMAC-SHA512 with the password.
+*/
+func NewSeed(mnemonic string, password string) ([]byte, error) {
+	_, err := MnemonicToEntropy(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := strings.Join(strings.Fields(mnemonic), " ")
+
+	return pbkdf2.Key([]byte(normalized), []byte(SeedSaltPrefix+password), SeedIterations, SeedLength, sha512.New), nil
+}
+
+func isValidEntropyBitSize(bitSize int) bool {
+	return bitSize%32 == 0 && bitSize >= MinEntropyBitSize && bitSize <= MaxEntropyBitSize
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package bip39
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+var (
+	tVectors = []struct {
+		entropy  string
+		mnemonic string
+	}{
+		{
+			entropy:  "00000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		},
+		{
+			entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		},
+		{
+			entropy:  "ffffffffffffffffffffffffffffffff",
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		},
+		{
+			entropy:  "9e885d952ad362caeb4efe34a8e91bd2",
+			mnemonic: "ozone drill grab fiber curtain grace pudding thank cruise elder eight picnic",
+		},
+		{
+			entropy:  "6610b25967cdcca9d59875f5cb50b0ea75433311869e930b",
+			mnemonic: "gravity machine north sort system female filter attitude volume fold club stay feature office ecology stable narrow fog",
+		},
+		{
+			entropy:  "68a79eaca2324873eacc50cb9c6eca8cc68ea5d936f98787c60c7ebc74e6ce7c",
+			mnemonic: "hamster diagram private dutch cause delay private meat slide toddler razor book happy fancy gospel tennis maple dilemma loan word shrug inflict delay length",
+		},
+	}
+)
+
+func TestNewMnemonic(t *testing.T) {
+	for _, tt := range tVectors {
+		t.Run(tt.entropy, func(t *testing.T) {
+			entropy, _ := hex.DecodeString(tt.entropy)
+
+			got, err := NewMnemonic(entropy)
+			if err != nil {
+				t.Errorf("NewMnemonic() error = %v", err)
+				return
+			}
+			if got != tt.mnemonic {
+				t.Errorf("NewMnemonic() = %v, want %v", got, tt.mnemonic)
+			}
+
+			gotEntropy, err := MnemonicToEntropy(got)
+			if err != nil {
+				t.Errorf("MnemonicToEntropy() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(gotEntropy, entropy) {
+				t.Errorf("MnemonicToEntropy() = %v, want %v", gotEntropy, entropy)
+			}
+		})
+	}
+}
+
+func TestMnemonicToEntropy(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		wantErr  error
+	}{
+		{
+			name:     "invalid checksum",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+			wantErr:  ErrInvalidChecksum,
+		},
+		{
+			name:     "invalid word",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon pttai",
+			wantErr:  ErrInvalidMnemonic,
+		},
+		{
+			name:     "invalid length",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			wantErr:  ErrInvalidMnemonic,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MnemonicToEntropy(tt.mnemonic)
+			if err != tt.wantErr {
+				t.Errorf("MnemonicToEntropy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewSeed(t *testing.T) {
+	want := "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"
+
+	got, err := NewSeed(tVectors[0].mnemonic, "TREZOR")
+	if err != nil {
+		t.Errorf("NewSeed() error = %v", err)
+		return
+	}
+	if hex.EncodeToString(got) != want {
+		t.Errorf("NewSeed() = %x, want %v", got, want)
+	}
+}
+
+func TestNewEntropy(t *testing.T) {
+	entropy, err := NewEntropy(DefaultEntropyBitSize)
+	if err != nil {
+		t.Errorf("NewEntropy() error = %v", err)
+		return
+	}
+
+	mnemonic, _ := NewMnemonic(entropy)
+	if !IsValidMnemonic(mnemonic) {
+		t.Errorf("IsValidMnemonic() = false, want true: %v", mnemonic)
+	}
+
+	_, err = NewEntropy(100)
+	if err != ErrInvalidEntropy {
+		t.Errorf("NewEntropy() error = %v, wantErr %v", err, ErrInvalidEntropy)
+	}
+}
@@ -175,6 +175,39 @@ func (api *PrivateAPI) RemoveBoardRequests(entityID string, hash []byte) (bool,
 	return api.b.RemoveBoardRequests([]byte(entityID), hash)
 }
 
+/**********
+ * JoinGroup
+ **********/
+
+func (api *PrivateAPI) JoinGroup(groupURL string) (*pkgservice.BackendJoinRequest, error) {
+	return api.b.JoinGroup([]byte(groupURL))
+}
+
+/*
+GetGroupRequests get the group-requests from me to the others.
+*/
+func (api *PrivateAPI) GetGroupRequests(entityID string) ([]*pkgservice.BackendJoinRequest, error) {
+	var err error
+	if len(entityID) == 0 {
+		entityID, err = api.b.GetMyIDStr()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return api.b.GetGroupRequests([]byte(entityID))
+}
+
+func (api *PrivateAPI) RemoveGroupRequests(entityID string, hash []byte) (bool, error) {
+	var err error
+	if len(entityID) == 0 {
+		entityID, err = api.b.GetMyIDStr()
+		if err != nil {
+			return false, err
+		}
+	}
+	return api.b.RemoveGroupRequests([]byte(entityID), hash)
+}
+
 /**********
  * Op
  **********/
@@ -462,6 +495,10 @@ func (api *PrivateAPI) ValidateMyNodeKey(nodeKeyBytes []byte) (bool, error) {
 	return api.b.ValidateMyNodeKey(nodeKeyBytes)
 }
 
+func (api *PrivateAPI) ShowMnemonic(myKey string) (string, error) {
+	return api.b.ShowMnemonic([]byte(myKey))
+}
+
 func (api *PrivateAPI) ShowMySignKey() (*pkgservice.KeyInfo, error) {
 	return api.b.ShowMySignKey()
 }
@@ -478,6 +515,14 @@ func (api *PrivateAPI) RefreshMyNodeSignKey() (*pkgservice.KeyInfo, error) {
 	return api.b.RefreshMyNodeSignKey()
 }
 
+/**********
+ * Backup
+ **********/
+
+func (api *PrivateAPI) ExportBackup(passphrase string) (string, error) {
+	return api.b.ExportBackup([]byte(passphrase))
+}
+
 /**********
  * Misc
  **********/
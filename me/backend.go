@@ -20,6 +20,7 @@ import (
 	"github.com/ailabstw/go-pttai/account"
 	"github.com/ailabstw/go-pttai/content"
 	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/group"
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/rpc"
 	pkgservice "github.com/ailabstw/go-pttai/service"
@@ -33,11 +34,12 @@ type Backend struct {
 	accountBackend *account.Backend
 	contentBackend *content.Backend
 	friendBackend  *friend.Backend
+	groupBackend   *group.Backend
 
 	myPtt pkgservice.MyPtt
 }
 
-func NewBackend(ctx *pkgservice.ServiceContext, cfg *Config, ptt pkgservice.MyPtt, accountBackend *account.Backend, contentBackend *content.Backend, friendBacked *friend.Backend) (*Backend, error) {
+func NewBackend(ctx *pkgservice.ServiceContext, cfg *Config, ptt pkgservice.MyPtt, accountBackend *account.Backend, contentBackend *content.Backend, friendBacked *friend.Backend, groupBackend *group.Backend) (*Backend, error) {
 	err := InitMe(cfg.DataDir)
 	if err != nil {
 		return nil, err
@@ -50,6 +52,7 @@ func NewBackend(ctx *pkgservice.ServiceContext, cfg *Config, ptt pkgservice.MyPt
 		accountBackend: accountBackend,
 		contentBackend: contentBackend,
 		friendBackend:  friendBacked,
+		groupBackend:   groupBackend,
 	}
 
 	spm, err := NewServiceProtocolManager(cfg.ID, ptt, backend, contentBackend)
@@ -17,7 +17,11 @@
 package me
 
 import (
+	"bufio"
+	"os"
+	"path/filepath"
 	"reflect"
+	"time"
 
 	"github.com/ailabstw/go-pttai/account"
 	"github.com/ailabstw/go-pttai/common/types"
@@ -148,6 +152,64 @@ func (b *Backend) RefreshMyNodeSignKey() (*pkgservice.KeyInfo, error) {
 	return key, nil
 }
 
+func (b *Backend) ShowMnemonic(myKey []byte) (string, error) {
+	isValid, err := b.ValidateValidateKey(myKey)
+	if err != nil {
+		return "", err
+	}
+	if !isValid {
+		return "", ErrInvalidMe
+	}
+
+	if b.Config.Mnemonic == "" {
+		return "", ErrNoMnemonic
+	}
+
+	return b.Config.Mnemonic, nil
+}
+
+/**********
+ * Backup
+ **********/
+
+/*
+ExportBackup streams the encrypted backup to a new file in the backup-dir of the data-dir,
+and returns the filename.
+*/
+func (b *Backend) ExportBackup(passphrase []byte) (string, error) {
+	dir := b.Config.ResolvePath(BackupDir)
+	if dir == "" {
+		return "", ErrInvalidBackup
+	}
+
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return "", err
+	}
+
+	filename := filepath.Join(dir, time.Now().UTC().Format(BackupFilenameFormat))
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	w := bufio.NewWriter(f)
+	err = WriteBackup(w, b.Config, passphrase)
+	if err == nil {
+		err = w.Flush()
+	}
+	closeErr := f.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filename)
+		return "", err
+	}
+
+	return filename, nil
+}
+
 /**********
  * Join Me
  **********/
@@ -377,6 +439,63 @@ func (b *Backend) RemoveBoardRequests(entityIDBytes []byte, hash []byte) (bool,
 	return pm.RemoveBoardRequests(hash)
 }
 
+/**********
+ * JoinGroup
+ **********/
+
+func (b *Backend) JoinGroup(groupURL []byte) (*pkgservice.BackendJoinRequest, error) {
+	joinRequest, err := pkgservice.ParseBackendJoinURL(groupURL, pkgservice.PathJoinGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	myNodeID := b.myPtt.MyNodeID
+	if reflect.DeepEqual(myNodeID, joinRequest.NodeID) {
+		return nil, ErrInvalidNode
+	}
+
+	myInfo := b.SPM().(*ServiceProtocolManager).MyInfo
+	pm := myInfo.PM().(*ProtocolManager)
+	err = pm.JoinGroup(joinRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	backendJoinRequest := pkgservice.JoinRequestToBackendJoinRequest(joinRequest)
+
+	return backendJoinRequest, nil
+}
+
+func (b *Backend) GetGroupRequests(entityIDBytes []byte) ([]*pkgservice.BackendJoinRequest, error) {
+
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	joinGroupRequests, err := pm.GetGroupRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	theList := make([]*pkgservice.BackendJoinRequest, len(joinGroupRequests))
+	for i, request := range joinGroupRequests {
+		theList[i] = pkgservice.JoinRequestToBackendJoinRequest(request)
+	}
+	return theList, nil
+}
+
+func (b *Backend) RemoveGroupRequests(entityIDBytes []byte, hash []byte) (bool, error) {
+	thePM, err := b.EntityIDToPM(entityIDBytes)
+	if err != nil {
+		return false, err
+	}
+	pm := thePM.(*ProtocolManager)
+
+	return pm.RemoveGroupRequests(hash)
+}
+
 /**********
  * MyInfo
  **********/
@@ -0,0 +1,585 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/p2p/discover"
+	"github.com/ailabstw/go-pttai/pttdb"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"golang.org/x/crypto/scrypt"
+)
+
+/*
+Backup is the header of the backup, containing the key of me.
+
+The header is followed by the key/vals of the dbs (BackupKV),
+including the profile, the friend / board memberships, the op-keys and the local-only states.
+The contents / media re-syncable from the boards, the node-key and the dbs bound to the node-key are not in the backup,
+and the restored node joins as a new node of me.
+
+With the backup the node can be recovered even if none of my other nodes is online.
+*/
+type Backup struct {
+	V        types.Version
+	CreateTS types.Timestamp `json:"CT"`
+	ID       *types.PttID
+	MyKey    []byte `json:"K"`
+	Postfix  []byte `json:"P"`
+	Mnemonic string `json:"M"`
+}
+
+type BackupKV struct {
+	DB string `json:"D"`
+	K  []byte
+	V  []byte
+}
+
+/*
+BackupArchive is the header of the encrypted backup.
+The key is derived by scrypt, and the backup is sealed by AES-GCM in chunks of BackupChunkSize,
+so that the backup is streamed without being loaded in memory.
+
+Each chunk is prefixed by the 4-byte size, and the last chunk is sealed with a different additional-data
+to detect the truncated backup.
+*/
+type BackupArchive struct {
+	V     types.Version
+	Salt  []byte `json:"S"`
+	Nonce []byte `json:"N"`
+}
+
+func NewBackup(cfg *Config) (*Backup, error) {
+	if cfg.PrivateKey == nil {
+		return nil, ErrInvalidMe
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Backup{
+		V:        types.CurrentVersion,
+		CreateTS: ts,
+		ID:       cfg.ID,
+		MyKey:    crypto.FromECDSA(cfg.PrivateKey),
+		Postfix:  []byte(cfg.Postfix),
+		Mnemonic: cfg.Mnemonic,
+	}, nil
+}
+
+/*
+WriteBackup writes the encrypted backup to w,
+with the key/vals of the dbs from the snapshots of the dbs.
+*/
+func WriteBackup(w io.Writer, cfg *Config, passphrase []byte) error {
+	b, err := NewBackup(cfg)
+	if err != nil {
+		return err
+	}
+
+	ew, err := EncryptBackup(w, passphrase)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(ew)
+	err = enc.Encode(b)
+	if err != nil {
+		return err
+	}
+
+	for _, db := range pttdb.OpenedDatabases() {
+		if BackupExcludedDBs[db.Name()] {
+			continue
+		}
+
+		err = writeBackupDB(enc, db)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ew.Close()
+}
+
+func writeBackupDB(enc *json.Encoder, db *pttdb.LDBDatabase) error {
+	iter, err := db.NewSnapshotIterator()
+	if err != nil {
+		return err
+	}
+	defer iter.Release()
+
+	name := db.Name()
+	filter, err := newBackupFilter(name, iter)
+	if err != nil {
+		return err
+	}
+
+	kv := &BackupKV{DB: name}
+	for isOK := iter.First(); isOK; isOK = iter.Next() {
+		key := iter.Key()
+		val := iter.Value()
+		if filter != nil && filter.IsExcluded(key, val) {
+			continue
+		}
+
+		kv.K = key
+		kv.V = val
+		err = enc.Encode(kv)
+		if err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+/*
+backupFilter filters out the key/vals re-syncable from the peers in the db.
+*/
+type backupFilter interface {
+	IsExcluded(key []byte, val []byte) bool
+}
+
+func newBackupFilter(name string, iter iterator.Iterator) (backupFilter, error) {
+	switch name {
+	case content.DBBoardName:
+		return content.NewBoardBackupFilter(iter)
+	}
+
+	return nil, nil
+}
+
+/*
+RestoreBackup decrypts the backup from r, and loads the key/vals to the opened dbs with the same names.
+
+The dbs are required to be empty to avoid mixing the backup with another identity.
+*/
+func RestoreBackup(r io.Reader, passphrase []byte) (*Backup, error) {
+	dr, err := DecryptBackup(r, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(dr)
+	b := &Backup{}
+	err = dec.Decode(b)
+	if err != nil {
+		return nil, backupReadError(err)
+	}
+
+	_, err = b.myKey()
+	if err != nil {
+		return nil, err
+	}
+
+	dbByName := make(map[string]*pttdb.LDBDatabase)
+	for _, db := range pttdb.OpenedDatabases() {
+		name := db.Name()
+		if BackupExcludedDBs[name] {
+			continue
+		}
+
+		if !db.IsEmpty() {
+			log.Error("RestoreBackup: db not empty", "name", name)
+			return nil, ErrDBNotEmpty
+		}
+
+		dbByName[name] = db
+	}
+
+	kvsByName := make(map[string][]*pttdb.KeyVal)
+	sizeByName := make(map[string]int)
+	for {
+		kv := &BackupKV{}
+		err = dec.Decode(kv)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, backupReadError(err)
+		}
+
+		db, ok := dbByName[kv.DB]
+		if !ok {
+			log.Error("RestoreBackup: db not opened", "name", kv.DB)
+			return nil, ErrInvalidBackup
+		}
+
+		kvsByName[kv.DB] = append(kvsByName[kv.DB], &pttdb.KeyVal{K: kv.K, V: kv.V})
+		sizeByName[kv.DB] += len(kv.K) + len(kv.V)
+		if sizeByName[kv.DB] < pttdb.IdealBatchSize {
+			continue
+		}
+
+		err = db.LoadAll(kvsByName[kv.DB])
+		if err != nil {
+			return nil, err
+		}
+		delete(kvsByName, kv.DB)
+		delete(sizeByName, kv.DB)
+	}
+
+	for name, kvs := range kvsByName {
+		err = dbByName[name].LoadAll(kvs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+func backupReadError(err error) error {
+	if err == ErrInvalidPassphrase || err == ErrInvalidBackup {
+		return err
+	}
+
+	return ErrInvalidBackup
+}
+
+func (b *Backup) myKey() (*ecdsa.PrivateKey, error) {
+	key, err := crypto.ToECDSA(b.MyKey)
+	if err != nil {
+		return nil, ErrInvalidBackup
+	}
+
+	id, err := types.NewPttIDFromKeyPostfix(key, b.Postfix)
+	if err != nil || !reflect.DeepEqual(id, b.ID) {
+		return nil, ErrInvalidBackup
+	}
+
+	return key, nil
+}
+
+/*
+SaveMyNode saves the restored node as the only node of me,
+as the my-nodes are bound to the node-keys, which are not in the backup.
+*/
+func (b *Backup) SaveMyNode(nodeID *discover.NodeID, nodeType pkgservice.NodeType) error {
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	myNode, err := NewMyNode(ts, b.ID, nodeID, 1)
+	if err != nil {
+		return err
+	}
+
+	myNode.Status = types.StatusAlive
+	myNode.NodeType = nodeType
+
+	_, err = myNode.Save()
+	return err
+}
+
+/**********
+ * Encrypt
+ **********/
+
+/*
+EncryptBackup writes the archive-header to w,
+and returns the writer sealing the backup to w in chunks.
+The last chunk is written on Close.
+*/
+func EncryptBackup(w io.Writer, passphrase []byte) (io.WriteCloser, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrInvalidPassphrase
+	}
+
+	salt := make([]byte, BackupSaltLength)
+	_, err := io.ReadFull(rand.Reader, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newBackupAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &BackupArchive{
+		V:     types.CurrentVersion,
+		Salt:  salt,
+		Nonce: nonce,
+	}
+	marshaled, err := json.Marshal(archive)
+	if err != nil {
+		return nil, err
+	}
+	marshaled = append(marshaled, '\n')
+
+	_, err = w.Write(marshaled)
+	if err != nil {
+		return nil, err
+	}
+
+	return &backupWriter{
+		w:     w,
+		aead:  aead,
+		nonce: nonce,
+		buf:   make([]byte, 0, BackupChunkSize),
+	}, nil
+}
+
+/*
+DecryptBackup reads the archive-header from r,
+and returns the reader opening the chunks of the backup from r.
+*/
+func DecryptBackup(r io.Reader, passphrase []byte) (io.Reader, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrInvalidPassphrase
+	}
+
+	br := bufio.NewReader(r)
+	marshaled, err := br.ReadBytes('\n')
+	if err != nil {
+		return nil, ErrInvalidBackup
+	}
+
+	archive := &BackupArchive{}
+	err = json.Unmarshal(marshaled, archive)
+	if err != nil {
+		return nil, ErrInvalidBackup
+	}
+
+	aead, err := newBackupAEAD(passphrase, archive.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(archive.Nonce) != aead.NonceSize() {
+		return nil, ErrInvalidBackup
+	}
+
+	return &backupReader{
+		r:     br,
+		aead:  aead,
+		nonce: archive.Nonce,
+	}, nil
+}
+
+func newBackupAEAD(passphrase []byte, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, salt, BackupScryptN, BackupScryptR, BackupScryptP, BackupKeyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+/*
+backupChunkNonce xors the idx of the chunk to the tail of the nonce.
+*/
+func backupChunkNonce(nonce []byte, idx uint64) []byte {
+	chunkNonce := common.CloneBytes(nonce)
+	offset := len(chunkNonce) - 8
+
+	binary.BigEndian.PutUint64(chunkNonce[offset:], binary.BigEndian.Uint64(chunkNonce[offset:])^idx)
+
+	return chunkNonce
+}
+
+func backupChunkAD(isLast bool) []byte {
+	if isLast {
+		return []byte{1}
+	}
+
+	return []byte{0}
+}
+
+type backupWriter struct {
+	w     io.Writer
+	aead  cipher.AEAD
+	nonce []byte
+	idx   uint64
+	buf   []byte
+}
+
+func (w *backupWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if len(w.buf) == BackupChunkSize {
+			err := w.seal(false)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		size := BackupChunkSize - len(w.buf)
+		if size > len(p) {
+			size = len(p)
+		}
+		w.buf = append(w.buf, p[:size]...)
+		p = p[size:]
+	}
+
+	return n, nil
+}
+
+func (w *backupWriter) Close() error {
+	return w.seal(true)
+}
+
+func (w *backupWriter) seal(isLast bool) error {
+	sealed := w.aead.Seal(nil, backupChunkNonce(w.nonce, w.idx), w.buf, backupChunkAD(isLast))
+
+	sizeBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(sizeBytes, uint32(len(sealed)))
+
+	_, err := w.w.Write(sizeBytes)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.w.Write(sealed)
+	if err != nil {
+		return err
+	}
+
+	w.buf = w.buf[:0]
+	w.idx++
+
+	return nil
+}
+
+type backupReader struct {
+	r      *bufio.Reader
+	aead   cipher.AEAD
+	nonce  []byte
+	idx    uint64
+	buf    []byte
+	isLast bool
+}
+
+func (r *backupReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.isLast {
+			return 0, io.EOF
+		}
+
+		err := r.open()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+
+	return n, nil
+}
+
+func (r *backupReader) open() error {
+	sizeBytes := make([]byte, 4)
+	_, err := io.ReadFull(r.r, sizeBytes)
+	if err != nil {
+		return ErrInvalidBackup
+	}
+
+	size := binary.BigEndian.Uint32(sizeBytes)
+	if size > uint32(BackupChunkSize+r.aead.Overhead()) {
+		return ErrInvalidBackup
+	}
+
+	sealed := make([]byte, size)
+	_, err = io.ReadFull(r.r, sealed)
+	if err != nil {
+		return ErrInvalidBackup
+	}
+
+	nonce := backupChunkNonce(r.nonce, r.idx)
+	isLast := false
+	buf, err := r.aead.Open(nil, nonce, sealed, backupChunkAD(false))
+	if err != nil {
+		isLast = true
+		buf, err = r.aead.Open(nil, nonce, sealed, backupChunkAD(true))
+	}
+	if err != nil {
+		// unable to open the first chunk: the key derived from the passphrase is invalid.
+		if r.idx == 0 {
+			return ErrInvalidPassphrase
+		}
+		return ErrInvalidBackup
+	}
+
+	// no data after the last chunk.
+	if isLast {
+		_, err = r.r.ReadByte()
+		if err != io.EOF {
+			return ErrInvalidBackup
+		}
+	}
+
+	r.buf = buf
+	r.idx++
+	r.isLast = isLast
+
+	return nil
+}
+
+/*
+RestoreBackupKey saves my key in the backup as the key of the config.
+*/
+func (c *Config) RestoreBackupKey(b *Backup) error {
+	key, err := b.myKey()
+	if err != nil {
+		return err
+	}
+	postfix := string(b.Postfix)
+	c.Mnemonic = b.Mnemonic
+
+	err = c.saveKeyFile(DataDirPrivateKey, key, postfix, b.ID)
+	if err != nil {
+		return err
+	}
+
+	c.PrivateKey = key
+	c.Postfix = postfix
+	c.ID = b.ID
+
+	return nil
+}
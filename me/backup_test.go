@@ -0,0 +1,131 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestEncryptDecryptBackup(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	passphrase := []byte("test-passphrase")
+
+	data := make([]byte, BackupChunkSize*2+100)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := EncryptBackup(buf, passphrase)
+	if err != nil {
+		t.Errorf("EncryptBackup() error = %v", err)
+		return
+	}
+	// written in pieces across the chunks.
+	w.Write(data[:100])
+	w.Write(data[100:])
+	err = w.Close()
+	if err != nil {
+		t.Errorf("EncryptBackup().Close() error = %v", err)
+		return
+	}
+	archive := buf.Bytes()
+
+	if bytes.Contains(archive, data[:BackupChunkSize]) {
+		t.Errorf("EncryptBackup() not encrypted")
+	}
+
+	headerSize := bytes.IndexByte(archive, '\n') + 1
+
+	tampered := append([]byte{}, archive...)
+	tampered[len(tampered)-1] ^= 0xff
+
+	// prepare test-cases
+	tests := []struct {
+		name       string
+		archive    []byte
+		passphrase []byte
+		want       []byte
+		wantErr    error
+	}{
+		{
+			name:       "valid",
+			archive:    archive,
+			passphrase: passphrase,
+			want:       data,
+		},
+		{
+			name:       "invalid-passphrase",
+			archive:    archive,
+			passphrase: []byte("invalid-passphrase"),
+			wantErr:    ErrInvalidPassphrase,
+		},
+		{
+			name:       "empty-passphrase",
+			archive:    archive,
+			passphrase: nil,
+			wantErr:    ErrInvalidPassphrase,
+		},
+		{
+			name:       "truncated",
+			archive:    archive[:headerSize+4+BackupChunkSize+16],
+			passphrase: passphrase,
+			wantErr:    ErrInvalidBackup,
+		},
+		{
+			name:       "trailing",
+			archive:    append(append([]byte{}, archive...), 0),
+			passphrase: passphrase,
+			wantErr:    ErrInvalidBackup,
+		},
+		{
+			name:       "tampered",
+			archive:    tampered,
+			passphrase: passphrase,
+			wantErr:    ErrInvalidBackup,
+		},
+		{
+			name:       "no-header",
+			archive:    archive[headerSize:],
+			passphrase: passphrase,
+			wantErr:    ErrInvalidBackup,
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := DecryptBackup(bytes.NewReader(tt.archive), tt.passphrase)
+			var got []byte
+			if err == nil {
+				got, err = ioutil.ReadAll(r)
+			}
+			if err != tt.wantErr {
+				t.Errorf("DecryptBackup() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr == nil && !bytes.Equal(got, tt.want) {
+				t.Errorf("DecryptBackup() = %v bytes, want %v bytes", len(got), len(tt.want))
+			}
+		})
+	}
+}
@@ -25,9 +25,7 @@ import (
 	"time"
 
 	"github.com/ailabstw/go-pttai/common/types"
-	"github.com/ailabstw/go-pttai/key"
 	"github.com/ailabstw/go-pttai/log"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -37,6 +35,7 @@ type Config struct {
 	PrivateKey *ecdsa.PrivateKey `toml:"-"`
 	ID         *types.PttID      `toml:"-"` // we also need ID because other services need to know ID, but cannot directly acccess private-key and postfix.
 	Postfix    string
+	Mnemonic   string `toml:"-"` // the mnemonic of the private-key and postfix, empty if the key is not generated from mnemonic.
 }
 
 func (c *Config) SetMyKey(hex string, file string, postfix string, isSave bool) error {
@@ -54,11 +53,13 @@ func (c *Config) SetMyKey(hex string, file string, postfix string, isSave bool)
 			return ErrInvalidPrivateKeyFile
 		}
 		c.PrivateKey = key
+		c.Mnemonic = ""
 	case hex != "":
 		if key, err = crypto.HexToECDSA(hex); err != nil {
 			return ErrInvalidPrivateKeyHex
 		}
 		c.PrivateKey = key
+		c.Mnemonic = ""
 	}
 
 	if postfix != "" {
@@ -84,12 +85,21 @@ func (c *Config) SetMyKey(hex string, file string, postfix string, isSave bool)
 
 func (c *Config) saveKeyFile(DataDirPrivateKey string, key *ecdsa.PrivateKey, postfix string, id *types.PttID) error {
 
+	if err := os.MkdirAll(c.DataDir, 0700); err != nil {
+		log.Error(fmt.Sprintf("Failed to persist node key: %v", err))
+		return err
+	}
+
 	// save DataDirPrivKey
 	keyfile := c.ResolvePath(DataDirPrivateKey)
 	if err := c.SaveKey(keyfile, key, postfix); err != nil {
 		log.Error(fmt.Sprintf("Failed to persist node key: %v", err))
 		return err
 	}
+	if err := c.saveMnemonic(keyfile); err != nil {
+		log.Error(fmt.Sprintf("Failed to persist mnemonic: %v", err))
+		return err
+	}
 
 	// save DataDirPrivKeyWithID
 	keyfile, err := c.ResolvePrivateKeyWithIDPath(id)
@@ -100,6 +110,43 @@ func (c *Config) saveKeyFile(DataDirPrivateKey string, key *ecdsa.PrivateKey, po
 		log.Error(fmt.Sprintf("Failed to persist node key: %v", err))
 		return err
 	}
+	if err := c.saveMnemonic(keyfile); err != nil {
+		log.Error(fmt.Sprintf("Failed to persist mnemonic: %v", err))
+		return err
+	}
+
+	return nil
+}
+
+/*
+saveMnemonic saves the mnemonic along with the keyfile,
+or removes the outdated one if the key is not generated from mnemonic.
+*/
+func (c *Config) saveMnemonic(keyfile string) error {
+	mnemonicFilename := keyfile + ".mnemonic"
+	if c.Mnemonic == "" {
+		err := os.Remove(mnemonicFilename)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	return ioutil.WriteFile(mnemonicFilename, []byte(c.Mnemonic), 0600)
+}
+
+/*
+SetMnemonic sets the private-key and the postfix deterministically derived from the mnemonic.
+*/
+func (c *Config) SetMnemonic(mnemonic string) error {
+	key, postfix, err := KeyFromMnemonic(mnemonic)
+	if err != nil {
+		return err
+	}
+
+	c.PrivateKey = key
+	c.Postfix = postfix
+	c.Mnemonic = mnemonic
 
 	return nil
 }
@@ -125,18 +172,12 @@ func (c *Config) myKey() (*ecdsa.PrivateKey, string, *types.PttID, error) {
 
 	// Generate ephemeral key if no datadir is being used.
 	if c.DataDir == "" {
-		key, err := key.GenerateKey()
+		mnemonic, key, postfix, id, err := newMnemonicKey()
 		if err != nil {
 			log.Crit(fmt.Sprintf("Failed to generate ephemeral node key: %v", err))
 			return nil, "", nil, ErrInvalidMe
 		}
-
-		id, err := types.NewPttIDFromKey(key)
-		if err != nil {
-			return nil, "", nil, ErrInvalidMe
-		}
-
-		postfix := string(id[common.AddressLength:])
+		c.Mnemonic = mnemonic
 
 		return key, postfix, id, nil
 	}
@@ -151,22 +192,23 @@ func (c *Config) myKey() (*ecdsa.PrivateKey, string, *types.PttID, error) {
 			return nil, "", nil, ErrInvalidMe
 		}
 
+		mnemonicBytes, err := ioutil.ReadFile(keyfile + ".mnemonic")
+		if err != nil {
+			mnemonicBytes = nil
+		}
+		c.Mnemonic = string(mnemonicBytes)
+
 		return privKey, string(postfixBytes), id, nil
 	}
 
 	log.Warn(fmt.Sprintf("Failed to load key: %v. create a new one.", err))
 	// No persistent key found, generate and store a new one.
-	privKey, err = key.GenerateKey()
+	mnemonic, privKey, postfix, id, err := newMnemonicKey()
 	if err != nil {
 		log.Crit(fmt.Sprintf("Failed to generate node key: %v", err))
 		return nil, "", nil, ErrInvalidMe
 	}
-
-	id, err := types.NewPttIDFromKey(privKey)
-	if err != nil {
-		return nil, "", nil, ErrInvalidMe
-	}
-	postfix := string(id[common.AddressLength:])
+	c.Mnemonic = mnemonic
 
 	if err := os.MkdirAll(c.DataDir, 0700); err != nil {
 		log.Error(fmt.Sprintf("Failed to persist node key: %v", err))
@@ -266,6 +308,9 @@ func (c *Config) RevokeMyKey(myID *types.PttID) error {
 	postfixFilename := keyfile + ".postfix"
 	os.Remove(postfixFilename)
 
+	mnemonicFilename := keyfile + ".mnemonic"
+	os.Remove(mnemonicFilename)
+
 	return nil
 }
 
@@ -37,4 +37,12 @@ var (
 	ErrUnableToBeLead = errors.New("unable to be lead")
 
 	ErrWithLead = errors.New("with lead")
+
+	ErrInvalidPassphrase = errors.New("invalid passphrase")
+	ErrInvalidBackup     = errors.New("invalid backup")
+	ErrDBNotEmpty        = errors.New("db not empty")
+
+	ErrInvalidMnemonic           = errors.New("invalid mnemonic")
+	ErrNoMnemonic                = errors.New("no mnemonic")
+	ErrInvalidPrivateKeyMnemonic = errors.New("cannot set mnemonic and private-key / postfix at the same time")
 )
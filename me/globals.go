@@ -85,6 +85,10 @@ const (
 	// sync-friend
 	InternalSyncFriendMsg
 	InternalSyncFriendAckMsg
+
+	// sync-group
+	InternalSyncGroupMsg
+	InternalSyncGroupAckMsg
 )
 
 // db
@@ -98,9 +102,11 @@ var (
 	DBMyNodePrefix = []byte(".mndb")
 
 	DBRaftPrefix                    = []byte(".rfdb")
+	DBRaftName                      = "raft"
 	dbRaft       *pttdb.LDBDatabase = nil
 
-	dbMyNodes *pttdb.LDBDatabase = nil
+	DBMyNodesName                    = "mynodes"
+	dbMyNodes     *pttdb.LDBDatabase = nil
 
 	dbMeta *pttdb.LDBDatabase = nil
 
@@ -198,6 +204,35 @@ const (
 	WeightMobile  = 2
 )
 
+// mnemonic
+const (
+	MnemonicMyKeyIdx   uint32 = 0
+	MnemonicPostfixIdx uint32 = 0x10000
+)
+
+// backup
+const (
+	BackupScryptN = 1 << 18
+	BackupScryptR = 8
+	BackupScryptP = 1
+
+	BackupKeyLength  = 32
+	BackupSaltLength = 32
+
+	BackupChunkSize = 64 * 1024
+
+	BackupDir            = "backups"
+	BackupFilenameFormat = "20060102-150405.backup"
+)
+
+var (
+	// the dbs bound to the node-key, which is not in the backup.
+	BackupExcludedDBs = map[string]bool{
+		DBMyNodesName: true,
+		DBRaftName:    true,
+	}
+)
+
 // init-me-info
 
 const (
@@ -218,12 +253,12 @@ func InitMe(dataDir string) error {
 		return err
 	}
 
-	dbMyNodes, err = pttdb.NewLDBDatabase("mynodes", dataDir, 0, 0)
+	dbMyNodes, err = pttdb.NewLDBDatabase(DBMyNodesName, dataDir, 0, 0)
 	if err != nil {
 		return err
 	}
 
-	dbRaft, err = pttdb.NewLDBDatabase("raft", dataDir, 0, 0)
+	dbRaft, err = pttdb.NewLDBDatabase(DBRaftName, dataDir, 0, 0)
 	if err != nil {
 		return err
 	}
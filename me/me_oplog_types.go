@@ -37,6 +37,9 @@ const (
 	MeOpTypeMigrateMe
 	MeOpTypeDeleteMe
 
+	MeOpTypeCreateGroup
+	MeOpTypeJoinGroup
+
 	NMeOpType
 )
 
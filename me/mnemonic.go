@@ -0,0 +1,117 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/key"
+	"github.com/ailabstw/go-pttai/key/bip32"
+	"github.com/ailabstw/go-pttai/key/bip39"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+/*
+KeyFromMnemonic deterministically derives my key and postfix from the mnemonic.
+
+The bip39 seed of the mnemonic is the seed of the bip32 master-key.
+My key is the 1st valid child from MnemonicMyKeyIdx,
+and the postfix is from the hash of the 1st valid child from MnemonicPostfixIdx.
+*/
+func KeyFromMnemonic(mnemonic string) (*ecdsa.PrivateKey, string, error) {
+	seed, err := bip39.NewSeed(mnemonic, "")
+	if err != nil {
+		return nil, "", ErrInvalidMnemonic
+	}
+
+	masterKey, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, "", ErrInvalidMnemonic
+	}
+
+	myKey, err := deriveMnemonicKey(masterKey, MnemonicMyKeyIdx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	postfixKey, err := deriveMnemonicKey(masterKey, MnemonicPostfixIdx)
+	if err != nil {
+		return nil, "", err
+	}
+	postfixHash := crypto.Keccak256(crypto.FromECDSA(postfixKey))
+
+	return myKey, string(postfixHash[:types.SizePostfix]), nil
+}
+
+func deriveMnemonicKey(masterKey *bip32.ExtendedKey, idx uint32) (*ecdsa.PrivateKey, error) {
+	for i := uint32(0); i < key.NGenerateKey; i++ {
+		extendedKey, err := masterKey.Child(idx + i)
+		if err == bip32.ErrInvalidChild {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		theKey, err := extendedKey.ToPrivkey()
+		if err != nil {
+			return nil, err
+		}
+		if !key.IsValidPrivateKey(theKey) {
+			continue
+		}
+
+		return theKey, nil
+	}
+
+	return nil, ErrInvalidMnemonic
+}
+
+/*
+newMnemonicKey generates a new mnemonic with my key, postfix and id derived from the mnemonic.
+*/
+func newMnemonicKey() (string, *ecdsa.PrivateKey, string, *types.PttID, error) {
+	for i := 0; i < key.NGenerateKey; i++ {
+		entropy, err := bip39.NewEntropy(bip39.DefaultEntropyBitSize)
+		if err != nil {
+			return "", nil, "", nil, err
+		}
+
+		mnemonic, err := bip39.NewMnemonic(entropy)
+		if err != nil {
+			return "", nil, "", nil, err
+		}
+
+		myKey, postfix, err := KeyFromMnemonic(mnemonic)
+		if err == ErrInvalidMnemonic {
+			continue
+		}
+		if err != nil {
+			return "", nil, "", nil, err
+		}
+
+		id, err := types.NewPttIDFromKeyPostfix(myKey, []byte(postfix))
+		if err != nil {
+			return "", nil, "", nil, err
+		}
+
+		return mnemonic, myKey, postfix, id, nil
+	}
+
+	return "", nil, "", nil, ErrInvalidMnemonic
+}
@@ -0,0 +1,119 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestKeyFromMnemonic(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	// prepare test-cases
+	tests := []struct {
+		name     string
+		mnemonic string
+		wantErr  error
+	}{
+		{
+			name:     "valid",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		},
+		{
+			name:     "extra-spaces",
+			mnemonic: "  legal winner thank year wave sausage worth useful legal winner thank yellow ",
+		},
+		{
+			name:     "invalid-checksum",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank thank",
+			wantErr:  ErrInvalidMnemonic,
+		},
+		{
+			name:     "invalid-word",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank pttai",
+			wantErr:  ErrInvalidMnemonic,
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, postfix, err := KeyFromMnemonic(tt.mnemonic)
+			if err != tt.wantErr {
+				t.Errorf("KeyFromMnemonic() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if len(postfix) != types.SizePostfix {
+				t.Errorf("KeyFromMnemonic() postfix = %v, want size %v", len(postfix), types.SizePostfix)
+			}
+
+			key2, postfix2, err := KeyFromMnemonic(tt.mnemonic)
+			if err != nil {
+				t.Errorf("KeyFromMnemonic() 2nd error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(crypto.FromECDSA(key), crypto.FromECDSA(key2)) {
+				t.Errorf("KeyFromMnemonic() key is not deterministic")
+			}
+			if postfix != postfix2 {
+				t.Errorf("KeyFromMnemonic() postfix is not deterministic")
+			}
+		})
+	}
+}
+
+func TestNewMnemonicKey(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	mnemonic, myKey, postfix, myID, err := newMnemonicKey()
+	if err != nil {
+		t.Errorf("newMnemonicKey() error = %v", err)
+		return
+	}
+
+	key, postfix2, err := KeyFromMnemonic(mnemonic)
+	if err != nil {
+		t.Errorf("KeyFromMnemonic() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(crypto.FromECDSA(myKey), crypto.FromECDSA(key)) {
+		t.Errorf("newMnemonicKey() key is not recovered by the mnemonic")
+	}
+	if postfix != postfix2 {
+		t.Errorf("newMnemonicKey() postfix is not recovered by the mnemonic")
+	}
+
+	id, err := types.NewPttIDFromKeyPostfix(key, []byte(postfix2))
+	if err != nil {
+		t.Errorf("NewPttIDFromKeyPostfix() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(myID, id) {
+		t.Errorf("newMnemonicKey() id = %v, want %v", myID, id)
+	}
+}
@@ -53,6 +53,9 @@ func (pm *ProtocolManager) HandleApproveJoin(dataBytes []byte, hash *common.Addr
 	case pm.IsJoinBoardRequests(hash):
 		log.Debug("HandleApproveJoin: is join-board request", "hash", hash)
 		err = pm.HandleApproveJoinBoard(dataBytes, joinRequest, peer)
+	case pm.IsJoinGroupRequests(hash):
+		log.Debug("HandleApproveJoin: is join-group request", "hash", hash)
+		err = pm.HandleApproveJoinGroup(dataBytes, joinRequest, peer)
 	}
 
 	return err
@@ -0,0 +1,53 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/group"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) HandleApproveJoinGroup(dataBytes []byte, joinRequest *pkgservice.JoinRequest, peer *pkgservice.PttPeer) error {
+
+	theGroupData := group.NewEmptyApproveJoinGroup()
+	approveJoin := &pkgservice.ApproveJoin{Data: theGroupData}
+	err := json.Unmarshal(dataBytes, approveJoin)
+	if err != nil {
+		log.Error("HandleApproveJoinGroup: unable to unmarshal", "e", err)
+		return err
+	}
+
+	groupData := theGroupData
+
+	// group
+	groupService := pm.Entity().Service().(*Backend).groupBackend
+	groupSPM := groupService.SPM().(*group.ServiceProtocolManager)
+	_, err = groupSPM.CreateJoinEntity(groupData, peer, nil, true, true, false, false, true)
+	if err != nil {
+		return err
+	}
+
+	// remove joinGroupRequest
+	pm.lockJoinGroupRequest.Lock()
+	defer pm.lockJoinGroupRequest.Unlock()
+	delete(pm.joinGroupRequests, *joinRequest.Hash)
+
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/content"
 	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/group"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
@@ -30,6 +31,8 @@ func (m *MyInfo) CreateEntityOpType(entity pkgservice.Entity) (pkgservice.OpType
 		return MeOpTypeCreateBoard, nil
 	case *friend.Friend:
 		return MeOpTypeCreateFriend, nil
+	case *group.Group:
+		return MeOpTypeCreateGroup, nil
 	}
 	return MeOpTypeInvalid, pkgservice.ErrInvalidEntity
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/content"
 	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/group"
 	pkgservice "github.com/ailabstw/go-pttai/service"
 )
 
@@ -30,6 +31,8 @@ func (m *MyInfo) JoinEntityOpType(entity pkgservice.Entity) (pkgservice.OpType,
 		return MeOpTypeJoinBoard, nil
 	case *friend.Friend:
 		return MeOpTypeJoinFriend, nil
+	case *group.Group:
+		return MeOpTypeJoinGroup, nil
 	}
 	return MeOpTypeInvalid, pkgservice.ErrInvalidEntity
 }
@@ -0,0 +1,67 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+func (pm *ProtocolManager) handleGroupLog(
+	oplog *pkgservice.BaseOplog,
+
+	info *ProcessMeInfo,
+) ([]*pkgservice.BaseOplog, error) {
+
+	groupSPM := pm.Entity().Service().(*Backend).groupBackend.SPM()
+
+	opData := &MeOpEntity{}
+
+	log.Debug("handleGroupLog: to HandleEntityLog", "op", oplog.Op, "MeOpTypeCreateGroup", MeOpTypeCreateGroup)
+
+	return pm.HandleEntityLog(oplog, groupSPM, opData, info, pm.updateGroupInfo)
+}
+
+func (pm *ProtocolManager) updateGroupInfo(oplog *pkgservice.BaseOplog, info *ProcessMeInfo) {
+	info.GroupInfo[*oplog.ObjID] = oplog
+}
+
+func (pm *ProtocolManager) setNewestGroupLog(
+	oplog *pkgservice.BaseOplog,
+) (types.Bool, error) {
+
+	opData := &MeOpEntity{}
+
+	err := oplog.GetData(opData)
+	if err != nil {
+		return true, err
+	}
+
+	groupSPM := pm.Entity().Service().(*Backend).groupBackend.SPM()
+
+	entity := groupSPM.Entity(oplog.ObjID)
+	if entity == nil {
+		return true, err
+	}
+
+	return !types.Bool(reflect.DeepEqual(opData.LogID, entity.GetLogID())), nil
+
+}
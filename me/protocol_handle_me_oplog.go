@@ -27,6 +27,7 @@ type ProcessMeInfo struct {
 	MetaInfo     map[types.PttID]*pkgservice.BaseOplog
 	BoardInfo    map[types.PttID]*pkgservice.BaseOplog
 	FriendInfo   map[types.PttID]*pkgservice.BaseOplog
+	GroupInfo    map[types.PttID]*pkgservice.BaseOplog
 }
 
 func NewProcessMeInfo() *ProcessMeInfo {
@@ -35,6 +36,7 @@ func NewProcessMeInfo() *ProcessMeInfo {
 		MetaInfo:     make(map[types.PttID]*pkgservice.BaseOplog),
 		BoardInfo:    make(map[types.PttID]*pkgservice.BaseOplog),
 		FriendInfo:   make(map[types.PttID]*pkgservice.BaseOplog),
+		GroupInfo:    make(map[types.PttID]*pkgservice.BaseOplog),
 	}
 }
 
@@ -65,6 +67,11 @@ func (pm *ProtocolManager) processMeLog(oplog *pkgservice.BaseOplog, processInfo
 	case MeOpTypeJoinFriend:
 		origLogs, err = pm.handleFriendLog(oplog, info)
 
+	case MeOpTypeCreateGroup:
+		origLogs, err = pm.handleGroupLog(oplog, info)
+	case MeOpTypeJoinGroup:
+		origLogs, err = pm.handleGroupLog(oplog, info)
+
 	case MeOpTypeSetNodeName:
 	}
 	return
@@ -113,6 +120,11 @@ func (pm *ProtocolManager) postprocessMeOplogs(processInfo pkgservice.ProcessInf
 		pm.InternalSyncFriend(oplog, peer)
 	}
 
+	// group
+	for _, oplog := range info.GroupInfo {
+		pm.InternalSyncGroup(oplog, peer)
+	}
+
 	// delete-me
 
 	log.Debug("postprocessMeOplogs: to check delete-me", "isPending", isPending, "DeleteMeInfo", info.DeleteMeInfo)
@@ -149,6 +161,11 @@ func (pm *ProtocolManager) SetNewestMeOplog(oplog *pkgservice.BaseOplog) (err er
 	case MeOpTypeJoinFriend:
 		isNewer, err = pm.setNewestFriendLog(oplog)
 
+	case MeOpTypeCreateGroup:
+		isNewer, err = pm.setNewestGroupLog(oplog)
+	case MeOpTypeJoinGroup:
+		isNewer, err = pm.setNewestGroupLog(oplog)
+
 	case MeOpTypeSetNodeName:
 	}
 
@@ -0,0 +1,196 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/group"
+	"github.com/ailabstw/go-pttai/log"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type InternalSyncGroupAck struct {
+	LogID *types.PttID `json:"l"`
+
+	GroupData *pkgservice.ApproveJoinEntity `json:"B"`
+}
+
+func (pm *ProtocolManager) InternalSyncGroup(
+	oplog *pkgservice.BaseOplog,
+	peer *pkgservice.PttPeer,
+) error {
+
+	syncID := &pkgservice.SyncID{ID: oplog.ObjID, LogID: oplog.ID}
+	log.Debug("InternalSyncGroup: to SendDataToPeer", "syncID", syncID, "peer", peer)
+
+	return pm.SendDataToPeer(InternalSyncGroupMsg, syncID, peer)
+}
+
+func (pm *ProtocolManager) HandleInternalSyncGroup(
+	dataBytes []byte,
+	peer *pkgservice.PttPeer,
+) error {
+
+	syncID := &pkgservice.SyncID{}
+	err := json.Unmarshal(dataBytes, syncID)
+	if err != nil {
+		return err
+	}
+
+	groupSPM := pm.Entity().Service().(*Backend).groupBackend.SPM()
+	g := groupSPM.Entity(syncID.ID)
+	if g == nil {
+		return types.ErrInvalidID
+	}
+	groupPM := g.PM()
+
+	myID := pm.Ptt().GetMyEntity().GetID()
+	joinEntity := &pkgservice.JoinEntity{ID: myID}
+	_, theApproveJoinEntity, err := groupPM.ApproveJoin(joinEntity, nil, peer)
+	log.Debug("HandleInternalSyncGroup: after ApproveJoin", "e", err)
+	if err != nil {
+		return err
+	}
+
+	approveJoinEntity, ok := theApproveJoinEntity.(*pkgservice.ApproveJoinEntity)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	ackData := &InternalSyncGroupAck{LogID: syncID.LogID, GroupData: approveJoinEntity}
+	log.Debug("HandleInternalSyncGroup: to SendData", "ackData", ackData)
+
+	pm.SendDataToPeer(InternalSyncGroupAckMsg, ackData, peer)
+
+	return nil
+}
+
+func (pm *ProtocolManager) HandleInternalSyncGroupAck(
+	dataBytes []byte,
+	peer *pkgservice.PttPeer,
+
+) error {
+
+	// unmarshal data
+	log.Debug("HandleInternalSyncGroupAck: start")
+	theGroupData := group.NewEmptyApproveJoinGroup()
+
+	data := &InternalSyncGroupAck{GroupData: theGroupData}
+	err := json.Unmarshal(dataBytes, data)
+	if err != nil {
+		return err
+	}
+
+	// oplog
+	oplog := &pkgservice.BaseOplog{ID: data.LogID}
+	pm.SetMeDB(oplog)
+
+	// lock
+	err = oplog.Lock()
+	if err != nil {
+		return err
+	}
+	defer oplog.Unlock()
+
+	// get
+	err = oplog.Get(data.LogID, true)
+	log.Debug("HandleInternalSyncGroupAck: after oplog.Get", "e", err, "isSync", oplog.IsSync)
+	if oplog.IsSync {
+		return nil
+	}
+
+	// lock entity
+	groupSPM := pm.Entity().Service().(*Backend).groupBackend.SPM().(*group.ServiceProtocolManager)
+
+	err = groupSPM.Lock(oplog.ObjID)
+	if err != nil {
+		return err
+	}
+	defer groupSPM.Unlock(oplog.ObjID)
+
+	theGroup := groupSPM.Entity(oplog.ObjID)
+	if theGroup == nil {
+		err = pm.handleInternalSyncGroupAckNew(groupSPM, theGroupData, oplog, peer)
+		if err != nil {
+			return err
+		}
+
+		oplog.IsSync = true
+		oplog.Save(true, pm.meOplogMerkle)
+
+		return nil
+	}
+	g, ok := theGroup.(*group.Group)
+	if !ok {
+		return pkgservice.ErrInvalidData
+	}
+
+	// exists
+	groupStatus := g.Status
+
+	switch {
+	case groupStatus == types.StatusAlive && reflect.DeepEqual(g.LogID, oplog.ID):
+		err = pm.handleInternalSyncEntityAckSameLog(g, oplog, peer)
+	case groupStatus >= types.StatusTerminal:
+	case groupStatus == types.StatusAlive:
+		err = pm.handleInternalSyncEntityAckDiffAliveLog(g, oplog, peer)
+	default:
+		err = pm.handleInternalSyncGroupAckDiffLog(groupSPM, theGroupData, oplog, peer)
+	}
+	if err != nil {
+		return err
+	}
+
+	oplog.IsSync = true
+	oplog.Save(true, pm.meOplogMerkle)
+	return nil
+}
+
+func (pm *ProtocolManager) handleInternalSyncGroupAckNew(
+	spm *group.ServiceProtocolManager,
+	data *pkgservice.ApproveJoinEntity,
+	oplog *pkgservice.BaseOplog,
+	peer *pkgservice.PttPeer,
+) error {
+
+	_, err := spm.CreateJoinEntity(data, peer, oplog, true, true, true, true, false)
+	log.Debug("HandleInternalSyncGroupAckNew: after CreateJoinEntity", "e", err)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (pm *ProtocolManager) handleInternalSyncGroupAckDiffLog(
+	spm *group.ServiceProtocolManager,
+	data *pkgservice.ApproveJoinEntity,
+	oplog *pkgservice.BaseOplog,
+	peer *pkgservice.PttPeer,
+) error {
+
+	_, err := spm.CreateJoinEntity(data, peer, oplog, false, false, true, true, false)
+	log.Debug("HandleInternalSyncGroupAckDiffLog: after CreateJoinEntity", "e", err)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package me
+
+import (
+	"time"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ethereum/go-ethereum/common"
+
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+type JoinGroupEvent struct {
+	JoinRequest *pkgservice.JoinRequest
+}
+
+func (pm *ProtocolManager) JoinGroup(joinRequest *pkgservice.JoinRequest) error {
+
+	myInfo := pm.Entity().(*MyInfo)
+	if myInfo.Status != types.StatusAlive {
+		return nil
+	}
+
+	// lock
+	pm.lockJoinGroupRequest.Lock()
+	defer pm.lockJoinGroupRequest.Unlock()
+
+	// hash-val
+	hashVal := *joinRequest.Hash
+
+	_, ok := pm.joinGroupRequests[hashVal]
+	if ok {
+		return types.ErrAlreadyExists
+	}
+
+	pm.joinGroupRequests[hashVal] = joinRequest
+
+	pm.EventMux().Post(&JoinGroupEvent{JoinRequest: joinRequest})
+
+	return nil
+}
+
+func (pm *ProtocolManager) SyncJoinGroupLoop() error {
+	log.Debug("SyncJoinGroupLoop: Start")
+	ticker := time.NewTicker(SyncJoinSeconds)
+	defer ticker.Stop()
+
+	pm.SyncJoinGroup()
+
+loop:
+	for {
+		select {
+		case <-ticker.C:
+			pm.SyncJoinGroup()
+		case <-pm.QuitSync():
+			log.Info("SyncJoinGroupLoop: QuitSync", "entity", pm.Entity().IDString())
+			break loop
+		}
+	}
+
+	return nil
+}
+
+func (pm *ProtocolManager) SyncJoinGroup() error {
+	pm.lockJoinGroupRequest.Lock()
+	defer pm.lockJoinGroupRequest.Unlock()
+
+	now, err := types.GetTimestamp()
+	if err != nil {
+		return err
+	}
+
+	toRemoveHashs := make([]*common.Address, 0)
+	for _, joinRequest := range pm.joinGroupRequests {
+		if joinRequest.CreateTS.Ts < now.Ts-pkgservice.IntRenewJoinKeySeconds {
+			log.Warn("SyncJoinGroup: expired", "joinRequest", joinRequest.CreateTS, "now", now)
+			toRemoveHashs = append(toRemoveHashs, joinRequest.Hash)
+			continue
+		}
+
+		if joinRequest.Status != pkgservice.JoinStatusPending {
+			continue
+		}
+
+		pm.processJoinGroupEvent(joinRequest, true)
+	}
+
+	for _, hash := range toRemoveHashs {
+		delete(pm.joinGroupRequests, *hash)
+	}
+
+	return nil
+}
+
+/**********
+ * BroadcastLoop
+ **********/
+
+func (pm *ProtocolManager) JoinGroupLoop() {
+	for obj := range pm.joinGroupSub.Chan() {
+		ev, ok := obj.Data.(*JoinGroupEvent)
+		if !ok {
+			continue
+		}
+
+		err := pm.processJoinGroupEvent(ev.JoinRequest, false)
+		if err != nil {
+			log.Error("Unable to process join group event", "data", ev, "e", err)
+		}
+	}
+}
+
+func (pm *ProtocolManager) processJoinGroupEvent(request *pkgservice.JoinRequest, isLocked bool) error {
+	if !isLocked {
+		pm.lockJoinGroupRequest.Lock()
+		defer pm.lockJoinGroupRequest.Unlock()
+	}
+
+	if request.Status != pkgservice.JoinStatusPending {
+		return pkgservice.ErrInvalidStatus
+	}
+
+	hash, key, challenge := request.Hash, request.Key, request.Challenge
+
+	ptt := pm.Ptt()
+	err := ptt.TryJoin(challenge, hash, key, request)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
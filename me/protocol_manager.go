@@ -49,6 +49,11 @@ type ProtocolManager struct {
 	joinBoardRequests    map[common.Address]*pkgservice.JoinRequest
 	joinBoardSub         *event.TypeMuxSubscription
 
+	// requests to join-group
+	lockJoinGroupRequest sync.RWMutex
+	joinGroupRequests    map[common.Address]*pkgservice.JoinRequest
+	joinGroupSub         *event.TypeMuxSubscription
+
 	// my-nodes
 	lockJoinMeRequest sync.RWMutex
 	joinMeRequests    map[common.Address]*pkgservice.JoinRequest
@@ -133,6 +138,8 @@ func NewProtocolManager(myInfo *MyInfo, ptt pkgservice.MyPtt, svc pkgservice.Ser
 
 		joinBoardRequests: make(map[common.Address]*pkgservice.JoinRequest),
 
+		joinGroupRequests: make(map[common.Address]*pkgservice.JoinRequest),
+
 		// merkle
 		meOplogMerkle: meOplogMerkle,
 
@@ -249,7 +256,19 @@ func (pm *ProtocolManager) Start() error {
 		raftPeerList := []raft.Peer{{ID: myRaftID, Weight: weight, Context: myNodeID[:]}}
 		go pm.StartRaft(raftPeerList, true)
 	case types.StatusAlive:
-		go pm.StartRaft(nil, false)
+		isEmpty, err := IsEmptyRaftStorage(myInfo.ID)
+		if err != nil {
+			return err
+		}
+		if !isEmpty {
+			go pm.StartRaft(nil, false)
+			break
+		}
+
+		// restored from the backup (raft not in the backup): starting a new raft with only this node.
+		weight := pm.nodeTypeToWeight(myNodeType)
+		raftPeerList := []raft.Peer{{ID: myRaftID, Weight: weight, Context: myNodeID[:]}}
+		go pm.StartRaft(raftPeerList, true)
 	}
 
 	syncWG := pm.SyncWG()
@@ -296,6 +315,16 @@ func (pm *ProtocolManager) Start() error {
 		pm.SyncJoinBoardLoop()
 	}()
 
+	// join-group
+	pm.joinGroupSub = pm.EventMux().Subscribe(&JoinGroupEvent{})
+	go pm.JoinGroupLoop()
+
+	syncWG.Add(1)
+	go func() {
+		defer syncWG.Done()
+		pm.SyncJoinGroupLoop()
+	}()
+
 	// oplog-merkle-tree
 	syncWG.Add(1)
 	go func() {
@@ -323,6 +352,7 @@ func (pm *ProtocolManager) Stop() error {
 	pm.joinFriendSub.Unsubscribe()
 	pm.joinMeSub.Unsubscribe()
 	pm.joinBoardSub.Unsubscribe()
+	pm.joinGroupSub.Unsubscribe()
 
 	pm.StopRaft()
 
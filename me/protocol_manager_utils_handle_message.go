@@ -102,6 +102,12 @@ func (pm *ProtocolManager) HandleMessage(op pkgservice.OpType, dataBytes []byte,
 	case InternalSyncFriendAckMsg:
 		err = pm.HandleInternalSyncFriendAck(dataBytes, peer)
 
+	// internal-sync-group
+	case InternalSyncGroupMsg:
+		err = pm.HandleInternalSyncGroup(dataBytes, peer)
+	case InternalSyncGroupAckMsg:
+		err = pm.HandleInternalSyncGroupAck(dataBytes, peer)
+
 	default:
 		err = pkgservice.ErrInvalidMsgCode
 	}
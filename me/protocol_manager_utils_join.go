@@ -43,6 +43,12 @@ func (pm *ProtocolManager) GetJoinRequest(hash *common.Address) (*pkgservice.Joi
 		return joinRequest, nil
 	}
 
+	// group
+	joinRequest, err = pm.getJoinRequestCore(hash, &pm.lockJoinGroupRequest, pm.joinGroupRequests)
+	if err == nil {
+		return joinRequest, nil
+	}
+
 	return nil, pkgservice.ErrInvalidMsg
 
 }
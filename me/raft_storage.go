@@ -141,6 +141,22 @@ func NewRaftStorageWithClean(myID *types.PttID) (*RaftStorage, error) {
 	return rs, nil
 }
 
+/*
+IsEmptyRaftStorage checks whether there is no raft-entry of me,
+which happens only if the raft is never started with the data-dir.
+*/
+func IsEmptyRaftStorage(myID *types.PttID) (bool, error) {
+	rs := &RaftStorage{myID: myID}
+
+	iter, err := rs.GetIter(0)
+	if err != nil {
+		return false, err
+	}
+	defer iter.Release()
+
+	return !iter.Next(), nil
+}
+
 func CleanRaftStorage(myID *types.PttID, rs *RaftStorage, isLocked bool) error {
 	if rs == nil {
 		rs = &RaftStorage{myID: myID}
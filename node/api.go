@@ -227,6 +227,48 @@ func (api *PrivateAdminAPI) StopWS() (bool, error) {
 	return true, nil
 }
 
+// BackendIssueToken is the issued api-token. The token is shown only once.
+type BackendIssueToken struct {
+	*AuthToken
+	Token string `json:"T"`
+}
+
+// IssueToken issues a new api-token with the scopes (read / post / admin / key).
+// The issuer from the HTTP / websocket RPC can only issue the scopes it has.
+func (api *PrivateAdminAPI) IssueToken(ctx context.Context, name string, scopeStrs []string) (*BackendIssueToken, error) {
+	auth := api.node.Authenticator()
+
+	scopes := make([]AuthScope, len(scopeStrs))
+	for i, scopeStr := range scopeStrs {
+		scopes[i] = AuthScope(strings.TrimSpace(scopeStr))
+	}
+
+	if err := auth.checkIssuer(ctx, scopes); err != nil {
+		return nil, err
+	}
+
+	authToken, token, err := auth.Issue(name, scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BackendIssueToken{AuthToken: authToken, Token: token}, nil
+}
+
+// RevokeToken revokes the api-token by the id.
+func (api *PrivateAdminAPI) RevokeToken(id string) (bool, error) {
+	err := api.node.Authenticator().Revoke(id)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetTokenList lists the api-tokens.
+func (api *PrivateAdminAPI) GetTokenList() ([]*AuthToken, error) {
+	return api.node.Authenticator().List(), nil
+}
+
 // PublicAdminAPI is the collection of administrative API methods exposed over
 // both secure and unsecure RPC channels.
 type PublicAdminAPI struct {
@@ -0,0 +1,376 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/rpc"
+)
+
+/*
+AuthScope is the scope of the api-token.
+
+	read: the public apis and the getters of the private apis.
+	post: creating / updating / deleting the contents (including read).
+	admin: administrating the node (including read / post), e.g. shutdown, revoke, force-sync, and the api-tokens.
+	key: showing / refreshing / validating the keys, mnemonic, and backup.
+*/
+type AuthScope string
+
+const (
+	AuthScopeRead  AuthScope = "read"
+	AuthScopePost  AuthScope = "post"
+	AuthScopeAdmin AuthScope = "admin"
+	AuthScopeKey   AuthScope = "key"
+)
+
+func (s AuthScope) IsValid() bool {
+	switch s {
+	case AuthScopeRead, AuthScopePost, AuthScopeAdmin, AuthScopeKey:
+		return true
+	}
+	return false
+}
+
+/*
+Includes returns whether the scope satisfies the required scope.
+*/
+func (s AuthScope) Includes(required AuthScope) bool {
+	switch s {
+	case AuthScopeAdmin:
+		return required != AuthScopeKey
+	case AuthScopePost:
+		return required == AuthScopePost || required == AuthScopeRead
+	}
+	return s == required
+}
+
+/*
+AuthToken is the api-token. Only the sha256 of the token is stored.
+*/
+type AuthToken struct {
+	ID       string          `json:"ID"`
+	Name     string          `json:"N"`
+	Scopes   []AuthScope     `json:"S"`
+	CreateTS types.Timestamp `json:"CT"`
+	Hash     string          `json:"H,omitempty"`
+}
+
+func (t *AuthToken) HasScope(required AuthScope) bool {
+	for _, scope := range t.Scopes {
+		if scope.Includes(required) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Authenticator authorizes the requests to the http / ws rpc-endpoints and ptthttp by the api-tokens.
+The requests are all authorized if the authenticator is not enabled.
+*/
+type Authenticator struct {
+	lock sync.RWMutex
+
+	isEnabled bool
+	filename  string
+
+	tokens       []*AuthToken
+	methodScopes map[string]AuthScope
+}
+
+func NewAuthenticator(filename string, isEnabled bool) *Authenticator {
+	return &Authenticator{
+		isEnabled:    isEnabled,
+		filename:     filename,
+		methodScopes: make(map[string]AuthScope),
+	}
+}
+
+func (a *Authenticator) IsEnabled() bool {
+	return a.isEnabled
+}
+
+/*
+Load loads the tokens from the file.
+*/
+func (a *Authenticator) Load() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	tokens := make([]*AuthToken, 0)
+	if a.filename == "" {
+		a.tokens = tokens
+		return nil
+	}
+
+	marshaled, err := ioutil.ReadFile(a.filename)
+	if os.IsNotExist(err) {
+		a.tokens = tokens
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(marshaled, &tokens)
+	if err != nil {
+		return err
+	}
+	a.tokens = tokens
+
+	return nil
+}
+
+func (a *Authenticator) save() error {
+	if a.filename == "" { // ephemeral node
+		return nil
+	}
+
+	marshaled, err := json.Marshal(a.tokens)
+	if err != nil {
+		return err
+	}
+
+	tmpFilename := a.filename + ".tmp"
+	err = ioutil.WriteFile(tmpFilename, marshaled, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFilename, a.filename)
+}
+
+/*
+SetAPIs sets the required scopes of the methods of the apis based on AuthMethodScopes.
+The methods not in AuthMethodScopes require admin.
+*/
+func (a *Authenticator) SetAPIs(apis []rpc.API) {
+	methodScopes := make(map[string]AuthScope)
+	for _, api := range apis {
+		for _, name := range rpc.MethodNames(api.Service) {
+			method := api.Namespace + "_" + name
+			scope, ok := AuthMethodScopes[method]
+			if !ok {
+				log.Warn("SetAPIs: method not in AuthMethodScopes, require admin", "method", method)
+				scope = AuthScopeAdmin
+			}
+			methodScopes[method] = scope
+		}
+	}
+	methodScopes["rpc_modules"] = AuthMethodScopes["rpc_modules"]
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.methodScopes = methodScopes
+}
+
+/*
+MethodScope returns the required scope of the method. Unknown methods require admin.
+*/
+func (a *Authenticator) MethodScope(method string) AuthScope {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	scope, ok := a.methodScopes[method]
+	if !ok {
+		return AuthScopeAdmin
+	}
+	return scope
+}
+
+/*
+Authorize implements rpc.Authenticator.
+*/
+func (a *Authenticator) Authorize(token string, method string) error {
+	if !a.isEnabled {
+		return nil
+	}
+
+	authToken := a.getToken(token)
+	if authToken == nil {
+		return ErrInvalidAuthToken
+	}
+
+	required := a.MethodScope(method)
+	if !authToken.HasScope(required) {
+		log.Warn("Authorize: insufficient scope", "id", authToken.ID, "method", method, "required", required)
+		return ErrInsufficientAuthScope
+	}
+
+	return nil
+}
+
+func (a *Authenticator) getToken(token string) *AuthToken {
+	if token == "" {
+		return nil
+	}
+
+	hash := hashAuthToken(token)
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	for _, authToken := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(authToken.Hash), []byte(hash)) == 1 {
+			return authToken
+		}
+	}
+	return nil
+}
+
+func hashAuthToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+/*
+Issue issues a new token with the scopes. The token is returned only once and is not recoverable.
+*/
+func (a *Authenticator) Issue(name string, scopes []AuthScope) (*AuthToken, string, error) {
+	if len(scopes) == 0 {
+		return nil, "", ErrInvalidAuthScope
+	}
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, "", ErrInvalidAuthScope
+		}
+	}
+
+	tokenBytes := make([]byte, AuthTokenLength)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(tokenBytes)
+
+	idBytes := make([]byte, AuthTokenIDLength)
+	_, err = rand.Read(idBytes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, "", err
+	}
+
+	authToken := &AuthToken{
+		ID:       hex.EncodeToString(idBytes),
+		Name:     name,
+		Scopes:   scopes,
+		CreateTS: ts,
+		Hash:     hashAuthToken(token),
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.tokens = append(a.tokens, authToken)
+	err = a.save()
+	if err != nil {
+		a.tokens = a.tokens[:len(a.tokens)-1]
+		return nil, "", err
+	}
+
+	return authToken.public(), token, nil
+}
+
+/*
+Revoke revokes the token by the id.
+*/
+func (a *Authenticator) Revoke(id string) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for i, authToken := range a.tokens {
+		if authToken.ID != id {
+			continue
+		}
+
+		origTokens := a.tokens
+		a.tokens = append(append(make([]*AuthToken, 0, len(origTokens)-1), origTokens[:i]...), origTokens[i+1:]...)
+		err := a.save()
+		if err != nil {
+			a.tokens = origTokens
+			return err
+		}
+		return nil
+	}
+
+	return ErrAuthTokenNotFound
+}
+
+/*
+List lists the tokens (without the hashes).
+*/
+func (a *Authenticator) List() []*AuthToken {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	tokens := make([]*AuthToken, len(a.tokens))
+	for i, authToken := range a.tokens {
+		tokens[i] = authToken.public()
+	}
+	return tokens
+}
+
+func (t *AuthToken) public() *AuthToken {
+	return &AuthToken{
+		ID:       t.ID,
+		Name:     t.Name,
+		Scopes:   t.Scopes,
+		CreateTS: t.CreateTS,
+	}
+}
+
+/*
+checkIssuer checks that the issuer (the token in ctx, from the http / ws rpc-endpoints) has all the scopes to be issued,
+to avoid escalating the scopes. The requests from ipc / in-proc are trusted.
+*/
+func (a *Authenticator) checkIssuer(ctx context.Context, scopes []AuthScope) error {
+	token, ok := rpc.TokenFromContext(ctx)
+	if !ok || !a.isEnabled {
+		return nil
+	}
+
+	issuer := a.getToken(token)
+	if issuer == nil {
+		return ErrInvalidAuthToken
+	}
+
+	for _, scope := range scopes {
+		if !issuer.HasScope(scope) {
+			return ErrInsufficientAuthScope
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package node_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/account"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/friend"
+	"github.com/ailabstw/go-pttai/group"
+	"github.com/ailabstw/go-pttai/internal/debug"
+	"github.com/ailabstw/go-pttai/me"
+	"github.com/ailabstw/go-pttai/node"
+	"github.com/ailabstw/go-pttai/rpc"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+)
+
+/*
+tAPIs are the apis registered by the node and the services of gptt.
+*/
+func tAPIs() []rpc.API {
+	apis := []rpc.API{
+		{Namespace: "admin", Service: node.NewPrivateAdminAPI(nil)},
+		{Namespace: "admin", Service: node.NewPublicAdminAPI(nil), Public: true},
+		{Namespace: "debug", Service: debug.Handler},
+		{Namespace: "debug", Service: node.NewPublicDebugAPI(nil), Public: true},
+	}
+	apis = append(apis, (&pkgservice.BasePtt{}).PttAPIs()...)
+	apis = append(apis, (&me.Backend{}).APIs()...)
+	apis = append(apis, (&account.Backend{}).APIs()...)
+	apis = append(apis, (&content.Backend{}).APIs()...)
+	apis = append(apis, (&friend.Backend{}).APIs()...)
+	apis = append(apis, (&group.Backend{}).APIs()...)
+
+	return apis
+}
+
+func TestAuthMethodScopes(t *testing.T) {
+	// setup test
+	apis := tAPIs()
+
+	methods := []string{"rpc_modules"}
+	for _, api := range apis {
+		for _, name := range rpc.MethodNames(api.Service) {
+			methods = append(methods, api.Namespace+"_"+name)
+		}
+	}
+	sort.Strings(methods)
+
+	a := node.NewAuthenticator("", true)
+	a.SetAPIs(apis)
+
+	// run test: every registered method is with the explicit scope.
+	methodMap := make(map[string]bool)
+	for _, method := range methods {
+		methodMap[method] = true
+
+		scope, ok := node.AuthMethodScopes[method]
+		if !ok {
+			t.Errorf("AuthMethodScopes: %v not in the table", method)
+			continue
+		}
+		if got := a.MethodScope(method); got != scope {
+			t.Errorf("Authenticator.MethodScope(%v) = %v, want %v", method, got, scope)
+		}
+
+		t.Logf("%v: %v", method, scope)
+	}
+
+	// no stale methods in the table.
+	for method := range node.AuthMethodScopes {
+		if !methodMap[method] {
+			t.Errorf("AuthMethodScopes: %v is not registered", method)
+		}
+	}
+}
+
+func TestAuthenticator_MethodScope(t *testing.T) {
+	// setup test
+	a := node.NewAuthenticator("", true)
+	a.SetAPIs(tAPIs())
+
+	// prepare test-cases
+	tests := []struct {
+		method string
+		want   node.AuthScope
+	}{
+		{method: "content_getArticleList", want: node.AuthScopeRead},
+		{method: "content_subscribeBoard", want: node.AuthScopeRead},
+		{method: "content_createArticle", want: node.AuthScopePost},
+		{method: "friend_setPresence", want: node.AuthScopePost},
+		{method: "ptt_shutdown", want: node.AuthScopeAdmin},
+		{method: "content_forceSync", want: node.AuthScopeAdmin},
+		{method: "content_exportBoard", want: node.AuthScopeAdmin},
+		{method: "admin_issueToken", want: node.AuthScopeAdmin},
+		{method: "me_showMnemonic", want: node.AuthScopeKey},
+		{method: "content_showBoardURL", want: node.AuthScopeKey},
+		{method: "content_notExists", want: node.AuthScopeAdmin},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			if got := a.MethodScope(tt.method); got != tt.want {
+				t.Errorf("Authenticator.MethodScope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -112,6 +112,10 @@ type Config struct {
 	// exposed.
 	HTTPModules []string `toml:",omitempty"`
 
+	// RPCAuth requires the api-tokens with sufficient scopes for the requests
+	// to the HTTP / websocket RPC interfaces and ptthttp. IPC is always trusted.
+	RPCAuth bool `toml:",omitempty"`
+
 	// WSHost is the host interface on which to start the websocket RPC server. If
 	// this field is empty, no websocket API endpoint will be started.
 	WSHost string `toml:",omitempty"`
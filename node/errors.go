@@ -31,6 +31,11 @@ var (
 
 	ErrNodeRestart = errors.New("node restart")
 
+	ErrInvalidAuthToken      = errors.New("invalid or missing api-token")
+	ErrInsufficientAuthScope = errors.New("insufficient scope of the api-token")
+	ErrInvalidAuthScope      = errors.New("invalid scope (read / post / admin / key)")
+	ErrAuthTokenNotFound     = errors.New("api-token not found")
+
 	dataDirInUseErrnos = map[uint]bool{11: true, 32: true, 35: true}
 )
 
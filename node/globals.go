@@ -39,6 +39,7 @@ const (
 	DataDirStaticNodes     = "static-nodes.json"  // Path within the datadir to the static node list
 	DataDirTrustedNodes    = "trusted-nodes.json" // Path within the datadir to the trusted node list
 	DataDirNodeDatabase    = "nodes"              // Path within the datadir to store the node infos
+	DataDirAuthTokens      = "authtokens.json"    // Path within the datadir to the api-tokens
 
 	DefaultHTTPHost = ""    // Default host interface for the HTTP RPC server
 	DefaultHTTPPort = 14779 // Default TCP port for the HTTP RPC server
@@ -48,6 +49,369 @@ const (
 	DefaultNetworkID = Devnet
 )
 
+// auth
+const (
+	AuthTokenLength   = 32
+	AuthTokenIDLength = 8
+)
+
+var (
+	// AuthMethodScopes is the required scope of each rpc-method.
+	// The methods not in the table require admin.
+	AuthMethodScopes = map[string]AuthScope{
+		// account
+		"account_countPeers":                        AuthScopeRead,
+		"account_forceSync":                         AuthScopeAdmin,
+		"account_forceSyncMasterMerkle":             AuthScopeAdmin,
+		"account_forceSyncMemberMerkle":             AuthScopeAdmin,
+		"account_forceSyncUserMerkle":               AuthScopeAdmin,
+		"account_getMasterList":                     AuthScopeRead,
+		"account_getMasterListFromCache":            AuthScopeRead,
+		"account_getMasterOplogList":                AuthScopeRead,
+		"account_getMasterOplogMerkleNodeList":      AuthScopeRead,
+		"account_getMemberList":                     AuthScopeRead,
+		"account_getMemberOplogList":                AuthScopeRead,
+		"account_getMemberOplogMerkleNodeList":      AuthScopeRead,
+		"account_getMyMemberLog":                    AuthScopeRead,
+		"account_getNameCard":                       AuthScopeRead,
+		"account_getNameCardByIDs":                  AuthScopeRead,
+		"account_getOpKeyInfos":                     AuthScopeKey,
+		"account_getOpKeyInfosFromDB":               AuthScopeKey,
+		"account_getOpKeyOplogList":                 AuthScopeRead,
+		"account_getPeers":                          AuthScopeRead,
+		"account_getPendingMasterOplogInternalList": AuthScopeRead,
+		"account_getPendingMasterOplogMasterList":   AuthScopeRead,
+		"account_getPendingMemberOplogInternalList": AuthScopeRead,
+		"account_getPendingMemberOplogMasterList":   AuthScopeRead,
+		"account_getPendingOpKeyOplogInternalList":  AuthScopeRead,
+		"account_getPendingOpKeyOplogMasterList":    AuthScopeRead,
+		"account_getPendingUserOplogInternalList":   AuthScopeRead,
+		"account_getPendingUserOplogMasterList":     AuthScopeRead,
+		"account_getRawNameCard":                    AuthScopeRead,
+		"account_getRawProfile":                     AuthScopeRead,
+		"account_getRawUserImg":                     AuthScopeRead,
+		"account_getRawUserName":                    AuthScopeRead,
+		"account_getUserImg":                        AuthScopeRead,
+		"account_getUserImgByIDs":                   AuthScopeRead,
+		"account_getUserName":                       AuthScopeRead,
+		"account_getUserNameByIDs":                  AuthScopeRead,
+		"account_getUserNodeInfo":                   AuthScopeRead,
+		"account_getUserNodeList":                   AuthScopeRead,
+		"account_getUserOplogList":                  AuthScopeRead,
+		"account_getUserOplogMerkleNodeList":        AuthScopeRead,
+		"account_removeUserNode":                    AuthScopeAdmin,
+		"account_revokeOpKey":                       AuthScopeKey,
+		"account_showValidateKey":                   AuthScopeKey,
+		"account_validateValidateKey":               AuthScopeKey,
+
+		// admin
+		"admin_addPeer":      AuthScopeAdmin,
+		"admin_datadir":      AuthScopeAdmin,
+		"admin_getTokenList": AuthScopeAdmin,
+		"admin_issueToken":   AuthScopeAdmin,
+		"admin_nodeInfo":     AuthScopeAdmin,
+		"admin_peerEvents":   AuthScopeAdmin,
+		"admin_peers":        AuthScopeAdmin,
+		"admin_removePeer":   AuthScopeAdmin,
+		"admin_revokeToken":  AuthScopeAdmin,
+		"admin_startRPC":     AuthScopeAdmin,
+		"admin_startWS":      AuthScopeAdmin,
+		"admin_stopRPC":      AuthScopeAdmin,
+		"admin_stopWS":       AuthScopeAdmin,
+
+		// content
+		"content_addModerator":                      AuthScopePost,
+		"content_banMember":                         AuthScopePost,
+		"content_countPeers":                        AuthScopeRead,
+		"content_createArticle":                     AuthScopePost,
+		"content_createAudioUpload":                 AuthScopePost,
+		"content_createBoard":                       AuthScopePost,
+		"content_createComment":                     AuthScopePost,
+		"content_createFileUpload":                  AuthScopePost,
+		"content_createReply":                       AuthScopePost,
+		"content_createVideoUpload":                 AuthScopePost,
+		"content_deleteArticle":                     AuthScopePost,
+		"content_deleteBoard":                       AuthScopePost,
+		"content_deleteComment":                     AuthScopePost,
+		"content_deleteFileUpload":                  AuthScopePost,
+		"content_deleteMember":                      AuthScopePost,
+		"content_deleteReply":                       AuthScopePost,
+		"content_exportBoard":                       AuthScopeAdmin,
+		"content_forceSync":                         AuthScopeAdmin,
+		"content_forceSyncBoardMerkle":              AuthScopeAdmin,
+		"content_forceSyncMasterMerkle":             AuthScopeAdmin,
+		"content_forceSyncMemberMerkle":             AuthScopeAdmin,
+		"content_getArticle":                        AuthScopeRead,
+		"content_getArticleBlockList":               AuthScopeRead,
+		"content_getArticleList":                    AuthScopeRead,
+		"content_getArticleListByTag":               AuthScopeRead,
+		"content_getArticleRevision":                AuthScopeRead,
+		"content_getArticleRevisionDiff":            AuthScopeRead,
+		"content_getArticleRevisionLimit":           AuthScopeRead,
+		"content_getArticleRevisionList":            AuthScopeRead,
+		"content_getArticleSummary":                 AuthScopeRead,
+		"content_getArticleSummaryByIDs":            AuthScopeRead,
+		"content_getBanList":                        AuthScopeRead,
+		"content_getBoard":                          AuthScopeRead,
+		"content_getBoardList":                      AuthScopeRead,
+		"content_getBoardOplogList":                 AuthScopeRead,
+		"content_getBoardOplogMerkle":               AuthScopeRead,
+		"content_getBoardOplogMerkleNodeList":       AuthScopeRead,
+		"content_getBoardPostInterval":              AuthScopeRead,
+		"content_getBoardTags":                      AuthScopeRead,
+		"content_getFile":                           AuthScopeRead,
+		"content_getFileInfo":                       AuthScopeRead,
+		"content_getFileRange":                      AuthScopeRead,
+		"content_getFileUpload":                     AuthScopeRead,
+		"content_getImage":                          AuthScopeRead,
+		"content_getJoinKeyInfos":                   AuthScopeKey,
+		"content_getMasterList":                     AuthScopeRead,
+		"content_getMasterListFromCache":            AuthScopeRead,
+		"content_getMasterOplogList":                AuthScopeRead,
+		"content_getMasterOplogMerkleNodeList":      AuthScopeRead,
+		"content_getMemberList":                     AuthScopeRead,
+		"content_getMemberOplogList":                AuthScopeRead,
+		"content_getMemberOplogMerkleNodeList":      AuthScopeRead,
+		"content_getModeratorList":                  AuthScopeRead,
+		"content_getMyMemberLog":                    AuthScopeRead,
+		"content_getOpKeyInfos":                     AuthScopeKey,
+		"content_getOpKeyInfosFromDB":               AuthScopeKey,
+		"content_getOpKeyOplogList":                 AuthScopeRead,
+		"content_getPeers":                          AuthScopeRead,
+		"content_getPendingBoardOplogInternalList":  AuthScopeRead,
+		"content_getPendingBoardOplogMasterList":    AuthScopeRead,
+		"content_getPendingMasterOplogInternalList": AuthScopeRead,
+		"content_getPendingMasterOplogMasterList":   AuthScopeRead,
+		"content_getPendingMemberOplogInternalList": AuthScopeRead,
+		"content_getPendingMemberOplogMasterList":   AuthScopeRead,
+		"content_getPendingOpKeyOplogInternalList":  AuthScopeRead,
+		"content_getPendingOpKeyOplogMasterList":    AuthScopeRead,
+		"content_getPinnedArticleList":              AuthScopeRead,
+		"content_getPokedArticleList":               AuthScopeRead,
+		"content_getRawArticle":                     AuthScopeRead,
+		"content_getRawBoard":                       AuthScopeRead,
+		"content_getRawComment":                     AuthScopeRead,
+		"content_getRawReply":                       AuthScopeRead,
+		"content_getRawTitle":                       AuthScopeRead,
+		"content_getReactions":                      AuthScopeRead,
+		"content_getReplyList":                      AuthScopeRead,
+		"content_importBBSArticles":                 AuthScopePost,
+		"content_inviteMaster":                      AuthScopePost,
+		"content_isBoardPublicFeed":                 AuthScopeRead,
+		"content_leaveBoard":                        AuthScopePost,
+		"content_liftBan":                           AuthScopePost,
+		"content_markArticleSeen":                   AuthScopePost,
+		"content_markBoardSeen":                     AuthScopePost,
+		"content_muteMember":                        AuthScopePost,
+		"content_pinArticle":                        AuthScopePost,
+		"content_react":                             AuthScopePost,
+		"content_removeModerator":                   AuthScopePost,
+		"content_revokeOpKey":                       AuthScopeKey,
+		"content_searchArticles":                    AuthScopeRead,
+		"content_setArticleRevisionLimit":           AuthScopePost,
+		"content_setArticleTags":                    AuthScopePost,
+		"content_setBoardPostInterval":              AuthScopePost,
+		"content_setBoardPublicFeed":                AuthScopePost,
+		"content_setBoardTags":                      AuthScopePost,
+		"content_setTitle":                          AuthScopePost,
+		"content_showBoardURL":                      AuthScopeKey,
+		"content_showValidateKey":                   AuthScopeKey,
+		"content_subscribeBoard":                    AuthScopeRead,
+		"content_unpinArticle":                      AuthScopePost,
+		"content_unreact":                           AuthScopePost,
+		"content_updateArticle":                     AuthScopePost,
+		"content_updateReply":                       AuthScopePost,
+		"content_uploadFile":                        AuthScopePost,
+		"content_uploadFileChunk":                   AuthScopePost,
+		"content_uploadImage":                       AuthScopePost,
+		"content_validateValidateKey":               AuthScopeKey,
+
+		// debug
+		"debug_backtraceAt":             AuthScopeAdmin,
+		"debug_blockProfile":            AuthScopeAdmin,
+		"debug_cpuProfile":              AuthScopeAdmin,
+		"debug_freeOSMemory":            AuthScopeAdmin,
+		"debug_gcStats":                 AuthScopeAdmin,
+		"debug_goTrace":                 AuthScopeAdmin,
+		"debug_memStats":                AuthScopeAdmin,
+		"debug_metrics":                 AuthScopeAdmin,
+		"debug_mutexProfile":            AuthScopeAdmin,
+		"debug_setBlockProfileRate":     AuthScopeAdmin,
+		"debug_setGCPercent":            AuthScopeAdmin,
+		"debug_setMutexProfileFraction": AuthScopeAdmin,
+		"debug_stacks":                  AuthScopeAdmin,
+		"debug_startCPUProfile":         AuthScopeAdmin,
+		"debug_startGoTrace":            AuthScopeAdmin,
+		"debug_stopCPUProfile":          AuthScopeAdmin,
+		"debug_stopGoTrace":             AuthScopeAdmin,
+		"debug_verbosity":               AuthScopeAdmin,
+		"debug_vmodule":                 AuthScopeAdmin,
+		"debug_writeBlockProfile":       AuthScopeAdmin,
+		"debug_writeMemProfile":         AuthScopeAdmin,
+		"debug_writeMutexProfile":       AuthScopeAdmin,
+
+		// friend
+		"friend_countPeers":                        AuthScopeRead,
+		"friend_createMessage":                     AuthScopePost,
+		"friend_deleteFriend":                      AuthScopePost,
+		"friend_deleteMessage":                     AuthScopePost,
+		"friend_forceOpKey":                        AuthScopeAdmin,
+		"friend_forceSync":                         AuthScopeAdmin,
+		"friend_forceSyncFriendMerkle":             AuthScopeAdmin,
+		"friend_forceSyncMasterMerkle":             AuthScopeAdmin,
+		"friend_forceSyncMemberMerkle":             AuthScopeAdmin,
+		"friend_getFriend":                         AuthScopeRead,
+		"friend_getFriendByFriendID":               AuthScopeRead,
+		"friend_getFriendList":                     AuthScopeRead,
+		"friend_getFriendListByMsgCreateTS":        AuthScopeRead,
+		"friend_getFriendListSeen":                 AuthScopeRead,
+		"friend_getFriendOplogList":                AuthScopeRead,
+		"friend_getFriendOplogMerkleNodeList":      AuthScopeRead,
+		"friend_getMasterList":                     AuthScopeRead,
+		"friend_getMasterListFromCache":            AuthScopeRead,
+		"friend_getMasterOplogList":                AuthScopeRead,
+		"friend_getMasterOplogMerkleNodeList":      AuthScopeRead,
+		"friend_getMemberList":                     AuthScopeRead,
+		"friend_getMemberOplogList":                AuthScopeRead,
+		"friend_getMemberOplogMerkleNodeList":      AuthScopeRead,
+		"friend_getMessageBlockList":               AuthScopeRead,
+		"friend_getMessageList":                    AuthScopeRead,
+		"friend_getMessageReceipts":                AuthScopeRead,
+		"friend_getMyMemberLog":                    AuthScopeRead,
+		"friend_getOpKeyInfos":                     AuthScopeKey,
+		"friend_getOpKeyInfosFromDB":               AuthScopeKey,
+		"friend_getOpKeyOplogList":                 AuthScopeRead,
+		"friend_getPeers":                          AuthScopeRead,
+		"friend_getPendingFriendOplogInternalList": AuthScopeRead,
+		"friend_getPendingFriendOplogMasterList":   AuthScopeRead,
+		"friend_getPendingMasterOplogInternalList": AuthScopeRead,
+		"friend_getPendingMasterOplogMasterList":   AuthScopeRead,
+		"friend_getPendingMemberOplogInternalList": AuthScopeRead,
+		"friend_getPendingMemberOplogMasterList":   AuthScopeRead,
+		"friend_getPendingOpKeyOplogInternalList":  AuthScopeRead,
+		"friend_getPendingOpKeyOplogMasterList":    AuthScopeRead,
+		"friend_getPresence":                       AuthScopeRead,
+		"friend_getRawFriend":                      AuthScopeRead,
+		"friend_markFriendListSeen":                AuthScopePost,
+		"friend_markFriendSeen":                    AuthScopePost,
+		"friend_revokeOpKey":                       AuthScopeKey,
+		"friend_searchMessages":                    AuthScopeRead,
+		"friend_setPresence":                       AuthScopePost,
+		"friend_setTyping":                         AuthScopePost,
+		"friend_showValidateKey":                   AuthScopeKey,
+		"friend_subscribeMessages":                 AuthScopeRead,
+		"friend_subscribePresence":                 AuthScopeRead,
+		"friend_updateMessage":                     AuthScopePost,
+		"friend_validateValidateKey":               AuthScopeKey,
+
+		// group
+		"group_createGroup":              AuthScopePost,
+		"group_createGroupMessage":       AuthScopePost,
+		"group_deleteGroup":              AuthScopePost,
+		"group_deleteMember":             AuthScopePost,
+		"group_forceSync":                AuthScopeAdmin,
+		"group_getGroup":                 AuthScopeRead,
+		"group_getGroupList":             AuthScopeRead,
+		"group_getGroupMessageBlockList": AuthScopeRead,
+		"group_getGroupMessageList":      AuthScopeRead,
+		"group_getGroupOplogList":        AuthScopeRead,
+		"group_getJoinKeyInfos":          AuthScopeKey,
+		"group_getMemberList":            AuthScopeRead,
+		"group_getRawGroup":              AuthScopeRead,
+		"group_leaveGroup":               AuthScopePost,
+		"group_markGroupSeen":            AuthScopePost,
+		"group_setTitle":                 AuthScopePost,
+		"group_showGroupURL":             AuthScopeKey,
+		"group_subscribeGroup":           AuthScopeRead,
+
+		// me
+		"me_countPeers":                       AuthScopeRead,
+		"me_exportBackup":                     AuthScopeKey,
+		"me_forceRemoveNode":                  AuthScopeAdmin,
+		"me_forceSyncMeMerkle":                AuthScopeAdmin,
+		"me_get":                              AuthScopeRead,
+		"me_getBoard":                         AuthScopeRead,
+		"me_getBoardRequests":                 AuthScopeRead,
+		"me_getFriendRequests":                AuthScopeRead,
+		"me_getGroupRequests":                 AuthScopeRead,
+		"me_getJoinKeyInfos":                  AuthScopeKey,
+		"me_getMeList":                        AuthScopeRead,
+		"me_getMeOplogList":                   AuthScopeRead,
+		"me_getMeOplogMerkleNodeList":         AuthScopeRead,
+		"me_getMeRequests":                    AuthScopeRead,
+		"me_getMyBoard":                       AuthScopeRead,
+		"me_getMyMasterOplogList":             AuthScopeRead,
+		"me_getMyNodes":                       AuthScopeRead,
+		"me_getOpKeyInfos":                    AuthScopeKey,
+		"me_getOpKeyInfosFromDB":              AuthScopeKey,
+		"me_getOpKeyOplogList":                AuthScopeRead,
+		"me_getPeers":                         AuthScopeRead,
+		"me_getPendingMeOplogInternalList":    AuthScopeRead,
+		"me_getPendingMeOplogMasterList":      AuthScopeRead,
+		"me_getPendingOpKeyOplogInternalList": AuthScopeRead,
+		"me_getPendingOpKeyOplogMasterList":   AuthScopeRead,
+		"me_getRaftStatus":                    AuthScopeRead,
+		"me_getRawMe":                         AuthScopeRead,
+		"me_getRawMeOplogList":                AuthScopeRead,
+		"me_getRawMyNodes":                    AuthScopeRead,
+		"me_getTotalWeight":                   AuthScopeRead,
+		"me_joinBoard":                        AuthScopePost,
+		"me_joinFriend":                       AuthScopePost,
+		"me_joinGroup":                        AuthScopePost,
+		"me_joinMe":                           AuthScopePost,
+		"me_refreshMyNodeSignKey":             AuthScopeKey,
+		"me_refreshMySignKey":                 AuthScopeKey,
+		"me_removeBoardRequests":              AuthScopePost,
+		"me_removeFriendRequests":             AuthScopePost,
+		"me_removeGroupRequests":              AuthScopePost,
+		"me_removeMeRequests":                 AuthScopePost,
+		"me_removeNode":                       AuthScopeAdmin,
+		"me_requestRaftLead":                  AuthScopeAdmin,
+		"me_revoke":                           AuthScopeAdmin,
+		"me_revokeOpKey":                      AuthScopeKey,
+		"me_setMyImage":                       AuthScopePost,
+		"me_setMyName":                        AuthScopePost,
+		"me_setMyNameCard":                    AuthScopePost,
+		"me_setMyNodeName":                    AuthScopePost,
+		"me_showMeURL":                        AuthScopeKey,
+		"me_showMnemonic":                     AuthScopeKey,
+		"me_showMyKey":                        AuthScopeKey,
+		"me_showMyMasterKey":                  AuthScopeKey,
+		"me_showMyNodeKey":                    AuthScopeKey,
+		"me_showMyNodeSignKey":                AuthScopeKey,
+		"me_showMySignKey":                    AuthScopeKey,
+		"me_showURL":                          AuthScopeKey,
+		"me_validateMyKey":                    AuthScopeKey,
+		"me_validateMyMasterKey":              AuthScopeKey,
+		"me_validateMyNodeKey":                AuthScopeKey,
+
+		// ptt
+		"ptt_countEntities":        AuthScopeRead,
+		"ptt_countPeers":           AuthScopeRead,
+		"ptt_getConfirmJoins":      AuthScopeRead,
+		"ptt_getGitCommit":         AuthScopeRead,
+		"ptt_getJoins":             AuthScopeRead,
+		"ptt_getLastAnnounceP2PTS": AuthScopeRead,
+		"ptt_getLocale":            AuthScopeRead,
+		"ptt_getOffsetSecond":      AuthScopeRead,
+		"ptt_getOps":               AuthScopeRead,
+		"ptt_getPeers":             AuthScopeRead,
+		"ptt_getPttOplogList":      AuthScopeRead,
+		"ptt_getPttOplogSeen":      AuthScopeRead,
+		"ptt_getTimestamp":         AuthScopeRead,
+		"ptt_getVersion":           AuthScopeRead,
+		"ptt_markPttOplogSeen":     AuthScopePost,
+		"ptt_restart":              AuthScopeAdmin,
+		"ptt_setLocale":            AuthScopePost,
+		"ptt_setOffsetSecond":      AuthScopeAdmin,
+		"ptt_shutdown":             AuthScopeAdmin,
+		"ptt_subscribeOplogs":      AuthScopeRead,
+
+		// rpc
+		"rpc_modules": AuthScopeRead,
+	}
+)
+
 var (
 	DefaultConfig = Config{
 		DataDir:          DefaultDataDir(),
@@ -56,7 +420,7 @@ var (
 		HTTPPort:         DefaultHTTPPort,
 		HTTPCors:         []string{"localhost"},
 		HTTPVirtualHosts: []string{"localhost"},
-		HTTPModules:      []string{"debug", "net", "admin", "ptt", "account", "content", "me", "friend"},
+		HTTPModules:      []string{"debug", "net", "admin", "ptt", "account", "content", "me", "friend", "group"},
 		WSPort:           DefaultWSPort,
 		P2P: p2p.Config{
 			ListenAddr:    ":29487",
@@ -82,6 +82,8 @@ type Node struct {
 	wsListener net.Listener // Websocket RPC listener socket to server API requests
 	wsHandler  *rpc.Server  // Websocket RPC request handler to process the API requests
 
+	auth *Authenticator // Authenticator of the api-tokens for the HTTP / websocket RPC and ptthttp
+
 	lock     sync.RWMutex
 	StopChan chan error
 
@@ -128,6 +130,7 @@ func New(cfg *Config) (*Node, error) {
 		ipcEndpoint:  cfg.IPCEndpoint(),
 		httpEndpoint: cfg.HTTPEndpoint(),
 		wsEndpoint:   cfg.WSEndpoint(),
+		auth:         NewAuthenticator(cfg.ResolvePath(DataDirAuthTokens), cfg.RPCAuth),
 		eventmux:     new(event.TypeMux),
 		log:          cfg.Logger,
 	}, nil
@@ -159,6 +162,11 @@ func (n *Node) Start() error {
 		return err
 	}
 
+	err = n.auth.Load()
+	if err != nil {
+		return err
+	}
+
 	// Initialize the p2p server. This creates the node key and
 	// discovery databases.
 	n.serverConfig = n.Config.P2P
@@ -344,6 +352,11 @@ func (n *Node) RPCHandler() (*rpc.Server, error) {
 	return n.inprocHandler, nil
 }
 
+// Authenticator returns the authenticator of the api-tokens.
+func (n *Node) Authenticator() *Authenticator {
+	return n.auth
+}
+
 // Server retrieves the currently running P2P network layer. This method is meant
 // only to inspect fields of the currently running server, life cycle management
 // should be left to this Node entity.
@@ -393,6 +406,8 @@ func (n *Node) startRPC(services map[reflect.Type]pkgservice.PttService) error {
 		apis = append(apis, service.APIs()...)
 	}
 
+	n.auth.SetAPIs(apis)
+
 	// Start the various API endpoints, terminating all in case of errors
 	log.Debug("startRPC: to startInProc")
 	if err := n.startInProc(apis); err != nil {
@@ -488,6 +503,7 @@ func (n *Node) startHTTP(endpoint string, apis []rpc.API, modules []string, cors
 	if err != nil {
 		return err
 	}
+	handler.SetAuthenticator(n.auth)
 	n.log.Info("HTTP endpoint opened", "url", fmt.Sprintf("http://%s", endpoint), "cors", strings.Join(cors, ","), "vhosts", strings.Join(vhosts, ","))
 	// All listeners booted successfully
 	n.httpEndpoint = endpoint
@@ -528,6 +544,7 @@ func (n *Node) startWS(endpoint string, apis []rpc.API, modules []string, wsOrig
 	if err != nil {
 		return err
 	}
+	handler.SetAuthenticator(n.auth)
 	n.log.Info("WebSocket endpoint opened", "url", fmt.Sprintf("ws://%s", listener.Addr()))
 	// All listeners booted successfully
 	n.wsEndpoint = endpoint
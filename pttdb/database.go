@@ -16,6 +16,12 @@
 
 package pttdb
 
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
 // Putter wraps the database write operation supported by both batches and regular databases.
 type Putter interface {
 	Put(key []byte, value []byte) error
@@ -51,3 +57,51 @@ type Batch interface {
 	// Reset resets the batch for reuse
 	Reset()
 }
+
+// KVDatabase wraps the database operations required by the entities,
+// including map-locks, timestamp-based put and ordered iterators.
+type KVDatabase interface {
+	Database
+
+	Name() string
+	Path() string
+
+	TryPut(key []byte, value []byte, updateTS types.Timestamp) ([]byte, error)
+	Pop(key []byte) ([]byte, error)
+
+	TryLockMap(key []byte) error
+	UnlockMap(key []byte) error
+	TryRLockMap(key []byte) error
+	RUnlockMap(key []byte) error
+
+	NewIterator(listOrder ListOrder) iterator.Iterator
+	NewIteratorWithRange(r *util.Range, listOrder ListOrder) iterator.Iterator
+	NewIteratorWithPrefix(start []byte, prefix []byte, listOrder ListOrder) (iterator.Iterator, error)
+}
+
+// IndexedBatch is the batch aware of the indexes of the objects.
+type IndexedBatch interface {
+	Batch
+
+	DB() KVDatabase
+
+	DBGet(key []byte) ([]byte, error)
+	DBDelete(key []byte) error
+
+	TryPutAll(idxKey []byte, idx *Index, kvs []*KeyVal, isDeleteOrig bool, isGetOrig bool) ([]*KeyVal, error)
+	ForcePutAll(idxKey []byte, idx *Index, kvs []*KeyVal) ([][]byte, error)
+	DeleteAllKeys(keys [][]byte) error
+	DeleteAll(idxKey []byte) error
+
+	GetByIdxKey(idxKey []byte, idx int) ([]byte, error)
+	GetKeyByIdxKey(idxKey []byte, idx int) ([]byte, error)
+	GetBy2ndIdxKey(idxKey []byte) ([]byte, error)
+	GetKeyBy2ndIdxKey(idxKey []byte) ([]byte, error)
+}
+
+var (
+	_ KVDatabase   = &LDBDatabase{}
+	_ IndexedBatch = &LDBBatch{}
+	_ Store        = &ldbStore{}
+	_ Store        = &memStore{}
+)
@@ -44,6 +44,18 @@ func newTestLDB() (*pttdb.LDBDatabase, func()) {
 	}
 }
 
+func newTestMemLDB() (*pttdb.LDBDatabase, func()) {
+	db, err := pttdb.NewMemLDBDatabase("pttdb_test_mem", os.TempDir())
+	if err != nil {
+		panic("failed to create test database: " + err.Error())
+	}
+
+	return db, func() {
+		db.Close()
+		pttdb.ResetMemStores()
+	}
+}
+
 var test_values = []string{"", "a", "1251", "\x00123\x00"}
 
 func TestLDB_PutGet(t *testing.T) {
@@ -52,6 +64,12 @@ func TestLDB_PutGet(t *testing.T) {
 	testPutGet(db, t)
 }
 
+func TestMemLDB_PutGet(t *testing.T) {
+	db, remove := newTestMemLDB()
+	defer remove()
+	testPutGet(db, t)
+}
+
 func TestMemoryDB_PutGet(t *testing.T) {
 	testPutGet(pttdb.NewMemDatabase(), t)
 }
@@ -129,6 +147,12 @@ func TestLDB_ParallelPutGet(t *testing.T) {
 	testParallelPutGet(db, t)
 }
 
+func TestMemLDB_ParallelPutGet(t *testing.T) {
+	db, remove := newTestMemLDB()
+	defer remove()
+	testParallelPutGet(db, t)
+}
+
 func TestMemoryDB_ParallelPutGet(t *testing.T) {
 	testParallelPutGet(pttdb.NewMemDatabase(), t)
 }
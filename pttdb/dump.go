@@ -0,0 +1,112 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package pttdb
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+)
+
+var (
+	lockOpenedDBs sync.Mutex
+	openedDBs     = make(map[*LDBDatabase]struct{})
+)
+
+func registerOpenedDB(db *LDBDatabase) {
+	lockOpenedDBs.Lock()
+	defer lockOpenedDBs.Unlock()
+
+	openedDBs[db] = struct{}{}
+}
+
+func unregisterOpenedDB(db *LDBDatabase) {
+	lockOpenedDBs.Lock()
+	defer lockOpenedDBs.Unlock()
+
+	delete(openedDBs, db)
+}
+
+/*
+OpenedDatabases returns the currently opened databases sorted by the names.
+*/
+func OpenedDatabases() []*LDBDatabase {
+	lockOpenedDBs.Lock()
+	defer lockOpenedDBs.Unlock()
+
+	dbs := make([]*LDBDatabase, 0, len(openedDBs))
+	for db := range openedDBs {
+		dbs = append(dbs, db)
+	}
+
+	sort.Slice(dbs, func(i, j int) bool {
+		if dbs[i].name == dbs[j].name {
+			return dbs[i].fn < dbs[j].fn
+		}
+		return dbs[i].name < dbs[j].name
+	})
+
+	return dbs
+}
+
+/*
+NewSnapshotIterator iterates all the key/vals in the snapshot of the db,
+so that the db can be dumped consistently while being written.
+*/
+func (db *LDBDatabase) NewSnapshotIterator() (iterator.Iterator, error) {
+	return db.store.NewSnapshotIterator(nil)
+}
+
+/*
+LoadAll writes the key/vals to the db in batches of IdealBatchSize.
+*/
+func (db *LDBDatabase) LoadAll(kvs []*KeyVal) error {
+	batch := new(leveldb.Batch)
+	size := 0
+	for _, kv := range kvs {
+		batch.Put(kv.K, kv.V)
+		size += len(kv.K) + len(kv.V)
+		if size < IdealBatchSize {
+			continue
+		}
+
+		err := db.store.Write(batch)
+		if err != nil {
+			return err
+		}
+		batch.Reset()
+		size = 0
+	}
+
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	return db.store.Write(batch)
+}
+
+/*
+IsEmpty checks whether the db contains no key.
+*/
+func (db *LDBDatabase) IsEmpty() bool {
+	iter := db.store.NewIterator(nil)
+	defer iter.Release()
+
+	return !iter.Next()
+}
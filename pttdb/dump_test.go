@@ -0,0 +1,112 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package pttdb
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common"
+)
+
+func TestLDBDatabase_SnapshotLoadAll(t *testing.T) {
+	// setup test
+	dirname, err := ioutil.TempDir(os.TempDir(), "pttdb_test_")
+	if err != nil {
+		t.Errorf("unable to create tmp dir: e: %v", err)
+		return
+	}
+	defer os.RemoveAll(dirname)
+
+	db, _ := NewLDBDatabase("test-dump", dirname, 0, 0)
+	db2, _ := NewLDBDatabase("test-load", dirname, 0, 0)
+	defer db.Close()
+	defer db2.Close()
+
+	// run test
+	testSnapshotLoadAll(t, db, db2)
+}
+
+func TestMemLDBDatabase_SnapshotLoadAll(t *testing.T) {
+	// setup test
+	db, _ := NewMemLDBDatabase("test-dump", "./test.out")
+	db2, _ := NewMemLDBDatabase("test-load", "./test.out")
+	defer ResetMemStores()
+	defer db.Close()
+	defer db2.Close()
+
+	// run test
+	testSnapshotLoadAll(t, db, db2)
+}
+
+func testSnapshotLoadAll(t *testing.T, db *LDBDatabase, db2 *LDBDatabase) {
+	db.Put([]byte("test-b"), []byte("b"))
+	db.Put([]byte("test-a"), []byte("a"))
+
+	want := []*KeyVal{
+		&KeyVal{K: []byte("test-a"), V: []byte("a")},
+		&KeyVal{K: []byte("test-b"), V: []byte("b")},
+	}
+
+	if !db2.IsEmpty() {
+		t.Errorf("LDBDatabase.IsEmpty() = false, want true")
+	}
+
+	iter, err := db.NewSnapshotIterator()
+	if err != nil {
+		t.Errorf("LDBDatabase.NewSnapshotIterator() error = %v", err)
+		return
+	}
+
+	// the writes after the snapshot are not in the snapshot.
+	db.Put([]byte("test-c"), []byte("c"))
+	db.Delete([]byte("test-a"))
+
+	kvs := make([]*KeyVal, 0)
+	for iter.Next() {
+		kvs = append(kvs, &KeyVal{K: common.CloneBytes(iter.Key()), V: common.CloneBytes(iter.Value())})
+	}
+	iter.Release()
+	if !reflect.DeepEqual(kvs, want) {
+		t.Errorf("LDBDatabase.NewSnapshotIterator() = %v, want %v", kvs, want)
+	}
+
+	err = db2.LoadAll(kvs)
+	if err != nil {
+		t.Errorf("LDBDatabase.LoadAll() error = %v", err)
+		return
+	}
+
+	for _, kv := range want {
+		got, err := db2.Get(kv.K)
+		if err != nil || !reflect.DeepEqual(got, kv.V) {
+			t.Errorf("LDBDatabase.LoadAll() = %v (%v), want %v", got, err, kv.V)
+		}
+	}
+
+	isFound := false
+	for _, each := range OpenedDatabases() {
+		if each == db2 {
+			isFound = true
+		}
+	}
+	if !isFound {
+		t.Errorf("OpenedDatabases() does not include the opened db")
+	}
+}
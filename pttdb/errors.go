@@ -26,4 +26,10 @@ var (
 	ErrBusy            = errors.New("db busy")
 	ErrInvalidKeys     = errors.New("invalid db keys")
 	ErrInvalidIndex    = errors.New("invalid db index")
+
+	ErrInvalidStoreType = errors.New("invalid store type")
+
+	ErrInvalidSearchQuery = errors.New("invalid search query")
+
+	ErrInvalidTag = errors.New("invalid tag")
 )
@@ -40,9 +40,38 @@ var (
 	ValueTrue = []byte{1}
 )
 
+// search
+const (
+	MaxSearchTermLength = 64
+	MaxSearchLimit      = 100
+)
+
+var (
+	searchTermSep = []byte{0}
+)
+
+// tag
+const (
+	MaxTagLength = 32
+)
+
+var (
+	tagSep = []byte{0}
+)
+
 const (
 	minCache   = 16
 	minHandles = 16
 
 	OpenFileLimit = 64
 )
+
+// store
+const (
+	StoreTypeLevelDB = "leveldb"
+	StoreTypeMemory  = "memory"
+)
+
+var (
+	DefaultStoreType = StoreTypeLevelDB
+)
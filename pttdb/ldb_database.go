@@ -37,9 +37,10 @@ import (
 )
 
 type LDBDatabase struct {
-	name string      // filename not including data-dir
-	fn   string      // filename for reporting
-	db   *leveldb.DB // LevelDB instance
+	name      string // filename not including data-dir
+	fn        string // filename for reporting
+	storeType string // storage engine
+	store     Store  // storage engine instance
 
 	compTimeMeter    metrics.Meter // Meter for measuring the total time spent in database compaction
 	compReadMeter    metrics.Meter // Meter for measuring the data read during compaction
@@ -58,8 +59,16 @@ type LDBDatabase struct {
 	lockMap     map[string]int
 }
 
-// NewLDBDatabase returns a LevelDB wrapped object.
+// NewLDBDatabase returns a LevelDB wrapped object, or an in-memory one if DefaultStoreType is StoreTypeMemory.
 func NewLDBDatabase(file string, dataDir string, cache int, handles int) (*LDBDatabase, error) {
+	switch DefaultStoreType {
+	case StoreTypeLevelDB:
+	case StoreTypeMemory:
+		return NewMemLDBDatabase(file, dataDir)
+	default:
+		return nil, ErrInvalidStoreType
+	}
+
 	fullFilename := filepath.Join(dataDir, file)
 
 	logger := log.New("database", fullFilename)
@@ -89,13 +98,43 @@ func NewLDBDatabase(file string, dataDir string, cache int, handles int) (*LDBDa
 	if err != nil {
 		return nil, err
 	}
-	return &LDBDatabase{
-		name:    file,
-		fn:      fullFilename,
-		db:      db,
-		log:     logger,
-		lockMap: make(map[string]int),
-	}, nil
+	ldb := &LDBDatabase{
+		name:      file,
+		fn:        fullFilename,
+		storeType: StoreTypeLevelDB,
+		store:     &ldbStore{db: db},
+		log:       logger,
+		lockMap:   make(map[string]int),
+	}
+	registerOpenedDB(ldb)
+
+	return ldb, nil
+}
+
+/*
+NewMemLDBDatabase returns an in-memory database with the same functionalities as the LevelDB one.
+
+The data is kept in the process with the full filename as the key,
+so re-opening the same file in the same data-dir gets the same data.
+*/
+func NewMemLDBDatabase(file string, dataDir string) (*LDBDatabase, error) {
+	fullFilename := filepath.Join(dataDir, file)
+
+	logger := log.New("database", fullFilename)
+
+	logger.Info("Using in-memory database")
+
+	db := &LDBDatabase{
+		name:      file,
+		fn:        fullFilename,
+		storeType: StoreTypeMemory,
+		store:     getMemStore(fullFilename),
+		log:       logger,
+		lockMap:   make(map[string]int),
+	}
+	registerOpenedDB(db)
+
+	return db, nil
 }
 
 // Path returns the path to the database directory.
@@ -107,6 +146,14 @@ func (db *LDBDatabase) Name() string {
 	return db.name
 }
 
+func (db *LDBDatabase) StoreType() string {
+	return db.storeType
+}
+
+func (db *LDBDatabase) Store() Store {
+	return db.store
+}
+
 /*
 TryPut tries to put the key/val based on the updateTS of val.
 
@@ -237,16 +284,16 @@ func (db *LDBDatabase) RUnlockMap(key []byte) error {
 
 // Put puts the given key / value to the queue
 func (db *LDBDatabase) Put(key []byte, value []byte) error {
-	return db.db.Put(key, value, nil)
+	return db.store.Put(key, value)
 }
 
 func (db *LDBDatabase) Has(key []byte) (bool, error) {
-	return db.db.Has(key, nil)
+	return db.store.Has(key)
 }
 
 // Get returns the given key if it's present.
 func (db *LDBDatabase) Get(key []byte) ([]byte, error) {
-	dat, err := db.db.Get(key, nil)
+	dat, err := db.store.Get(key)
 	if err != nil {
 		return nil, err
 	}
@@ -255,7 +302,7 @@ func (db *LDBDatabase) Get(key []byte) ([]byte, error) {
 
 // Delete deletes the key from the queue and database
 func (db *LDBDatabase) Delete(key []byte) error {
-	err := db.db.Delete(key, nil)
+	err := db.store.Delete(key)
 	if err == leveldb.ErrNotFound {
 		err = nil
 	}
@@ -286,7 +333,7 @@ func (db *LDBDatabase) Pop(key []byte) ([]byte, error) {
 }
 
 func (db *LDBDatabase) NewIterator(listOrder ListOrder) iterator.Iterator {
-	iter := db.db.NewIterator(nil, nil)
+	iter := db.store.NewIterator(nil)
 	if listOrder == ListOrderPrev {
 		iter.Seek(dbLastKey)
 	}
@@ -295,7 +342,7 @@ func (db *LDBDatabase) NewIterator(listOrder ListOrder) iterator.Iterator {
 }
 
 func (db *LDBDatabase) NewIteratorWithRange(r *util.Range, listOrder ListOrder) iterator.Iterator {
-	iter := db.db.NewIterator(r, nil)
+	iter := db.store.NewIterator(r)
 	if listOrder == ListOrderPrev {
 		iter.Seek(r.Limit)
 	}
@@ -361,7 +408,9 @@ func (db *LDBDatabase) Close() {
 		}
 		db.quitChan = nil
 	}
-	err := db.db.Close()
+	unregisterOpenedDB(db)
+
+	err := db.store.Close()
 	if err == nil {
 		db.log.Info("Database closed")
 	} else {
@@ -369,12 +418,22 @@ func (db *LDBDatabase) Close() {
 	}
 }
 
+// LDB returns the underlying LevelDB instance, nil if the database is not backed by LevelDB.
 func (db *LDBDatabase) LDB() *leveldb.DB {
-	return db.db
+	s, ok := db.store.(*ldbStore)
+	if !ok {
+		return nil
+	}
+	return s.db
 }
 
 // Meter configures the database metrics collectors and
 func (db *LDBDatabase) Meter(prefix string) {
+	// the metrics are based on the leveldb properties.
+	if db.LDB() == nil {
+		return
+	}
+
 	if metrics.Enabled {
 		// Initialize all the metrics collector at the requested prefix
 		db.compTimeMeter = metrics.NewRegisteredMeter(prefix+"compact/time", nil)
@@ -413,6 +472,8 @@ func (db *LDBDatabase) Meter(prefix string) {
 // This is how the iostats look like (currently):
 // Read(MB):3895.04860 Write(MB):3654.64712
 func (db *LDBDatabase) meter(refresh time.Duration) {
+	ldb := db.LDB()
+
 	// Create the counters to store current and previous compaction values
 	compactions := make([][]float64, 2)
 	for i := 0; i < 2; i++ {
@@ -437,7 +498,7 @@ func (db *LDBDatabase) meter(refresh time.Duration) {
 	// Iterate ad infinitum and collect the stats
 	for i := 1; errc == nil && merr == nil; i++ {
 		// Retrieve the database stats
-		stats, err := db.db.GetProperty("leveldb.stats")
+		stats, err := ldb.GetProperty("leveldb.stats")
 		if err != nil {
 			db.log.Error("Failed to read database stats", "err", err)
 			merr = err
@@ -486,7 +547,7 @@ func (db *LDBDatabase) meter(refresh time.Duration) {
 		}
 
 		// Retrieve the write delay statistic
-		writedelay, err := db.db.GetProperty("leveldb.writedelay")
+		writedelay, err := ldb.GetProperty("leveldb.writedelay")
 		if err != nil {
 			db.log.Error("Failed to read database write delay statistic", "err", err)
 			merr = err
@@ -544,7 +605,7 @@ func (db *LDBDatabase) meter(refresh time.Duration) {
 		delaystats[0], delaystats[1] = delayN, duration.Nanoseconds()
 
 		// Retrieve the database iostats.
-		ioStats, err := db.db.GetProperty("leveldb.iostats")
+		ioStats, err := ldb.GetProperty("leveldb.iostats")
 		if err != nil {
 			db.log.Error("Failed to read database iostats", "err", err)
 			merr = err
@@ -621,8 +682,7 @@ func (b *ldbBatch) Delete(key []byte) error {
 }
 
 func (b *ldbBatch) Write() error {
-	db := b.db.LDB()
-	return db.Write(b.b, nil)
+	return b.db.store.Write(b.b)
 }
 
 func (b *ldbBatch) ValueSize() int {
@@ -634,7 +694,7 @@ func (b *ldbBatch) Reset() {
 	b.size = 0
 }
 
-func (b *ldbBatch) DB() *LDBDatabase {
+func (b *ldbBatch) DB() KVDatabase {
 	return b.db
 }
 
@@ -0,0 +1,251 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package pttdb
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+SearchIndex is the local inverted-index of the texts.
+
+The docs are grouped in fixed-length scopes (ex: the entity-id), and each doc refers to the ref-id
+returned in the search-results (ex: a comment refers to the article).
+
+Keys:
+
+	term-key: termPrefix | scope | term | 0x00 | docID => refID
+	doc-key:  docPrefix | scope | docID => searchDoc
+*/
+type SearchIndex struct {
+	db *LDBDatabase
+
+	termPrefix []byte
+	docPrefix  []byte
+
+	lock sync.Mutex
+}
+
+type searchDoc struct {
+	RefID []byte   `json:"R"`
+	Terms []string `json:"T"`
+}
+
+func NewSearchIndex(db *LDBDatabase, termPrefix []byte, docPrefix []byte) *SearchIndex {
+	return &SearchIndex{
+		db:         db,
+		termPrefix: termPrefix,
+		docPrefix:  docPrefix,
+	}
+}
+
+/*
+Put (re-)indexes the texts of the doc.
+*/
+func (s *SearchIndex) Put(scope []byte, docID []byte, refID []byte, texts [][]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	termMap := make(map[string]bool)
+	terms := make([]string, 0)
+	for _, text := range texts {
+		for _, term := range Tokenize(string(text)) {
+			if termMap[term] {
+				continue
+			}
+			termMap[term] = true
+			terms = append(terms, term)
+		}
+	}
+
+	batch := s.db.NewBatch()
+
+	docKey, err := s.marshalDocKey(scope, docID)
+	if err != nil {
+		return err
+	}
+
+	err = s.deleteDocCore(batch, scope, docID, docKey)
+	if err != nil {
+		return err
+	}
+
+	for _, term := range terms {
+		termKey, err := s.marshalTermKey(scope, term, docID)
+		if err != nil {
+			return err
+		}
+		batch.Put(termKey, refID)
+	}
+
+	doc := &searchDoc{RefID: refID, Terms: terms}
+	docBytes, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	batch.Put(docKey, docBytes)
+
+	return batch.Write()
+}
+
+/*
+Delete removes the doc from the index.
+*/
+func (s *SearchIndex) Delete(scope []byte, docID []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	docKey, err := s.marshalDocKey(scope, docID)
+	if err != nil {
+		return err
+	}
+
+	batch := s.db.NewBatch()
+	err = s.deleteDocCore(batch, scope, docID, docKey)
+	if err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
+func (s *SearchIndex) deleteDocCore(batch Batch, scope []byte, docID []byte, docKey []byte) error {
+	docBytes, err := s.db.Get(docKey)
+	if err == leveldb.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	doc := &searchDoc{}
+	err = json.Unmarshal(docBytes, doc)
+	if err != nil {
+		return err
+	}
+
+	for _, term := range doc.Terms {
+		termKey, err := s.marshalTermKey(scope, term, docID)
+		if err != nil {
+			return err
+		}
+		batch.Delete(termKey)
+	}
+	batch.Delete(docKey)
+
+	return nil
+}
+
+/*
+Search returns the ref-ids of the docs containing all the terms of the query.
+
+The ref-ids are de-duplicated and filtered by isValid (nil as all valid) before counting the limit,
+so that the scan continues until limit valid ref-ids are found.
+limit is clamped to MaxSearchLimit (limit <= 0 as MaxSearchLimit).
+*/
+func (s *SearchIndex) Search(scope []byte, query string, limit int, isValid func(refID []byte) bool) ([][]byte, error) {
+	terms := TokenizeQuery(query)
+	if len(terms) == 0 {
+		return nil, ErrInvalidSearchQuery
+	}
+
+	if limit <= 0 || limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	prefix, err := s.marshalTermPrefix(scope, terms[0])
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := s.db.NewIteratorWithPrefix(nil, prefix, ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	refIDs := make([][]byte, 0)
+	refIDMap := make(map[string]bool)
+	lenPrefix := len(prefix)
+
+	var isMatch bool
+	var docID []byte
+	for iter.Next() {
+		if len(refIDs) >= limit {
+			break
+		}
+
+		refID := iter.Value()
+		if refIDMap[string(refID)] {
+			continue
+		}
+
+		docID = iter.Key()[lenPrefix:]
+		isMatch, err = s.hasAllTerms(scope, terms[1:], docID)
+		if err != nil {
+			return nil, err
+		}
+		if !isMatch {
+			continue
+		}
+
+		refIDMap[string(refID)] = true
+		refID = common.CloneBytes(refID)
+		if isValid != nil && !isValid(refID) {
+			continue
+		}
+
+		refIDs = append(refIDs, refID)
+	}
+
+	return refIDs, nil
+}
+
+func (s *SearchIndex) hasAllTerms(scope []byte, terms []string, docID []byte) (bool, error) {
+	for _, term := range terms {
+		termKey, err := s.marshalTermKey(scope, term, docID)
+		if err != nil {
+			return false, err
+		}
+
+		isExists, err := s.db.Has(termKey)
+		if err != nil {
+			return false, err
+		}
+		if !isExists {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (s *SearchIndex) marshalTermPrefix(scope []byte, term string) ([]byte, error) {
+	return common.Concat([][]byte{s.termPrefix, scope, []byte(term), searchTermSep})
+}
+
+func (s *SearchIndex) marshalTermKey(scope []byte, term string, docID []byte) ([]byte, error) {
+	return common.Concat([][]byte{s.termPrefix, scope, []byte(term), searchTermSep, docID})
+}
+
+func (s *SearchIndex) marshalDocKey(scope []byte, docID []byte) ([]byte, error) {
+	return common.Concat([][]byte{s.docPrefix, scope, docID})
+}
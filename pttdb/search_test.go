@@ -0,0 +1,129 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package pttdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "latin",
+			text: "Hello, PTT-ai 2019",
+			want: []string{"hello", "ptt", "ai", "2019"},
+		},
+		{
+			name: "cjk",
+			text: "臺灣大學",
+			want: []string{"臺", "灣", "大", "學", "臺灣", "灣大", "大學"},
+		},
+		{
+			name: "mixed with full-width",
+			text: "ＰＴＴ批踢踢",
+			want: []string{"ptt", "批", "踢", "批踢", "踢踢"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Tokenize(tt.text))
+		})
+	}
+}
+
+func TestTokenizeQuery(t *testing.T) {
+	assert.Equal(t, []string{"臺灣", "灣大"}, TokenizeQuery("臺灣大"))
+	assert.Equal(t, []string{"臺", "ptt"}, TokenizeQuery("臺 PTT"))
+	assert.Equal(t, []string{}, TokenizeQuery(" ，。"))
+}
+
+func TestSearchIndex(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	scope := []byte("scope001")
+	scope2 := []byte("scope002")
+
+	idx := NewSearchIndex(tDefaultDB, []byte(".tsst"), []byte(".tssd"))
+
+	err := idx.Put(scope, []byte("article1"), []byte("article1"), [][]byte{[]byte("今天天氣很好"), []byte("Good weather")})
+	assert.NoError(t, err)
+	err = idx.Put(scope, []byte("comment1"), []byte("article1"), [][]byte{[]byte("天氣真的很好")})
+	assert.NoError(t, err)
+	err = idx.Put(scope, []byte("article2"), []byte("article2"), [][]byte{[]byte("明天會下雨")})
+	assert.NoError(t, err)
+	err = idx.Put(scope2, []byte("article3"), []byte("article3"), [][]byte{[]byte("天氣")})
+	assert.NoError(t, err)
+
+	got, err := idx.Search(scope, "天氣", 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article1")}, got)
+
+	got, err = idx.Search(scope, "天", 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article1"), []byte("article2")}, got)
+
+	got, err = idx.Search(scope, "天 weather", 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article1")}, got)
+
+	got, err = idx.Search(scope, "天", 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(got))
+
+	// filtered before the limit
+	got, err = idx.Search(scope, "天", 1, func(refID []byte) bool { return string(refID) != "article1" })
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article2")}, got)
+
+	// re-index
+	err = idx.Put(scope, []byte("article2"), []byte("article2"), [][]byte{[]byte("明天會放晴")})
+	assert.NoError(t, err)
+
+	got, err = idx.Search(scope, "下雨", 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(got))
+
+	got, err = idx.Search(scope, "放晴", 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article2")}, got)
+
+	// delete
+	err = idx.Delete(scope, []byte("article1"))
+	assert.NoError(t, err)
+
+	got, err = idx.Search(scope, "天氣", 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article1")}, got)
+
+	err = idx.Delete(scope, []byte("comment1"))
+	assert.NoError(t, err)
+
+	got, err = idx.Search(scope, "天氣", 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(got))
+
+	_, err = idx.Search(scope, "，", 0, nil)
+	assert.Equal(t, ErrInvalidSearchQuery, err)
+}
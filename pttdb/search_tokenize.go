@@ -0,0 +1,112 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package pttdb
+
+import (
+	"strings"
+	"unicode"
+)
+
+/*
+Tokenize splits the text into the search-terms for indexing.
+
+Latin words (and digits) are lower-cased and kept as a whole.
+CJK characters do not have word-boundaries, so each run of CJK characters is
+split into the unigrams and the overlapping bigrams (ex: "臺灣大學" => "臺", "灣", "大", "學", "臺灣", "灣大", "大學").
+*/
+func Tokenize(text string) []string {
+	return tokenize(text, false)
+}
+
+/*
+TokenizeQuery splits the query into the search-terms.
+
+The runs of CJK characters are split only into the bigrams,
+so that "臺灣" does not match the text with only "臺" and "灣".
+*/
+func TokenizeQuery(query string) []string {
+	return tokenize(query, true)
+}
+
+func tokenize(text string, isQuery bool) []string {
+	terms := make([]string, 0)
+	termMap := make(map[string]bool)
+	addTerm := func(term string) {
+		if len(term) == 0 || len(term) > MaxSearchTermLength || termMap[term] {
+			return
+		}
+		termMap[term] = true
+		terms = append(terms, term)
+	}
+
+	var word strings.Builder
+	flushWord := func() {
+		addTerm(word.String())
+		word.Reset()
+	}
+
+	cjkRun := make([]rune, 0)
+	flushCJK := func() {
+		nRun := len(cjkRun)
+		if !isQuery || nRun == 1 {
+			for _, r := range cjkRun {
+				addTerm(string(r))
+			}
+		}
+		for i := 0; i < nRun-1; i++ {
+			addTerm(string(cjkRun[i : i+2]))
+		}
+		cjkRun = cjkRun[:0]
+	}
+
+	for _, r := range text {
+		r = foldWidth(r)
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjkRun = append(cjkRun, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+
+	return terms
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+/*
+foldWidth folds the full-width ascii (ex: "ＰＴＴ") to the half-width ascii.
+*/
+func foldWidth(r rune) rune {
+	if r >= 0xff01 && r <= 0xff5e {
+		return r - 0xfee0
+	}
+	if r == 0x3000 {
+		return ' '
+	}
+
+	return r
+}
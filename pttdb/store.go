@@ -0,0 +1,262 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package pttdb
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/comparer"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/memdb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+/*
+Store is the key-value engine underneath LDBDatabase.
+
+All the higher-level functionalities (map-locks, TryPut, iterators with prefix/order, index-aware batches)
+are implemented on LDBDatabase / LDBBatch on top of Store, so any engine satisfying Store can back the entities.
+
+Get returns leveldb.ErrNotFound if the key does not exist.
+
+NewSnapshotIterator iterates the consistent view of the store at the time of the call,
+not affected by the following writes.
+*/
+type Store interface {
+	Put(key []byte, value []byte) error
+	Has(key []byte) (bool, error)
+	Get(key []byte) ([]byte, error)
+	Delete(key []byte) error
+
+	Write(batch *leveldb.Batch) error
+	NewIterator(r *util.Range) iterator.Iterator
+	NewSnapshotIterator(r *util.Range) (iterator.Iterator, error)
+
+	Close() error
+}
+
+/**********
+ * LevelDB
+ **********/
+
+type ldbStore struct {
+	db *leveldb.DB
+}
+
+func (s *ldbStore) Put(key []byte, value []byte) error {
+	return s.db.Put(key, value, nil)
+}
+
+func (s *ldbStore) Has(key []byte) (bool, error) {
+	return s.db.Has(key, nil)
+}
+
+func (s *ldbStore) Get(key []byte) ([]byte, error) {
+	return s.db.Get(key, nil)
+}
+
+func (s *ldbStore) Delete(key []byte) error {
+	return s.db.Delete(key, nil)
+}
+
+func (s *ldbStore) Write(batch *leveldb.Batch) error {
+	return s.db.Write(batch, nil)
+}
+
+func (s *ldbStore) NewIterator(r *util.Range) iterator.Iterator {
+	return s.db.NewIterator(r, nil)
+}
+
+func (s *ldbStore) NewSnapshotIterator(r *util.Range) (iterator.Iterator, error) {
+	snapshot, err := s.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshotIterator{Iterator: snapshot.NewIterator(r, nil), snapshot: snapshot}, nil
+}
+
+func (s *ldbStore) Close() error {
+	return s.db.Close()
+}
+
+/*
+snapshotIterator releases the snapshot with the iterator.
+*/
+type snapshotIterator struct {
+	iterator.Iterator
+	snapshot *leveldb.Snapshot
+}
+
+func (it *snapshotIterator) Release() {
+	it.Iterator.Release()
+	it.snapshot.Release()
+}
+
+/**********
+ * Memory
+ **********/
+
+/*
+memStore is the in-memory Store based on the skiplist of goleveldb (memdb),
+which is concurrent-safe and provides the same ordering and iterator semantics as LevelDB.
+
+memStore does not reclaim the space of overwritten or deleted values,
+and is intended for tests and e2e scenarios.
+
+memdb is safe for the single operations only, so the lock makes Write
+apply the whole batch atomically against the other reads / writes.
+*/
+type memStore struct {
+	lock sync.RWMutex
+	db   *memdb.DB
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		db: memdb.New(comparer.DefaultComparer, 0),
+	}
+}
+
+func (s *memStore) Put(key []byte, value []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.db.Put(key, value)
+}
+
+func (s *memStore) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.db.Contains(key), nil
+}
+
+func (s *memStore) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	val, err := s.db.Get(key)
+	if err == memdb.ErrNotFound {
+		return nil, leveldb.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return common.CopyBytes(val), nil
+}
+
+func (s *memStore) Delete(key []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	err := s.db.Delete(key)
+	if err == memdb.ErrNotFound {
+		return leveldb.ErrNotFound
+	}
+
+	return err
+}
+
+func (s *memStore) Write(batch *leveldb.Batch) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return batch.Replay(&memStoreReplayer{s: s})
+}
+
+func (s *memStore) NewIterator(r *util.Range) iterator.Iterator {
+	return s.db.NewIterator(r)
+}
+
+/*
+NewSnapshotIterator copies the key/vals in the range to a new memdb,
+as memdb does not support snapshots.
+*/
+func (s *memStore) NewSnapshotIterator(r *util.Range) (iterator.Iterator, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	snapshot := memdb.New(comparer.DefaultComparer, 0)
+
+	iter := s.db.NewIterator(r)
+	defer iter.Release()
+
+	for iter.Next() {
+		err := snapshot.Put(iter.Key(), iter.Value())
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := iter.Error()
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.NewIterator(r), nil
+}
+
+/*
+Close does not discard the data. The data is kept in the registry until ResetMemStores,
+so the db can be re-opened with the same path in the same process.
+*/
+func (s *memStore) Close() error {
+	return nil
+}
+
+type memStoreReplayer struct {
+	s *memStore
+}
+
+func (r *memStoreReplayer) Put(key, value []byte) {
+	r.s.db.Put(key, value)
+}
+
+func (r *memStoreReplayer) Delete(key []byte) {
+	r.s.db.Delete(key)
+}
+
+var (
+	lockMemStores sync.Mutex
+	memStores     = make(map[string]*memStore)
+)
+
+func getMemStore(fullFilename string) *memStore {
+	lockMemStores.Lock()
+	defer lockMemStores.Unlock()
+
+	s, ok := memStores[fullFilename]
+	if !ok {
+		s = newMemStore()
+		memStores[fullFilename] = s
+	}
+
+	return s
+}
+
+/*
+ResetMemStores discards all the in-memory stores.
+*/
+func ResetMemStores() {
+	lockMemStores.Lock()
+	defer lockMemStores.Unlock()
+
+	memStores = make(map[string]*memStore)
+}
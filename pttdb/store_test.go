@@ -0,0 +1,175 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package pttdb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestMemLDBDatabase_NewIteratorWithPrefix(t *testing.T) {
+	// setup test
+	db, _ := NewMemLDBDatabase("test-mem", "./test.out")
+	defer ResetMemStores()
+
+	db.Put([]byte("test-a"), []byte("a"))
+	db.Put([]byte("test-c"), []byte("c"))
+	db.Put([]byte("test-b"), []byte("b"))
+	db.Put([]byte("test2-d"), []byte("d"))
+
+	// prepare test-cases
+	tests := []struct {
+		name      string
+		start     []byte
+		prefix    []byte
+		listOrder ListOrder
+		want      []string
+	}{
+		{
+			name:      "next with prefix",
+			prefix:    []byte("test-"),
+			listOrder: ListOrderNext,
+			want:      []string{"test-a", "test-b", "test-c"},
+		},
+		{
+			name:      "prev with prefix",
+			prefix:    []byte("test-"),
+			listOrder: ListOrderPrev,
+			want:      []string{"test-c", "test-b", "test-a"},
+		},
+		{
+			name:      "next with start and prefix",
+			start:     []byte("test-b"),
+			prefix:    []byte("test-"),
+			listOrder: ListOrderNext,
+			want:      []string{"test-b", "test-c"},
+		},
+		{
+			name:      "prev with start and prefix",
+			start:     []byte("test-b"),
+			prefix:    []byte("test-"),
+			listOrder: ListOrderPrev,
+			want:      []string{"test-b", "test-a"},
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iter, err := db.NewIteratorWithPrefix(tt.start, tt.prefix, tt.listOrder)
+			if err != nil {
+				t.Errorf("LDBDatabase.NewIteratorWithPrefix() error = %v", err)
+				return
+			}
+			defer iter.Release()
+
+			got := make([]string, 0, len(tt.want))
+			for {
+				if tt.listOrder == ListOrderPrev {
+					if !iter.Prev() {
+						break
+					}
+				} else if !iter.Next() {
+					break
+				}
+				got = append(got, string(iter.Key()))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LDBDatabase.NewIteratorWithPrefix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemLDBDatabase_Reopen(t *testing.T) {
+	// setup test
+	defer ResetMemStores()
+
+	db, _ := NewMemLDBDatabase("test-mem", "./test.out")
+	db.Put([]byte("test-key"), []byte("test-value"))
+	db.Close()
+
+	// run test
+	db, _ = NewMemLDBDatabase("test-mem", "./test.out")
+	got, err := db.Get([]byte("test-key"))
+	if err != nil {
+		t.Errorf("LDBDatabase.Get() error = %v", err)
+		return
+	}
+	if string(got) != "test-value" {
+		t.Errorf("LDBDatabase.Get() = %v, want %v", string(got), "test-value")
+	}
+
+	err = db.Delete([]byte("test-key"))
+	if err != nil {
+		t.Errorf("LDBDatabase.Delete() error = %v", err)
+	}
+
+	_, err = db.Get([]byte("test-key"))
+	if err != leveldb.ErrNotFound {
+		t.Errorf("LDBDatabase.Get() error = %v, want %v", err, leveldb.ErrNotFound)
+	}
+}
+
+func TestMemLDBBatch_TryPutAll(t *testing.T) {
+	// setup test
+	db, _ := NewMemLDBDatabase("test-mem", "./test.out")
+	defer ResetMemStores()
+
+	dbBatch, _ := NewLDBBatch(db)
+
+	updateTS, _ := types.GetTimestamp()
+	idxKey := []byte("test-idx-key")
+	idx := &Index{
+		Keys:     [][]byte{[]byte("test-idx-1"), []byte("test-idx-2")},
+		UpdateTS: updateTS,
+	}
+	kvs := []*KeyVal{
+		&KeyVal{K: []byte("test-idx-1"), V: []byte("test-value-1")},
+		&KeyVal{K: []byte("test-idx-2"), V: []byte("test-value-2")},
+	}
+
+	// run test
+	_, err := dbBatch.TryPutAll(idxKey, idx, kvs, false, false)
+	if err != nil {
+		t.Errorf("LDBBatch.TryPutAll() error = %v", err)
+		return
+	}
+
+	got, err := dbBatch.GetByIdxKey(idxKey, 1)
+	if err != nil {
+		t.Errorf("LDBBatch.GetByIdxKey() error = %v", err)
+		return
+	}
+	if string(got) != "test-value-2" {
+		t.Errorf("LDBBatch.GetByIdxKey() = %v, want %v", string(got), "test-value-2")
+	}
+
+	err = dbBatch.DeleteAll(idxKey)
+	if err != nil {
+		t.Errorf("LDBBatch.DeleteAll() error = %v", err)
+		return
+	}
+
+	isHas, _ := db.Has([]byte("test-idx-1"))
+	if isHas {
+		t.Errorf("LDBDatabase.Has() = %v, want %v", isHas, false)
+	}
+}
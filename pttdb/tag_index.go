@@ -0,0 +1,218 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package pttdb
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+TagIndex is the local secondary-index of the docs by the tags.
+
+The docs are grouped in fixed-length scopes (ex: the entity-id), and the docs with the same tag
+are ordered by the sort-key of the doc (ex: create-ts | id of the article).
+
+Keys:
+
+	tag-key: tagPrefix | scope | tag | 0x00 | sortKey => docID
+	doc-key: docPrefix | scope | docID => tagDoc
+*/
+type TagIndex struct {
+	db *LDBDatabase
+
+	tagPrefix []byte
+	docPrefix []byte
+
+	lock sync.Mutex
+}
+
+type tagDoc struct {
+	SortKey []byte   `json:"S"`
+	Tags    []string `json:"T"`
+}
+
+func NewTagIndex(db *LDBDatabase, tagPrefix []byte, docPrefix []byte) *TagIndex {
+	return &TagIndex{
+		db:        db,
+		tagPrefix: tagPrefix,
+		docPrefix: docPrefix,
+	}
+}
+
+/*
+NormalizeTag trims the spaces and lower-cases the tag.
+Returns empty string if the tag is empty, too long or containing the separator.
+*/
+func NormalizeTag(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if len(tag) == 0 || len(tag) > MaxTagLength {
+		return ""
+	}
+	if !utf8.ValidString(tag) || strings.IndexByte(tag, tagSep[0]) >= 0 {
+		return ""
+	}
+
+	return tag
+}
+
+/*
+Put (re-)indexes the tags of the doc. The tags are expected to be normalized.
+*/
+func (t *TagIndex) Put(scope []byte, docID []byte, sortKey []byte, tags []string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	batch := t.db.NewBatch()
+
+	docKey, err := t.marshalDocKey(scope, docID)
+	if err != nil {
+		return err
+	}
+
+	err = t.deleteDocCore(batch, scope, docKey)
+	if err != nil {
+		return err
+	}
+
+	if len(tags) == 0 {
+		return batch.Write()
+	}
+
+	for _, tag := range tags {
+		tagKey, err := t.marshalTagKey(scope, tag, sortKey)
+		if err != nil {
+			return err
+		}
+		batch.Put(tagKey, docID)
+	}
+
+	doc := &tagDoc{SortKey: sortKey, Tags: tags}
+	docBytes, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	batch.Put(docKey, docBytes)
+
+	return batch.Write()
+}
+
+/*
+Delete removes the doc from the index.
+*/
+func (t *TagIndex) Delete(scope []byte, docID []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	docKey, err := t.marshalDocKey(scope, docID)
+	if err != nil {
+		return err
+	}
+
+	batch := t.db.NewBatch()
+	err = t.deleteDocCore(batch, scope, docKey)
+	if err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
+
+func (t *TagIndex) deleteDocCore(batch Batch, scope []byte, docKey []byte) error {
+	docBytes, err := t.db.Get(docKey)
+	if err == leveldb.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	doc := &tagDoc{}
+	err = json.Unmarshal(docBytes, doc)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range doc.Tags {
+		tagKey, err := t.marshalTagKey(scope, tag, doc.SortKey)
+		if err != nil {
+			return err
+		}
+		batch.Delete(tagKey)
+	}
+	batch.Delete(docKey)
+
+	return nil
+}
+
+/*
+List returns the doc-ids with the tag, starting from startSortKey (inclusive, nil as from the beginning / the end)
+in the list-order.
+
+At most limit doc-ids are returned (limit <= 0 as no limit).
+*/
+func (t *TagIndex) List(scope []byte, tag string, startSortKey []byte, limit int, listOrder ListOrder) ([][]byte, error) {
+	tag = NormalizeTag(tag)
+	if len(tag) == 0 {
+		return nil, ErrInvalidTag
+	}
+
+	prefix, err := t.marshalTagKey(scope, tag, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var startKey []byte
+	if len(startSortKey) != 0 {
+		startKey, err = t.marshalTagKey(scope, tag, startSortKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	iter, err := t.db.NewIteratorWithPrefix(startKey, prefix, listOrder)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Release()
+
+	funcIter := GetFuncIter(iter, listOrder)
+
+	docIDs := make([][]byte, 0)
+	for funcIter() {
+		if limit > 0 && len(docIDs) >= limit {
+			break
+		}
+
+		docIDs = append(docIDs, common.CloneBytes(iter.Value()))
+	}
+
+	return docIDs, nil
+}
+
+func (t *TagIndex) marshalTagKey(scope []byte, tag string, sortKey []byte) ([]byte, error) {
+	return common.Concat([][]byte{t.tagPrefix, scope, []byte(tag), tagSep, sortKey})
+}
+
+func (t *TagIndex) marshalDocKey(scope []byte, docID []byte) ([]byte, error) {
+	return common.Concat([][]byte{t.docPrefix, scope, docID})
+}
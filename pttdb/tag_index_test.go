@@ -0,0 +1,99 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package pttdb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	assert.Equal(t, "公告", NormalizeTag(" 公告 "))
+	assert.Equal(t, "golang", NormalizeTag("GoLang"))
+	assert.Equal(t, "", NormalizeTag("  "))
+	assert.Equal(t, "", NormalizeTag("a\x00b"))
+	assert.Equal(t, "", NormalizeTag(strings.Repeat("a", MaxTagLength+1)))
+}
+
+func TestTagIndex(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	scope := []byte("scope001")
+	scope2 := []byte("scope002")
+
+	idx := NewTagIndex(tDefaultDB, []byte(".ttgt"), []byte(".ttgd"))
+
+	err := idx.Put(scope, []byte("article1"), []byte("0001article1"), []string{"公告", "go"})
+	assert.NoError(t, err)
+	err = idx.Put(scope, []byte("article2"), []byte("0002article2"), []string{"go"})
+	assert.NoError(t, err)
+	err = idx.Put(scope, []byte("article3"), []byte("0003article3"), []string{"go", "rust"})
+	assert.NoError(t, err)
+	err = idx.Put(scope2, []byte("article4"), []byte("0004article4"), []string{"go"})
+	assert.NoError(t, err)
+
+	got, err := idx.List(scope, "go", nil, 0, ListOrderNext)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article1"), []byte("article2"), []byte("article3")}, got)
+
+	got, err = idx.List(scope, "GO", nil, 2, ListOrderPrev)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article3"), []byte("article2")}, got)
+
+	got, err = idx.List(scope, "go", []byte("0002article2"), 0, ListOrderNext)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article2"), []byte("article3")}, got)
+
+	got, err = idx.List(scope, "go", []byte("0002article2"), 0, ListOrderPrev)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article2"), []byte("article1")}, got)
+
+	got, err = idx.List(scope, "公告", nil, 0, ListOrderNext)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article1")}, got)
+
+	// re-index
+	err = idx.Put(scope, []byte("article1"), []byte("0001article1"), []string{"rust"})
+	assert.NoError(t, err)
+
+	got, err = idx.List(scope, "公告", nil, 0, ListOrderNext)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(got))
+
+	got, err = idx.List(scope, "rust", nil, 0, ListOrderNext)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article1"), []byte("article3")}, got)
+
+	// delete
+	err = idx.Delete(scope, []byte("article3"))
+	assert.NoError(t, err)
+
+	got, err = idx.List(scope, "go", nil, 0, ListOrderNext)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article2")}, got)
+
+	got, err = idx.List(scope2, "go", nil, 0, ListOrderNext)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("article4")}, got)
+
+	_, err = idx.List(scope, " ", nil, 0, ListOrderNext)
+	assert.Equal(t, ErrInvalidTag, err)
+}
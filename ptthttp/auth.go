@@ -0,0 +1,48 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package ptthttp
+
+import (
+	"net/http"
+
+	"github.com/ailabstw/go-pttai/node"
+	"github.com/ailabstw/go-pttai/rpc"
+)
+
+/*
+authorize authorizes the api-token of the request to call the rpc-method.
+The rpc-methods are called through the in-proc rpc-client (not authorized by the rpc-server),
+so the handlers need to authorize the requests before calling the rpc-methods.
+*/
+func (s *Server) authorize(r *http.Request, method string) (int, error) {
+	err := s.auth.Authorize(rpc.TokenFromRequest(r), method)
+	switch err {
+	case nil:
+		return 0, nil
+	case node.ErrInvalidAuthToken:
+		return http.StatusUnauthorized, err
+	}
+
+	return http.StatusForbidden, err
+}
+
+func (s *Server) renderAuthError(w http.ResponseWriter, r *http.Request, err error, statusCode int) {
+	w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+	w.Header().Set("Access-Control-Allow-Credentials", "true")
+
+	s.renderError(w, err.Error(), statusCode)
+}
@@ -16,4 +16,24 @@
 
 package ptthttp
 
-var ()
+import "errors"
+
+var (
+	ErrInvalidRESTBody = errors.New("invalid rest body")
+
+	ErrInvalidRESTContentType = errors.New("invalid content type, only application/json is supported")
+
+	ErrInvalidRESTHost = errors.New("invalid host specified")
+
+	ErrInvalidRESTParamType = errors.New("invalid rest param type")
+
+	ErrInvalidListOrder = errors.New("invalid list order (prev / next)")
+
+	ErrInvalidContentRange = errors.New("invalid content range (bytes start-end/size)")
+
+	ErrInvalidSeek = errors.New("invalid seek")
+
+	ErrInvalidMediaRange = errors.New("invalid media range")
+
+	ErrMediaRangeNotReady = errors.New("media range not ready")
+)
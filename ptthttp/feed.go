@@ -0,0 +1,292 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package ptthttp
+
+import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/ailabstw/go-pttai/account"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/gorilla/mux"
+)
+
+/**********
+ * atom
+ **********/
+
+type atomFeed struct {
+	XMLName xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Link    *atomLink    `xml:"link"`
+	Entries []*atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Link      *atomLink   `xml:"link"`
+	Author    *atomAuthor `xml:"author"`
+	Summary   string      `xml:"summary"`
+}
+
+/**********
+ * rss
+ **********/
+
+type rssFeed struct {
+	XMLName xml.Name    `xml:"rss"`
+	Version string      `xml:"version,attr"`
+	DC      string      `xml:"xmlns:dc,attr"`
+	Channel *rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	LastBuildDate string     `xml:"lastBuildDate"`
+	Items         []*rssItem `xml:"item"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        *rssGUID `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Creator     string   `xml:"dc:creator"`
+	Description string   `xml:"description"`
+}
+
+/*
+feedEntry is the article in the feed, with the summary and the name of the creator.
+*/
+type feedEntry struct {
+	article     *content.BackendGetArticle
+	creatorName string
+	summary     string
+	link        string
+}
+
+/*
+feedHandler renders the latest articles of the board as the atom / rss feed.
+Only the boards set as the public-feed by the masters are served.
+The feed requires the read-scope when the api-tokens are enabled
+(the feed-readers can pass the token by the "token" query).
+*/
+func (s *Server) feedHandler(w http.ResponseWriter, r *http.Request) {
+	if statusCode, err := s.authorize(r, "content_getArticleList"); err != nil {
+		s.renderAuthError(w, r, err, statusCode)
+		return
+	}
+
+	vars := mux.Vars(r)
+	boardIDStr := vars["boardID"]
+	format := vars["format"]
+
+	isPublicFeed := false
+	err := s.rpcClient.Call(&isPublicFeed, "content_isBoardPublicFeed", boardIDStr)
+	if err != nil || !isPublicFeed {
+		s.renderError(w, "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	board := &content.BackendGetBoard{}
+	err = s.rpcClient.Call(board, "content_getBoard", boardIDStr)
+	if err != nil {
+		s.renderError(w, "NOT_FOUND", http.StatusNotFound)
+		return
+	}
+
+	entries, err := s.getFeedEntries(boardIDStr)
+	if err != nil {
+		log.Error("feedHandler: unable to get feed entries", "boardID", boardIDStr, "e", err)
+		s.renderError(w, "UNABLE_TO_GET_FEED", http.StatusInternalServerError)
+		return
+	}
+
+	boardLink := string(extHTTPAddr) + "/board/" + boardIDStr
+
+	var feed interface{}
+	switch format {
+	case FeedFormatAtom:
+		w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+		feed = toAtomFeed(board, boardLink, entries)
+	case FeedFormatRSS:
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		feed = toRSSFeed(board, boardLink, entries)
+	default:
+		s.renderError(w, "INVALID_FEED_FORMAT", http.StatusNotFound)
+		return
+	}
+
+	feedBytes, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		s.renderError(w, "UNABLE_TO_MARSHAL", http.StatusInternalServerError)
+		return
+	}
+
+	w.Write([]byte(xml.Header))
+	w.Write(feedBytes)
+}
+
+/*
+getFeedEntries gets the latest alive articles with the summaries and the names of the creators.
+*/
+func (s *Server) getFeedEntries(boardIDStr string) ([]*feedEntry, error) {
+	articles := make([]*content.BackendGetArticle, 0)
+	err := s.rpcClient.Call(&articles, "content_getArticleList", boardIDStr, "", FeedNArticle, pttdb.ListOrderPrev)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*feedEntry, 0, len(articles))
+	summaryParams := make([]*content.BackendArticleSummaryParams, 0, len(articles))
+	creatorIDs := make([]string, 0, len(articles))
+	for _, article := range articles {
+		if article.Status != types.StatusAlive {
+			continue
+		}
+
+		articleIDStr := article.ID.String()
+		entries = append(entries, &feedEntry{
+			article: article,
+			link:    string(extHTTPAddr) + "/board/" + boardIDStr + "/article/" + articleIDStr,
+		})
+
+		summaryParams = append(summaryParams, &content.BackendArticleSummaryParams{
+			ArticleID:      articleIDStr,
+			ContentBlockID: article.ContentBlockID.String(),
+		})
+		creatorIDs = append(creatorIDs, article.CreatorID.String())
+	}
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	summaries := make(map[string]*content.ArticleBlock)
+	err = s.rpcClient.Call(&summaries, "content_getArticleSummaryByIDs", boardIDStr, summaryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	userNames := make(map[string]*account.BackendUserName)
+	err = s.rpcClient.Call(&userNames, "account_getUserNameByIDs", creatorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		summary, ok := summaries[entry.article.ID.String()]
+		if ok {
+			entry.summary = string(bytes.Join(summary.Buf, []byte("\n")))
+		}
+
+		userName, ok := userNames[entry.article.CreatorID.String()]
+		if ok {
+			entry.creatorName = string(userName.Name)
+		}
+		if entry.creatorName == "" {
+			entry.creatorName = entry.article.CreatorID.String()
+		}
+	}
+
+	return entries, nil
+}
+
+func toAtomFeed(board *content.BackendGetBoard, boardLink string, entries []*feedEntry) *atomFeed {
+	updated := board.UpdateTS
+	atomEntries := make([]*atomEntry, len(entries))
+	for i, entry := range entries {
+		article := entry.article
+		if updated.IsLess(article.UpdateTS) {
+			updated = article.UpdateTS
+		}
+
+		atomEntries[i] = &atomEntry{
+			ID:        FeedIDPrefix + "article:" + article.ID.String(),
+			Title:     string(article.Title),
+			Published: article.CreateTS.ToTime().Format(time.RFC3339),
+			Updated:   article.UpdateTS.ToTime().Format(time.RFC3339),
+			Link:      &atomLink{Href: entry.link},
+			Author:    &atomAuthor{Name: entry.creatorName},
+			Summary:   entry.summary,
+		}
+	}
+
+	return &atomFeed{
+		ID:      FeedIDPrefix + "board:" + board.ID.String(),
+		Title:   string(board.Title),
+		Updated: updated.ToTime().Format(time.RFC3339),
+		Link:    &atomLink{Href: boardLink},
+		Entries: atomEntries,
+	}
+}
+
+func toRSSFeed(board *content.BackendGetBoard, boardLink string, entries []*feedEntry) *rssFeed {
+	updated := board.UpdateTS
+	items := make([]*rssItem, len(entries))
+	for i, entry := range entries {
+		article := entry.article
+		if updated.IsLess(article.UpdateTS) {
+			updated = article.UpdateTS
+		}
+
+		items[i] = &rssItem{
+			Title:       string(article.Title),
+			Link:        entry.link,
+			GUID:        &rssGUID{Value: FeedIDPrefix + "article:" + article.ID.String()},
+			PubDate:     article.CreateTS.ToTime().Format(time.RFC1123Z),
+			Creator:     entry.creatorName,
+			Description: entry.summary,
+		}
+	}
+
+	return &rssFeed{
+		Version: "2.0",
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: &rssChannel{
+			Title:         string(board.Title),
+			Link:          boardLink,
+			Description:   string(board.Title),
+			LastBuildDate: updated.ToTime().Format(time.RFC1123Z),
+			Items:         items,
+		},
+	}
+}
@@ -22,6 +22,39 @@ const (
 	MaxUploadSize = 10000000 // 10MB
 )
 
+// media
+const (
+	MaxUploadChunkSize = 4194304 // 4MB, for each PUT of the resumable upload
+	MediaReadChunkSize = 1048576 // 1MB, should be <= service.MaxGetMediaRangeSize
+
+	MediaRetryAfter = "5" // seconds, for the media still in sync.
+)
+
+// feed
+const (
+	FeedFormatAtom = "atom"
+	FeedFormatRSS  = "rss"
+
+	FeedNArticle = 20
+
+	FeedIDPrefix = "urn:pttai:"
+)
+
+// rest
+const (
+	RESTPrefix      = "/api/v1"
+	RESTOpenAPIPath = "/openapi.json"
+
+	RESTDefaultLimit = 20
+
+	RESTListOrderPrev = "prev"
+	RESTListOrderNext = "next"
+
+	OpenAPIVersion    = "3.0.0"
+	OpenAPITitle      = "go-pttai REST API"
+	OpenAPIAPIVersion = "1.0.0"
+)
+
 // re
 
 var (
@@ -0,0 +1,382 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package ptthttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/rpc"
+	pkgservice "github.com/ailabstw/go-pttai/service"
+	"github.com/gorilla/mux"
+)
+
+/*
+mediaReader reads the media lazily through content_getFileRange,
+so that http.ServeContent loads only the blocks in the requested ranges.
+*/
+type mediaReader struct {
+	rpcClient *rpc.Client
+	boardID   string
+	mediaID   string
+	size      int64
+	offset    int64
+}
+
+func newMediaReader(rpcClient *rpc.Client, boardID string, mediaID string, size int64) *mediaReader {
+	return &mediaReader{
+		rpcClient: rpcClient,
+		boardID:   boardID,
+		mediaID:   mediaID,
+		size:      size,
+	}
+}
+
+func (r *mediaReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	n := int64(len(p))
+	if n > MediaReadChunkSize {
+		n = MediaReadChunkSize
+	}
+	if r.offset+n > r.size {
+		n = r.size - r.offset
+	}
+
+	backendGetFileRange := &content.BackendGetFileRange{}
+	err := r.rpcClient.Call(backendGetFileRange, "content_getFileRange", r.boardID, r.mediaID, r.offset, r.offset+n)
+	if err != nil {
+		return 0, err
+	}
+	if len(backendGetFileRange.Buf) == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+
+	copied := copy(p, backendGetFileRange.Buf)
+	r.offset += int64(copied)
+
+	return copied, nil
+}
+
+func (r *mediaReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, ErrInvalidSeek
+	}
+	if offset < 0 {
+		return 0, ErrInvalidSeek
+	}
+
+	r.offset = offset
+
+	return offset, nil
+}
+
+/*
+serveMedia serves the media with Range / If-None-Match support (through http.ServeContent).
+The media-id is used as the etag because the media is immutable.
+While in sync, only the ranges within the synced bytes (X-Ready-Size) are served, 503 with Retry-After for the others.
+*/
+func (s *Server) serveMedia(w http.ResponseWriter, r *http.Request, boardIDStr string, mediaIDStr string, isImage bool) {
+	backendGetFileInfo := &content.BackendGetFileInfo{}
+	err := s.rpcClient.Call(backendGetFileInfo, "content_getFileInfo", boardIDStr, mediaIDStr)
+	if err != nil && err.Error() == pkgservice.ErrMediaNotReady.Error() {
+		w.Header().Set("Retry-After", MediaRetryAfter)
+		s.renderError(w, "MEDIA_NOT_READY", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		log.Warn("serveMedia: unable to get file info", "boardID", boardIDStr, "mediaID", mediaIDStr, "e", err)
+		s.renderError(w, "UNABLE_TO_MARSHAL", http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case isImage:
+		switch backendGetFileInfo.MediaType {
+		case pkgservice.MediaTypeJPEG:
+			w.Header().Set("Content-Type", "image/jpg")
+		case pkgservice.MediaTypePNG:
+			w.Header().Set("Content-Type", "image/png")
+		case pkgservice.MediaTypeGIF:
+			w.Header().Set("Content-Type", "image/gif")
+		}
+	case backendGetFileInfo.MediaType.IsStream() && isStreamContentType(backendGetFileInfo.ContentType):
+		// the content-type is from the uploader, only audio / video are served as is.
+		w.Header().Set("Content-Type", backendGetFileInfo.ContentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+	default:
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	w.Header().Set("X-Ready-Size", strconv.FormatInt(backendGetFileInfo.ReadySize, 10))
+
+	w.Header().Set("ETag", fmt.Sprintf(`"%v"`, mediaIDStr))
+	w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+	w.Header().Set("Access-Control-Expose-Headers", "Content-Range,Content-Length,Accept-Ranges,ETag,X-Ready-Size")
+
+	// ServeContent writes the 200 / 206 before reading the media,
+	// so the ranges are clamped to the synced bytes before serving.
+	if backendGetFileInfo.ReadySize < backendGetFileInfo.Size {
+		rangeStr, err := readyMediaRange(r.Header.Get("Range"), backendGetFileInfo.Size, backendGetFileInfo.ReadySize)
+		switch err {
+		case ErrMediaRangeNotReady:
+			w.Header().Set("Retry-After", MediaRetryAfter)
+			s.renderError(w, "MEDIA_NOT_READY", http.StatusServiceUnavailable)
+			return
+		case ErrInvalidMediaRange:
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%v", backendGetFileInfo.Size))
+			s.renderError(w, "INVALID_RANGE", http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		r.Header.Set("Range", rangeStr)
+	}
+
+	reader := newMediaReader(s.rpcClient, boardIDStr, mediaIDStr, backendGetFileInfo.Size)
+
+	http.ServeContent(w, r, "", time.Time{}, reader)
+}
+
+/*
+serveThumbnail serves the thumbnail of the image with the size (the original image if without the thumbnail).
+The thumbnails are small, so the whole thumbnail is loaded.
+*/
+func (s *Server) serveThumbnail(w http.ResponseWriter, r *http.Request, boardIDStr string, imgIDStr string, size string) {
+	if !pkgservice.ImageSize(size).IsValid() {
+		s.renderError(w, "INVALID_SIZE", http.StatusBadRequest)
+		return
+	}
+
+	backendGetImg := &content.BackendGetImg{}
+	err := s.rpcClient.Call(backendGetImg, "content_getImage", boardIDStr, imgIDStr, size)
+	if err != nil {
+		log.Warn("serveThumbnail: unable to get image", "boardID", boardIDStr, "imgID", imgIDStr, "size", size, "e", err)
+		s.renderError(w, "UNABLE_TO_MARSHAL", http.StatusBadRequest)
+		return
+	}
+
+	switch backendGetImg.Type {
+	case pkgservice.MediaTypeJPEG:
+		w.Header().Set("Content-Type", "image/jpg")
+	case pkgservice.MediaTypePNG:
+		w.Header().Set("Content-Type", "image/png")
+	case pkgservice.MediaTypeGIF:
+		w.Header().Set("Content-Type", "image/gif")
+	}
+
+	// the etag is with the returned size, the image may be replaced by the thumbnail after synced.
+	w.Header().Set("ETag", fmt.Sprintf(`"%v-%v"`, imgIDStr, backendGetImg.Size))
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("Access-Control-Expose-Headers", "Content-Range,Content-Length,Accept-Ranges,ETag")
+
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(backendGetImg.Buf))
+}
+
+/*
+readyMediaRange clamps the ranges of the Range header (bytes=start-last, bytes=start-, bytes=-suffix)
+to the first readySize bytes already synced, so that the playback can start while in sync.
+
+ErrMediaRangeNotReady if the whole media is requested or a range starts after the synced bytes.
+ErrInvalidMediaRange if the Range header is invalid or not satisfiable.
+*/
+func readyMediaRange(rangeStr string, size int64, readySize int64) (string, error) {
+	if rangeStr == "" {
+		return "", ErrMediaRangeNotReady
+	}
+	if !strings.HasPrefix(rangeStr, "bytes=") {
+		return "", ErrInvalidMediaRange
+	}
+
+	ranges := make([]string, 0, 1)
+	for _, each := range strings.Split(rangeStr[len("bytes="):], ",") {
+		each = strings.TrimSpace(each)
+		idx := strings.Index(each, "-")
+		if idx < 0 {
+			return "", ErrInvalidMediaRange
+		}
+		startStr, lastStr := strings.TrimSpace(each[:idx]), strings.TrimSpace(each[idx+1:])
+
+		var start, end int64
+		switch {
+		case startStr == "":
+			// suffix
+			suffix, err := strconv.ParseInt(lastStr, 10, 64)
+			if err != nil || suffix <= 0 {
+				return "", ErrInvalidMediaRange
+			}
+			start, end = size-suffix, size
+			if start < 0 {
+				start = 0
+			}
+		default:
+			var err error
+			start, err = strconv.ParseInt(startStr, 10, 64)
+			if err != nil || start < 0 {
+				return "", ErrInvalidMediaRange
+			}
+			end = size
+			if lastStr != "" {
+				last, err := strconv.ParseInt(lastStr, 10, 64)
+				if err != nil || last < start {
+					return "", ErrInvalidMediaRange
+				}
+				if last+1 < end {
+					end = last + 1
+				}
+			}
+		}
+
+		// not satisfiable
+		if start >= size {
+			continue
+		}
+		if start >= readySize {
+			return "", ErrMediaRangeNotReady
+		}
+		if end > readySize {
+			end = readySize
+		}
+
+		ranges = append(ranges, fmt.Sprintf("%v-%v", start, end-1))
+	}
+	if len(ranges) == 0 {
+		return "", ErrInvalidMediaRange
+	}
+
+	return "bytes=" + strings.Join(ranges, ","), nil
+}
+
+func isStreamContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, pkgservice.MediaContentTypeAudioPrefix) ||
+		strings.HasPrefix(contentType, pkgservice.MediaContentTypeVideoPrefix)
+}
+
+/*
+uploadChunkHandler appends the chunk in the body to the upload created by
+POST RESTPrefix/boards/{boardID}/uploads.
+
+The offset of the chunk is from the Content-Range header (bytes start-end/size).
+409 with the current state of the upload is returned if the offset does not match,
+so that the client can resume from the current offset.
+MediaID of the result is set when all the bytes are uploaded.
+*/
+func (s *Server) uploadChunkHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.preprocessREST(w, r) {
+		return
+	}
+
+	if statusCode, err := s.authorize(r, "content_uploadFileChunk"); err != nil {
+		s.renderRESTError(w, err, statusCode)
+		return
+	}
+
+	vars := mux.Vars(r)
+	boardIDStr := vars["boardID"]
+	uploadIDStr := vars["uploadID"]
+
+	start, end, err := parseContentRange(r.Header.Get("Content-Range"))
+	if err != nil {
+		s.renderRESTError(w, err, http.StatusBadRequest)
+		return
+	}
+	if end-start > MaxUploadChunkSize {
+		s.renderRESTError(w, ErrInvalidContentRange, http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxUploadChunkSize)
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		s.renderRESTError(w, err, http.StatusRequestEntityTooLarge)
+		return
+	}
+	if int64(len(buf)) != end-start {
+		s.renderRESTError(w, ErrInvalidContentRange, http.StatusBadRequest)
+		return
+	}
+
+	backendUpload := &content.BackendFileUpload{}
+	err = s.rpcClient.Call(backendUpload, "content_uploadFileChunk", boardIDStr, uploadIDStr, start, buf)
+	if err != nil {
+		s.renderUploadChunkError(w, err, boardIDStr, uploadIDStr, start)
+		return
+	}
+
+	resultBytes, err := json.Marshal(&struct {
+		Result interface{} `json:"result"`
+	}{Result: backendUpload})
+	if err != nil {
+		s.renderRESTError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(resultBytes)
+}
+
+func (s *Server) renderUploadChunkError(w http.ResponseWriter, err error, boardIDStr string, uploadIDStr string, start int64) {
+	backendUpload := &content.BackendFileUpload{}
+	errGet := s.rpcClient.Call(backendUpload, "content_getFileUpload", boardIDStr, uploadIDStr)
+	if errGet != nil || backendUpload.Offset == start {
+		s.renderRESTError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	resultBytes, _ := json.Marshal(&struct {
+		Error  string      `json:"error"`
+		Result interface{} `json:"result"`
+	}{Error: err.Error(), Result: backendUpload})
+
+	w.WriteHeader(http.StatusConflict)
+	w.Write(resultBytes)
+}
+
+/*
+parseContentRange parses the Content-Range header (bytes start-end/size)
+to the [start, end) of the chunk.
+*/
+func parseContentRange(str string) (int64, int64, error) {
+	if str == "" {
+		return 0, 0, ErrInvalidContentRange
+	}
+
+	var start, last int64
+	var size string
+	_, err := fmt.Sscanf(str, "bytes %d-%d/%s", &start, &last, &size)
+	if err != nil {
+		return 0, 0, ErrInvalidContentRange
+	}
+	if start < 0 || last < start {
+		return 0, 0, ErrInvalidContentRange
+	}
+
+	return start, last + 1, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package ptthttp
+
+import "testing"
+
+func TestParseContentRange(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	// prepare test-cases
+	tests := []struct {
+		name    string
+		str     string
+		want    int64
+		want1   int64
+		wantErr error
+	}{
+		{name: "first-chunk", str: "bytes 0-1023/4096", want: 0, want1: 1024},
+		{name: "last-chunk", str: "bytes 3072-4095/4096", want: 3072, want1: 4096},
+		{name: "unknown-size", str: "bytes 0-0/*", want: 0, want1: 1},
+		{name: "empty", str: "", wantErr: ErrInvalidContentRange},
+		{name: "no-unit", str: "0-1023/4096", wantErr: ErrInvalidContentRange},
+		{name: "reversed", str: "bytes 1023-0/4096", wantErr: ErrInvalidContentRange},
+		{name: "negative", str: "bytes -1-1023/4096", wantErr: ErrInvalidContentRange},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, err := parseContentRange(tt.str)
+			if err != tt.wantErr {
+				t.Errorf("parseContentRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseContentRange() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("parseContentRange() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestReadyMediaRange(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	// prepare test-cases
+	tests := []struct {
+		name      string
+		rangeStr  string
+		size      int64
+		readySize int64
+		want      string
+		wantErr   error
+	}{
+		{name: "ready", rangeStr: "bytes=0-1023", size: 4096, readySize: 2048, want: "bytes=0-1023"},
+		{name: "open", rangeStr: "bytes=1024-", size: 4096, readySize: 2048, want: "bytes=1024-2047"},
+		{name: "over-ready", rangeStr: "bytes=1024-3071", size: 4096, readySize: 2048, want: "bytes=1024-2047"},
+		{name: "multi", rangeStr: "bytes=0-9, 100-199", size: 4096, readySize: 2048, want: "bytes=0-9,100-199"},
+		{name: "suffix", rangeStr: "bytes=-4096", size: 4096, readySize: 2048, want: "bytes=0-2047"},
+		{name: "no-range", rangeStr: "", size: 4096, readySize: 2048, wantErr: ErrMediaRangeNotReady},
+		{name: "after-ready", rangeStr: "bytes=2048-", size: 4096, readySize: 2048, wantErr: ErrMediaRangeNotReady},
+		{name: "suffix-after-ready", rangeStr: "bytes=-1024", size: 4096, readySize: 2048, wantErr: ErrMediaRangeNotReady},
+		{name: "none-ready", rangeStr: "bytes=0-", size: 4096, readySize: 0, wantErr: ErrMediaRangeNotReady},
+		{name: "not-satisfiable", rangeStr: "bytes=4096-", size: 4096, readySize: 2048, wantErr: ErrInvalidMediaRange},
+		{name: "no-unit", rangeStr: "0-1023", size: 4096, readySize: 2048, wantErr: ErrInvalidMediaRange},
+		{name: "reversed", rangeStr: "bytes=1023-0", size: 4096, readySize: 2048, wantErr: ErrInvalidMediaRange},
+		{name: "invalid", rangeStr: "bytes=a-b", size: 4096, readySize: 2048, wantErr: ErrInvalidMediaRange},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readyMediaRange(tt.rangeStr, tt.size, tt.readySize)
+			if err != tt.wantErr {
+				t.Errorf("readyMediaRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("readyMediaRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
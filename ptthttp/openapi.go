@@ -0,0 +1,169 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package ptthttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ailabstw/go-pttai/pttdb"
+)
+
+/*
+openAPIHandler serves the openapi (3.0) document generated from restRoutes.
+*/
+func (s *Server) openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.preprocessREST(w, r) {
+		return
+	}
+
+	docBytes, err := json.Marshal(openAPIDoc(restRoutes))
+	if err != nil {
+		s.renderRESTError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Write(docBytes)
+}
+
+func openAPIDoc(routes []*RESTRoute) map[string]interface{} {
+	paths := make(map[string]interface{})
+	for _, route := range routes {
+		pathItem, ok := paths[route.Path].(map[string]interface{})
+		if !ok {
+			pathItem = make(map[string]interface{})
+			paths[route.Path] = pathItem
+		}
+		pathItem[strings.ToLower(route.Method)] = openAPIOperation(route)
+	}
+
+	return map[string]interface{}{
+		"openapi": OpenAPIVersion,
+		"info": map[string]interface{}{
+			"title":   OpenAPITitle,
+			"version": OpenAPIAPIVersion,
+		},
+		"servers": []interface{}{
+			map[string]interface{}{"url": RESTPrefix},
+		},
+		"paths": paths,
+	}
+}
+
+func openAPIOperation(route *RESTRoute) map[string]interface{} {
+	parameters := make([]interface{}, 0, len(route.Params))
+	properties := make(map[string]interface{})
+	required := make([]string, 0)
+	for _, param := range route.Params {
+		switch param.In {
+		case RESTParamInPath, RESTParamInQuery:
+			parameter := map[string]interface{}{
+				"name":     param.Name,
+				"in":       string(param.In),
+				"required": param.Required || param.In == RESTParamInPath,
+				"schema":   param.openAPISchema(),
+			}
+			if param.Description != "" {
+				parameter["description"] = param.Description
+			}
+			parameters = append(parameters, parameter)
+		case RESTParamInBody:
+			properties[param.Name] = param.openAPISchema()
+			if param.Required {
+				required = append(required, param.Name)
+			}
+		}
+	}
+
+	operation := map[string]interface{}{
+		"operationId": route.RPCMethod,
+		"tags":        []string{route.Tag},
+		"summary":     route.Summary,
+		"parameters":  parameters,
+		"responses": map[string]interface{}{
+			"200": openAPIResponse("ok, the result of " + route.RPCMethod + " in \"result\""),
+			"400": openAPIResponse("error, the reason in \"error\""),
+		},
+	}
+
+	if len(properties) != 0 {
+		schema := map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+		}
+		if len(required) != 0 {
+			schema["required"] = required
+		}
+		operation["requestBody"] = map[string]interface{}{
+			"required": len(required) != 0,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": schema,
+				},
+			},
+		}
+	}
+
+	return operation
+}
+
+func openAPIResponse(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"type": "object"},
+			},
+		},
+	}
+}
+
+func (p *RESTParam) openAPISchema() map[string]interface{} {
+	var schema map[string]interface{}
+	switch p.Type {
+	case RESTParamTypeInt:
+		schema = map[string]interface{}{"type": "integer"}
+	case RESTParamTypeUint32:
+		schema = map[string]interface{}{"type": "integer", "format": "int32", "minimum": 0}
+	case RESTParamTypeBool:
+		schema = map[string]interface{}{"type": "boolean"}
+	case RESTParamTypeLines, RESTParamTypeStrings:
+		schema = map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}
+	case RESTParamTypeListOrder:
+		schema = map[string]interface{}{"type": "string", "enum": []string{RESTListOrderPrev, RESTListOrderNext}}
+	default:
+		schema = map[string]interface{}{"type": "string"}
+	}
+
+	if p.Required {
+		return schema
+	}
+
+	switch p.Type {
+	case RESTParamTypeListOrder:
+		if p.Default == pttdb.ListOrderNext {
+			schema["default"] = RESTListOrderNext
+		} else {
+			schema["default"] = RESTListOrderPrev
+		}
+	default:
+		schema["default"] = p.Default
+	}
+
+	return schema
+}
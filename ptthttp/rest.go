@@ -0,0 +1,372 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package ptthttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/gorilla/mux"
+)
+
+/*
+RESTParamIn is where the param of the rest-route comes from.
+*/
+type RESTParamIn string
+
+const (
+	RESTParamInPath  RESTParamIn = "path"
+	RESTParamInQuery RESTParamIn = "query"
+	RESTParamInBody  RESTParamIn = "body"
+	RESTParamInConst RESTParamIn = "const" // not from the request, always the default.
+)
+
+/*
+RESTParamType is the type of the param, determining how the param is converted to the rpc-arg.
+*/
+type RESTParamType string
+
+const (
+	RESTParamTypeString    RESTParamType = "string"
+	RESTParamTypeInt       RESTParamType = "int"
+	RESTParamTypeUint32    RESTParamType = "uint32"
+	RESTParamTypeBool      RESTParamType = "bool"
+	RESTParamTypeBytes     RESTParamType = "bytes"     // plain string in rest, []byte in rpc.
+	RESTParamTypeLines     RESTParamType = "lines"     // []string in rest, [][]byte in rpc.
+	RESTParamTypeStrings   RESTParamType = "strings"   // []string
+	RESTParamTypeListOrder RESTParamType = "listOrder" // "prev" / "next"
+)
+
+type RESTParam struct {
+	Name        string
+	In          RESTParamIn
+	Type        RESTParamType
+	Required    bool
+	Default     interface{}
+	Description string
+}
+
+/*
+RESTRoute maps the rest-route to the rpc-method.
+The params are the positional args of the rpc-method in order.
+*/
+type RESTRoute struct {
+	Method    string
+	Path      string
+	RPCMethod string
+	Tag       string
+	Summary   string
+	Params    []*RESTParam
+}
+
+func (s *Server) registerRESTRoutes(r *mux.Router) {
+	for _, route := range restRoutes {
+		r.HandleFunc(RESTPrefix+route.Path, s.restHandler(route)).
+			Methods(route.Method)
+		r.HandleFunc(RESTPrefix+route.Path, s.restOptionHandler).
+			Methods("OPTIONS")
+	}
+
+	r.HandleFunc(RESTPrefix+RESTOpenAPIPath, s.openAPIHandler).
+		Methods("GET")
+}
+
+func (s *Server) restOptionHandler(w http.ResponseWriter, r *http.Request) {
+	s.preprocessREST(w, r)
+}
+
+/*
+preprocessREST validates the host and sets the rest-headers with the vhosts / cors of the rpc-endpoint.
+(The rest-routes are called through the in-proc rpc-client, which is not protected by the rpc-endpoint.)
+*/
+func (s *Server) preprocessREST(w http.ResponseWriter, r *http.Request) bool {
+	if !isAllowedHost(r.Host, s.vhosts) {
+		s.renderRESTError(w, ErrInvalidRESTHost, http.StatusForbidden)
+		return false
+	}
+
+	setRESTHeaders(w, r, s.cors)
+
+	return true
+}
+
+/*
+setRESTHeaders sets the cors-headers only if the origin is in the allowed-origins.
+*/
+func setRESTHeaders(w http.ResponseWriter, r *http.Request, allowedOrigins []string) {
+	w.Header().Set("Accept", "*")
+	w.Header().Set("Content-Type", "application/json")
+
+	origin := r.Header.Get("Origin")
+	if origin == "" || !isAllowedOrigin(origin, allowedOrigins) {
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Set("Access-Control-Allow-Credentials", "true")
+	w.Header().Set("Access-Control-Allow-Methods", "OPTIONS,GET,POST,PUT,DELETE")
+	w.Header().Set("Access-Control-Allow-Headers", "X-CSRFToken,Content-Type,Authorization")
+	w.Header().Add("Vary", "Origin")
+}
+
+func isAllowedOrigin(origin string, allowedOrigins []string) bool {
+	for _, allowedOrigin := range allowedOrigins {
+		if allowedOrigin == "*" || strings.EqualFold(allowedOrigin, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+isAllowedHost validates the host as the vhosts of the rpc-endpoint (rpc.virtualHostHandler),
+preventing the dns-rebinding attacks.
+*/
+func isAllowedHost(hostport string, vhosts []string) bool {
+	if hostport == "" {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = hostport
+	}
+
+	if net.ParseIP(host) != nil {
+		return true
+	}
+
+	for _, vhost := range vhosts {
+		if vhost == "*" || strings.EqualFold(vhost, host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) restHandler(route *RESTRoute) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.preprocessREST(w, r) {
+			return
+		}
+
+		if statusCode, err := s.authorize(r, route.RPCMethod); err != nil {
+			s.renderRESTError(w, err, statusCode)
+			return
+		}
+
+		if r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, MaxUploadSize)
+		}
+
+		args, err := restArgs(route, r)
+		if err == ErrInvalidRESTContentType {
+			s.renderRESTError(w, err, http.StatusUnsupportedMediaType)
+			return
+		}
+		if err != nil {
+			s.renderRESTError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		log.Debug("restHandler: to rpc", "method", route.RPCMethod, "args", args)
+
+		var result json.RawMessage
+		err = s.rpcClient.Call(&result, route.RPCMethod, args...)
+		if err != nil {
+			s.renderRESTError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		resultBytes, err := json.Marshal(&struct {
+			Result json.RawMessage `json:"result"`
+		}{Result: result})
+		if err != nil {
+			s.renderRESTError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		w.Write(resultBytes)
+	}
+}
+
+func (s *Server) renderRESTError(w http.ResponseWriter, err error, statusCode int) {
+	errBytes, _ := json.Marshal(&struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+
+	w.WriteHeader(statusCode)
+	w.Write(errBytes)
+}
+
+/*
+restArgs converts the path-vars, the query and the json-body of the request
+to the positional args of the rpc-method.
+
+The body is required to be application/json, so that the cross-origin requests need the cors-preflight.
+*/
+func restArgs(route *RESTRoute, r *http.Request) ([]interface{}, error) {
+	vars := mux.Vars(r)
+	query := r.URL.Query()
+
+	body := make(map[string]json.RawMessage)
+	if r.Body != nil && (r.Method == "POST" || r.Method == "PUT") {
+		bodyBytes, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		if len(bodyBytes) != 0 {
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || mediaType != "application/json" {
+				return nil, ErrInvalidRESTContentType
+			}
+
+			err = json.Unmarshal(bodyBytes, &body)
+			if err != nil {
+				return nil, ErrInvalidRESTBody
+			}
+		}
+	}
+
+	args := make([]interface{}, len(route.Params))
+	for i, param := range route.Params {
+		var arg interface{}
+		var err error
+		isSet := false
+		switch param.In {
+		case RESTParamInPath:
+			var str string
+			str, isSet = vars[param.Name]
+			if isSet {
+				arg, err = param.parseString(str)
+			}
+		case RESTParamInQuery:
+			_, isSet = query[param.Name]
+			if isSet {
+				arg, err = param.parseString(query.Get(param.Name))
+			}
+		case RESTParamInBody:
+			var raw json.RawMessage
+			raw, isSet = body[param.Name]
+			if isSet {
+				arg, err = param.parseJSON(raw)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid param %v: %v", param.Name, err)
+		}
+
+		if !isSet {
+			if param.Required {
+				return nil, fmt.Errorf("missing param %v", param.Name)
+			}
+			arg = param.Default
+		}
+
+		args[i] = arg
+	}
+
+	return args, nil
+}
+
+func (p *RESTParam) parseString(str string) (interface{}, error) {
+	switch p.Type {
+	case RESTParamTypeString:
+		return str, nil
+	case RESTParamTypeInt:
+		return strconv.Atoi(str)
+	case RESTParamTypeUint32:
+		val, err := strconv.ParseUint(str, 10, 32)
+		return uint32(val), err
+	case RESTParamTypeBool:
+		return strconv.ParseBool(str)
+	case RESTParamTypeBytes:
+		return []byte(str), nil
+	case RESTParamTypeListOrder:
+		return parseListOrder(str)
+	}
+
+	return nil, ErrInvalidRESTParamType
+}
+
+func (p *RESTParam) parseJSON(raw json.RawMessage) (interface{}, error) {
+	var err error
+	switch p.Type {
+	case RESTParamTypeString:
+		var val string
+		err = json.Unmarshal(raw, &val)
+		return val, err
+	case RESTParamTypeInt:
+		var val int
+		err = json.Unmarshal(raw, &val)
+		return val, err
+	case RESTParamTypeUint32:
+		var val uint32
+		err = json.Unmarshal(raw, &val)
+		return val, err
+	case RESTParamTypeBool:
+		var val bool
+		err = json.Unmarshal(raw, &val)
+		return val, err
+	case RESTParamTypeBytes:
+		var val string
+		err = json.Unmarshal(raw, &val)
+		return []byte(val), err
+	case RESTParamTypeLines:
+		var val []string
+		err = json.Unmarshal(raw, &val)
+		lines := make([][]byte, len(val))
+		for i, line := range val {
+			lines[i] = []byte(line)
+		}
+		return lines, err
+	case RESTParamTypeStrings:
+		var val []string
+		err = json.Unmarshal(raw, &val)
+		return val, err
+	case RESTParamTypeListOrder:
+		var val string
+		err = json.Unmarshal(raw, &val)
+		if err != nil {
+			return nil, err
+		}
+		return parseListOrder(val)
+	}
+
+	return nil, ErrInvalidRESTParamType
+}
+
+func parseListOrder(str string) (pttdb.ListOrder, error) {
+	switch str {
+	case RESTListOrderPrev:
+		return pttdb.ListOrderPrev, nil
+	case RESTListOrderNext:
+		return pttdb.ListOrderNext, nil
+	}
+
+	return 0, ErrInvalidListOrder
+}
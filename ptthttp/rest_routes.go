@@ -0,0 +1,291 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package ptthttp
+
+import (
+	"github.com/ailabstw/go-pttai/content"
+	"github.com/ailabstw/go-pttai/pttdb"
+)
+
+// params
+var (
+	restParamBoardID   = &RESTParam{Name: "boardID", In: RESTParamInPath, Type: RESTParamTypeString, Required: true}
+	restParamArticleID = &RESTParam{Name: "articleID", In: RESTParamInPath, Type: RESTParamTypeString, Required: true}
+	restParamCommentID = &RESTParam{Name: "commentID", In: RESTParamInPath, Type: RESTParamTypeString, Required: true}
+	restParamReplyID   = &RESTParam{Name: "replyID", In: RESTParamInPath, Type: RESTParamTypeString, Required: true}
+	restParamFriendID  = &RESTParam{Name: "friendID", In: RESTParamInPath, Type: RESTParamTypeString, Required: true}
+	restParamMessageID = &RESTParam{Name: "messageID", In: RESTParamInPath, Type: RESTParamTypeString, Required: true}
+	restParamUserID    = &RESTParam{Name: "userID", In: RESTParamInPath, Type: RESTParamTypeString, Required: true}
+	restParamUploadID  = &RESTParam{Name: "uploadID", In: RESTParamInPath, Type: RESTParamTypeString, Required: true}
+
+	restParamStart = &RESTParam{Name: "start", In: RESTParamInQuery, Type: RESTParamTypeString, Default: "", Description: "starting id of the list"}
+	restParamLimit = &RESTParam{Name: "limit", In: RESTParamInQuery, Type: RESTParamTypeInt, Default: RESTDefaultLimit}
+	restParamOrder = &RESTParam{Name: "order", In: RESTParamInQuery, Type: RESTParamTypeListOrder, Default: pttdb.ListOrderPrev, Description: "prev (newest first) / next (oldest first)"}
+
+	restParamMediaIDs = &RESTParam{Name: "mediaIDs", In: RESTParamInBody, Type: RESTParamTypeStrings, Default: []string{}, Description: "ids of the uploaded images / files"}
+	restParamMediaID  = &RESTParam{Name: "mediaID", In: RESTParamInBody, Type: RESTParamTypeString, Default: "", Description: "id of the uploaded image / file"}
+)
+
+/*
+restRoutes are the rest-routes under RESTPrefix, also described in the openapi document.
+*/
+var restRoutes = []*RESTRoute{
+	// ptt
+	{Method: "GET", Path: "/version", RPCMethod: "ptt_getVersion", Tag: "ptt", Summary: "Get the version"},
+	{Method: "GET", Path: "/peers", RPCMethod: "ptt_getPeers", Tag: "ptt", Summary: "Get the connected peers"},
+
+	// me
+	{Method: "GET", Path: "/me", RPCMethod: "me_get", Tag: "me", Summary: "Get my info"},
+	{Method: "GET", Path: "/me/url", RPCMethod: "me_showURL", Tag: "me", Summary: "Get my url for adding friends"},
+	{Method: "PUT", Path: "/me/name", RPCMethod: "me_setMyName", Tag: "me", Summary: "Set my name", Params: []*RESTParam{
+		{Name: "name", In: RESTParamInBody, Type: RESTParamTypeBytes, Required: true},
+	}},
+	{Method: "GET", Path: "/me/board", RPCMethod: "me_getMyBoard", Tag: "me", Summary: "Get my personal board"},
+
+	// account
+	{Method: "GET", Path: "/users/{userID}/name", RPCMethod: "account_getUserName", Tag: "account", Summary: "Get the name of the user", Params: []*RESTParam{
+		restParamUserID,
+	}},
+	{Method: "GET", Path: "/users/{userID}/image", RPCMethod: "account_getUserImg", Tag: "account", Summary: "Get the image of the user", Params: []*RESTParam{
+		restParamUserID,
+	}},
+	{Method: "GET", Path: "/users/{userID}/namecard", RPCMethod: "account_getNameCard", Tag: "account", Summary: "Get the name-card of the user", Params: []*RESTParam{
+		restParamUserID,
+	}},
+
+	// content - board
+	{Method: "GET", Path: "/boards", RPCMethod: "content_getBoardList", Tag: "content", Summary: "List the boards", Params: []*RESTParam{
+		restParamStart, restParamLimit, restParamOrder,
+	}},
+	{Method: "POST", Path: "/boards", RPCMethod: "content_createBoard", Tag: "content", Summary: "Create a board", Params: []*RESTParam{
+		{Name: "title", In: RESTParamInBody, Type: RESTParamTypeBytes, Required: true},
+		{Name: "isPrivate", In: RESTParamInBody, Type: RESTParamTypeBool, Default: false},
+	}},
+	{Method: "GET", Path: "/boards/{boardID}", RPCMethod: "content_getBoard", Tag: "content", Summary: "Get the board", Params: []*RESTParam{
+		restParamBoardID,
+	}},
+	{Method: "DELETE", Path: "/boards/{boardID}", RPCMethod: "content_deleteBoard", Tag: "content", Summary: "Delete the board", Params: []*RESTParam{
+		restParamBoardID,
+	}},
+	{Method: "PUT", Path: "/boards/{boardID}/title", RPCMethod: "content_setTitle", Tag: "content", Summary: "Set the title of the board", Params: []*RESTParam{
+		restParamBoardID,
+		{Name: "title", In: RESTParamInBody, Type: RESTParamTypeBytes, Required: true},
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/url", RPCMethod: "content_showBoardURL", Tag: "content", Summary: "Get the url for joining the board", Params: []*RESTParam{
+		restParamBoardID,
+	}},
+
+	// content - article
+	{Method: "GET", Path: "/boards/{boardID}/articles", RPCMethod: "content_getArticleList", Tag: "content", Summary: "List the articles", Params: []*RESTParam{
+		restParamBoardID, restParamStart, restParamLimit, restParamOrder,
+	}},
+	{Method: "POST", Path: "/boards/{boardID}/articles", RPCMethod: "content_createArticle", Tag: "content", Summary: "Create an article", Params: []*RESTParam{
+		restParamBoardID,
+		{Name: "title", In: RESTParamInBody, Type: RESTParamTypeBytes, Required: true},
+		{Name: "article", In: RESTParamInBody, Type: RESTParamTypeLines, Required: true, Description: "lines of the article"},
+		restParamMediaIDs,
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/search", RPCMethod: "content_searchArticles", Tag: "content", Summary: "Search the articles", Params: []*RESTParam{
+		restParamBoardID,
+		{Name: "q", In: RESTParamInQuery, Type: RESTParamTypeString, Required: true},
+		restParamLimit,
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/tags", RPCMethod: "content_getBoardTags", Tag: "content", Summary: "Get the tag-vocabulary of the board", Params: []*RESTParam{
+		restParamBoardID,
+	}},
+	{Method: "PUT", Path: "/boards/{boardID}/tags", RPCMethod: "content_setBoardTags", Tag: "content", Summary: "Set the tag-vocabulary of the board", Params: []*RESTParam{
+		restParamBoardID,
+		{Name: "tags", In: RESTParamInBody, Type: RESTParamTypeStrings, Required: true, Description: "tags allowed on the articles (empty as any tags)"},
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/tags/{tag}/articles", RPCMethod: "content_getArticleListByTag", Tag: "content", Summary: "List the articles with the tag", Params: []*RESTParam{
+		restParamBoardID,
+		{Name: "tag", In: RESTParamInPath, Type: RESTParamTypeString, Required: true},
+		restParamStart, restParamLimit, restParamOrder,
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/articles/{articleID}", RPCMethod: "content_getArticle", Tag: "content", Summary: "Get the article", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID,
+	}},
+	{Method: "PUT", Path: "/boards/{boardID}/articles/{articleID}", RPCMethod: "content_updateArticle", Tag: "content", Summary: "Update the article", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID,
+		{Name: "article", In: RESTParamInBody, Type: RESTParamTypeLines, Required: true, Description: "lines of the article"},
+		restParamMediaIDs,
+	}},
+	{Method: "DELETE", Path: "/boards/{boardID}/articles/{articleID}", RPCMethod: "content_deleteArticle", Tag: "content", Summary: "Delete the article", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID,
+	}},
+	{Method: "PUT", Path: "/boards/{boardID}/articles/{articleID}/tags", RPCMethod: "content_setArticleTags", Tag: "content", Summary: "Set the tags of the article", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID,
+		{Name: "tags", In: RESTParamInBody, Type: RESTParamTypeStrings, Required: true},
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/articles/{articleID}/revisions", RPCMethod: "content_getArticleRevisionList", Tag: "content", Summary: "List the revisions of the article", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID,
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/articles/{articleID}/revisions/{revisionID}", RPCMethod: "content_getArticleRevision", Tag: "content", Summary: "Get the content of the revision", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID,
+		{Name: "revisionID", In: RESTParamInPath, Type: RESTParamTypeString, Required: true},
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/articles/{articleID}/diff", RPCMethod: "content_getArticleRevisionDiff", Tag: "content", Summary: "Get the line-level diff between the revisions", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID,
+		{Name: "from", In: RESTParamInQuery, Type: RESTParamTypeString, Required: true, Description: "revision id"},
+		{Name: "to", In: RESTParamInQuery, Type: RESTParamTypeString, Required: true, Description: "revision id"},
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/articles/{articleID}/blocks", RPCMethod: "content_getArticleBlockList", Tag: "content", Summary: "List the content blocks of the article, including the comments and the replies", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID,
+		{Name: "contentID", In: RESTParamInQuery, Type: RESTParamTypeString, Default: "", Description: "id of the starting article / comment / reply"},
+		{Name: "contentType", In: RESTParamInQuery, Type: RESTParamTypeInt, Default: int(content.ContentTypeArticle), Description: "0: article, 1: comment, 2: reply"},
+		{Name: "blockID", In: RESTParamInQuery, Type: RESTParamTypeUint32, Default: uint32(0)},
+		restParamLimit,
+		{Name: "order", In: RESTParamInQuery, Type: RESTParamTypeListOrder, Default: pttdb.ListOrderNext, Description: "prev / next (default)"},
+	}},
+
+	// content - comment / reply
+	{Method: "POST", Path: "/boards/{boardID}/articles/{articleID}/comments", RPCMethod: "content_createComment", Tag: "content", Summary: "Create a comment", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID,
+		{Name: "commentType", In: RESTParamInBody, Type: RESTParamTypeInt, Default: int(content.CommentTypePush), Description: "0: push, 1: boo, 2: none"},
+		{Name: "comment", In: RESTParamInBody, Type: RESTParamTypeBytes, Required: true},
+		restParamMediaID,
+	}},
+	{Method: "DELETE", Path: "/boards/{boardID}/articles/{articleID}/comments/{commentID}", RPCMethod: "content_deleteComment", Tag: "content", Summary: "Delete the comment", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID, restParamCommentID,
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/articles/{articleID}/comments/{commentID}/replies", RPCMethod: "content_getReplyList", Tag: "content", Summary: "List the replies of the comment", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID, restParamCommentID, restParamStart, restParamLimit,
+		{Name: "order", In: RESTParamInQuery, Type: RESTParamTypeListOrder, Default: pttdb.ListOrderNext, Description: "prev / next (default)"},
+	}},
+	{Method: "POST", Path: "/boards/{boardID}/articles/{articleID}/comments/{commentID}/replies", RPCMethod: "content_createReply", Tag: "content", Summary: "Create a reply to the comment", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID, restParamCommentID,
+		{Name: "parentID", In: RESTParamInBody, Type: RESTParamTypeString, Default: "", Description: "id of the replied reply"},
+		{Name: "reply", In: RESTParamInBody, Type: RESTParamTypeLines, Required: true, Description: "lines of the reply"},
+		restParamMediaID,
+	}},
+	{Method: "DELETE", Path: "/boards/{boardID}/articles/{articleID}/comments/{commentID}/replies/{replyID}", RPCMethod: "content_deleteReply", Tag: "content", Summary: "Delete the reply", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID, restParamCommentID, restParamReplyID,
+	}},
+
+	// content - upload
+	{Method: "POST", Path: "/boards/{boardID}/uploads", RPCMethod: "content_createFileUpload", Tag: "content", Summary: "Create the resumable upload of the large file, the chunks are uploaded by PUT /boards/{boardID}/uploads/{uploadID} with Content-Range", Params: []*RESTParam{
+		restParamBoardID,
+		{Name: "filename", In: RESTParamInBody, Type: RESTParamTypeString, Required: true},
+		{Name: "size", In: RESTParamInBody, Type: RESTParamTypeInt, Required: true, Description: "size of the file in bytes"},
+	}},
+	{Method: "POST", Path: "/boards/{boardID}/uploads/audio", RPCMethod: "content_createAudioUpload", Tag: "content", Summary: "Create the resumable upload of the audio, played while being synced by the peers", Params: []*RESTParam{
+		restParamBoardID,
+		{Name: "filename", In: RESTParamInBody, Type: RESTParamTypeString, Required: true},
+		{Name: "size", In: RESTParamInBody, Type: RESTParamTypeInt, Required: true, Description: "size of the audio in bytes"},
+		{Name: "contentType", In: RESTParamInBody, Type: RESTParamTypeString, Required: true, Description: "audio/*"},
+		{Name: "codec", In: RESTParamInBody, Type: RESTParamTypeString, Default: ""},
+		{Name: "duration", In: RESTParamInBody, Type: RESTParamTypeInt, Default: 0, Description: "duration in milliseconds"},
+	}},
+	{Method: "POST", Path: "/boards/{boardID}/uploads/video", RPCMethod: "content_createVideoUpload", Tag: "content", Summary: "Create the resumable upload of the video, played while being synced by the peers", Params: []*RESTParam{
+		restParamBoardID,
+		{Name: "filename", In: RESTParamInBody, Type: RESTParamTypeString, Required: true},
+		{Name: "size", In: RESTParamInBody, Type: RESTParamTypeInt, Required: true, Description: "size of the video in bytes"},
+		{Name: "contentType", In: RESTParamInBody, Type: RESTParamTypeString, Required: true, Description: "video/*"},
+		{Name: "codec", In: RESTParamInBody, Type: RESTParamTypeString, Default: ""},
+		{Name: "audioCodec", In: RESTParamInBody, Type: RESTParamTypeString, Default: ""},
+		{Name: "duration", In: RESTParamInBody, Type: RESTParamTypeInt, Default: 0, Description: "duration in milliseconds"},
+		{Name: "width", In: RESTParamInBody, Type: RESTParamTypeInt, Default: 0},
+		{Name: "height", In: RESTParamInBody, Type: RESTParamTypeInt, Default: 0},
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/uploads/{uploadID}", RPCMethod: "content_getFileUpload", Tag: "content", Summary: "Get the resumable upload (the current offset)", Params: []*RESTParam{
+		restParamBoardID, restParamUploadID,
+	}},
+	{Method: "DELETE", Path: "/boards/{boardID}/uploads/{uploadID}", RPCMethod: "content_deleteFileUpload", Tag: "content", Summary: "Abort the resumable upload", Params: []*RESTParam{
+		restParamBoardID, restParamUploadID,
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/files/{mediaID}", RPCMethod: "content_getFileInfo", Tag: "content", Summary: "Get the info (type / size) of the file, the bytes are served by /api/file/{boardID}/{mediaID} with Range", Params: []*RESTParam{
+		restParamBoardID,
+		{Name: "mediaID", In: RESTParamInPath, Type: RESTParamTypeString, Required: true},
+	}},
+
+	// content - moderation
+	{Method: "GET", Path: "/boards/{boardID}/bans", RPCMethod: "content_getBanList", Tag: "content", Summary: "List the bans and the mutes of the board", Params: []*RESTParam{
+		restParamBoardID,
+	}},
+	{Method: "PUT", Path: "/boards/{boardID}/bans/{userID}", RPCMethod: "content_banMember", Tag: "content", Summary: "Ban the user from the board", Params: []*RESTParam{
+		restParamBoardID, restParamUserID,
+	}},
+	{Method: "DELETE", Path: "/boards/{boardID}/bans/{userID}", RPCMethod: "content_liftBan", Tag: "content", Summary: "Lift the ban and the mute of the user", Params: []*RESTParam{
+		restParamBoardID, restParamUserID,
+	}},
+	{Method: "PUT", Path: "/boards/{boardID}/mutes/{userID}", RPCMethod: "content_muteMember", Tag: "content", Summary: "Mute the user on the board", Params: []*RESTParam{
+		restParamBoardID, restParamUserID,
+		{Name: "seconds", In: RESTParamInBody, Type: RESTParamTypeInt, Required: true, Description: "duration of the mute"},
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/post-interval", RPCMethod: "content_getBoardPostInterval", Tag: "content", Summary: "Get the min intervals between the posts of each member", Params: []*RESTParam{
+		restParamBoardID,
+	}},
+	{Method: "PUT", Path: "/boards/{boardID}/post-interval", RPCMethod: "content_setBoardPostInterval", Tag: "content", Summary: "Set the min intervals between the posts of each member", Params: []*RESTParam{
+		restParamBoardID,
+		{Name: "articleSeconds", In: RESTParamInBody, Type: RESTParamTypeInt, Default: 0, Description: "min interval between the articles (0 as not limited)"},
+		{Name: "commentSeconds", In: RESTParamInBody, Type: RESTParamTypeInt, Default: 0, Description: "min interval between the comments / replies (0 as not limited)"},
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/moderators", RPCMethod: "content_getModeratorList", Tag: "content", Summary: "List the moderators of the board", Params: []*RESTParam{
+		restParamBoardID,
+	}},
+	{Method: "PUT", Path: "/boards/{boardID}/moderators/{userID}", RPCMethod: "content_addModerator", Tag: "content", Summary: "Grant the moderator perms to the member", Params: []*RESTParam{
+		restParamBoardID, restParamUserID,
+		{Name: "perms", In: RESTParamInBody, Type: RESTParamTypeUint32, Description: "1: delete article, 2: delete comment, 4: mute, 8: pin (bitwise-or), all if not specified"},
+	}},
+	{Method: "DELETE", Path: "/boards/{boardID}/moderators/{userID}", RPCMethod: "content_removeModerator", Tag: "content", Summary: "Remove the moderator perms of the member", Params: []*RESTParam{
+		restParamBoardID, restParamUserID,
+	}},
+	{Method: "GET", Path: "/boards/{boardID}/pins", RPCMethod: "content_getPinnedArticleList", Tag: "content", Summary: "List the pinned articles of the board", Params: []*RESTParam{
+		restParamBoardID,
+	}},
+	{Method: "PUT", Path: "/boards/{boardID}/pins/{articleID}", RPCMethod: "content_pinArticle", Tag: "content", Summary: "Pin the article on the board", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID,
+		{Name: "order", In: RESTParamInBody, Type: RESTParamTypeInt, Default: 0, Description: "order of the pinned article (ascending)"},
+	}},
+	{Method: "DELETE", Path: "/boards/{boardID}/pins/{articleID}", RPCMethod: "content_unpinArticle", Tag: "content", Summary: "Unpin the article", Params: []*RESTParam{
+		restParamBoardID, restParamArticleID,
+	}},
+
+	// friend
+	{Method: "GET", Path: "/friends", RPCMethod: "friend_getFriendList", Tag: "friend", Summary: "List the friends", Params: []*RESTParam{
+		restParamStart, restParamLimit,
+	}},
+	{Method: "GET", Path: "/friends/{friendID}", RPCMethod: "friend_getFriend", Tag: "friend", Summary: "Get the friend", Params: []*RESTParam{
+		restParamFriendID,
+	}},
+	{Method: "DELETE", Path: "/friends/{friendID}", RPCMethod: "friend_deleteFriend", Tag: "friend", Summary: "Delete the friend", Params: []*RESTParam{
+		restParamFriendID,
+	}},
+	{Method: "GET", Path: "/friends/{friendID}/messages", RPCMethod: "friend_getMessageList", Tag: "friend", Summary: "List the messages", Params: []*RESTParam{
+		restParamFriendID, restParamStart, restParamLimit, restParamOrder,
+	}},
+	{Method: "POST", Path: "/friends/{friendID}/messages", RPCMethod: "friend_createMessage", Tag: "friend", Summary: "Send a message", Params: []*RESTParam{
+		restParamFriendID,
+		{Name: "message", In: RESTParamInBody, Type: RESTParamTypeLines, Required: true, Description: "lines of the message"},
+		restParamMediaIDs,
+	}},
+	{Method: "PUT", Path: "/friends/{friendID}/messages/{messageID}", RPCMethod: "friend_updateMessage", Tag: "friend", Summary: "Edit the message", Params: []*RESTParam{
+		restParamFriendID, restParamMessageID,
+		{Name: "message", In: RESTParamInBody, Type: RESTParamTypeLines, Required: true, Description: "lines of the message"},
+		restParamMediaIDs,
+	}},
+	{Method: "DELETE", Path: "/friends/{friendID}/messages/{messageID}", RPCMethod: "friend_deleteMessage", Tag: "friend", Summary: "Delete the message", Params: []*RESTParam{
+		restParamFriendID, restParamMessageID,
+	}},
+	{Method: "GET", Path: "/friends/{friendID}/messages/{messageID}/blocks", RPCMethod: "friend_getMessageBlockList", Tag: "friend", Summary: "Get the content blocks of the message", Params: []*RESTParam{
+		restParamFriendID, restParamMessageID,
+		{Name: "dummy0", In: RESTParamInConst, Type: RESTParamTypeString, Default: ""},
+		{Name: "dummy1", In: RESTParamInConst, Type: RESTParamTypeInt, Default: 0},
+		{Name: "dummy2", In: RESTParamInConst, Type: RESTParamTypeUint32, Default: uint32(0)},
+		{Name: "limit", In: RESTParamInQuery, Type: RESTParamTypeUint32, Default: uint32(RESTDefaultLimit)},
+	}},
+}
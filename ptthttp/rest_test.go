@@ -0,0 +1,259 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package ptthttp
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ailabstw/go-pttai/node"
+	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/gorilla/mux"
+)
+
+func TestRESTRoutes(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	rePathVar := regexp.MustCompile(`{([^}]+)}`)
+
+	// run test
+	routeKeys := make(map[string]bool)
+	for _, route := range restRoutes {
+		name := route.Method + " " + route.Path
+		t.Run(name, func(t *testing.T) {
+			if routeKeys[name] {
+				t.Errorf("duplicated route")
+			}
+			routeKeys[name] = true
+
+			switch route.Method {
+			case "GET", "POST", "PUT", "DELETE":
+			default:
+				t.Errorf("invalid method = %v", route.Method)
+			}
+
+			// the rest-routes are authorized with the scope of the rpc-method.
+			if _, ok := node.AuthMethodScopes[route.RPCMethod]; !ok {
+				t.Errorf("rpc-method not in AuthMethodScopes = %v", route.RPCMethod)
+			}
+
+			pathVars := make(map[string]bool)
+			for _, match := range rePathVar.FindAllStringSubmatch(route.Path, -1) {
+				pathVars[match[1]] = true
+			}
+
+			for _, param := range route.Params {
+				switch param.In {
+				case RESTParamInPath:
+					if !pathVars[param.Name] {
+						t.Errorf("path param not in path = %v", param.Name)
+					}
+					delete(pathVars, param.Name)
+				case RESTParamInBody:
+					if route.Method != "POST" && route.Method != "PUT" {
+						t.Errorf("body param in %v = %v", route.Method, param.Name)
+					}
+				}
+			}
+
+			for name := range pathVars {
+				t.Errorf("path var without param = %v", name)
+			}
+		})
+	}
+}
+
+func TestRestArgs(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	route := &RESTRoute{Method: "POST", Path: "/boards/{boardID}/articles", RPCMethod: "content_createArticle", Params: []*RESTParam{
+		restParamBoardID,
+		{Name: "title", In: RESTParamInBody, Type: RESTParamTypeBytes, Required: true},
+		{Name: "article", In: RESTParamInBody, Type: RESTParamTypeLines, Required: true},
+		restParamMediaIDs,
+		restParamLimit,
+		restParamOrder,
+	}}
+
+	// prepare test-cases
+	tests := []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+		want        []interface{}
+		wantErr     bool
+		wantErrIs   error
+	}{
+		{
+			name:        "json",
+			contentType: "application/json",
+			body:        `{"title": "t", "article": ["a", "b"]}`,
+			want:        []interface{}{"board", []byte("t"), [][]byte{[]byte("a"), []byte("b")}, []string{}, RESTDefaultLimit, pttdb.ListOrderPrev},
+		},
+		{
+			name:        "json-charset-query",
+			query:       "?limit=3&order=next",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"title": "t", "article": [], "mediaIDs": ["m"]}`,
+			want:        []interface{}{"board", []byte("t"), [][]byte{}, []string{"m"}, 3, pttdb.ListOrderNext},
+		},
+		{
+			name:        "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        `{"title": "t", "article": []}`,
+			wantErr:     true,
+			wantErrIs:   ErrInvalidRESTContentType,
+		},
+		{
+			name:      "no-content-type",
+			body:      `{"title": "t", "article": []}`,
+			wantErr:   true,
+			wantErrIs: ErrInvalidRESTContentType,
+		},
+		{
+			name:        "invalid-json",
+			contentType: "application/json",
+			body:        `{"title": `,
+			wantErr:     true,
+			wantErrIs:   ErrInvalidRESTBody,
+		},
+		{
+			name:        "missing-required",
+			contentType: "application/json",
+			body:        `{"title": "t"}`,
+			wantErr:     true,
+		},
+		{
+			name:        "invalid-type",
+			contentType: "application/json",
+			body:        `{"title": "t", "article": "a"}`,
+			wantErr:     true,
+		},
+		{
+			name:        "invalid-order",
+			query:       "?order=random",
+			contentType: "application/json",
+			body:        `{"title": "t", "article": []}`,
+			wantErr:     true,
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", RESTPrefix+"/boards/board/articles"+tt.query, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			r = mux.SetURLVars(r, map[string]string{"boardID": "board"})
+
+			got, err := restArgs(route, r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("restArgs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIs != nil && err != tt.wantErrIs {
+				t.Errorf("restArgs() error = %v, wantErrIs %v", err, tt.wantErrIs)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("restArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetRESTHeaders(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	// prepare test-cases
+	tests := []struct {
+		name           string
+		origin         string
+		allowedOrigins []string
+		want           string
+	}{
+		{name: "allowed", origin: "http://localhost:9774", allowedOrigins: []string{"http://localhost:9774"}, want: "http://localhost:9774"},
+		{name: "wildcard", origin: "http://example.com", allowedOrigins: []string{"*"}, want: "http://example.com"},
+		{name: "not-allowed", origin: "http://example.com", allowedOrigins: []string{"http://localhost:9774"}},
+		{name: "no-cors", origin: "http://example.com"},
+		{name: "no-origin", allowedOrigins: []string{"*"}},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("OPTIONS", RESTPrefix+"/me", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+
+			setRESTHeaders(w, r, tt.allowedOrigins)
+
+			got := w.Header().Get("Access-Control-Allow-Origin")
+			if got != tt.want {
+				t.Errorf("setRESTHeaders() origin = %v, want %v", got, tt.want)
+			}
+			isCredentials := w.Header().Get("Access-Control-Allow-Credentials") == "true"
+			if isCredentials != (tt.want != "") {
+				t.Errorf("setRESTHeaders() credentials = %v, want %v", isCredentials, tt.want != "")
+			}
+		})
+	}
+}
+
+func TestIsAllowedHost(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	// prepare test-cases
+	tests := []struct {
+		name   string
+		host   string
+		vhosts []string
+		want   bool
+	}{
+		{name: "no-host", host: "", vhosts: []string{"localhost"}, want: true},
+		{name: "ip", host: "127.0.0.1:9774", vhosts: []string{"localhost"}, want: true},
+		{name: "vhost", host: "localhost:9774", vhosts: []string{"localhost"}, want: true},
+		{name: "vhost-no-port", host: "LocalHost", vhosts: []string{"localhost"}, want: true},
+		{name: "wildcard", host: "example.com", vhosts: []string{"*"}, want: true},
+		{name: "rebinding", host: "example.com:9774", vhosts: []string{"localhost"}, want: false},
+		{name: "no-vhosts", host: "localhost", want: false},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAllowedHost(tt.host, tt.vhosts); got != tt.want {
+				t.Errorf("isAllowedHost() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -41,6 +41,11 @@ type Server struct {
 	rpcServer *rpc.Server
 	rpcClient *rpc.Client
 	srv       *http.Server
+	auth      *node.Authenticator
+
+	// the cors / vhosts of the rpc-endpoint, also applied on the rest-routes.
+	cors   []string
+	vhosts []string
 }
 
 type MyDir http.Dir
@@ -93,10 +98,15 @@ func NewServer(dir string, addr string, rpcPort int, extAddr string, extRPCAddrS
 		rpcServer: rpcServer,
 		srv:       srv,
 		rpcClient: client,
+		auth:      node.Authenticator(),
+
+		cors:   node.Config.HTTPCors,
+		vhosts: node.Config.HTTPVirtualHosts,
 	}
 
 	fs := http.FileServer(MyDir(s.dir))
 	r := mux.NewRouter()
+	s.registerRESTRoutes(r)
 	r.HandleFunc("/api/upload/{boardID}", s.uploadHandler).
 		Methods("POST")
 	r.HandleFunc("/api/upload/{boardID}", s.optionHandler).
@@ -113,6 +123,10 @@ func NewServer(dir string, addr string, rpcPort int, extAddr string, extRPCAddrS
 		Methods("GET")
 	r.HandleFunc("/api/file/{boardID}/{mediaID}", s.optionHandler).
 		Methods("OPTIONS")
+	r.HandleFunc(RESTPrefix+"/boards/{boardID}/uploads/{uploadID}", s.uploadChunkHandler).
+		Methods("PUT")
+	r.HandleFunc("/feed/{boardID}.{format:atom|rss}", s.feedHandler).
+		Methods("GET")
 	r.HandleFunc("/static/js/{path:main.*js}", func(w http.ResponseWriter, r *http.Request) {
 		s.jsHandler(w, r, dir)
 	}).Methods("Get")
@@ -143,6 +157,9 @@ func (s *Server) SetRPCServer(n *node.Node) error {
 
 	s.rpcServer = rpcServer
 	s.rpcClient = client
+	s.auth = n.Authenticator()
+	s.cors = n.Config.HTTPCors
+	s.vhosts = n.Config.HTTPVirtualHosts
 
 	return nil
 }
@@ -155,10 +172,15 @@ func (s *Server) optionHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Access-Control-Allow-Origin", origin)
 	w.Header().Set("Access-Control-Allow-Credentials", "true")
 	w.Header().Set("Access-Control-Allow-Methods", "OPTIONS,GET,POST")
-	w.Header().Set("Access-Control-Allow-Headers", "X-CSRFToken")
+	w.Header().Set("Access-Control-Allow-Headers", "X-CSRFToken,Authorization,Range")
 }
 
 func (s *Server) uploadHandler(w http.ResponseWriter, r *http.Request) {
+	if statusCode, err := s.authorize(r, "content_uploadImage"); err != nil {
+		s.renderAuthError(w, r, err, statusCode)
+		return
+	}
+
 	vars := mux.Vars(r)
 	boardIDStr := vars["boardID"]
 
@@ -195,6 +217,11 @@ func (s *Server) uploadHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) uploadFileHandler(w http.ResponseWriter, r *http.Request) {
+	if statusCode, err := s.authorize(r, "content_uploadFile"); err != nil {
+		s.renderAuthError(w, r, err, statusCode)
+		return
+	}
+
 	vars := mux.Vars(r)
 	boardIDStr := vars["boardID"]
 	filename := r.FormValue("filename")
@@ -231,7 +258,7 @@ func (s *Server) uploadPreprocess(w http.ResponseWriter, r *http.Request) ([]byt
 	w.Header().Set("Access-Control-Allow-Origin", origin)
 	w.Header().Set("Access-Control-Allow-Credentials", "true")
 	w.Header().Set("Access-Control-Allow-Methods", "OPTIONS,GET,POST")
-	w.Header().Set("Access-Control-Allow-Headers", "X-CSRFToken")
+	w.Header().Set("Access-Control-Allow-Headers", "X-CSRFToken,Authorization")
 	w.Header().Set("Content-Type", "application/json")
 
 	r.Body = http.MaxBytesReader(w, r.Body, MaxUploadSize)
@@ -256,46 +283,48 @@ func (s *Server) uploadPreprocess(w http.ResponseWriter, r *http.Request) ([]byt
 }
 
 func (s *Server) imgHandler(w http.ResponseWriter, r *http.Request) {
+	if statusCode, err := s.authorize(r, "content_getFileInfo"); err != nil {
+		s.renderAuthError(w, r, err, statusCode)
+		return
+	}
+
 	origin := r.Header.Get("Origin")
 
 	w.Header().Set("Accept", "*")
 	w.Header().Set("Access-Control-Allow-Origin", origin)
 	w.Header().Set("Access-Control-Allow-Credentials", "true")
 	w.Header().Set("Access-Control-Allow-Methods", "OPTIONS,GET,POST")
-	w.Header().Set("Access-Control-Allow-Headers", "X-CSRFToken")
+	w.Header().Set("Access-Control-Allow-Headers", "X-CSRFToken,Authorization,Range")
 
 	vars := mux.Vars(r)
 	boardIDStr := vars["boardID"]
 	imgIDStr := vars["imgID"]
 
-	log.Debug("imgHandler: to backend", "boardIDStr", boardIDStr, "imgIDStr", imgIDStr)
+	size := r.URL.Query().Get("size")
 
-	backendGetImg := &content.BackendGetImg{}
-	err := s.rpcClient.Call(backendGetImg, "content_getImage", boardIDStr, imgIDStr)
-	if err != nil {
-		s.renderError(w, "UNABLE_TO_MARSHAL", http.StatusBadRequest)
+	log.Debug("imgHandler: to backend", "boardIDStr", boardIDStr, "imgIDStr", imgIDStr, "size", size)
+
+	if size == "" {
+		s.serveMedia(w, r, boardIDStr, imgIDStr, true)
 		return
 	}
 
-	switch backendGetImg.Type {
-	case pkgservice.MediaTypeJPEG:
-		w.Header().Set("Content-Type", "image/jpg")
-	case pkgservice.MediaTypePNG:
-		w.Header().Set("Content-Type", "image/png")
-	case pkgservice.MediaTypeGIF:
-		w.Header().Set("Content-Type", "image/gif")
-	}
-	w.Write(backendGetImg.Buf)
+	s.serveThumbnail(w, r, boardIDStr, imgIDStr, size)
 }
 
 func (s *Server) fileHandler(w http.ResponseWriter, r *http.Request) {
+	if statusCode, err := s.authorize(r, "content_getFileInfo"); err != nil {
+		s.renderAuthError(w, r, err, statusCode)
+		return
+	}
+
 	origin := r.Header.Get("Origin")
 
 	w.Header().Set("Accept", "*")
 	w.Header().Set("Access-Control-Allow-Origin", origin)
 	w.Header().Set("Access-Control-Allow-Credentials", "true")
 	w.Header().Set("Access-Control-Allow-Methods", "OPTIONS,GET,POST")
-	w.Header().Set("Access-Control-Allow-Headers", "X-CSRFToken")
+	w.Header().Set("Access-Control-Allow-Headers", "X-CSRFToken,Authorization,Range")
 
 	vars := mux.Vars(r)
 	boardIDStr := vars["boardID"]
@@ -303,25 +332,22 @@ func (s *Server) fileHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Debug("attachHandler: to backend", "boardIDStr", boardIDStr, "mediaIDStr", mediaIDStr)
 
-	backendGetFile := &content.BackendGetFile{}
-	err := s.rpcClient.Call(backendGetFile, "content_getFile", boardIDStr, mediaIDStr)
-	if err != nil {
-		s.renderError(w, "UNABLE_TO_MARSHAL", http.StatusBadRequest)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/octet-stream")
-	w.Write(backendGetFile.Buf)
+	s.serveMedia(w, r, boardIDStr, mediaIDStr, false)
 }
 
 func (s *Server) origImgHandler(w http.ResponseWriter, r *http.Request) {
+	if statusCode, err := s.authorize(r, "content_getOrigImage"); err != nil {
+		s.renderAuthError(w, r, err, statusCode)
+		return
+	}
+
 	origin := r.Header.Get("Origin")
 
 	w.Header().Set("Accept", "*")
 	w.Header().Set("Access-Control-Allow-Origin", origin)
 	w.Header().Set("Access-Control-Allow-Credentials", "true")
 	w.Header().Set("Access-Control-Allow-Methods", "OPTIONS,GET,POST")
-	w.Header().Set("Access-Control-Allow-Headers", "X-CSRFToken")
+	w.Header().Set("Access-Control-Allow-Headers", "X-CSRFToken,Authorization")
 
 	vars := mux.Vars(r)
 	boardIDStr := vars["boardID"]
@@ -372,6 +398,6 @@ func (s *Server) jsHandler(w http.ResponseWriter, r *http.Request, dir string) {
 }
 
 func (s *Server) renderError(w http.ResponseWriter, message string, statusCode int) {
-	w.WriteHeader(http.StatusBadRequest)
+	w.WriteHeader(statusCode)
 	w.Write([]byte(message))
 }
@@ -0,0 +1,104 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	authHeader       = "Authorization"
+	authBearerPrefix = "Bearer "
+	authQueryToken   = "token"
+
+	authTokenContextKey = "token"
+)
+
+// Authenticator authorizes the token of the request to call the method (namespace_method).
+type Authenticator interface {
+	Authorize(token string, method string) error
+}
+
+// SetAuthenticator sets the authenticator of the server.
+// All the method calls (including subscriptions) are authorized by the authenticator if it is set.
+func (s *Server) SetAuthenticator(auth Authenticator) {
+	s.authMu.Lock()
+	defer s.authMu.Unlock()
+
+	s.auth = auth
+}
+
+func (s *Server) authorize(ctx context.Context, req *serverRequest) Error {
+	s.authMu.RLock()
+	auth := s.auth
+	s.authMu.RUnlock()
+
+	if auth == nil {
+		return nil
+	}
+
+	token, _ := TokenFromContext(ctx)
+	method := req.svcname + serviceMethodSeparator + formatName(req.callb.method.Name)
+
+	err := auth.Authorize(token, method)
+	if err != nil {
+		return &unauthorizedError{err.Error()}
+	}
+
+	return nil
+}
+
+// TokenFromRequest gets the token from the "Authorization: Bearer" header,
+// or from the "token" query for the requests not able to set the header (websocket / img-src from browsers).
+func TokenFromRequest(r *http.Request) string {
+	header := r.Header.Get(authHeader)
+	if strings.HasPrefix(header, authBearerPrefix) {
+		return strings.TrimSpace(header[len(authBearerPrefix):])
+	}
+
+	return r.URL.Query().Get(authQueryToken)
+}
+
+func withToken(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, authTokenContextKey, TokenFromRequest(r))
+}
+
+// MethodNames returns the names of the rpc-methods (including subscriptions) provided by rcvr,
+// in the same format as the methods of the requests.
+func MethodNames(rcvr interface{}) []string {
+	callbacks, subscriptions := suitableCallbacks(reflect.ValueOf(rcvr), reflect.TypeOf(rcvr))
+
+	names := make([]string, 0, len(callbacks)+len(subscriptions))
+	for name := range callbacks {
+		names = append(names, name)
+	}
+	for name := range subscriptions {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// TokenFromContext returns the token of the request from the http / ws endpoints.
+// ok is false if the request is not from the http / ws endpoints (ipc / in-proc).
+func TokenFromContext(ctx context.Context) (token string, ok bool) {
+	token, ok = ctx.Value(authTokenContextKey).(string)
+	return
+}
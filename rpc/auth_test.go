@@ -0,0 +1,105 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type testAuthenticator struct{}
+
+func (a *testAuthenticator) Authorize(token string, method string) error {
+	if token == "valid" && method == "test_echo" {
+		return nil
+	}
+	return errors.New("unauthorized")
+}
+
+func TestServerAuthenticator(t *testing.T) {
+	server := NewServer()
+	if err := server.RegisterName("test", new(Service)); err != nil {
+		t.Fatal(err)
+	}
+	server.SetAuthenticator(&testAuthenticator{})
+	httpsrv := httptest.NewServer(server)
+	defer httpsrv.Close()
+
+	tests := []struct {
+		header   string
+		query    string
+		method   string
+		expected bool
+	}{
+		{"", "", "test_echo", false},
+		{"Bearer invalid", "", "test_echo", false},
+		{"Bearer valid", "", "test_echo", true},
+		{"", "?token=valid", "test_echo", true},
+		{"Bearer valid", "", "test_rets", false},
+	}
+
+	for i, test := range tests {
+		body := `{"jsonrpc":"2.0","id":1,"method":"` + test.method + `","params":["hello",1,{"S":"x"}]}`
+		if test.method != "test_echo" {
+			body = `{"jsonrpc":"2.0","id":1,"method":"` + test.method + `","params":[]}`
+		}
+		req, _ := http.NewRequest(http.MethodPost, httpsrv.URL+test.query, strings.NewReader(body))
+		req.Header.Set("content-type", contentType)
+		if test.header != "" {
+			req.Header.Set("Authorization", test.header)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var msg jsonrpcMessage
+		err = json.NewDecoder(resp.Body).Decode(&msg)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if (msg.Error == nil) != test.expected {
+			t.Errorf("test %d: expected authorized: %v, got error: %v", i, test.expected, msg.Error)
+		}
+		if msg.Error != nil && msg.Error.Code != -32001 {
+			t.Errorf("test %d: unexpected error code: %v", i, msg.Error.Code)
+		}
+	}
+}
+
+func TestMethodNames(t *testing.T) {
+	names := MethodNames(new(Service))
+	sort.Strings(names)
+
+	expected := []string{"echo", "echoWithCtx", "noArgsRets", "rets", "sleep", "subscription"}
+	if len(names) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+	for i, name := range names {
+		if name != expected[i] {
+			t.Errorf("expected %v, got %v", expected, names)
+		}
+	}
+}
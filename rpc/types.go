@@ -74,6 +74,9 @@ type Server struct {
 	run      int32
 	codecsMu sync.Mutex
 	codecs   *set.Set
+
+	authMu sync.RWMutex
+	auth   Authenticator
 }
 
 // rpcRequest represents a raw incoming RPC request
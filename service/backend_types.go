@@ -34,6 +34,7 @@ const (
 	PathJoinMe     = "/joinme"
 	PathJoinFriend = "/joinfriend"
 	PathJoinBoard  = "/joinboard"
+	PathJoinGroup  = "/joingroup"
 )
 
 type BackendCountPeers struct {
@@ -70,6 +70,78 @@ func GetBlockList(blockInfo *BlockInfo, limit uint32, isLocked bool) ([]*Block,
 	return blocks, nil
 }
 
+/*
+GetBlockListByRange gets the blocks in [startBlockID, startBlockID + nBlock) based on the information of block-info.
+
+Only the blocks in the range are required to be good (the media may be still in sync).
+*/
+func GetBlockListByRange(blockInfo *BlockInfo, startBlockID uint32, nBlock uint32) ([]*Block, error) {
+	uint32NBlock := uint32(blockInfo.NBlock)
+	if startBlockID >= uint32NBlock {
+		return nil, ErrInvalidBlock
+	}
+	endBlockID := startBlockID + nBlock
+	if endBlockID > uint32NBlock || endBlockID < startBlockID {
+		endBlockID = uint32NBlock
+	}
+
+	blocks := make([]*Block, 0, (endBlockID-startBlockID)*NSubBlock)
+	for blockID := startBlockID; blockID < endBlockID; blockID++ {
+		for subBlockID := uint8(0); subBlockID < NSubBlock; subBlockID++ {
+			block, err := getBlock(blockInfo, blockID, subBlockID)
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks, nil
+}
+
+func getBlock(blockInfo *BlockInfo, blockID uint32, subBlockID uint8) (*Block, error) {
+	if !isBlockGood(blockInfo, blockID, subBlockID) {
+		return nil, ErrMediaNotReady
+	}
+
+	block := NewEmptyBlock()
+	blockInfo.SetBlockDB(block)
+	block.BlockID = blockID
+	block.SubBlockID = subBlockID
+
+	key, err := block.MarshalKey()
+	if err != nil {
+		return nil, err
+	}
+	marshaled, err := block.db.DB().Get(key)
+	if err != nil {
+		return nil, ErrInvalidBlock
+	}
+	err = block.Unmarshal(marshaled)
+	if err != nil {
+		return nil, ErrInvalidBlock
+	}
+
+	return block, nil
+}
+
+/*
+isBlockGood is the same as GetIsGood, but without logging the not-yet-init IsGood (the object is still in sync).
+*/
+func isBlockGood(blockInfo *BlockInfo, blockID uint32, subBlockID uint8) bool {
+	if blockInfo.IsAllGood {
+		return true
+	}
+	if blockID >= uint32(blockInfo.NBlock) || subBlockID >= NSubBlock {
+		return false
+	}
+	if blockInfo.IsGood == nil || int(blockID) >= len(blockInfo.IsGood) {
+		return false
+	}
+
+	return bool(blockInfo.IsGood[blockID][subBlockID])
+}
+
 /*
 GetContentBlockList gets the block list based on the information of block-info.
 
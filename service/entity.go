@@ -92,9 +92,9 @@ type Entity interface {
 	Name() string
 	SetName(name string)
 
-	DB() *pttdb.LDBBatch
+	DB() pttdb.IndexedBatch
 	DBLock() *types.LockMap
-	SetDB(db *pttdb.LDBBatch, dbLock *types.LockMap)
+	SetDB(db pttdb.IndexedBatch, dbLock *types.LockMap)
 
 	MustLock() error
 	Lock() error
@@ -134,7 +134,7 @@ type BaseEntity struct {
 	ptt     Ptt
 	service Service
 
-	db     *pttdb.LDBBatch
+	db     pttdb.IndexedBatch
 	dbLock *types.LockMap
 
 	SyncInfo SyncInfo
@@ -142,7 +142,7 @@ type BaseEntity struct {
 	idString string
 }
 
-func NewBaseEntity(id *types.PttID, createTS types.Timestamp, creatorID *types.PttID, status types.Status, db *pttdb.LDBBatch, dbLock *types.LockMap) *BaseEntity {
+func NewBaseEntity(id *types.PttID, createTS types.Timestamp, creatorID *types.PttID, status types.Status, db pttdb.IndexedBatch, dbLock *types.LockMap) *BaseEntity {
 
 	e := &BaseEntity{
 		V:         types.CurrentVersion,
@@ -170,7 +170,7 @@ func (e *BaseEntity) Init(pm ProtocolManager, ptt Ptt, service Service) {
 	e.idString = "(" + e.ID.String() + "/" + service.Name() + ")"
 }
 
-func (e *BaseEntity) SetDB(db *pttdb.LDBBatch, dbLock *types.LockMap) {
+func (e *BaseEntity) SetDB(db pttdb.IndexedBatch, dbLock *types.LockMap) {
 	e.db = db
 	e.dbLock = dbLock
 }
@@ -361,7 +361,7 @@ func (e *BaseEntity) GetEntityType() EntityType {
 func (e *BaseEntity) SetEntityType(t EntityType) {
 	e.EntityType = t
 }
-func (e *BaseEntity) DB() *pttdb.LDBBatch {
+func (e *BaseEntity) DB() pttdb.IndexedBatch {
 	return e.db
 }
 
@@ -42,6 +42,20 @@ var (
 
 	ErrFileTooLarge = errors.New("file too large")
 
+	ErrInvalidMediaUploadOffset = errors.New("invalid offset of the media-upload")
+
+	ErrInvalidMediaRange = errors.New("invalid range of the media")
+
+	ErrInvalidMediaContentType = errors.New("invalid content-type of the media")
+
+	ErrInvalidMediaCodec = errors.New("invalid codec of the media")
+
+	ErrInvalidMediaDuration = errors.New("invalid duration of the media")
+
+	ErrMediaNotReady = errors.New("media not synced yet")
+
+	ErrInvalidImageSize = errors.New("invalid image size")
+
 	ErrInvalidMaster0 = errors.New("invalid master0")
 
 	ErrServiceUnknown = errors.New("service unknown")
@@ -51,6 +65,8 @@ var (
 
 	ErrNotSent = errors.New("not sent")
 
+	ErrRateLimited = errors.New("rate limited")
+
 	ErrInvalidData = errors.New("invalid data")
 
 	ErrInvalidObject = errors.New("invalid object")
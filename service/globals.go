@@ -229,19 +229,49 @@ var (
 	DBContentBlockPrefix = []byte(".bkdb")
 )
 
+// diff
+const (
+	MaxDiffLineCells = 4194304 // (n+1) * (m+1) of the lcs-table
+)
+
 // media
 const (
 	NByteInBlock = 65535
 
 	MaxUploadMediaSize = 10485760 // 10MB
 
+	MaxUploadStreamMediaSize = 524288000 // 500MB, through MediaUpload
+	MaxGetMediaRangeSize     = 4194304   // 4MB
+
 	MaxUploadImageWidth  = 8192
 	MaxUploadImageHeight = 8192
+
+	MaxMediaCodecLength         = 64
+	MediaContentTypeAudioPrefix = "audio/"
+	MediaContentTypeVideoPrefix = "video/"
+
+	// the blocks of the large media are synced by ranges,
+	// with the head and the last block first, so the playback can start before all the blocks are synced.
+	MediaSyncHeadNBlock  = 16 // ~1MB
+	MediaSyncRangeNBlock = 64 // ~4MB
+)
+
+// thumbnails, in the order of the sizes.
+var (
+	ThumbnailSizes = []ImageSize{ImageSizeSmall, ImageSizeMedium, ImageSizeLarge}
+
+	ThumbnailMaxEdges = map[ImageSize]int{
+		ImageSizeSmall:  160,
+		ImageSizeMedium: 480,
+		ImageSizeLarge:  1080,
+	}
 )
 
 var (
 	DBMediaPrefix    = []byte(".mddb")
 	DBMediaIdxPrefix = []byte(".mdix")
+
+	DBMediaUploadPrefix = []byte(".mdup")
 )
 
 // db
@@ -255,7 +285,7 @@ const (
 )
 
 var (
-	dbOplog     *pttdb.LDBBatch
+	dbOplog     pttdb.IndexedBatch
 	dbOplogCore *pttdb.LDBDatabase
 
 	dbMeta *pttdb.LDBDatabase
@@ -302,6 +332,21 @@ var (
 	ExpireGenerateOplogMerkleTreeSeconds int64 = 450               // 7.5 mins
 )
 
+// event
+const (
+	ObjEventChanSize      = 100
+	PttOplogEventChanSize = 100
+)
+
+// ephemeral
+var (
+	MinEphemeralSendInterval = 1 * time.Second
+	MinEphemeralRecvInterval = 500 * time.Millisecond
+
+	MaxRateLimiterKeys   = 1000
+	MaxRateLimiterEvents = 16
+)
+
 // dial-history
 var (
 	ExpireDialHistorySeconds int64 = 30
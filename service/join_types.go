@@ -33,6 +33,7 @@ const (
 	JoinTypeMe
 	JoinTypeFriend
 	JoinTypeBoard
+	JoinTypeGroup
 )
 
 // JoinStatus
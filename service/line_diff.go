@@ -0,0 +1,141 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import "bytes"
+
+type DiffOp uint8
+
+const (
+	DiffOpEqual DiffOp = iota
+	DiffOpDelete
+	DiffOpInsert
+)
+
+/*
+LineDiff is a line in the line-level diff.
+
+FromLine / ToLine are the 0-based line-numbers in the from / to lines (-1 if the line is not in the from / to lines).
+*/
+type LineDiff struct {
+	Op       DiffOp `json:"O"`
+	Line     []byte `json:"L"`
+	FromLine int    `json:"f"`
+	ToLine   int    `json:"t"`
+}
+
+/*
+ContentBlocksToLines concats the lines of the content-blocks.
+*/
+func ContentBlocksToLines(contentBlocks []*ContentBlock) [][]byte {
+	lines := make([][]byte, 0)
+	for _, contentBlock := range contentBlocks {
+		lines = append(lines, contentBlock.Buf...)
+	}
+
+	return lines
+}
+
+/*
+DiffLines returns the line-level diff from the from-lines to the to-lines, based on the longest-common-subsequence.
+
+The deleted lines are placed before the inserted lines in each changed hunk.
+If the lcs-table is too large (> MaxDiffLineCells), the lines between the common prefix and the common suffix
+are taken as all deleted and then all inserted.
+*/
+func DiffLines(from [][]byte, to [][]byte) []*LineDiff {
+	lenFrom, lenTo := len(from), len(to)
+
+	// common prefix / suffix
+	nPrefix := 0
+	for nPrefix < lenFrom && nPrefix < lenTo && bytes.Equal(from[nPrefix], to[nPrefix]) {
+		nPrefix++
+	}
+
+	nSuffix := 0
+	for nSuffix < lenFrom-nPrefix && nSuffix < lenTo-nPrefix && bytes.Equal(from[lenFrom-1-nSuffix], to[lenTo-1-nSuffix]) {
+		nSuffix++
+	}
+
+	diffs := make([]*LineDiff, 0, lenFrom+lenTo-nPrefix-nSuffix)
+	for i := 0; i < nPrefix; i++ {
+		diffs = append(diffs, &LineDiff{Op: DiffOpEqual, Line: to[i], FromLine: i, ToLine: i})
+	}
+
+	diffs = append(diffs, diffLinesCore(from[nPrefix:lenFrom-nSuffix], to[nPrefix:lenTo-nSuffix], nPrefix, nPrefix)...)
+
+	for i := nSuffix; i > 0; i-- {
+		diffs = append(diffs, &LineDiff{Op: DiffOpEqual, Line: to[lenTo-i], FromLine: lenFrom - i, ToLine: lenTo - i})
+	}
+
+	return diffs
+}
+
+func diffLinesCore(from [][]byte, to [][]byte, fromOffset int, toOffset int) []*LineDiff {
+	lenFrom, lenTo := len(from), len(to)
+
+	diffs := make([]*LineDiff, 0, lenFrom+lenTo)
+	if (lenFrom+1)*(lenTo+1) > MaxDiffLineCells {
+		for i, line := range from {
+			diffs = append(diffs, &LineDiff{Op: DiffOpDelete, Line: line, FromLine: fromOffset + i, ToLine: -1})
+		}
+		for j, line := range to {
+			diffs = append(diffs, &LineDiff{Op: DiffOpInsert, Line: line, FromLine: -1, ToLine: toOffset + j})
+		}
+		return diffs
+	}
+
+	// lcs[i][j]: the length of the lcs of from[i:] and to[j:]
+	width := lenTo + 1
+	lcs := make([]int32, (lenFrom+1)*width)
+	for i := lenFrom - 1; i >= 0; i-- {
+		for j := lenTo - 1; j >= 0; j-- {
+			switch {
+			case bytes.Equal(from[i], to[j]):
+				lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+			case lcs[(i+1)*width+j] >= lcs[i*width+j+1]:
+				lcs[i*width+j] = lcs[(i+1)*width+j]
+			default:
+				lcs[i*width+j] = lcs[i*width+j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < lenFrom && j < lenTo {
+		switch {
+		case bytes.Equal(from[i], to[j]):
+			diffs = append(diffs, &LineDiff{Op: DiffOpEqual, Line: to[j], FromLine: fromOffset + i, ToLine: toOffset + j})
+			i++
+			j++
+		case lcs[(i+1)*width+j] >= lcs[i*width+j+1]:
+			diffs = append(diffs, &LineDiff{Op: DiffOpDelete, Line: from[i], FromLine: fromOffset + i, ToLine: -1})
+			i++
+		default:
+			diffs = append(diffs, &LineDiff{Op: DiffOpInsert, Line: to[j], FromLine: -1, ToLine: toOffset + j})
+			j++
+		}
+	}
+	for ; i < lenFrom; i++ {
+		diffs = append(diffs, &LineDiff{Op: DiffOpDelete, Line: from[i], FromLine: fromOffset + i, ToLine: -1})
+	}
+	for ; j < lenTo; j++ {
+		diffs = append(diffs, &LineDiff{Op: DiffOpInsert, Line: to[j], FromLine: -1, ToLine: toOffset + j})
+	}
+
+	return diffs
+}
@@ -0,0 +1,103 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func toDiffLines(lines ...string) [][]byte {
+	theLines := make([][]byte, len(lines))
+	for i, line := range lines {
+		theLines[i] = []byte(line)
+	}
+	return theLines
+}
+
+func TestDiffLines(t *testing.T) {
+	// prepare test-cases
+	tests := []struct {
+		name string
+		from [][]byte
+		to   [][]byte
+		want []*LineDiff
+	}{
+		{
+			name: "same",
+			from: toDiffLines("a", "b"),
+			to:   toDiffLines("a", "b"),
+			want: []*LineDiff{
+				{Op: DiffOpEqual, Line: []byte("a"), FromLine: 0, ToLine: 0},
+				{Op: DiffOpEqual, Line: []byte("b"), FromLine: 1, ToLine: 1},
+			},
+		},
+		{
+			name: "empty from",
+			from: nil,
+			to:   toDiffLines("a"),
+			want: []*LineDiff{
+				{Op: DiffOpInsert, Line: []byte("a"), FromLine: -1, ToLine: 0},
+			},
+		},
+		{
+			name: "changed line",
+			from: toDiffLines("a", "b", "c"),
+			to:   toDiffLines("a", "x", "c"),
+			want: []*LineDiff{
+				{Op: DiffOpEqual, Line: []byte("a"), FromLine: 0, ToLine: 0},
+				{Op: DiffOpDelete, Line: []byte("b"), FromLine: 1, ToLine: -1},
+				{Op: DiffOpInsert, Line: []byte("x"), FromLine: -1, ToLine: 1},
+				{Op: DiffOpEqual, Line: []byte("c"), FromLine: 2, ToLine: 2},
+			},
+		},
+		{
+			name: "insert and delete in the middle",
+			from: toDiffLines("a", "b", "c", "d", "e"),
+			to:   toDiffLines("a", "c", "d", "y", "e"),
+			want: []*LineDiff{
+				{Op: DiffOpEqual, Line: []byte("a"), FromLine: 0, ToLine: 0},
+				{Op: DiffOpDelete, Line: []byte("b"), FromLine: 1, ToLine: -1},
+				{Op: DiffOpEqual, Line: []byte("c"), FromLine: 2, ToLine: 1},
+				{Op: DiffOpEqual, Line: []byte("d"), FromLine: 3, ToLine: 2},
+				{Op: DiffOpInsert, Line: []byte("y"), FromLine: -1, ToLine: 3},
+				{Op: DiffOpEqual, Line: []byte("e"), FromLine: 4, ToLine: 4},
+			},
+		},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffLines(tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffLines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentBlocksToLines(t *testing.T) {
+	contentBlocks := []*ContentBlock{
+		NewContentBlock(0, toDiffLines("a", "b")),
+		NewContentBlock(1, toDiffLines("c")),
+	}
+
+	want := toDiffLines("a", "b", "c")
+	if got := ContentBlocksToLines(contentBlocks); !reflect.DeepEqual(got, want) {
+		t.Errorf("ContentBlocksToLines() = %v, want %v", got, want)
+	}
+}
@@ -27,7 +27,7 @@ type LRUCount struct {
 	Full     types.Bool `json:"f"`
 }
 
-func NewLRUCount(maxCount uint64, db *pttdb.LDBBatch, dbPrefixID *types.PttID, dbID *types.PttID, dbPrefix []byte, p uint, isNewBits bool) (*LRUCount, error) {
+func NewLRUCount(maxCount uint64, db pttdb.IndexedBatch, dbPrefixID *types.PttID, dbID *types.PttID, dbPrefix []byte, p uint, isNewBits bool) (*LRUCount, error) {
 	count, err := NewCount(db, dbPrefixID, dbID, dbPrefix, p, isNewBits)
 	if err != nil {
 		return nil, err
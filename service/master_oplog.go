@@ -29,7 +29,7 @@ func (o *MasterOplog) GetBaseOplog() *BaseOplog {
 	return o.BaseOplog
 }
 
-func NewMasterOplog(keyID *types.PttID, ts types.Timestamp, doerID *types.PttID, op OpType, opData OpData, db *pttdb.LDBBatch, entityID *types.PttID, dbLock *types.LockMap) (*MasterOplog, error) {
+func NewMasterOplog(keyID *types.PttID, ts types.Timestamp, doerID *types.PttID, op OpType, opData OpData, db pttdb.IndexedBatch, entityID *types.PttID, dbLock *types.LockMap) (*MasterOplog, error) {
 
 	oplog, err := NewOplog(keyID, ts, doerID, op, opData, db, entityID, DBMasterOplogPrefix, DBMasterIdxOplogPrefix, nil, dbLock)
 	if err != nil {
@@ -298,3 +298,218 @@ func (m *Media) GetBuf() error {
 
 	return nil
 }
+
+/*
+GetContentType gets the content-type of the media.
+The content-type of the audio / video is from the media-data.
+*/
+func (m *Media) GetContentType() string {
+	switch m.MediaType {
+	case MediaTypeJPEG:
+		return "image/jpeg"
+	case MediaTypePNG:
+		return "image/png"
+	case MediaTypeGIF:
+		return "image/gif"
+	case MediaTypeAudio, MediaTypeVideo:
+		data := &MediaDataVideo{} // ContentType of MediaDataAudio is the same as MediaDataVideo.
+		marshaled, err := json.Marshal(m.MediaData)
+		if err != nil {
+			break
+		}
+		err = json.Unmarshal(marshaled, data)
+		if err != nil {
+			break
+		}
+		return data.ContentType
+	}
+
+	return "application/octet-stream"
+}
+
+/*
+GetThumbnailID gets the id of the thumbnail of the image with the size.
+nil if the size is ImageSizeOrig or the image is not larger than the size (the original image is used).
+*/
+func (m *Media) GetThumbnailID(size ImageSize) (*types.PttID, error) {
+	if !size.IsValid() {
+		return nil, ErrInvalidImageSize
+	}
+	if size == ImageSizeOrig {
+		return nil, nil
+	}
+
+	switch m.MediaType {
+	case MediaTypeJPEG, MediaTypeGIF:
+	default:
+		return nil, nil
+	}
+
+	data := &MediaDataJPEG{} // Thumbnails of MediaDataGIF is the same as MediaDataJPEG.
+	marshaled, err := json.Marshal(m.MediaData)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(marshaled, data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, thumbnail := range data.Thumbnails {
+		if thumbnail.Size == size {
+			return thumbnail.ID, nil
+		}
+	}
+
+	return nil, nil
+}
+
+/*
+GetSize gets the size of the media from the block-info and the last block,
+without loading all the blocks.
+(All the blocks are with NByteInBlock bytes except the last one.)
+
+The last block is synced first for the large media, so the size is available while the media is still in sync.
+*/
+func (m *Media) GetSize() (int64, error) {
+	blockInfo, err := m.getBlockInfoWithDB()
+	if err != nil {
+		return 0, err
+	}
+	if blockInfo.NBlock == 0 {
+		return 0, nil
+	}
+
+	lastBuf, err := getMediaBlockBuf(blockInfo, uint32(blockInfo.NBlock-1))
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(blockInfo.NBlock-1)*NByteInBlock + int64(len(lastBuf)), nil
+}
+
+/*
+GetReadySize gets the number of the bytes from the beginning of the media which are already synced,
+so the playback of the audio / video can start before all the blocks are synced.
+*/
+func (m *Media) GetReadySize(size int64) (int64, error) {
+	blockInfo, err := m.getBlockInfoWithDB()
+	if err != nil {
+		return 0, err
+	}
+	if blockInfo.GetIsAllGood() {
+		return size, nil
+	}
+
+	nBlock := 0
+	for ; nBlock < blockInfo.NBlock; nBlock++ {
+		if !isMediaBlockGood(blockInfo, uint32(nBlock)) {
+			break
+		}
+	}
+
+	readySize := int64(nBlock) * NByteInBlock
+	if readySize > size {
+		readySize = size
+	}
+
+	return readySize, nil
+}
+
+/*
+GetBufRange gets the bytes in [start, end) of the media, by loading only the needed blocks.
+
+ErrMediaNotReady if the needed blocks are not synced yet.
+*/
+func (m *Media) GetBufRange(start int64, end int64) ([]byte, error) {
+	if start < 0 || end < start || end-start > MaxGetMediaRangeSize {
+		return nil, ErrInvalidMediaRange
+	}
+
+	blockInfo, err := m.getBlockInfoWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	if start == end {
+		return []byte{}, nil
+	}
+
+	startBlockID := uint32(start / NByteInBlock)
+	endBlockID := uint32((end - 1) / NByteInBlock)
+	if endBlockID >= uint32(blockInfo.NBlock) {
+		// the last block may be with NByteInBlock + 1 bytes.
+		endBlockID = uint32(blockInfo.NBlock - 1)
+	}
+	if startBlockID > endBlockID {
+		return nil, ErrInvalidMediaRange
+	}
+
+	bufs := make([][]byte, 0, endBlockID-startBlockID+1)
+	for blockID := startBlockID; blockID <= endBlockID; blockID++ {
+		buf, err := getMediaBlockBuf(blockInfo, blockID)
+		if err != nil {
+			return nil, err
+		}
+		bufs = append(bufs, buf)
+	}
+
+	buf, err := common.Concat(bufs)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := start - int64(startBlockID)*NByteInBlock
+	if offset+end-start > int64(len(buf)) {
+		return nil, ErrInvalidMediaRange
+	}
+
+	return buf[offset : offset+end-start], nil
+}
+
+func (m *Media) getBlockInfoWithDB() (*BlockInfo, error) {
+	blockInfo := m.GetBlockInfo()
+	if blockInfo == nil {
+		return nil, ErrInvalidBlock
+	}
+	setBlockInfoDB := m.SetBlockInfoDB()
+	setBlockInfoDB(blockInfo, m.ID)
+
+	return blockInfo, nil
+}
+
+func isMediaBlockGood(blockInfo *BlockInfo, blockID uint32) bool {
+	for subBlockID := uint8(0); subBlockID < NSubBlock; subBlockID++ {
+		if !isBlockGood(blockInfo, blockID, subBlockID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+getMediaBlockBuf gets the sub-blocks of the block and unscrambles the buf of the block.
+*/
+func getMediaBlockBuf(blockInfo *BlockInfo, blockID uint32) ([]byte, error) {
+	if !isMediaBlockGood(blockInfo, blockID) {
+		return nil, ErrMediaNotReady
+	}
+
+	bufs := make([][]byte, NSubBlock)
+	for subBlockID := 0; subBlockID < NSubBlock; subBlockID++ {
+		block, err := getBlock(blockInfo, blockID, uint8(subBlockID))
+		if err != nil {
+			return nil, err
+		}
+
+		bufs[subBlockID] = block.Buf
+	}
+
+	unscrambledBufs, err := UnscrambleBuf(bufs)
+	if err != nil {
+		return nil, ErrInvalidBlock
+	}
+
+	return common.Concat(unscrambledBufs)
+}
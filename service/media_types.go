@@ -16,6 +16,8 @@
 
 package service
 
+import "github.com/ailabstw/go-pttai/common/types"
+
 type MediaType uint8
 
 const (
@@ -23,13 +25,24 @@ const (
 	MediaTypeGIF
 	MediaTypePNG
 	MediaTypeFile
+	MediaTypeAudio
+	MediaTypeVideo
 )
 
+/*
+IsStream is whether the media is played while being synced (audio / video).
+*/
+func (t MediaType) IsStream() bool {
+	return t == MediaTypeAudio || t == MediaTypeVideo
+}
+
 type MediaData interface{}
 
 type MediaDataJPEG struct {
 	Width  uint16 `json:"W"`
 	Height uint16 `json:"H"`
+
+	Thumbnails []*MediaThumbnail `json:"t,omitempty"`
 }
 
 type MediaDataPNG struct {
@@ -40,8 +53,68 @@ type MediaDataPNG struct {
 type MediaDataGIF struct {
 	Width  uint16 `json:"W"`
 	Height uint16 `json:"H"`
+
+	Thumbnails []*MediaThumbnail `json:"t,omitempty"`
+}
+
+/*
+ImageSize is the fixed size of the thumbnails of the image (ImageSizeOrig as the original image).
+*/
+type ImageSize string
+
+const (
+	ImageSizeOrig   ImageSize = ""
+	ImageSizeSmall  ImageSize = "small"
+	ImageSizeMedium ImageSize = "medium"
+	ImageSizeLarge  ImageSize = "large"
+)
+
+func (s ImageSize) IsValid() bool {
+	if s == ImageSizeOrig {
+		return true
+	}
+
+	_, ok := ThumbnailMaxEdges[s]
+	return ok
+}
+
+/*
+MediaThumbnail is the thumbnail of the image, which is another (jpeg) media created before the image.
+*/
+type MediaThumbnail struct {
+	Size   ImageSize    `json:"s"`
+	ID     *types.PttID `json:"ID"`
+	Width  uint16       `json:"W"`
+	Height uint16       `json:"H"`
 }
 
 type MediaDataFile struct {
 	Filename []byte `json:"f"`
 }
+
+/*
+MediaDataAudio is the meta-data of the audio, provided by the uploader.
+Duration is in milliseconds.
+*/
+type MediaDataAudio struct {
+	Filename    []byte `json:"f"`
+	ContentType string `json:"t"`
+	Codec       string `json:"c"`
+	Duration    int64  `json:"d"`
+	Size        int64  `json:"S"`
+}
+
+/*
+MediaDataVideo is the meta-data of the video, provided by the uploader.
+Duration is in milliseconds.
+*/
+type MediaDataVideo struct {
+	Filename    []byte `json:"f"`
+	ContentType string `json:"t"`
+	Codec       string `json:"c"`
+	AudioCodec  string `json:"a,omitempty"`
+	Duration    int64  `json:"d"`
+	Width       uint16 `json:"W"`
+	Height      uint16 `json:"H"`
+	Size        int64  `json:"S"`
+}
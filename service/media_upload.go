@@ -0,0 +1,130 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+/*
+MediaUpload is the resumable upload of the large media.
+
+The bytes are appended by chunks (in order), and are saved as the media-blocks of ObjID / BlockInfoID
+as soon as the blocks are full, so only Tail (at most NByteInBlock + 1 bytes) is kept in the upload.
+The media is created with ObjID and the hashs of the saved blocks after all the bytes are uploaded.
+*/
+type MediaUpload struct {
+	V           types.Version
+	ID          *types.PttID    `json:"ID"`
+	EntityID    *types.PttID    `json:"e"`
+	ObjID       *types.PttID    `json:"o"`
+	BlockInfoID *types.PttID    `json:"b"`
+	CreateTS    types.Timestamp `json:"CT"`
+	UpdateTS    types.Timestamp `json:"UT"`
+
+	Filename  []byte    `json:"f,omitempty"`
+	Size      int64     `json:"s"`
+	Offset    int64     `json:"O"`
+	MediaType MediaType `json:"M"`
+	MediaData MediaData `json:"D,omitempty"`
+
+	Hashs [][][]byte `json:"H,omitempty"`
+	Tail  []byte     `json:"T,omitempty"`
+}
+
+func NewMediaUpload(entityID *types.PttID, filename []byte, size int64, mediaType MediaType, mediaData MediaData) (*MediaUpload, error) {
+	if size <= 0 {
+		return nil, ErrNegativeSize
+	}
+	if size > MaxUploadStreamMediaSize {
+		return nil, ErrFileTooLarge
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := types.NewPttID()
+	if err != nil {
+		return nil, err
+	}
+
+	objID, err := types.NewPttID()
+	if err != nil {
+		return nil, err
+	}
+
+	blockInfoID, err := types.NewPttID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &MediaUpload{
+		V:           types.CurrentVersion,
+		ID:          id,
+		EntityID:    entityID,
+		ObjID:       objID,
+		BlockInfoID: blockInfoID,
+		CreateTS:    ts,
+		UpdateTS:    ts,
+
+		Filename:  filename,
+		Size:      size,
+		MediaType: mediaType,
+		MediaData: mediaData,
+	}, nil
+}
+
+func (u *MediaUpload) IsComplete() bool {
+	return u.Offset == u.Size && len(u.Tail) == 0
+}
+
+func (u *MediaUpload) MarshalKey() ([]byte, error) {
+	return common.Concat([][]byte{DBMediaUploadPrefix, u.EntityID[:], u.ID[:]})
+}
+
+func (u *MediaUpload) Marshal() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+func (u *MediaUpload) Unmarshal(theBytes []byte) error {
+	return json.Unmarshal(theBytes, u)
+}
+
+/*
+splitMediaUploadTail splits the full blocks from the tail.
+The blocks are the same as splitMediaBuf with the whole buf:
+the block is not split until there are more than NByteInBlock + 1 bytes (unless it's the last),
+to squeeze the last-char to the last block.
+*/
+func splitMediaUploadTail(tail []byte, isLast bool) ([][]byte, []byte) {
+	if isLast {
+		return splitMediaBuf(tail), nil
+	}
+
+	bufs := make([][]byte, 0, len(tail)/NByteInBlock)
+	for len(tail) > NByteInBlock+1 {
+		bufs = append(bufs, tail[:NByteInBlock])
+		tail = tail[NByteInBlock:]
+	}
+
+	return bufs, tail
+}
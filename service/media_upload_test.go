@@ -0,0 +1,77 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSplitMediaUploadTail(t *testing.T) {
+	// prepare test-cases
+	tests := []struct {
+		name      string
+		size      int
+		chunkSize int
+	}{
+		{name: "1 byte", size: 1, chunkSize: 1},
+		{name: "1 block", size: NByteInBlock, chunkSize: 1000},
+		{name: "1 block + 1 byte", size: NByteInBlock + 1, chunkSize: 1000},
+		{name: "1 block + 2 bytes", size: NByteInBlock + 2, chunkSize: NByteInBlock + 1},
+		{name: "3 blocks + 1 byte by 1 chunk", size: 3*NByteInBlock + 1, chunkSize: 3*NByteInBlock + 1},
+		{name: "5 blocks + 7 bytes by small chunks", size: 5*NByteInBlock + 7, chunkSize: 4097},
+		{name: "2 blocks + 1 byte by block-size chunks", size: 2*NByteInBlock + 1, chunkSize: NByteInBlock},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := make([]byte, tt.size)
+			for i := range buf {
+				buf[i] = byte(i % 251)
+			}
+
+			expected := splitMediaBuf(buf)
+
+			got := make([][]byte, 0)
+			var tail, chunk []byte
+			var bufs [][]byte
+			for offset := 0; offset < tt.size; offset += len(chunk) {
+				chunk = buf[offset:]
+				if len(chunk) > tt.chunkSize {
+					chunk = chunk[:tt.chunkSize]
+				}
+
+				tail = append(tail, chunk...)
+				bufs, tail = splitMediaUploadTail(tail, offset+len(chunk) == tt.size)
+				got = append(got, bufs...)
+			}
+
+			if len(tail) != 0 {
+				t.Errorf("splitMediaUploadTail: tail not empty: %v", len(tail))
+			}
+			if len(got) != len(expected) {
+				t.Fatalf("splitMediaUploadTail: nBlock: %v expected: %v", len(got), len(expected))
+			}
+			for i := range expected {
+				if !bytes.Equal(got[i], expected[i]) {
+					t.Errorf("splitMediaUploadTail: block %v: len: %v expected: %v", i, len(got[i]), len(expected[i]))
+				}
+			}
+		})
+	}
+}
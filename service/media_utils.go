@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"image"
 	"image/jpeg"
+	"strings"
 
 	"github.com/ailabstw/go-pttai/common"
 	"github.com/ailabstw/go-pttai/log"
@@ -71,6 +72,55 @@ func NormalizeImage(theBytes []byte) (MediaType, interface{}, []byte, error) {
 	return MediaTypeJPEG, &MediaDataJPEG{Width: uint16(normalizedWidth), Height: uint16(normalizedHeight)}, newBytes, nil
 }
 
+/*
+Thumbnail is the generated thumbnail of the image, to be created as the media.
+*/
+type Thumbnail struct {
+	Size   ImageSize
+	Width  uint16
+	Height uint16
+	Bytes  []byte
+}
+
+/*
+GenerateThumbnails generates the jpeg thumbnails of the (normalized) image at ThumbnailSizes.
+Only the sizes smaller than the image are generated.
+*/
+func GenerateThumbnails(theBytes []byte) ([]*Thumbnail, error) {
+	img, _, err := image.Decode(bytes.NewReader(theBytes))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	width := bounds.Dx()
+	height := bounds.Dy()
+
+	thumbnails := make([]*Thumbnail, 0, len(ThumbnailSizes))
+	for _, size := range ThumbnailSizes {
+		maxEdge := ThumbnailMaxEdges[size]
+		if width <= maxEdge && height <= maxEdge {
+			break
+		}
+
+		newWidth, newHeight := normalizeSize(width, height, maxEdge, maxEdge)
+		newImage := resize.Resize(uint(newWidth), uint(newHeight), img, resize.Lanczos3)
+		newBytes, err := imgToJPEG(newImage)
+		if err != nil {
+			return nil, err
+		}
+
+		thumbnails = append(thumbnails, &Thumbnail{
+			Size:   size,
+			Width:  uint16(newWidth),
+			Height: uint16(newHeight),
+			Bytes:  newBytes,
+		})
+	}
+
+	return thumbnails, nil
+}
+
 func imgToJPEG(img image.Image) ([]byte, error) {
 	buffer := &bytes.Buffer{}
 	err := jpeg.Encode(buffer, img, nil)
@@ -101,3 +151,46 @@ func normalizeSize(width int, height int, maxWidth int, maxHeight int) (int, int
 
 	return newWidth, newHeight
 }
+
+func NewMediaDataAudio(filename []byte, size int64, contentType string, codec string, duration int64) (*MediaDataAudio, error) {
+	if !strings.HasPrefix(contentType, MediaContentTypeAudioPrefix) {
+		return nil, ErrInvalidMediaContentType
+	}
+	if len(codec) > MaxMediaCodecLength {
+		return nil, ErrInvalidMediaCodec
+	}
+	if duration < 0 {
+		return nil, ErrInvalidMediaDuration
+	}
+
+	return &MediaDataAudio{
+		Filename:    filename,
+		ContentType: contentType,
+		Codec:       codec,
+		Duration:    duration,
+		Size:        size,
+	}, nil
+}
+
+func NewMediaDataVideo(filename []byte, size int64, contentType string, codec string, audioCodec string, duration int64, width uint16, height uint16) (*MediaDataVideo, error) {
+	if !strings.HasPrefix(contentType, MediaContentTypeVideoPrefix) {
+		return nil, ErrInvalidMediaContentType
+	}
+	if len(codec) > MaxMediaCodecLength || len(audioCodec) > MaxMediaCodecLength {
+		return nil, ErrInvalidMediaCodec
+	}
+	if duration < 0 {
+		return nil, ErrInvalidMediaDuration
+	}
+
+	return &MediaDataVideo{
+		Filename:    filename,
+		ContentType: contentType,
+		Codec:       codec,
+		AudioCodec:  audioCodec,
+		Duration:    duration,
+		Width:       width,
+		Height:      height,
+		Size:        size,
+	}, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"testing"
+)
+
+func TestGenerateThumbnails(t *testing.T) {
+	// prepare test-cases
+	tests := []struct {
+		name     string
+		width    int
+		height   int
+		expected []*Thumbnail
+	}{
+		{name: "tiny", width: 100, height: 80, expected: []*Thumbnail{}},
+		{name: "small only", width: 300, height: 200, expected: []*Thumbnail{
+			{Size: ImageSizeSmall, Width: 160, Height: 106},
+		}},
+		{name: "all sizes", width: 2000, height: 1000, expected: []*Thumbnail{
+			{Size: ImageSizeSmall, Width: 160, Height: 80},
+			{Size: ImageSizeMedium, Width: 480, Height: 240},
+			{Size: ImageSizeLarge, Width: 1080, Height: 540},
+		}},
+		{name: "portrait", width: 600, height: 1200, expected: []*Thumbnail{
+			{Size: ImageSizeSmall, Width: 80, Height: 160},
+			{Size: ImageSizeMedium, Width: 240, Height: 480},
+			{Size: ImageSizeLarge, Width: 540, Height: 1080},
+		}},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := jpeg.Encode(buf, image.NewRGBA(image.Rect(0, 0, tt.width, tt.height)), nil)
+			if err != nil {
+				t.Fatalf("unable to encode: %v", err)
+			}
+
+			got, err := GenerateThumbnails(buf.Bytes())
+			if err != nil {
+				t.Fatalf("GenerateThumbnails: e: %v", err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("GenerateThumbnails: n: %v expected: %v", len(got), len(tt.expected))
+			}
+
+			for i, each := range got {
+				expected := tt.expected[i]
+				if each.Size != expected.Size || each.Width != expected.Width || each.Height != expected.Height {
+					t.Errorf("GenerateThumbnails: (%v) %v %vx%v expected: %v %vx%v", i, each.Size, each.Width, each.Height, expected.Size, expected.Width, expected.Height)
+				}
+
+				img, _, err := image.Decode(bytes.NewReader(each.Bytes))
+				if err != nil {
+					t.Errorf("GenerateThumbnails: (%v) unable to decode: %v", i, err)
+					continue
+				}
+				if img.Bounds().Dx() != int(each.Width) || img.Bounds().Dy() != int(each.Height) {
+					t.Errorf("GenerateThumbnails: (%v) bounds: %v", i, img.Bounds())
+				}
+			}
+		})
+	}
+}
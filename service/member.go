@@ -33,6 +33,20 @@ type Member struct {
 	TransferToID *types.PttID `json:"t,omitempty"`
 
 	SyncInfo *SyncPersonInfo `json:"s,omitempty"`
+
+	Perms        MemberPerm      `json:"P,omitempty"`
+	PermLogID    *types.PttID    `json:"pl,omitempty"`
+	PermUpdateTS types.Timestamp `json:"pt"`
+}
+
+/*
+MemberPerm is the permission set granted to the member by the masters.
+The meaning of each bit is defined by the service.
+*/
+type MemberPerm uint32
+
+func (p MemberPerm) Has(perm MemberPerm) bool {
+	return p&perm == perm
 }
 
 func NewMember(
@@ -202,3 +216,23 @@ func (m *Member) SetSyncInfo(theSyncInfo SyncInfo) error {
 
 	return nil
 }
+
+/**********
+ * Perms
+ **********/
+
+/*
+GetPerms returns the effective perms of the member.
+The perms are valid only if the member is alive and the perms are granted after the member is (re-)added.
+*/
+func (m *Member) GetPerms() MemberPerm {
+	if m.Status != types.StatusAlive {
+		return 0
+	}
+
+	if m.PermLogID == nil || !m.CreateTS.IsLess(m.PermUpdateTS) {
+		return 0
+	}
+
+	return m.Perms
+}
@@ -21,6 +21,7 @@ const (
 	MemberOpTypeAddMember
 	MemberOpTypeDeleteMember
 	MemberOpTypeMigrateMember
+	MemberOpTypeUpdateMember
 )
 
 type MemberOpAddMember struct {
@@ -28,3 +29,7 @@ type MemberOpAddMember struct {
 
 type MemberOpDeleteMember struct {
 }
+
+type MemberOpUpdateMember struct {
+	Perms MemberPerm `json:"P"`
+}
@@ -0,0 +1,70 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func TestMember_GetPerms(t *testing.T) {
+	// setup test
+	createTS := types.Timestamp{Ts: 1234567890}
+	permTS := types.Timestamp{Ts: 1234567891}
+	logID := &types.PttID{1}
+
+	// prepare test-cases
+	tests := []struct {
+		name         string
+		status       types.Status
+		perms        MemberPerm
+		permLogID    *types.PttID
+		permUpdateTS types.Timestamp
+		want         MemberPerm
+	}{
+		{name: "no perms", status: types.StatusAlive, want: 0},
+		{name: "granted", status: types.StatusAlive, perms: 3, permLogID: logID, permUpdateTS: permTS, want: 3},
+		{name: "deleted", status: types.StatusDeleted, perms: 3, permLogID: logID, permUpdateTS: permTS, want: 0},
+		{name: "granted before re-added", status: types.StatusAlive, perms: 3, permLogID: logID, permUpdateTS: types.Timestamp{Ts: 1234567889}, want: 0},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMember(&types.PttID{2}, createTS, nil, nil, nil, tt.status)
+			m.Perms = tt.perms
+			m.PermLogID = tt.permLogID
+			m.PermUpdateTS = tt.permUpdateTS
+
+			if got := m.GetPerms(); got != tt.want {
+				t.Errorf("Member.GetPerms() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemberPerm_Has(t *testing.T) {
+	perms := MemberPerm(1 | 4)
+
+	if !perms.Has(1) || !perms.Has(4) || !perms.Has(1|4) {
+		t.Errorf("MemberPerm.Has() = false, want true")
+	}
+	if perms.Has(2) || perms.Has(1|2) {
+		t.Errorf("MemberPerm.Has() = true, want false")
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ailabstw/go-pttai/rpc"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+/*
+ObjEvent is posted by the service when an object of an entity becomes alive, is updated, or is deleted.
+
+Op is the entity-specific op-type (ex: BoardOpTypeCreateArticle), and Status is StatusAlive for create/update and StatusDeleted for delete.
+*/
+type ObjEvent struct {
+	EntityID  *types.PttID    `json:"EID"`
+	ObjID     *types.PttID    `json:"ID"`
+	RefID     *types.PttID    `json:"RID,omitempty"`
+	CreatorID *types.PttID    `json:"CID"`
+	LogID     *types.PttID    `json:"LID"`
+	Op        OpType          `json:"O"`
+	Status    types.Status    `json:"S"`
+	UpdateTS  types.Timestamp `json:"UT"`
+}
+
+func NewObjEvent(obj Object, refID *types.PttID, oplog *BaseOplog, status types.Status) *ObjEvent {
+	return &ObjEvent{
+		EntityID:  obj.GetEntityID(),
+		ObjID:     obj.GetID(),
+		RefID:     refID,
+		CreatorID: oplog.CreatorID,
+		LogID:     oplog.ID,
+		Op:        oplog.Op,
+		Status:    status,
+		UpdateTS:  oplog.UpdateTS,
+	}
+}
+
+/*
+PostObjEvent posts the obj-event to the service of the entity.
+*/
+func (pm *BaseProtocolManager) PostObjEvent(obj Object, refID *types.PttID, oplog *BaseOplog, status types.Status) int {
+	svc := pm.Entity().Service()
+	if svc == nil {
+		return 0
+	}
+
+	return svc.PostObjEvent(NewObjEvent(obj, refID, oplog, status))
+}
+
+/*
+objEventSub is the subscriber of the obj-events.
+*/
+type objEventSub struct {
+	ch chan<- *ObjEvent
+}
+
+/*
+PostObjEvent sends the event to all the subscribers of the service without blocking.
+
+The event is dropped for the subscribers with the full channel,
+so that a slow subscriber does not block the posting op.
+Returns the number of the subscribers receiving the event.
+*/
+func (svc *BaseService) PostObjEvent(ev *ObjEvent) int {
+	svc.objSubsLock.RLock()
+	defer svc.objSubsLock.RUnlock()
+
+	nSent := 0
+	for sub := range svc.objSubs {
+		select {
+		case sub.ch <- ev:
+			nSent++
+		default:
+			log.Warn("PostObjEvent: subscriber is full, drop the event", "entity", ev.EntityID, "obj", ev.ObjID, "op", ev.Op)
+		}
+	}
+
+	return nSent
+}
+
+func (svc *BaseService) SubscribeObjEvent(ch chan<- *ObjEvent) event.Subscription {
+	sub := &objEventSub{ch: ch}
+
+	svc.objSubsLock.Lock()
+	if svc.objSubs == nil {
+		svc.objSubs = make(map[*objEventSub]bool)
+	}
+	svc.objSubs[sub] = true
+	svc.objSubsLock.Unlock()
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+
+		svc.objSubsLock.Lock()
+		defer svc.objSubsLock.Unlock()
+
+		delete(svc.objSubs, sub)
+
+		return nil
+	})
+}
+
+/*
+SubscribeEntityObjEvent creates the rpc-subscription notifying the obj-events of the entity.
+*/
+func (svc *BaseService) SubscribeEntityObjEvent(ctx context.Context, entityIDBytes []byte) (*rpc.Subscription, error) {
+	entity, err := svc.EntityIDToEntity(entityIDBytes)
+	if err != nil {
+		return nil, err
+	}
+	entityID := entity.GetID()
+
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		events := make(chan *ObjEvent, ObjEventChanSize)
+		sub := svc.SubscribeObjEvent(events)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-events:
+				if !reflect.DeepEqual(ev.EntityID, entityID) {
+					continue
+				}
+				notifier.Notify(rpcSub.ID, ev)
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
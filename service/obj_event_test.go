@@ -0,0 +1,59 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+)
+
+func TestBaseService_PostObjEvent(t *testing.T) {
+	// setup test
+	svc := &BaseService{}
+
+	ch := make(chan *ObjEvent, 1)
+	sub := svc.SubscribeObjEvent(ch)
+
+	slowCh := make(chan *ObjEvent)
+	slowSub := svc.SubscribeObjEvent(slowCh)
+	defer slowSub.Unsubscribe()
+
+	ev := &ObjEvent{Status: 1}
+
+	// run test: the slow subscriber does not block the posting.
+	if got := svc.PostObjEvent(ev); got != 1 {
+		t.Errorf("BaseService.PostObjEvent() = %v, want 1", got)
+	}
+
+	// the full channel drops the event.
+	if got := svc.PostObjEvent(ev); got != 0 {
+		t.Errorf("BaseService.PostObjEvent() = %v, want 0", got)
+	}
+
+	if got := <-ch; got != ev {
+		t.Errorf("BaseService.PostObjEvent() received %v, want %v", got, ev)
+	}
+
+	// unsubscribed
+	sub.Unsubscribe()
+
+	if got := svc.PostObjEvent(ev); got != 0 {
+		t.Errorf("BaseService.PostObjEvent() = %v, want 0", got)
+	}
+	if len(ch) != 0 {
+		t.Errorf("BaseService.PostObjEvent() len(ch) = %v, want 0", len(ch))
+	}
+}
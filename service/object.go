@@ -54,7 +54,7 @@ type Object interface {
 	 **********/
 
 	SetDB(
-		db *pttdb.LDBBatch,
+		db pttdb.IndexedBatch,
 		dbLock *types.LockMap,
 
 		entityID *types.PttID,
@@ -127,7 +127,7 @@ type BaseObject struct {
 
 	BlockInfo *BlockInfo `json:"b,omitempty"`
 
-	db              *pttdb.LDBBatch
+	db              pttdb.IndexedBatch
 	dbLock          *types.LockMap
 	fullDBPrefix    []byte
 	fullDBIdxPrefix []byte
@@ -162,7 +162,7 @@ func NewObject(
 }
 
 func (o *BaseObject) SetDB(
-	db *pttdb.LDBBatch,
+	db pttdb.IndexedBatch,
 	dbLock *types.LockMap,
 	entityID *types.PttID,
 	fullDBPrefix []byte,
@@ -404,7 +404,7 @@ func (o *BaseObject) RemoveMeta() {
 	return
 }
 
-func (o *BaseObject) DB() *pttdb.LDBBatch {
+func (o *BaseObject) DB() pttdb.IndexedBatch {
 	return o.db
 }
 
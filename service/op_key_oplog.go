@@ -29,7 +29,7 @@ func (o *OpKeyOplog) GetBaseOplog() *BaseOplog {
 	return o.BaseOplog
 }
 
-func NewOpKeyOplog(keyID *types.PttID, ts types.Timestamp, doerID *types.PttID, op OpType, opData OpData, db *pttdb.LDBBatch, entityID *types.PttID, dbLock *types.LockMap) (*OpKeyOplog, error) {
+func NewOpKeyOplog(keyID *types.PttID, ts types.Timestamp, doerID *types.PttID, op OpType, opData OpData, db pttdb.IndexedBatch, entityID *types.PttID, dbLock *types.LockMap) (*OpKeyOplog, error) {
 
 	oplog, err := NewOplog(keyID, ts, doerID, op, opData, db, entityID, DBOpKeyOplogPrefix, DBOpKeyIdxOplogPrefix, nil, dbLock)
 	if err != nil {
@@ -53,6 +53,8 @@ const (
 	CodeTypeOpCheckMember
 	CodeTypeOpCheckMemberAck
 
+	CodeTypeEphemeral // ephemeral signals, not going through oplog / merkle.
+
 	NCodeType
 )
 
@@ -82,6 +84,8 @@ var codeTypeStr = map[CodeType]string{
 
 	CodeTypeOpCheckMember:    "op-check-member",
 	CodeTypeOpCheckMemberAck: "op-check-member-ack",
+
+	CodeTypeEphemeral: "ephemeral",
 }
 
 func (c CodeType) String() string {
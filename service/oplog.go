@@ -53,7 +53,7 @@ type BaseOplog struct {
 
 	Data OpData `json:"D,omitempty"`
 
-	db               *pttdb.LDBBatch
+	db               pttdb.IndexedBatch
 	dbPrefixID       *types.PttID
 	dbPrefix         []byte
 	dbIdxPrefix      []byte
@@ -82,11 +82,11 @@ type BaseOplog struct {
 	Extra   interface{} `json:"e,omitempty"`
 }
 
-func NewOplogForLoadData(data interface{}, db *pttdb.LDBBatch) *BaseOplog {
+func NewOplogForLoadData(data interface{}, db pttdb.IndexedBatch) *BaseOplog {
 	return &BaseOplog{Data: data, db: db}
 }
 
-func NewOplog(id *types.PttID, ts types.Timestamp, doerID *types.PttID, op OpType, data interface{}, db *pttdb.LDBBatch, dbPrefixID *types.PttID, dbPrefix []byte, dbIdxPrefix []byte, dbMerklePrefix []byte, dbLock *types.LockMap) (*BaseOplog, error) {
+func NewOplog(id *types.PttID, ts types.Timestamp, doerID *types.PttID, op OpType, data interface{}, db pttdb.IndexedBatch, dbPrefixID *types.PttID, dbPrefix []byte, dbIdxPrefix []byte, dbMerklePrefix []byte, dbLock *types.LockMap) (*BaseOplog, error) {
 
 	opID, err := types.NewPttID()
 	if err != nil {
@@ -124,7 +124,7 @@ func NewOplog(id *types.PttID, ts types.Timestamp, doerID *types.PttID, op OpTyp
 	return oplog, nil
 }
 
-func (o *BaseOplog) SetDB(db *pttdb.LDBBatch, id *types.PttID, prefix []byte, idxPrefix []byte, merklePrefix []byte, dbLock *types.LockMap) {
+func (o *BaseOplog) SetDB(db pttdb.IndexedBatch, id *types.PttID, prefix []byte, idxPrefix []byte, merklePrefix []byte, dbLock *types.LockMap) {
 	dbPrefixInternal := dbPrefixToDBPrefixInternal(prefix)
 	dbPrefixMaster := dbPrefixToDBPrefixMaster(prefix)
 
@@ -138,7 +138,7 @@ func (o *BaseOplog) SetDB(db *pttdb.LDBBatch, id *types.PttID, prefix []byte, id
 	o.dbLock = dbLock
 }
 
-func (o *BaseOplog) GetDB() *pttdb.LDBBatch {
+func (o *BaseOplog) GetDB() pttdb.IndexedBatch {
 	return o.db
 }
 
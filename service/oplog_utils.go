@@ -18,11 +18,14 @@ package service
 
 import (
 	"bytes"
+	"encoding/json"
 	"reflect"
 	"sort"
 
+	"github.com/ailabstw/go-pttai/common"
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
 
@@ -98,13 +101,13 @@ func GetOplogIterWithOplog(oplog *BaseOplog, startID *types.PttID, listOrder ptt
 }
 
 /*
-func GetOplogIter(db *pttdb.LDBBatch, dbOplogPrefix []byte, dbOplogIdxPrefix []byte, dbOplogMerklePrefix []byte, prefixID *types.PttID, logID *types.PttID, dbLock *types.LockMap, isLocked bool, status types.Status, listOrder pttdb.ListOrder) (iterator.Iterator, error) {
+func GetOplogIter(db pttdb.IndexedBatch, dbOplogPrefix []byte, dbOplogIdxPrefix []byte, dbOplogMerklePrefix []byte, prefixID *types.PttID, logID *types.PttID, dbLock *types.LockMap, isLocked bool, status types.Status, listOrder pttdb.ListOrder) (iterator.Iterator, error) {
 
 	return getOplogIterCore(db, dbOplogPrefix, dbOplogIdxPrefix, dbOplogMerklePrefix, prefixID, logID, dbLock, isLocked, status, listOrder)
 }
 */
 
-func getOplogIterCore(db *pttdb.LDBBatch, dbOplogPrefix []byte, dbOplogIdxPrefix []byte, dbOplogMerklePrefix []byte, prefixID *types.PttID, logID *types.PttID, dbLock *types.LockMap, isLocked bool, status types.Status, listOrder pttdb.ListOrder) (iterator.Iterator, error) {
+func getOplogIterCore(db pttdb.IndexedBatch, dbOplogPrefix []byte, dbOplogIdxPrefix []byte, dbOplogMerklePrefix []byte, prefixID *types.PttID, logID *types.PttID, dbLock *types.LockMap, isLocked bool, status types.Status, listOrder pttdb.ListOrder) (iterator.Iterator, error) {
 
 	switch status {
 	case types.StatusInternalPending:
@@ -188,3 +191,124 @@ func ConcatLog(slices [][]*BaseOplog) ([]*BaseOplog, error) {
 
 	return tmp, nil
 }
+
+/*
+OplogDBPrefixes returns all the db-prefixes used by the oplogs,
+including the internal / master oplogs, the idx and the merkle-tree.
+*/
+func OplogDBPrefixes(dbOplogPrefix []byte, dbIdxOplogPrefix []byte, dbMerkleOplogPrefix []byte) [][]byte {
+	prefixes := [][]byte{
+		dbOplogPrefix,
+		dbPrefixToDBPrefixInternal(dbOplogPrefix),
+		dbPrefixToDBPrefixMaster(dbOplogPrefix),
+		dbIdxOplogPrefix,
+		dbMerkleOplogPrefix,
+	}
+
+	for _, postfix := range [][]byte{DBMerkleMetaPostfix, DBMerkleToUpdatePostfix, DBMerkleUpdatingPostfix} {
+		prefix := common.CloneBytes(dbMerkleOplogPrefix)
+		copy(prefix[pttdb.OffsetDBKeyPrefixPostfix:], postfix)
+		prefixes = append(prefixes, prefix)
+	}
+
+	return prefixes
+}
+
+/*
+GetLog0Keys returns the keys of the log0s (the first alive oplog of each entity)
+with the oplog-prefix in the iter.
+*/
+func GetLog0Keys(iter iterator.Iterator, dbOplogPrefix []byte) (map[string]bool, error) {
+	log0Keys := make(map[string]bool)
+
+	offsetID := len(dbOplogPrefix)
+	offsetTS := offsetID + types.SizePttID
+	var entityID []byte
+	for isOK := iter.Seek(dbOplogPrefix); isOK; isOK = iter.Next() {
+		key := iter.Key()
+		if !bytes.HasPrefix(key, dbOplogPrefix) {
+			break
+		}
+		if len(key) < offsetTS || bytes.Equal(key[offsetID:offsetTS], entityID) {
+			continue
+		}
+
+		entityID = common.CloneBytes(key[offsetID:offsetTS])
+		log0Keys[string(key)] = true
+	}
+
+	return log0Keys, iter.Error()
+}
+
+/*
+CheckOpDataSorted checks whether the fields of the op-data (and of the nested objects)
+are declared in the json-key order.
+
+The op-data is unmarshaled as map by the peers, and is re-marshaled in the json-key order
+when verifying the sign, so the unsorted op-data is not able to be verified.
+*/
+func CheckOpDataSorted(opData interface{}) error {
+	marshaled, err := json.Marshal(opData)
+	if err != nil {
+		return err
+	}
+
+	var canonical interface{}
+	dec := json.NewDecoder(bytes.NewReader(marshaled))
+	dec.UseNumber()
+	err = dec.Decode(&canonical)
+	if err != nil {
+		return err
+	}
+
+	marshaledCanonical, err := json.Marshal(canonical)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(marshaled, marshaledCanonical) {
+		return ErrInvalidData
+	}
+
+	return nil
+}
+
+/*
+CheckOplogSignVerify signs the oplog with a new key (as the creator of the oplog),
+and verifies the oplog after the json round-trip, as received by the peers.
+*/
+func CheckOplogSignVerify(oplog *BaseOplog) error {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return err
+	}
+
+	oplog.CreatorID, err = types.NewPttIDFromKey(key)
+	if err != nil {
+		return err
+	}
+
+	keyInfo := &KeyInfo{
+		Key:         key,
+		KeyBytes:    crypto.FromECDSA(key),
+		PubKeyBytes: crypto.FromECDSAPub(&key.PublicKey),
+	}
+
+	err = oplog.Sign(keyInfo)
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := oplog.Marshal()
+	if err != nil {
+		return err
+	}
+
+	received := &BaseOplog{}
+	err = received.Unmarshal(marshaled)
+	if err != nil {
+		return err
+	}
+
+	return received.Verify()
+}
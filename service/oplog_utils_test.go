@@ -0,0 +1,105 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+type tSortedOpData struct {
+	A  int64  `json:"A"`
+	B  []byte `json:"B"`
+	a  int64
+	Ab string `json:"a,omitempty"`
+}
+
+type tUnsortedOpData struct {
+	B int64 `json:"B"`
+	A int64 `json:"A"`
+}
+
+type tNestedOpData struct {
+	D  *tSortedOpData   `json:"D"`
+	E  []*tSortedOpData `json:"E"`
+	TS *types.Timestamp `json:"TS,omitempty"`
+}
+
+func TestCheckOpDataSorted(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	// prepare test-cases
+	tests := []struct {
+		name    string
+		opData  interface{}
+		wantErr bool
+	}{
+		{name: "sorted", opData: &tSortedOpData{A: 1234567890123, B: []byte{1, 2}, Ab: "c"}},
+		{name: "unsorted", opData: &tUnsortedOpData{A: 1, B: 2}, wantErr: true},
+		{name: "nested-sorted", opData: &tNestedOpData{D: &tSortedOpData{A: 1}, E: []*tSortedOpData{{A: 2}}}},
+		// the fields of types.Timestamp (T, NT) are not in the json-key order.
+		{name: "nested-unsorted", opData: &tNestedOpData{D: &tSortedOpData{A: 1}, TS: &tDefaultTimestamp}, wantErr: true},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckOpDataSorted(tt.opData); (err != nil) != tt.wantErr {
+				t.Errorf("CheckOpDataSorted() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// teardown test
+}
+
+func TestCheckOplogSignVerify(t *testing.T) {
+	// setup test
+	setupTest(t)
+	defer teardownTest(t)
+
+	id, _ := types.NewPttID()
+
+	// prepare test-cases
+	tests := []struct {
+		name    string
+		opData  interface{}
+		wantErr bool
+	}{
+		{name: "sorted", opData: &tSortedOpData{A: 1, B: []byte{1, 2}}},
+		{name: "unsorted", opData: &tUnsortedOpData{A: 1, B: 2}, wantErr: true},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := NewOplog(id, tDefaultTimestamp, id, tDefaultOpType, tt.opData, nil, id, DBMemberOplogPrefix, DBMemberIdxOplogPrefix, DBMemberMerkleOplogPrefix, nil)
+			if err != nil {
+				t.Errorf("NewOplog() error = %v", err)
+				return
+			}
+			if err := CheckOplogSignVerify(o); (err != nil) != tt.wantErr {
+				t.Errorf("CheckOplogSignVerify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// teardown test
+}
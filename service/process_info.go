@@ -52,6 +52,31 @@ func ProcessInfoToSyncBlockIDList(info map[types.PttID]*BaseOplog, op OpType) []
 	return theList
 }
 
+/*
+ProcessInfoToSyncMediaBlockIDList is ProcessInfoToSyncBlockIDList with the large media split into the ranges of the blocks.
+*/
+func ProcessInfoToSyncMediaBlockIDList(info map[types.PttID]*BaseOplog, op OpType) []*SyncBlockID {
+
+	theList := make([]*SyncBlockID, 0, len(info))
+	nBlocks := make([]int, 0, len(info))
+	var eachID *types.PttID
+	for id, eachLog := range info {
+		if eachLog.Op == op {
+			opData := &OpCreateMedia{}
+			err := eachLog.GetData(opData)
+			if err != nil {
+				continue
+			}
+
+			eachID = &types.PttID{}
+			copy(eachID[:], id[:])
+			theList = append(theList, &SyncBlockID{ID: eachID, ObjID: eachLog.ObjID, LogID: eachLog.ID})
+			nBlocks = append(nBlocks, opData.NBlock)
+		}
+	}
+	return SplitSyncMediaBlockIDs(theList, nBlocks)
+}
+
 func ProcessInfoToLogs(info map[types.PttID]*BaseOplog, op OpType) []*BaseOplog {
 	theList := make([]*BaseOplog, 0, len(info))
 	for _, eachLog := range info {
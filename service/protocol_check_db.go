@@ -0,0 +1,424 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"bytes"
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+DBCheckIssue is the inconsistency found by CheckDB.
+*/
+type DBCheckIssue struct {
+	Service  string           `json:"S"`
+	EntityID *types.PttID     `json:"EID"`
+	Type     DBCheckIssueType `json:"T"`
+	Name     string           `json:"N"`
+	Key      []byte           `json:"K"`
+
+	IsRepaired bool `json:"R"`
+}
+
+type dbCheckOplogInfo struct {
+	name   string
+	setDB  func(oplog *BaseOplog)
+	merkle *Merkle
+}
+
+/*
+DBCheckObjects returns the (empty) objects with db set, which are walked by CheckDB.
+
+All the objects referring to blocks need to be included,
+otherwise the blocks are considered orphans.
+*/
+func (pm *BaseProtocolManager) DBCheckObjects() []Object {
+	media := NewEmptyMedia()
+	pm.SetMediaDB(media)
+
+	return []Object{media}
+}
+
+/*
+DBCheckBlockInfos walks through the block-infos referred by the records other than the objects,
+which are walked by CheckDB in addition to DBCheckObjects.
+
+The blocks referred only by those records are considered orphans if not included.
+*/
+func (pm *BaseProtocolManager) DBCheckBlockInfos(addBlockInfo func(objID *types.PttID, blockInfo *BlockInfo)) error {
+	return nil
+}
+
+/*
+CheckDB walks through the oplogs, merkle-trees, objects and blocks of the entity and reports the inconsistencies.
+The node is expected not running (no sync in progress).
+
+With isRepair:
+ 1. expired pending oplogs are handled as failed.
+ 2. merkle-trees are reconstructed from the oplogs.
+ 3. corrupted oplogs / objects and orphan blocks are removed.
+
+Missing blocks are reported only, and need to be synced from the peers.
+*/
+func (pm *BaseProtocolManager) CheckDB(isRepair bool) ([]*DBCheckIssue, error) {
+	thePM := pm.Entity().PM()
+
+	issues := make([]*DBCheckIssue, 0)
+
+	// oplogs
+	expireTS, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+	expireTS.Ts -= int64(ExpireOplogSeconds)
+
+	for _, info := range pm.dbCheckOplogInfos(thePM) {
+		oplogIssues, err := pm.checkDBOplogs(info, expireTS, isRepair)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, oplogIssues...)
+
+		merkleIssues, err := pm.checkDBMerkle(info, oplogIssues, isRepair)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, merkleIssues...)
+	}
+
+	var expiredIssues []*DBCheckIssue
+	for _, issue := range issues {
+		if issue.Type == DBCheckIssueTypeExpiredPendingOplog {
+			expiredIssues = append(expiredIssues, issue)
+		}
+	}
+
+	// expired pending oplogs are handled as failed in sync with nil peer.
+	if isRepair && len(expiredIssues) != 0 {
+		thePM.Sync(nil)
+
+		db := pm.DB().DB()
+		for _, issue := range expiredIssues {
+			isHas, err := db.Has(issue.Key)
+			if err != nil {
+				return nil, err
+			}
+			issue.IsRepaired = !isHas
+		}
+	}
+
+	// objects / blocks
+	blockIssues, err := pm.checkDBBlocks(thePM.DBCheckObjects(), thePM.DBCheckBlockInfos, isRepair)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, blockIssues...)
+
+	return issues, nil
+}
+
+func (pm *BaseProtocolManager) dbCheckOplogInfos(thePM ProtocolManager) []*dbCheckOplogInfo {
+	setMasterDB := pm.SetMasterDB
+	if myPM, ok := thePM.(MyProtocolManager); ok {
+		setMasterDB = myPM.SetMasterDB
+	}
+
+	infos := []*dbCheckOplogInfo{
+		&dbCheckOplogInfo{name: "master", setDB: setMasterDB, merkle: pm.masterMerkle},
+		&dbCheckOplogInfo{name: "member", setDB: pm.SetMemberDB, merkle: pm.memberMerkle},
+		&dbCheckOplogInfo{name: "op-key", setDB: thePM.SetOpKeyDB},
+	}
+
+	if pm.setLog0DB != nil {
+		infos = append(infos, &dbCheckOplogInfo{name: "log0", setDB: pm.setLog0DB, merkle: pm.log0Merkle})
+	}
+
+	return infos
+}
+
+/*
+checkDBOplogs checks the corrupted oplogs, the expired pending oplogs,
+and the alive oplogs without the corresponding merkle-nodes.
+*/
+func (pm *BaseProtocolManager) checkDBOplogs(info *dbCheckOplogInfo, expireTS types.Timestamp, isRepair bool) ([]*DBCheckIssue, error) {
+	entityID := pm.Entity().GetID()
+	db := pm.DB().DB()
+
+	oplog := &BaseOplog{}
+	info.setDB(oplog)
+	isMerkle := info.merkle != nil && oplog.GetDBMerklePrefix() != nil
+
+	issues := make([]*DBCheckIssue, 0)
+	statusList := []types.Status{types.StatusInternalPending, types.StatusPending, types.StatusAlive}
+	for _, status := range statusList {
+		iter, err := GetOplogIterWithOplog(oplog, nil, pttdb.ListOrderNext, status, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for iter.Next() {
+			key := common.CopyBytes(iter.Key())
+
+			each := &BaseOplog{}
+			info.setDB(each)
+			err = each.Unmarshal(iter.Value())
+			if err != nil {
+				issue := &DBCheckIssue{EntityID: entityID, Type: DBCheckIssueTypeCorruptedOplog, Name: info.name, Key: key}
+				if isRepair {
+					issue.IsRepaired = db.Delete(key) == nil
+				}
+				issues = append(issues, issue)
+				continue
+			}
+
+			if status != types.StatusAlive {
+				if each.CreateTS.IsLess(expireTS) {
+					issues = append(issues, &DBCheckIssue{EntityID: entityID, Type: DBCheckIssueTypeExpiredPendingOplog, Name: info.name, Key: key})
+				}
+				continue
+			}
+
+			if !isMerkle || each.MasterLogID == nil {
+				continue
+			}
+			if !each.IsSync {
+				continue
+			}
+
+			merkleKey, err := each.MarshalMerkleKey()
+			if err != nil {
+				continue
+			}
+			isHas, err := db.Has(merkleKey)
+			if err != nil {
+				iter.Release()
+				return nil, err
+			}
+			if !isHas {
+				issues = append(issues, &DBCheckIssue{EntityID: entityID, Type: DBCheckIssueTypeMissingMerkleNode, Name: info.name, Key: key})
+			}
+		}
+		iter.Release()
+	}
+
+	return issues, nil
+}
+
+/*
+checkDBMerkle checks that the merkle-nodes (level-now) refer to the valid oplogs.
+The merkle-tree is reconstructed with isRepair if any of the merkle-nodes is inconsistent.
+*/
+func (pm *BaseProtocolManager) checkDBMerkle(info *dbCheckOplogInfo, oplogIssues []*DBCheckIssue, isRepair bool) ([]*DBCheckIssue, error) {
+	if info.merkle == nil {
+		return nil, nil
+	}
+
+	entityID := pm.Entity().GetID()
+	db := pm.DB().DB()
+
+	iter, err := info.merkle.GetMerkleIter(MerkleTreeLevelNow, types.ZeroTimestamp, types.MaxTimestamp, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := make([]*DBCheckIssue, 0)
+	for iter.Next() {
+		key := common.CopyBytes(iter.Key())
+
+		node := &MerkleNode{}
+		err = node.Unmarshal(iter.Value())
+		if err != nil {
+			issues = append(issues, &DBCheckIssue{EntityID: entityID, Type: DBCheckIssueTypeInvalidMerkleNode, Name: info.name, Key: key})
+			continue
+		}
+
+		val, err := db.Get(node.Key)
+		if err == leveldb.ErrNotFound {
+			issues = append(issues, &DBCheckIssue{EntityID: entityID, Type: DBCheckIssueTypeOrphanMerkleNode, Name: info.name, Key: key})
+			continue
+		}
+		if err != nil {
+			iter.Release()
+			return nil, err
+		}
+
+		each := &BaseOplog{}
+		err = each.Unmarshal(val)
+		if err != nil || !bytes.Equal(types.HashToAddr(each.Hash), node.Addr) {
+			issues = append(issues, &DBCheckIssue{EntityID: entityID, Type: DBCheckIssueTypeInvalidMerkleNode, Name: info.name, Key: key})
+		}
+	}
+	iter.Release()
+
+	if !isRepair {
+		return issues, nil
+	}
+
+	missingIssues := make([]*DBCheckIssue, 0)
+	for _, issue := range oplogIssues {
+		if issue.Type == DBCheckIssueTypeMissingMerkleNode {
+			missingIssues = append(missingIssues, issue)
+		}
+	}
+
+	if len(issues) == 0 && len(missingIssues) == 0 {
+		return issues, nil
+	}
+
+	err = pm.forceReconstructMerkleCore(info.merkle, info.setDB)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, issue := range issues {
+		issue.IsRepaired = true
+	}
+	for _, issue := range missingIssues {
+		issue.IsRepaired = true
+	}
+
+	return issues, nil
+}
+
+/*
+checkDBBlocks checks the corrupted objects, the orphan blocks (not referred by any non-deleted objects or walkBlockInfos),
+and the missing blocks of the alive objects marked as all-good.
+*/
+func (pm *BaseProtocolManager) checkDBBlocks(objs []Object, walkBlockInfos func(addBlockInfo func(objID *types.PttID, blockInfo *BlockInfo)) error, isRepair bool) ([]*DBCheckIssue, error) {
+	entityID := pm.Entity().GetID()
+	db := pm.DB().DB()
+
+	issues := make([]*DBCheckIssue, 0)
+
+	// objects
+	blockInfoIDs := make(map[types.PttID]map[types.PttID]bool)
+	addBlockInfo := func(objID *types.PttID, blockInfo *BlockInfo) {
+		if blockInfo == nil || blockInfo.ID == nil {
+			return
+		}
+		ids, ok := blockInfoIDs[*objID]
+		if !ok {
+			ids = make(map[types.PttID]bool)
+			blockInfoIDs[*objID] = ids
+		}
+		ids[*blockInfo.ID] = true
+	}
+
+	type expectedBlock struct {
+		name        string
+		key         []byte
+		blockInfoID *types.PttID
+	}
+	expectedBlocks := make(map[types.PttID]*expectedBlock)
+
+	for _, obj := range objs {
+		name := reflect.TypeOf(obj).Elem().Name()
+
+		iter, err := obj.GetBaseObject().GetObjIterWithObj(nil, pttdb.ListOrderNext, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for iter.Next() {
+			key := common.CopyBytes(iter.Key())
+
+			each := obj.NewEmptyObj()
+			err = each.Unmarshal(iter.Value())
+			if err != nil || each.GetID() == nil {
+				issue := &DBCheckIssue{EntityID: entityID, Type: DBCheckIssueTypeCorruptedObject, Name: name, Key: key}
+				if isRepair {
+					issue.IsRepaired = db.Delete(key) == nil
+				}
+				issues = append(issues, issue)
+				continue
+			}
+
+			// blocks of the deleted objects are removed.
+			if each.GetStatus() >= types.StatusDeleted {
+				continue
+			}
+
+			objID := each.GetID()
+			blockInfo := each.GetBlockInfo()
+			addBlockInfo(objID, blockInfo)
+
+			syncInfo := each.GetSyncInfo()
+			if syncInfo != nil {
+				addBlockInfo(objID, syncInfo.GetBlockInfo())
+			}
+
+			if each.GetStatus() == types.StatusAlive && each.GetIsAllGood() && blockInfo != nil && blockInfo.ID != nil && blockInfo.NBlock > 0 {
+				expectedBlocks[*objID] = &expectedBlock{name: name, key: key, blockInfoID: blockInfo.ID}
+			}
+		}
+		iter.Release()
+	}
+
+	// block-infos not in the objects
+	err := walkBlockInfos(addBlockInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	// blocks
+	iter, err := db.NewIteratorWithPrefix(nil, pm.dbBlockPrefix, pttdb.ListOrderNext)
+	if err != nil {
+		return nil, err
+	}
+
+	lenPrefix := len(pm.dbBlockPrefix)
+	objID := &types.PttID{}
+	blockInfoID := &types.PttID{}
+	foundBlocks := make(map[types.PttID]bool)
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) < lenPrefix+types.SizePttID*2 {
+			continue
+		}
+		copy(objID[:], key[lenPrefix:])
+		copy(blockInfoID[:], key[lenPrefix+types.SizePttID:])
+
+		if blockInfoIDs[*objID][*blockInfoID] {
+			if expected, ok := expectedBlocks[*objID]; ok && *expected.blockInfoID == *blockInfoID {
+				foundBlocks[*objID] = true
+			}
+			continue
+		}
+
+		key = common.CopyBytes(key)
+		issue := &DBCheckIssue{EntityID: entityID, Type: DBCheckIssueTypeOrphanBlock, Name: "block", Key: key}
+		if isRepair {
+			issue.IsRepaired = db.Delete(key) == nil
+		}
+		issues = append(issues, issue)
+	}
+	iter.Release()
+
+	for id, expected := range expectedBlocks {
+		if foundBlocks[id] {
+			continue
+		}
+		issues = append(issues, &DBCheckIssue{EntityID: entityID, Type: DBCheckIssueTypeMissingBlock, Name: expected.name, Key: expected.key})
+	}
+
+	return issues, nil
+}
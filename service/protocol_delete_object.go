@@ -74,8 +74,7 @@ func (pm *BaseProtocolManager) DeleteObject(
 		return nil
 	}
 
-	creatorID := origObj.GetCreatorID()
-	if !reflect.DeepEqual(myID, creatorID) && !pm.IsMaster(myID, false) {
+	if !pm.isValidDeleter(myID, deleteOp, origObj) {
 		return types.ErrInvalidID
 	}
 
@@ -154,3 +153,22 @@ func (pm *BaseProtocolManager) DeleteObject(
 
 	return nil
 }
+
+/*
+SetIsValidDeleter sets the customized check on whether the id is able to delete the object.
+*/
+func (pm *BaseProtocolManager) SetIsValidDeleter(isValidDeleter func(id *types.PttID, deleteOp OpType, origObj Object) bool) {
+	pm.isValidDeleter = isValidDeleter
+}
+
+/*
+defaultIsValidDeleter: only the creator and the masters are able to delete the object.
+*/
+func (pm *BaseProtocolManager) defaultIsValidDeleter(id *types.PttID, deleteOp OpType, origObj Object) bool {
+	creatorID := origObj.GetCreatorID()
+	if reflect.DeepEqual(id, creatorID) {
+		return true
+	}
+
+	return pm.IsMaster(id, false)
+}
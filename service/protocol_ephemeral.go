@@ -0,0 +1,77 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"strconv"
+
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+/*
+SendEphemeralToPeers sends the ephemeral signal (presence, typing, etc.) to the peers.
+
+The data is encrypted with the op-key, but bypasses the oplog and merkle-tree,
+and is dropped with ErrRateLimited if the same op is sent within MinEphemeralSendInterval.
+*/
+func (pm *BaseProtocolManager) SendEphemeralToPeers(op OpType, data interface{}, peerList []*PttPeer) error {
+	if len(peerList) == 0 {
+		return nil
+	}
+
+	if !pm.ephemeralSendLimiter.Allow(strconv.FormatUint(uint64(op), 10)) {
+		return ErrRateLimited
+	}
+
+	return pm.sendDataToPeersWithCode(CodeTypeEphemeral, op, data, peerList)
+}
+
+/*
+PMHandleEphemeralWrapper decrypts the ephemeral signal and passes to HandleEphemeralMessage.
+
+Only the registered peers are accepted,
+and the signals from the same peer with the same op within MinEphemeralRecvInterval are dropped.
+*/
+func PMHandleEphemeralWrapper(pm ProtocolManager, hash *common.Address, encData []byte, peer *PttPeer) error {
+	opKeyInfo, err := pm.GetOpKeyFromHash(hash, false)
+	if err != nil {
+		return err
+	}
+
+	op, dataBytes, err := pm.Ptt().DecryptData(encData, opKeyInfo)
+	if err != nil {
+		return err
+	}
+
+	if pm.Peers().Peer(peer.GetID(), false) == nil {
+		return ErrNotRegistered
+	}
+
+	if !pm.AllowEphemeral(op, peer) {
+		log.Debug("PMHandleEphemeralWrapper: rate limited", "op", op, "peer", peer, "entity", pm.Entity().IDString())
+		return nil
+	}
+
+	return pm.HandleEphemeralMessage(op, dataBytes, peer)
+}
+
+func (pm *BaseProtocolManager) AllowEphemeral(op OpType, peer *PttPeer) bool {
+	key := peer.GetID().String() + "/" + strconv.FormatUint(uint64(op), 10)
+
+	return pm.ephemeralRecvLimiter.Allow(key)
+}
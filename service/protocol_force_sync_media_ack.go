@@ -42,6 +42,7 @@ func (pm *BaseProtocolManager) HandleForceSyncMediaAck(
 	pm.SetMediaDB(origObj)
 
 	blockIDs := make([]*SyncBlockID, 0, len(data.Objs))
+	nBlocks := make([]int, 0, len(data.Objs))
 	var blockInfo *BlockInfo
 	var logID *types.PttID
 	for _, obj := range data.Objs {
@@ -74,11 +75,12 @@ func (pm *BaseProtocolManager) HandleForceSyncMediaAck(
 		}
 
 		blockIDs = append(blockIDs, &SyncBlockID{ID: blockInfo.ID, ObjID: obj.ID, LogID: logID})
+		nBlocks = append(nBlocks, blockInfo.NBlock)
 
 	}
 
 	if len(blockIDs) != 0 {
-		pm.SyncBlock(syncMediaBlockMsg, blockIDs, peer)
+		pm.SyncBlock(syncMediaBlockMsg, SplitSyncMediaBlockIDs(blockIDs, nBlocks), peer)
 	}
 
 	return nil
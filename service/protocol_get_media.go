@@ -18,6 +18,7 @@ package service
 
 import (
 	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
 )
 
 func (pm *BaseProtocolManager) GetMedia(mediaID *types.PttID) (*Media, error) {
@@ -37,3 +38,80 @@ func (pm *BaseProtocolManager) GetMedia(mediaID *types.PttID) (*Media, error) {
 
 	return media, nil
 }
+
+/*
+GetImage gets the image with the size.
+The original image is returned (with ImageSizeOrig) if the image is without the thumbnail
+or the thumbnail is not synced yet.
+*/
+func (pm *BaseProtocolManager) GetImage(mediaID *types.PttID, size ImageSize) (*Media, ImageSize, error) {
+	media := NewEmptyMedia()
+	pm.SetMediaDB(media)
+	media.SetID(mediaID)
+
+	err := media.GetByID(false)
+	if err != nil {
+		return nil, ImageSizeOrig, err
+	}
+
+	thumbnailID, err := media.GetThumbnailID(size)
+	if err != nil {
+		return nil, ImageSizeOrig, err
+	}
+
+	if thumbnailID != nil {
+		thumbnail, err := pm.GetMedia(thumbnailID)
+		if err == nil {
+			return thumbnail, size, nil
+		}
+		log.Debug("GetImage: unable to get thumbnail", "mediaID", mediaID, "size", size, "e", err)
+	}
+
+	err = media.GetBuf()
+	if err != nil {
+		return nil, ImageSizeOrig, err
+	}
+
+	return media, ImageSizeOrig, nil
+}
+
+/*
+GetMediaInfo gets the media and the size of the media without loading the buf.
+The media may be still in sync (ErrMediaNotReady if the media-type / media-data is not synced yet).
+*/
+func (pm *BaseProtocolManager) GetMediaInfo(mediaID *types.PttID) (*Media, int64, error) {
+	media := NewEmptyMedia()
+	pm.SetMediaDB(media)
+	media.SetID(mediaID)
+
+	err := media.GetByID(false)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !media.GetIsGood() {
+		return nil, 0, ErrMediaNotReady
+	}
+
+	size, err := media.GetSize()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return media, size, nil
+}
+
+/*
+GetMediaRange gets the bytes in [start, end) of the media.
+*/
+func (pm *BaseProtocolManager) GetMediaRange(mediaID *types.PttID, start int64, end int64) ([]byte, error) {
+	media := NewEmptyMedia()
+	pm.SetMediaDB(media)
+	media.SetID(mediaID)
+
+	err := media.GetByID(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return media.GetBufRange(start, end)
+}
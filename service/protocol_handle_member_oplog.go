@@ -36,6 +36,8 @@ func (pm *BaseProtocolManager) processMemberLog(oplog *BaseOplog, processInfo Pr
 		origLogs, err = pm.handleDeleteMemberLog(oplog, info)
 	case MemberOpTypeMigrateMember:
 		origLogs, err = pm.handleMigrateMemberLog(oplog, info)
+	case MemberOpTypeUpdateMember:
+		origLogs, err = pm.handleUpdateMemberLog(oplog, info)
 	}
 	return
 }
@@ -61,6 +63,8 @@ func (pm *BaseProtocolManager) processPendingMemberLog(oplog *BaseOplog, process
 		isToSign, origLogs, err = pm.handlePendingDeleteMemberLog(oplog, info)
 	case MemberOpTypeMigrateMember:
 		isToSign, origLogs, err = pm.handlePendingMigrateMemberLog(oplog, info)
+	case MemberOpTypeUpdateMember:
+		isToSign, origLogs, err = pm.handlePendingUpdateMemberLog(oplog, info)
 	}
 	return isToSign, origLogs, err
 }
@@ -109,6 +113,8 @@ func (pm *BaseProtocolManager) SetNewestMemberOplog(oplog *BaseOplog) error {
 		isNewer, err = pm.setNewestDeleteMemberLog(oplog)
 	case MemberOpTypeMigrateMember:
 		isNewer, err = pm.setNewestMigrateMemberLog(oplog)
+	case MemberOpTypeUpdateMember:
+		isNewer, err = pm.setNewestUpdateMemberLog(oplog)
 	}
 
 	if err != nil {
@@ -134,6 +140,8 @@ func (pm *BaseProtocolManager) HandleFailedMemberOplog(oplog *BaseOplog) error {
 		err = pm.handleFailedDeleteMemberLog(oplog)
 	case MemberOpTypeMigrateMember:
 		err = pm.handleFailedMigrateMemberLog(oplog)
+	case MemberOpTypeUpdateMember:
+		err = pm.handleFailedUpdateMemberLog(oplog)
 	}
 
 	return err
@@ -153,6 +161,8 @@ func (pm *BaseProtocolManager) HandleFailedValidMemberOplog(oplog *BaseOplog, pr
 		err = pm.handleFailedValidDeleteMemberLog(oplog)
 	case MemberOpTypeMigrateMember:
 		err = pm.handleFailedValidMigrateMemberLog(oplog)
+	case MemberOpTypeUpdateMember:
+		err = pm.handleFailedValidUpdateMemberLog(oplog)
 	}
 
 	return err
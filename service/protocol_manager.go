@@ -38,6 +38,7 @@ type ProtocolManager interface {
 
 	HandleNonRegisteredMessage(op OpType, dataBytes []byte, peer *PttPeer) error
 	HandleMessage(op OpType, dataBytes []byte, peer *PttPeer) error
+	HandleEphemeralMessage(op OpType, dataBytes []byte, peer *PttPeer) error
 
 	Sync(peer *PttPeer) error
 
@@ -73,6 +74,7 @@ type ProtocolManager interface {
 	AddMember(id *types.PttID, isForce bool) (*Member, *MemberOplog, error)
 	MigrateMember(fromID *types.PttID, toID *types.PttID) error
 	DeleteMember(id *types.PttID) (bool, error)
+	UpdateMember(id *types.PttID, perms MemberPerm) (*Member, error)
 
 	// owner-id
 	SetOwnerID(ownerID *types.PttID, isLocked bool)
@@ -217,7 +219,7 @@ type ProtocolManager interface {
 	ToRenewOpKeyTS() (types.Timestamp, error)
 
 	DBOpKeyLock() *types.LockMap
-	DBOpKey() *pttdb.LDBBatch
+	DBOpKey() pttdb.IndexedBatch
 	DBOpKeyPrefix() []byte
 	DBOpKeyIdxPrefix() []byte
 
@@ -278,6 +280,8 @@ type ProtocolManager interface {
 
 	SendDataToPeer(op OpType, data interface{}, peer *PttPeer) error
 	SendDataToPeers(op OpType, data interface{}, peerList []*PttPeer) error
+	SendEphemeralToPeers(op OpType, data interface{}, peerList []*PttPeer) error
+	AllowEphemeral(op OpType, peer *PttPeer) bool
 
 	CountPeers() (int, error)
 	GetPeers() ([]*PttPeer, error)
@@ -309,8 +313,13 @@ type ProtocolManager interface {
 	Ptt() Ptt
 
 	// db
-	DB() *pttdb.LDBBatch
+	DB() pttdb.IndexedBatch
 	DBObjLock() *types.LockMap
+
+	// check-db
+	DBCheckObjects() []Object
+	DBCheckBlockInfos(addBlockInfo func(objID *types.PttID, blockInfo *BlockInfo)) error
+	CheckDB(isRepair bool) ([]*DBCheckIssue, error)
 }
 
 type MyProtocolManager interface {
@@ -354,6 +363,8 @@ type BaseProtocolManager struct {
 
 	inpostdeleteMember func(id *types.PttID, oplog *BaseOplog, origObj Object, opData OpData) error
 
+	isValidDeleter func(id *types.PttID, deleteOp OpType, origObj Object) bool
+
 	// member-oplog
 	dbMemberLock *types.LockMap
 	memberMerkle *Merkle
@@ -413,6 +424,10 @@ type BaseProtocolManager struct {
 	sendDataToPeersSub        *event.TypeMuxSubscription
 	sendDataToPeerWithCodeSub *event.TypeMuxSubscription
 
+	// ephemeral
+	ephemeralSendLimiter *RateLimiter
+	ephemeralRecvLimiter *RateLimiter
+
 	// sync
 	maxSyncRandomSeconds int
 	minSyncRandomSeconds int
@@ -433,7 +448,7 @@ type BaseProtocolManager struct {
 	ptt Ptt
 
 	// db
-	db     *pttdb.LDBBatch
+	db     pttdb.IndexedBatch
 	dbLock *types.LockMap
 
 	// block
@@ -486,7 +501,7 @@ func NewBaseProtocolManager(
 	svc Service,
 
 	// db
-	db *pttdb.LDBBatch,
+	db pttdb.IndexedBatch,
 
 ) (*BaseProtocolManager, error) {
 
@@ -598,6 +613,10 @@ func NewBaseProtocolManager(
 		newPeerCh: make(chan *PttPeer),
 		peers:     peers,
 
+		// ephemeral
+		ephemeralSendLimiter: NewRateLimiter(MinEphemeralSendInterval),
+		ephemeralRecvLimiter: NewRateLimiter(MinEphemeralRecvInterval),
+
 		getPeerType:     getPeerType,
 		isMyDevice:      isMyDevice,
 		isImportantPeer: isImportantPeer,
@@ -651,6 +670,9 @@ func NewBaseProtocolManager(
 	if pm.isMember == nil {
 		pm.isMember = pm.defaultIsMember
 	}
+	if pm.isValidDeleter == nil {
+		pm.isValidDeleter = pm.defaultIsValidDeleter
+	}
 	if pm.getPeerType == nil {
 		pm.getPeerType = pm.defaultGetPeerType
 	}
@@ -685,6 +707,10 @@ func (pm *BaseProtocolManager) HandleMessage(op OpType, dataBytes []byte, peer *
 	return types.ErrNotImplemented
 }
 
+func (pm *BaseProtocolManager) HandleEphemeralMessage(op OpType, dataBytes []byte, peer *PttPeer) error {
+	return types.ErrNotImplemented
+}
+
 func (pm *BaseProtocolManager) Prestart() error {
 	if pm.isPrestart {
 		log.Warn("Prestart: already prestarted", "entity", pm.Entity().IDString())
@@ -875,7 +901,7 @@ func (pm *BaseProtocolManager) Ptt() Ptt {
 	return pm.ptt
 }
 
-func (pm *BaseProtocolManager) DB() *pttdb.LDBBatch {
+func (pm *BaseProtocolManager) DB() pttdb.IndexedBatch {
 	return pm.db
 }
 
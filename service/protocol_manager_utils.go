@@ -146,6 +146,10 @@ func (pm *BaseProtocolManager) sendDataToPeersLoop() {
 Send Data to Peers using op-key
 */
 func (pm *BaseProtocolManager) sendDataToPeers(op OpType, data interface{}, peerList []*PttPeer) error {
+	return pm.sendDataToPeersWithCode(CodeTypeOp, op, data, peerList)
+}
+
+func (pm *BaseProtocolManager) sendDataToPeersWithCode(code CodeType, op OpType, data interface{}, peerList []*PttPeer) error {
 
 	if len(peerList) == 0 {
 		return nil
@@ -170,7 +174,7 @@ func (pm *BaseProtocolManager) sendDataToPeers(op OpType, data interface{}, peer
 		return err
 	}
 
-	pttData, err := ptt.MarshalData(CodeTypeOp, opKeyInfo.Hash, encData)
+	pttData, err := ptt.MarshalData(code, opKeyInfo.Hash, encData)
 	if err != nil {
 		return err
 	}
@@ -88,77 +88,90 @@ func (pm *BaseProtocolManager) SplitContentBlocks(prefix []byte, objID *types.Pt
 
 func (pm *BaseProtocolManager) SplitMediaBlocks(objID *types.PttID, buf []byte) (*types.PttID, [][][]byte, error) {
 
-	myEntity := pm.Ptt().GetMyEntity()
-
 	blockInfoID, err := types.NewPttID()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	hashs := make([][][]byte, 0)
-
 	fullDBPrefix, err := pm.FullBlockDBPrefix(nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	lenCurrentBuf := 0
-	halfLenCurrentBuf := 0
-	realLenCurrentBuf := 0
-	var eachBlock *Block
-	var bufs [][]byte
-	var eachHashs [][]byte
-	var firstHalfBuf []byte
-	var secondHalfBuf []byte
-	var scrambledBufs [][]byte
-
-	for blockID, currentBuf := 0, buf[0:]; len(currentBuf) != 0; blockID, currentBuf = blockID+1, currentBuf[lenCurrentBuf:] {
-		// 1. Unless there is only 1 char, we hope that both blocks contains at least 1 char. Squeezing the last-char to the block.
-		realLenCurrentBuf = len(currentBuf)
-		if realLenCurrentBuf == NByteInBlock+1 {
-			lenCurrentBuf = realLenCurrentBuf
-		} else {
-			lenCurrentBuf = common.MinInt(NByteInBlock, len(currentBuf))
+	bufs := splitMediaBuf(buf)
+	hashs := make([][][]byte, len(bufs))
+	for blockID, eachBuf := range bufs {
+		hashs[blockID], err = pm.saveMediaBlock(fullDBPrefix, objID, blockInfoID, uint32(blockID), eachBuf)
+		if err != nil {
+			return nil, nil, err
 		}
+	}
 
-		// 2. construct the bufs
-		halfLenCurrentBuf = (lenCurrentBuf + 1) / 2
-		firstHalfBuf = currentBuf[:halfLenCurrentBuf]
-		secondHalfBuf = currentBuf[halfLenCurrentBuf:lenCurrentBuf]
+	return blockInfoID, hashs, nil
+}
 
-		bufs = [][]byte{firstHalfBuf, secondHalfBuf}
+/*
+splitMediaBuf splits the buf to the bufs of the media-blocks with NByteInBlock bytes.
 
-		// 3. scramble the buf
-		scrambledBufs, err = ScrambleBuf(bufs)
-		if err != nil {
-			return nil, nil, err
+Unless there is only 1 char, we hope that both sub-blocks contains at least 1 char.
+Squeezing the last-char to the last block.
+*/
+func splitMediaBuf(buf []byte) [][]byte {
+	bufs := make([][]byte, 0, len(buf)/NByteInBlock+1)
+
+	lenCurrentBuf := 0
+	for currentBuf := buf; len(currentBuf) != 0; currentBuf = currentBuf[lenCurrentBuf:] {
+		lenCurrentBuf = len(currentBuf)
+		if lenCurrentBuf != NByteInBlock+1 {
+			lenCurrentBuf = common.MinInt(NByteInBlock, lenCurrentBuf)
 		}
 
-		// 4. construct the hash
-		eachHashs = make([][]byte, len(scrambledBufs))
-		for subBlockID, scrambledBuf := range scrambledBufs {
-			eachBlock, err = NewBlock(uint32(blockID), uint8(subBlockID), scrambledBuf)
-			if err != nil {
-				return nil, nil, err
-			}
-			eachBlock.SetDB(pm.DB(), fullDBPrefix, objID, blockInfoID)
-			err = myEntity.SignBlock(eachBlock)
-			if err != nil {
-				return nil, nil, err
-			}
+		bufs = append(bufs, currentBuf[:lenCurrentBuf])
+	}
 
-			err = eachBlock.Save()
-			if err != nil {
-				return nil, nil, err
-			}
-			eachHashs[subBlockID] = eachBlock.Hash
+	return bufs
+}
+
+/*
+saveMediaBlock scrambles the buf of the media-block into the sub-blocks, signs and saves the sub-blocks,
+and returns the hashs of the sub-blocks.
+*/
+func (pm *BaseProtocolManager) saveMediaBlock(fullDBPrefix []byte, objID *types.PttID, blockInfoID *types.PttID, blockID uint32, buf []byte) ([][]byte, error) {
+
+	myEntity := pm.Ptt().GetMyEntity()
+
+	// 1. construct the bufs
+	halfLenBuf := (len(buf) + 1) / 2
+	bufs := [][]byte{buf[:halfLenBuf], buf[halfLenBuf:]}
+
+	// 2. scramble the buf
+	scrambledBufs, err := ScrambleBuf(bufs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. construct the hash
+	var eachBlock *Block
+	hashs := make([][]byte, len(scrambledBufs))
+	for subBlockID, scrambledBuf := range scrambledBufs {
+		eachBlock, err = NewBlock(blockID, uint8(subBlockID), scrambledBuf)
+		if err != nil {
+			return nil, err
+		}
+		eachBlock.SetDB(pm.DB(), fullDBPrefix, objID, blockInfoID)
+		err = myEntity.SignBlock(eachBlock)
+		if err != nil {
+			return nil, err
 		}
 
-		// 5. append hash
-		hashs = append(hashs, eachHashs)
+		err = eachBlock.Save()
+		if err != nil {
+			return nil, err
+		}
+		hashs[subBlockID] = eachBlock.Hash
 	}
 
-	return blockInfoID, hashs, nil
+	return hashs, nil
 }
 
 /*
@@ -430,7 +430,7 @@ func (pm *BaseProtocolManager) ExpireOpKeySeconds() int64 {
 	return pm.expireOpKeySeconds
 }
 
-func (pm *BaseProtocolManager) DBOpKey() *pttdb.LDBBatch {
+func (pm *BaseProtocolManager) DBOpKey() pttdb.IndexedBatch {
 	return pm.db
 }
 
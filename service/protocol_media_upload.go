@@ -0,0 +1,186 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"github.com/ailabstw/go-pttai/common"
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+/*
+CreateMediaUpload creates the resumable upload of the media with the size.
+The media is created with the media-type and the media-data after all the bytes are uploaded.
+*/
+func (pm *BaseProtocolManager) CreateMediaUpload(filename []byte, size int64, mediaType MediaType, mediaData MediaData) (*MediaUpload, error) {
+	upload, err := NewMediaUpload(pm.Entity().GetID(), filename, size, mediaType, mediaData)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pm.saveMediaUpload(upload)
+	if err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+func (pm *BaseProtocolManager) GetMediaUpload(id *types.PttID) (*MediaUpload, error) {
+	upload := &MediaUpload{ID: id, EntityID: pm.Entity().GetID()}
+	key, err := upload.MarshalKey()
+	if err != nil {
+		return nil, err
+	}
+
+	marshaled, err := pm.DB().DB().Get(key)
+	if err == leveldb.ErrNotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = upload.Unmarshal(marshaled)
+	if err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+func (pm *BaseProtocolManager) saveMediaUpload(upload *MediaUpload) error {
+	key, err := upload.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	marshaled, err := upload.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return pm.DB().DB().Put(key, marshaled)
+}
+
+/*
+AppendMediaUpload appends the buf at the offset of the upload.
+The offset is required to be the same as the offset of the upload (resuming from MediaUpload.Offset).
+
+The full blocks are signed and saved immediately, and the last block is saved when all the bytes are uploaded.
+*/
+func (pm *BaseProtocolManager) AppendMediaUpload(id *types.PttID, offset int64, buf []byte) (*MediaUpload, error) {
+	err := pm.DBObjLock().Lock(id)
+	if err != nil {
+		return nil, err
+	}
+	defer pm.DBObjLock().Unlock(id)
+
+	upload, err := pm.GetMediaUpload(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset != upload.Offset {
+		return upload, ErrInvalidMediaUploadOffset
+	}
+	if upload.Offset+int64(len(buf)) > upload.Size {
+		return upload, ErrFileTooLarge
+	}
+
+	fullDBPrefix, err := pm.FullBlockDBPrefix(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	newOffset := upload.Offset + int64(len(buf))
+	tail := append(common.CloneBytes(upload.Tail), buf...)
+	bufs, tail := splitMediaUploadTail(tail, newOffset == upload.Size)
+
+	hashs := upload.Hashs
+	var eachHashs [][]byte
+	for _, eachBuf := range bufs {
+		eachHashs, err = pm.saveMediaBlock(fullDBPrefix, upload.ObjID, upload.BlockInfoID, uint32(len(hashs)), eachBuf)
+		if err != nil {
+			return nil, err
+		}
+		hashs = append(hashs, eachHashs)
+	}
+
+	ts, err := types.GetTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	upload.Hashs = hashs
+	upload.Tail = tail
+	upload.Offset = newOffset
+	upload.UpdateTS = ts
+
+	err = pm.saveMediaUpload(upload)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("AppendMediaUpload: done", "id", id, "offset", upload.Offset, "size", upload.Size, "nBlock", len(upload.Hashs))
+
+	return upload, nil
+}
+
+/*
+FinishMediaUpload removes the upload after the media is created with the blocks of the upload.
+*/
+func (pm *BaseProtocolManager) FinishMediaUpload(id *types.PttID) error {
+	upload := &MediaUpload{ID: id, EntityID: pm.Entity().GetID()}
+	key, err := upload.MarshalKey()
+	if err != nil {
+		return err
+	}
+
+	return pm.DB().DB().Delete(key)
+}
+
+/*
+DeleteMediaUpload removes the upload and the saved blocks (abort).
+*/
+func (pm *BaseProtocolManager) DeleteMediaUpload(id *types.PttID) error {
+	err := pm.DBObjLock().Lock(id)
+	if err != nil {
+		return err
+	}
+	defer pm.DBObjLock().Unlock(id)
+
+	upload, err := pm.GetMediaUpload(id)
+	if err != nil {
+		return err
+	}
+
+	fullDBPrefix, err := pm.FullBlockDBPrefix(nil)
+	if err != nil {
+		return err
+	}
+
+	block := NewEmptyBlock()
+	block.SetDB(pm.DB(), fullDBPrefix, upload.ObjID, upload.BlockInfoID)
+	err = block.RemoveAll()
+	if err != nil {
+		return err
+	}
+
+	return pm.FinishMediaUpload(id)
+}
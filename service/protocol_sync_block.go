@@ -108,6 +108,19 @@ func (pm *BaseProtocolManager) HandleSyncBlock(
 		}
 		pm.SetBlockInfoDB(blockInfo, syncBlockID.ObjID)
 
+		// ranged: ack immediately to keep the order of the ranges and to bound the memory.
+		if syncBlockID.NBlock != 0 {
+			newBlocks, err = GetBlockListByRange(blockInfo, syncBlockID.StartBlockID, syncBlockID.NBlock)
+			if err != nil {
+				continue
+			}
+			err = pm.SyncBlockAck(syncAckMsg, newBlocks, peer)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		newBlocks, err = GetBlockList(blockInfo, 0, false)
 		if err != nil {
 			continue
@@ -36,6 +36,60 @@ func (pm *BaseProtocolManager) HandleSyncCreateMedia(dataBytes []byte, peer *Ptt
  * Sync Media Block
  **********/
 
+/*
+SplitSyncMediaBlockID splits the sync-block-id of the large media into the ranges of the blocks.
+heads are the first MediaSyncHeadNBlock blocks and the last block (for the size and the index of some containers),
+and are expected to be synced before the rest, so the playback can start before all the blocks are synced.
+*/
+func SplitSyncMediaBlockID(syncBlockID *SyncBlockID, nBlock int) ([]*SyncBlockID, []*SyncBlockID) {
+	if nBlock <= MediaSyncHeadNBlock+1 {
+		return []*SyncBlockID{syncBlockID}, nil
+	}
+
+	newSyncBlockID := func(startBlockID int, nBlock int) *SyncBlockID {
+		return &SyncBlockID{
+			ID:           syncBlockID.ID,
+			ObjID:        syncBlockID.ObjID,
+			LogID:        syncBlockID.LogID,
+			StartBlockID: uint32(startBlockID),
+			NBlock:       uint32(nBlock),
+		}
+	}
+
+	lastBlockID := nBlock - 1
+	heads := []*SyncBlockID{
+		newSyncBlockID(0, MediaSyncHeadNBlock),
+		newSyncBlockID(lastBlockID, 1),
+	}
+
+	rests := make([]*SyncBlockID, 0, (lastBlockID-MediaSyncHeadNBlock)/MediaSyncRangeNBlock+1)
+	for startBlockID := MediaSyncHeadNBlock; startBlockID < lastBlockID; startBlockID += MediaSyncRangeNBlock {
+		eachNBlock := MediaSyncRangeNBlock
+		if startBlockID+eachNBlock > lastBlockID {
+			eachNBlock = lastBlockID - startBlockID
+		}
+		rests = append(rests, newSyncBlockID(startBlockID, eachNBlock))
+	}
+
+	return heads, rests
+}
+
+/*
+SplitSyncMediaBlockIDs splits the sync-block-ids of the media with the n-blocks,
+with the heads of all the media before the rests.
+*/
+func SplitSyncMediaBlockIDs(syncBlockIDs []*SyncBlockID, nBlocks []int) []*SyncBlockID {
+	heads := make([]*SyncBlockID, 0, len(syncBlockIDs))
+	rests := make([]*SyncBlockID, 0, len(syncBlockIDs))
+	for i, syncBlockID := range syncBlockIDs {
+		eachHeads, eachRests := SplitSyncMediaBlockID(syncBlockID, nBlocks[i])
+		heads = append(heads, eachHeads...)
+		rests = append(rests, eachRests...)
+	}
+
+	return append(heads, rests...)
+}
+
 func (pm *BaseProtocolManager) HandleSyncMediaBlock(
 	dataBytes []byte,
 	peer *PttPeer,
@@ -16,7 +16,10 @@
 
 package service
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"reflect"
+)
 
 type SyncMediaAck struct {
 	Objs []*Media `json:"o"`
@@ -73,7 +76,10 @@ func (pm *BaseProtocolManager) updateSyncCreateMedia(theToObj Object, theFromObj
 		return ErrInvalidData
 	}
 
-	toObj.BlockInfo = fromObj.BlockInfo
+	// keep the blocks already synced (the blocks may be synced before the media for the large media).
+	if toObj.BlockInfo == nil || fromObj.BlockInfo == nil || !reflect.DeepEqual(toObj.BlockInfo.ID, fromObj.BlockInfo.ID) {
+		toObj.BlockInfo = fromObj.BlockInfo
+	}
 	toObj.MediaType = fromObj.MediaType
 	toObj.MediaData = fromObj.MediaData
 
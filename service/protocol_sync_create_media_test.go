@@ -0,0 +1,76 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func TestSplitSyncMediaBlockID(t *testing.T) {
+	// prepare test-cases
+	tests := []struct {
+		name   string
+		nBlock int
+		nHead  int
+	}{
+		{name: "1 block", nBlock: 1, nHead: 1},
+		{name: "head + 1 blocks", nBlock: MediaSyncHeadNBlock + 1, nHead: 1},
+		{name: "head + 2 blocks", nBlock: MediaSyncHeadNBlock + 2, nHead: 2},
+		{name: "head + range + 1 blocks", nBlock: MediaSyncHeadNBlock + MediaSyncRangeNBlock + 1, nHead: 2},
+		{name: "500MB", nBlock: MaxUploadStreamMediaSize / NByteInBlock, nHead: 2},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncBlockID := &SyncBlockID{ID: &types.PttID{1}, ObjID: &types.PttID{2}, LogID: &types.PttID{3}}
+
+			heads, rests := SplitSyncMediaBlockID(syncBlockID, tt.nBlock)
+			if len(heads) != tt.nHead {
+				t.Fatalf("SplitSyncMediaBlockID: heads: %v expected: %v", len(heads), tt.nHead)
+			}
+			if tt.nHead == 1 {
+				if heads[0].NBlock != 0 || len(rests) != 0 {
+					t.Errorf("SplitSyncMediaBlockID: not split: %v rests: %v", heads[0].NBlock, len(rests))
+				}
+				return
+			}
+
+			if heads[0].StartBlockID != 0 || heads[1].StartBlockID != uint32(tt.nBlock-1) || heads[1].NBlock != 1 {
+				t.Errorf("SplitSyncMediaBlockID: invalid heads: %v %v", heads[0], heads[1])
+			}
+
+			// every block is covered exactly once.
+			covered := make([]int, tt.nBlock)
+			for _, each := range append(heads, rests...) {
+				if each.NBlock == 0 || each.NBlock > MediaSyncRangeNBlock {
+					t.Errorf("SplitSyncMediaBlockID: invalid NBlock: %v", each.NBlock)
+				}
+				for i := each.StartBlockID; i < each.StartBlockID+each.NBlock; i++ {
+					covered[i]++
+				}
+			}
+			for i, each := range covered {
+				if each != 1 {
+					t.Errorf("SplitSyncMediaBlockID: block %v covered: %v", i, each)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"github.com/ailabstw/go-pttai/common/types"
+	"github.com/ailabstw/go-pttai/log"
+)
+
+/*
+UpdateMember sets the perms of the member. Only the masters are able to update the member,
+and the masters are not able to be updated (masters are with all the perms).
+*/
+func (pm *BaseProtocolManager) UpdateMember(id *types.PttID, perms MemberPerm) (*Member, error) {
+	myID := pm.Ptt().GetMyEntity().GetID()
+	entity := pm.Entity()
+
+	// 1. validate
+	if entity.GetStatus() != types.StatusAlive {
+		return nil, types.ErrInvalidStatus
+	}
+
+	if !pm.IsMaster(myID, false) {
+		return nil, types.ErrInvalidID
+	}
+
+	if pm.IsMaster(id, false) {
+		return nil, types.ErrInvalidID
+	}
+
+	// 2. lock member
+	member := NewEmptyMember()
+	pm.SetMemberObjDB(member)
+	member.SetID(id)
+
+	err := member.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer member.Unlock()
+
+	err = member.GetByID(true)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Status != types.StatusAlive {
+		return nil, types.ErrInvalidStatus
+	}
+
+	// 3. oplog
+	opData := &MemberOpUpdateMember{Perms: perms}
+
+	theOplog, err := pm.NewMemberOplog(id, MemberOpTypeUpdateMember, opData)
+	if err != nil {
+		return nil, err
+	}
+	oplog := theOplog.GetBaseOplog()
+
+	err = pm.SignOplog(oplog)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. update member
+	if oplog.ToStatus() == types.StatusAlive {
+		err = pm.updateMemberWithOplog(member, oplog, opData)
+	} else {
+		oplog.IsSync = true
+	}
+	log.Debug("UpdateMember: after updateMember", "id", id, "perms", perms, "status", oplog.ToStatus(), "e", err)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. oplog
+	err = oplog.Save(true, pm.MemberMerkle())
+	if err != nil {
+		return nil, err
+	}
+
+	pm.broadcastMemberOplogCore(oplog)
+
+	return member, nil
+}
+
+/*
+updateMemberWithOplog sets the perms of the member based on the oplog (member is locked).
+The perms are updated only with the newer oplog.
+*/
+func (pm *BaseProtocolManager) updateMemberWithOplog(member *Member, oplog *BaseOplog, opData *MemberOpUpdateMember) error {
+	if !member.PermUpdateTS.IsLess(oplog.UpdateTS) {
+		return ErrNewerOplog
+	}
+
+	member.Perms = opData.Perms
+	member.PermLogID = oplog.ID
+	member.PermUpdateTS = oplog.UpdateTS
+
+	err := member.Save(true)
+	if err != nil {
+		return err
+	}
+
+	oplog.IsSync = true
+
+	return nil
+}
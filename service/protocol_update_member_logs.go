@@ -0,0 +1,106 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"reflect"
+
+	"github.com/ailabstw/go-pttai/common/types"
+)
+
+func (pm *BaseProtocolManager) handleUpdateMemberLog(oplog *BaseOplog, info *ProcessPersonInfo) ([]*BaseOplog, error) {
+
+	opData := &MemberOpUpdateMember{}
+	err := oplog.GetData(opData)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pm.IsMaster(oplog.CreatorID, false) {
+		return nil, ErrInvalidOplog
+	}
+
+	member := NewEmptyMember()
+	pm.SetMemberObjDB(member)
+	member.SetID(oplog.ObjID)
+
+	err = member.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer member.Unlock()
+
+	err = member.GetByID(true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pm.updateMemberWithOplog(member, oplog, opData)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (pm *BaseProtocolManager) handlePendingUpdateMemberLog(oplog *BaseOplog, info *ProcessPersonInfo) (types.Bool, []*BaseOplog, error) {
+
+	opData := &MemberOpUpdateMember{}
+	err := oplog.GetData(opData)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if !pm.IsMaster(oplog.CreatorID, false) {
+		return false, nil, ErrInvalidOplog
+	}
+
+	member, err := pm.GetMember(oplog.ObjID, false)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if !member.PermUpdateTS.IsLess(oplog.UpdateTS) {
+		return false, nil, ErrNewerOplog
+	}
+
+	oplog.IsSync = true
+
+	return true, nil, nil
+}
+
+func (pm *BaseProtocolManager) setNewestUpdateMemberLog(oplog *BaseOplog) (types.Bool, error) {
+
+	member, err := pm.GetMember(oplog.ObjID, false)
+	if err != nil {
+		// possibly already deleted
+		return true, nil
+	}
+
+	return !types.Bool(reflect.DeepEqual(oplog.ID, member.PermLogID)), nil
+}
+
+/*
+handleFailedUpdateMemberLog: the perms are set only with the valid oplog. Nothing to revert.
+*/
+func (pm *BaseProtocolManager) handleFailedUpdateMemberLog(oplog *BaseOplog) error {
+	return nil
+}
+
+func (pm *BaseProtocolManager) handleFailedValidUpdateMemberLog(oplog *BaseOplog) error {
+	return nil
+}
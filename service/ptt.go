@@ -37,6 +37,8 @@ type Ptt interface {
 
 	ErrChan() *types.Chan
 
+	PostPttOplog(oplog *PttOplog) int
+
 	// peers
 	IdentifyPeer(entityID *types.PttID, quitSync chan struct{}, peer *PttPeer, isForce bool) (*IdentifyPeer, error)
 	IdentifyPeerAck(challenge *types.Salt, peer *PttPeer) (*IdentifyPeerAck, error)
@@ -123,6 +125,9 @@ type BasePtt struct {
 	notifyNodeStop    *types.Chan
 	errChan           *types.Chan
 
+	pttOplogSubs     map[*pttOplogSub]bool
+	pttOplogSubsLock sync.RWMutex
+
 	// peers
 	peerLock sync.RWMutex
 
@@ -393,6 +398,13 @@ func (p *BasePtt) RegisterService(service Service) error {
 	return nil
 }
 
+/*
+GetService returns the registered service by the name, nil if not registered.
+*/
+func (p *BasePtt) GetService(name string) Service {
+	return p.services[name]
+}
+
 /**********
  * Chan
  **********/
@@ -409,6 +421,63 @@ func (p *BasePtt) ErrChan() *types.Chan {
 	return p.errChan
 }
 
+/**********
+ * Feed
+ **********/
+
+/*
+pttOplogSub is the subscriber of the ptt-oplogs.
+*/
+type pttOplogSub struct {
+	ch chan<- *PttOplog
+}
+
+/*
+PostPttOplog sends the ptt-oplog to all the subscribers without blocking (as PostObjEvent).
+
+The ptt-oplog is dropped for the subscribers with the full channel,
+so that a slow subscriber does not block the posting op.
+Returns the number of the subscribers receiving the ptt-oplog.
+*/
+func (p *BasePtt) PostPttOplog(oplog *PttOplog) int {
+	p.pttOplogSubsLock.RLock()
+	defer p.pttOplogSubsLock.RUnlock()
+
+	nSent := 0
+	for sub := range p.pttOplogSubs {
+		select {
+		case sub.ch <- oplog:
+			nSent++
+		default:
+			log.Warn("PostPttOplog: subscriber is full, drop the oplog", "oplog", oplog.ID, "op", oplog.Op)
+		}
+	}
+
+	return nSent
+}
+
+func (p *BasePtt) SubscribePttOplog(ch chan<- *PttOplog) event.Subscription {
+	sub := &pttOplogSub{ch: ch}
+
+	p.pttOplogSubsLock.Lock()
+	if p.pttOplogSubs == nil {
+		p.pttOplogSubs = make(map[*pttOplogSub]bool)
+	}
+	p.pttOplogSubs[sub] = true
+	p.pttOplogSubsLock.Unlock()
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+
+		p.pttOplogSubsLock.Lock()
+		defer p.pttOplogSubsLock.Unlock()
+
+		delete(p.pttOplogSubs, sub)
+
+		return nil
+	})
+}
+
 /**********
  * Server
  **********/
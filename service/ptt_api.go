@@ -17,8 +17,11 @@
 package service
 
 import (
+	"context"
+
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/ailabstw/go-pttai/rpc"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -103,6 +106,13 @@ func (api *PrivateAPI) GetPttOplogSeen() (types.Timestamp, error) {
 	return api.p.GetPttOplogSeen()
 }
 
+/*
+SubscribeOplogs notifies the newly-saved ptt-oplogs (ptt_subscribe with "subscribeOplogs").
+*/
+func (api *PrivateAPI) SubscribeOplogs(ctx context.Context) (*rpc.Subscription, error) {
+	return api.p.BESubscribePttOplogs(ctx)
+}
+
 /**********
  * Locale
  **********/
@@ -0,0 +1,54 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"sort"
+
+	"github.com/ailabstw/go-pttai/log"
+)
+
+/*
+CheckDB checks the dbs of all the entities in all the services.
+It is expected to be called offline, without Prestart / Start.
+*/
+func (p *BasePtt) CheckDB(isRepair bool) ([]*DBCheckIssue, error) {
+	names := make([]string, 0, len(p.services))
+	for name := range p.services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	issues := make([]*DBCheckIssue, 0)
+	for _, name := range names {
+		entities := p.services[name].SPM().Entities()
+		for _, entity := range entities {
+			entityIssues, err := entity.PM().CheckDB(isRepair)
+			if err != nil {
+				log.Error("CheckDB: unable to check db", "service", name, "entity", entity.IDString(), "e", err)
+				return nil, err
+			}
+
+			for _, issue := range entityIssues {
+				issue.Service = name
+			}
+			issues = append(issues, entityIssues...)
+		}
+	}
+
+	return issues, nil
+}
@@ -17,9 +17,12 @@
 package service
 
 import (
+	"context"
+
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/log"
 	"github.com/ailabstw/go-pttai/pttdb"
+	"github.com/ailabstw/go-pttai/rpc"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -152,6 +155,35 @@ func (p *BasePtt) BEGetPttOplogList(logIDBytes []byte, limit int, listOrder pttd
 	return p.GetPttOplogList(logID, limit, listOrder, types.StatusAlive)
 }
 
+func (p *BasePtt) BESubscribePttOplogs(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		oplogs := make(chan *PttOplog, PttOplogEventChanSize)
+		sub := p.SubscribePttOplog(oplogs)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case oplog := <-oplogs:
+				notifier.Notify(rpcSub.ID, oplog)
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 func (p *BasePtt) MarkPttOplogSeen() (types.Timestamp, error) {
 	ts, err := types.GetTimestamp()
 	if err != nil {
@@ -120,6 +120,9 @@ func (p *BasePtt) HandleMessage(code CodeType, data *PttData, peer *PttPeer) err
 		err = p.HandleCodeIdentifyPeerWithMyIDChallengeAck(evHash, encData, peer)
 	case CodeTypeIdentifyPeerWithMyIDAck:
 		err = p.HandleCodeIdentifyPeerWithMyIDAck(evHash, encData, peer)
+
+	case CodeTypeEphemeral:
+		err = p.HandleCodeEphemeral(evHash, encData, peer)
 	default:
 		err = ErrInvalidMsgCode
 	}
@@ -211,6 +214,18 @@ func (p *BasePtt) HandleCodeOp(hash *common.Address, encData []byte, peer *PttPe
 	return err
 }
 
+func (p *BasePtt) HandleCodeEphemeral(hash *common.Address, encData []byte, peer *PttPeer) error {
+
+	entity, err := p.getEntityFromHash(hash, &p.lockOps, p.ops)
+	if err != nil {
+		return err
+	}
+
+	pm := entity.PM()
+
+	return PMHandleEphemeralWrapper(pm, hash, encData, peer)
+}
+
 func (p *BasePtt) HandleCodeOpFail(hash *common.Address, encData []byte, peer *PttPeer) error {
 
 	return p.HandleOpFail(encData, peer)
@@ -0,0 +1,101 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+RateLimiter allows at most one event per key within interval.
+
+The events of each key are kept ordered by the ts, because the events may come out of order
+(ex: the create-ts of the synced oplogs). An event is allowed only if it is at least interval
+from both the previous and the next allowed events.
+*/
+type RateLimiter struct {
+	lock     sync.Mutex
+	interval time.Duration
+	eventTS  map[string][]time.Time
+}
+
+func NewRateLimiter(interval time.Duration) *RateLimiter {
+	return &RateLimiter{
+		interval: interval,
+		eventTS:  make(map[string][]time.Time),
+	}
+}
+
+/*
+Allow returns true and records the event if there is no event of the key within interval.
+*/
+func (r *RateLimiter) Allow(key string) bool {
+	return r.allowAt(key, time.Now(), r.interval)
+}
+
+/*
+AllowAt is the same as Allow, but with the time and the interval of the event given
+(ex: the create-ts of the object and the interval set on the board).
+The same event (with the same ts) is allowed again, and all the events are allowed if interval is 0.
+*/
+func (r *RateLimiter) AllowAt(key string, ts time.Time, interval time.Duration) bool {
+	return r.allowAt(key, ts, interval)
+}
+
+func (r *RateLimiter) allowAt(key string, ts time.Time, interval time.Duration) bool {
+	if interval <= 0 {
+		return true
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	eventTS := r.eventTS[key]
+	idx := sort.Search(len(eventTS), func(i int) bool { return !eventTS[i].Before(ts) })
+	if idx < len(eventTS) && eventTS[idx].Equal(ts) {
+		return true
+	}
+	if idx > 0 && ts.Sub(eventTS[idx-1]) < interval {
+		return false
+	}
+	if idx < len(eventTS) && eventTS[idx].Sub(ts) < interval {
+		return false
+	}
+
+	eventTS = append(eventTS, time.Time{})
+	copy(eventTS[idx+1:], eventTS[idx:])
+	eventTS[idx] = ts
+
+	// keep only the latest events of the key.
+	if len(eventTS) > MaxRateLimiterEvents {
+		eventTS = append([]time.Time(nil), eventTS[len(eventTS)-MaxRateLimiterEvents:]...)
+	}
+	r.eventTS[key] = eventTS
+
+	// remove the expired keys to avoid growing indefinitely.
+	if len(r.eventTS) > MaxRateLimiterKeys {
+		for eachKey, eachTS := range r.eventTS {
+			if ts.Sub(eachTS[len(eachTS)-1]) >= interval {
+				delete(r.eventTS, eachKey)
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,63 @@
+// Copyright 2019 The go-pttai Authors
+// This file is part of the go-pttai library.
+//
+// The go-pttai library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-pttai library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-pttai library. If not, see <http://www.gnu.org/licenses/>.
+
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_allowAt(t *testing.T) {
+	// setup test
+	r := NewRateLimiter(time.Second)
+	now := time.Unix(1234567890, 0)
+
+	// prepare test-cases
+	tests := []struct {
+		name     string
+		key      string
+		now      time.Time
+		interval time.Duration
+		want     bool
+	}{
+		{name: "first", key: "a", now: now, want: true},
+		{name: "within interval", key: "a", now: now.Add(500 * time.Millisecond), want: false},
+		{name: "other key", key: "b", now: now.Add(500 * time.Millisecond), want: true},
+		{name: "after interval", key: "a", now: now.Add(time.Second), want: true},
+		{name: "within new interval", key: "a", now: now.Add(1500 * time.Millisecond), want: false},
+		{name: "same event", key: "a", now: now.Add(time.Second), want: true},
+		{name: "out of order within interval", key: "a", now: now.Add(-500 * time.Millisecond), want: false},
+		{name: "out of order", key: "a", now: now.Add(-time.Second), want: true},
+		{name: "later", key: "a", now: now.Add(3500 * time.Millisecond), want: true},
+		{name: "within interval of the next", key: "a", now: now.Add(3 * time.Second), want: false},
+		{name: "between", key: "a", now: now.Add(2250 * time.Millisecond), want: true},
+		{name: "not limited", key: "a", now: now.Add(2500 * time.Millisecond), interval: -1, want: true},
+	}
+
+	// run test
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval := time.Second
+			if tt.interval != 0 {
+				interval = tt.interval
+			}
+			if got := r.allowAt(tt.key, tt.now, interval); got != tt.want {
+				t.Errorf("RateLimiter.allowAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
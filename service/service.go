@@ -17,6 +17,8 @@
 package service
 
 import (
+	"sync"
+
 	"github.com/ailabstw/go-pttai/common/types"
 	"github.com/ailabstw/go-pttai/pttdb"
 	"github.com/ailabstw/go-pttai/rpc"
@@ -86,6 +88,8 @@ type Service interface {
 	Name() string
 
 	Ptt() Ptt
+
+	PostObjEvent(ev *ObjEvent) int
 }
 
 type MyService interface {
@@ -98,6 +102,9 @@ BaseService implements the base-type of Service
 type BaseService struct {
 	spm ServiceProtocolManager
 	ptt Ptt
+
+	objSubs     map[*objEventSub]bool
+	objSubsLock sync.RWMutex
 }
 
 func NewBaseService(ptt Ptt, spm ServiceProtocolManager) (*BaseService, error) {
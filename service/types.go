@@ -128,4 +128,42 @@ type SyncBlockID struct {
 	ID    *types.PttID
 	ObjID *types.PttID `json:"o"`
 	LogID *types.PttID `json:"l"`
+
+	// the range of the blocks, all the blocks if NBlock is 0.
+	StartBlockID uint32 `json:"s,omitempty"`
+	NBlock       uint32 `json:"n,omitempty"`
+}
+
+// DBCheckIssueType
+type DBCheckIssueType int
+
+const (
+	DBCheckIssueTypeInvalid DBCheckIssueType = iota
+	DBCheckIssueTypeCorruptedOplog
+	DBCheckIssueTypeExpiredPendingOplog
+	DBCheckIssueTypeMissingMerkleNode
+	DBCheckIssueTypeOrphanMerkleNode
+	DBCheckIssueTypeInvalidMerkleNode
+	DBCheckIssueTypeCorruptedObject
+	DBCheckIssueTypeOrphanBlock
+	DBCheckIssueTypeMissingBlock
+	NDBCheckIssueType
+)
+
+var (
+	dbCheckIssueStr = map[DBCheckIssueType]string{
+		DBCheckIssueTypeInvalid:             "invalid",
+		DBCheckIssueTypeCorruptedOplog:      "corrupted-oplog",
+		DBCheckIssueTypeExpiredPendingOplog: "expired-pending-oplog",
+		DBCheckIssueTypeMissingMerkleNode:   "missing-merkle-node",
+		DBCheckIssueTypeOrphanMerkleNode:    "orphan-merkle-node",
+		DBCheckIssueTypeInvalidMerkleNode:   "invalid-merkle-node",
+		DBCheckIssueTypeCorruptedObject:     "corrupted-object",
+		DBCheckIssueTypeOrphanBlock:         "orphan-block",
+		DBCheckIssueTypeMissingBlock:        "missing-block",
+	}
+)
+
+func (t DBCheckIssueType) String() string {
+	return dbCheckIssueStr[t]
 }